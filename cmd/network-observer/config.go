@@ -6,15 +6,18 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/skupperproject/skupper/internal/utils/tlscfg"
+	"sigs.k8s.io/yaml"
 )
 
 type Config struct {
 	APIListenAddress    string
 	APIEnableAccessLogs bool
 	APITLS              TLSSpec
+	AuthConfig          string
 
 	EnableConsole   bool
 	ConsoleLocation string
@@ -22,10 +25,43 @@ type Config struct {
 
 	RouterURL     string
 	RouterTLS     TLSSpec
+	NetworkName   string
+	NetworksFile  string
 	FlowRecordTTL time.Duration
 
+	SourceQueueSize int
+	ShedFlows       bool
+	EventQueueSize  int
+
+	ReplayFile  string
+	ReplaySpeed float64
+
+	RecordStore        string
+	RecordStorePath    string
+	RecordStoreMaxAge  time.Duration
+	RecordStoreMaxSize int64
+
+	SnapshotPath        string
+	SnapshotInterval    time.Duration
+	SnapshotGracePeriod time.Duration
+
 	VanflowLoggingProfile string
 
+	FlowExportPath     string
+	FlowExportFormat   string
+	FlowExportMaxSize  int64
+	FlowExportMaxFiles int
+	FlowExportSample   float64
+
+	OTLPEndpoint       string
+	OTLPProtocol       string
+	OTLPInsecure       bool
+	OTLPMetricInterval time.Duration
+
+	AlertRules      string
+	AlertWebhookURL string
+	AlertInterval   time.Duration
+
 	EnableProfile bool
 	CORSAllowAll  bool
 
@@ -33,10 +69,57 @@ type Config struct {
 }
 
 type TLSSpec struct {
-	CA         string
-	Cert       string
-	Key        string
-	SkipVerify bool
+	CA         string `json:"ca,omitempty"`
+	Cert       string `json:"cert,omitempty"`
+	Key        string `json:"key,omitempty"`
+	SkipVerify bool   `json:"insecure,omitempty"`
+}
+
+// NetworkSpec describes the router endpoint of an application network the
+// observer attaches to.
+type NetworkSpec struct {
+	Name      string  `json:"name"`
+	RouterURL string  `json:"routerEndpoint"`
+	RouterTLS TLSSpec `json:"tls,omitempty"`
+}
+
+// routerAddresses returns the comma separated router endpoints of the network.
+// The first is connected to first and the others are failed over to.
+func (s NetworkSpec) routerAddresses() []string {
+	var addresses []string
+	for _, address := range strings.Split(s.RouterURL, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
+
+// networks returns the networks listed in NetworksFile, or the single network
+// configured by the router flags when it is unset.
+func (c Config) networks() ([]NetworkSpec, error) {
+	if c.NetworksFile == "" {
+		return []NetworkSpec{{Name: c.NetworkName, RouterURL: c.RouterURL, RouterTLS: c.RouterTLS}}, nil
+	}
+	data, err := os.ReadFile(c.NetworksFile)
+	if err != nil {
+		return nil, err
+	}
+	var doc struct {
+		Networks []NetworkSpec `json:"networks"`
+	}
+	if err := yaml.UnmarshalStrict(data, &doc); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %s", c.NetworksFile, err)
+	}
+	if len(doc.Networks) == 0 {
+		return nil, fmt.Errorf("%s does not list any networks", c.NetworksFile)
+	}
+	for _, network := range doc.Networks {
+		if network.Name == "" || len(network.routerAddresses()) == 0 {
+			return nil, fmt.Errorf("networks in %s must each have a name and routerEndpoint", c.NetworksFile)
+		}
+	}
+	return doc.Networks, nil
 }
 
 func (t TLSSpec) hasCert() bool {
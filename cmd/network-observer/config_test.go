@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestConfigNetworks(t *testing.T) {
+	cfg := Config{NetworkName: "default", RouterURL: "amqps://skupper-router-local"}
+	networks, err := cfg.networks()
+	assert.Assert(t, err)
+	assert.DeepEqual(t, networks, []NetworkSpec{{Name: "default", RouterURL: "amqps://skupper-router-local"}})
+
+	cfg.NetworksFile = filepath.Join(t.TempDir(), "networks.yaml")
+	assert.Assert(t, os.WriteFile(cfg.NetworksFile, []byte(`
+networks:
+  - name: east
+    routerEndpoint: amqps://router.east:5671
+    tls:
+      ca: /etc/east/ca.crt
+      cert: /etc/east/tls.crt
+      key: /etc/east/tls.key
+  - name: west
+    routerEndpoint: amqp://router.west:5672, amqp://router-2.west:5672
+`), 0o644))
+	networks, err = cfg.networks()
+	assert.Assert(t, err)
+	assert.DeepEqual(t, networks, []NetworkSpec{
+		{
+			Name:      "east",
+			RouterURL: "amqps://router.east:5671",
+			RouterTLS: TLSSpec{CA: "/etc/east/ca.crt", Cert: "/etc/east/tls.crt", Key: "/etc/east/tls.key"},
+		},
+		{Name: "west", RouterURL: "amqp://router.west:5672, amqp://router-2.west:5672"},
+	})
+	assert.DeepEqual(t, networks[1].routerAddresses(), []string{"amqp://router.west:5672", "amqp://router-2.west:5672"})
+
+	for _, invalid := range []string{
+		"networks: []",
+		"networks: [{name: east}]",
+		"networks: [{routerEndpoint: amqp://router}]",
+		`networks: [{name: east, routerEndpoint: " , "}]`,
+		"networks: [{name: east, routerEndpoint: amqp://router, unknown: true}]",
+	} {
+		assert.Assert(t, os.WriteFile(cfg.NetworksFile, []byte(invalid), 0o644))
+		_, err := cfg.networks()
+		assert.Assert(t, err != nil, invalid)
+	}
+}
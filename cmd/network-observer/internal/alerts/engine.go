@@ -0,0 +1,400 @@
+// Package alerts evaluates declarative alert rules against the records held
+// by the network observer collector.
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+)
+
+const (
+	defaultInterval = 15 * time.Second
+	notifyTimeout   = 10 * time.Second
+)
+
+// Notifier is sent the alerts that started firing or were resolved during an
+// evaluation.
+type Notifier interface {
+	Notify(ctx context.Context, alerts []api.AlertRecord) error
+}
+
+type Config struct {
+	Rules []Rule
+	// Interval between rule evaluations. Defaults to 15s.
+	Interval time.Duration
+	// Notifier is optional
+	Notifier Notifier
+}
+
+// Engine keeps the samples needed to evaluate alert rules and the state of
+// each pending and firing alert.
+type Engine struct {
+	logger   *slog.Logger
+	records  store.Interface
+	graph    collector.Graph
+	rules    []Rule
+	interval time.Duration
+	notifier Notifier
+	now      func() time.Time
+
+	// maxWindow is the longest sample window of all rules
+	maxWindow time.Duration
+
+	mu      sync.Mutex
+	samples map[string]*sampleWindow
+	active  map[string]api.AlertRecord
+}
+
+func New(logger *slog.Logger, records store.Interface, graph collector.Graph, cfg Config) *Engine {
+	e := &Engine{
+		logger:   logger,
+		records:  records,
+		graph:    graph,
+		rules:    cfg.Rules,
+		interval: cfg.Interval,
+		notifier: cfg.Notifier,
+		now:      time.Now,
+		samples:  make(map[string]*sampleWindow),
+		active:   make(map[string]api.AlertRecord),
+	}
+	if e.interval <= 0 {
+		e.interval = defaultInterval
+	}
+	for _, rule := range cfg.Rules {
+		e.maxWindow = max(e.maxWindow, time.Duration(rule.Window))
+	}
+	return e
+}
+
+// OnFlowCompleted records latency and error samples from completed
+// connections and requests. It is a collector.FlowCompletedFunc.
+func (e *Engine) OnFlowCompleted(record vanflow.Record) {
+	if e.maxWindow == 0 {
+		return
+	}
+	var (
+		routingKey string
+		s          = sample{At: e.now()}
+	)
+	switch record := record.(type) {
+	case collector.ConnectionRecord:
+		flow, ok := record.GetFlow()
+		if !ok || flow.Latency == nil {
+			return
+		}
+		routingKey, s.Latency = record.RoutingKey, *flow.Latency
+	case collector.RequestRecord:
+		flow, ok := record.GetFlow()
+		if !ok {
+			return
+		}
+		routingKey, s.Request = record.RoutingKey, true
+		if flow.Latency != nil {
+			s.Latency = *flow.Latency
+		}
+		if flow.Result != nil {
+			s.Error = strings.HasPrefix(*flow.Result, "5")
+		}
+	default:
+		return
+	}
+	e.addSample(routingKey, s)
+}
+
+func (e *Engine) addSample(routingKey string, s sample) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	window, ok := e.samples[routingKey]
+	if !ok {
+		window = new(sampleWindow)
+		e.samples[routingKey] = window
+	}
+	window.add(s)
+}
+
+// Alerts returns the pending and firing alerts ordered by identity
+func (e *Engine) Alerts() []api.AlertRecord {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	out := make([]api.AlertRecord, 0, len(e.active))
+	for _, alert := range e.active {
+		out = append(out, alert)
+	}
+	slices.SortFunc(out, func(a, b api.AlertRecord) int {
+		return strings.Compare(a.Identity, b.Identity)
+	})
+	return out
+}
+
+// Run evaluates the rules every interval until ctx is cancelled, sending any
+// changes to the Notifier.
+func (e *Engine) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			changed := e.Evaluate()
+			if e.notifier == nil || len(changed) == 0 {
+				continue
+			}
+			nctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+			if err := e.notifier.Notify(nctx, changed); err != nil {
+				e.logger.Error("failed to send alert notification",
+					slog.Int("alerts", len(changed)),
+					slog.Any("error", err))
+			}
+			cancel()
+		}
+	}
+}
+
+// observation is a subject for which a rule condition currently holds
+type observation struct {
+	SubjectID   string
+	SubjectName string
+	RoutingKey  *string
+	// Sites are the identities and names of the sites of a subject other
+	// than a routing key
+	Sites     []string
+	Value     float64
+	Threshold float64
+	Message   string
+	// Held reports the seconds the condition has held as the Value in place
+	// of a measurement.
+	Held bool
+}
+
+// Evaluate checks each rule and updates the alert states. It returns the
+// alerts that started firing and those that were resolved.
+func (e *Engine) Evaluate() []api.AlertRecord {
+	now := e.now()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, window := range e.samples {
+		window.prune(now.Add(-e.maxWindow))
+	}
+	for key, window := range e.samples {
+		if window.len() == 0 {
+			delete(e.samples, key)
+		}
+	}
+
+	var changed []api.AlertRecord
+	for _, rule := range e.rules {
+		observed := make(map[string]bool)
+		for _, obs := range e.observe(rule, now) {
+			id := rule.Name + "@" + obs.SubjectID
+			observed[id] = true
+			alert, ok := e.active[id]
+			if !ok {
+				alert = api.AlertRecord{
+					Identity:  id,
+					StartTime: uint64(now.UnixMicro()),
+					Rule:      rule.Name,
+					RuleType:  rule.Type,
+					Severity:  rule.Severity,
+					State:     api.Pending,
+				}
+			}
+			alert.SubjectId = obs.SubjectID
+			alert.SubjectName = obs.SubjectName
+			alert.RoutingKey = obs.RoutingKey
+			alert.Sites = nil
+			if obs.Sites != nil {
+				alert.Sites = &obs.Sites
+			}
+			alert.Value = obs.Value
+			alert.Threshold = obs.Threshold
+			alert.Message = obs.Message
+			held := now.Sub(time.UnixMicro(int64(alert.StartTime)))
+			if obs.Held {
+				alert.Value = held.Seconds()
+				alert.Threshold = time.Duration(rule.For).Seconds()
+			}
+			if alert.State == api.Pending && held >= time.Duration(rule.For) {
+				firing := uint64(now.UnixMicro())
+				alert.State = api.Firing
+				alert.FiringTime = &firing
+				changed = append(changed, alert)
+				e.logger.Info("alert firing",
+					slog.String("alert", id),
+					slog.String("message", alert.Message))
+			}
+			e.active[id] = alert
+		}
+		for id, alert := range e.active {
+			if alert.Rule != rule.Name || observed[id] {
+				continue
+			}
+			delete(e.active, id)
+			if alert.State != api.Firing {
+				continue
+			}
+			alert.State = api.Resolved
+			alert.EndTime = uint64(now.UnixMicro())
+			changed = append(changed, alert)
+			e.logger.Info("alert resolved", slog.String("alert", id))
+		}
+	}
+	slices.SortFunc(changed, func(a, b api.AlertRecord) int {
+		return strings.Compare(a.Identity, b.Identity)
+	})
+	return changed
+}
+
+func (e *Engine) observe(rule Rule, now time.Time) []observation {
+	switch rule.Type {
+	case api.Latency:
+		return e.observeLatency(rule, now)
+	case api.ErrorRate:
+		return e.observeErrorRate(rule, now)
+	case api.ConnectorWithoutProcess:
+		return e.observeConnectors(rule)
+	case api.LinkDown:
+		return e.observeLinks()
+	}
+	return nil
+}
+
+// windows returns the samples within the rule window for each routing key
+// the rule applies to.
+func (e *Engine) windows(rule Rule, now time.Time) map[string][]sample {
+	cutoff := now.Add(-time.Duration(rule.Window))
+	out := make(map[string][]sample)
+	for key, window := range e.samples {
+		if rule.RoutingKey != "" && key != rule.RoutingKey {
+			continue
+		}
+		out[key] = window.since(cutoff)
+	}
+	return out
+}
+
+func (e *Engine) observeLatency(rule Rule, now time.Time) []observation {
+	var out []observation
+	threshold := uint64(time.Duration(rule.MaxLatency) / time.Microsecond)
+	for key, samples := range e.windows(rule, now) {
+		latency, n := latencyQuantile(samples, rule.Quantile)
+		if n < rule.MinSamples || latency <= threshold {
+			continue
+		}
+		out = append(out, observation{
+			SubjectID:   key,
+			SubjectName: key,
+			RoutingKey:  &key,
+			Value:       float64(latency),
+			Threshold:   float64(threshold),
+			Message: fmt.Sprintf("p%g latency of %s is %s, above %s",
+				rule.Quantile*100, key, time.Duration(latency)*time.Microsecond, time.Duration(rule.MaxLatency)),
+		})
+	}
+	return out
+}
+
+func (e *Engine) observeErrorRate(rule Rule, now time.Time) []observation {
+	var out []observation
+	for key, samples := range e.windows(rule, now) {
+		percent, n := errorPercent(samples)
+		if n < rule.MinSamples || percent <= rule.MaxErrorPercent {
+			continue
+		}
+		out = append(out, observation{
+			SubjectID:   key,
+			SubjectName: key,
+			RoutingKey:  &key,
+			Value:       percent,
+			Threshold:   rule.MaxErrorPercent,
+			Message: fmt.Sprintf("%.1f%% of requests to %s resulted in a server error, above %g%%",
+				percent, key, rule.MaxErrorPercent),
+		})
+	}
+	return out
+}
+
+func (e *Engine) observeConnectors(rule Rule) []observation {
+	var out []observation
+	for _, entry := range e.records.Index(store.TypeIndex, store.Entry{Record: vanflow.ConnectorRecord{}}) {
+		connector := entry.Record.(vanflow.ConnectorRecord)
+		if connector.EndTime != nil {
+			continue
+		}
+		routingKey := dref(connector.Address)
+		if rule.RoutingKey != "" && routingKey != rule.RoutingKey {
+			continue
+		}
+		if process, ok := e.graph.Connector(connector.ID).Target().GetRecord(); ok && process.EndTime == nil {
+			continue
+		}
+		name := nameOrID(connector.Name, connector.ID)
+		out = append(out, observation{
+			SubjectID:   connector.ID,
+			SubjectName: name,
+			RoutingKey:  connector.Address,
+			Sites:       siteOf(e.graph.Connector(connector.ID).Parent().Parent()),
+			Held:        true,
+			Message:     fmt.Sprintf("connector %s for %s has no live target process", name, routingKey),
+		})
+	}
+	return out
+}
+
+func (e *Engine) observeLinks() []observation {
+	var out []observation
+	for _, entry := range e.records.Index(store.TypeIndex, store.Entry{Record: vanflow.LinkRecord{}}) {
+		link := entry.Record.(vanflow.LinkRecord)
+		if link.EndTime != nil || strings.EqualFold(dref(link.Status), string(api.Up)) {
+			continue
+		}
+		name := nameOrID(link.Name, link.ID)
+		node := e.graph.Link(link.ID)
+		out = append(out, observation{
+			SubjectID:   link.ID,
+			SubjectName: name,
+			Sites:       append(siteOf(node.Parent().Parent()), siteOf(node.Peer().Parent().Parent())...),
+			Held:        true,
+			Message:     fmt.Sprintf("router link %s is down", name),
+		})
+	}
+	return out
+}
+
+// siteOf returns the identity and name of site, or an empty list when it is
+// not known.
+func siteOf(site collector.Site) []string {
+	out := []string{}
+	if record, ok := site.GetRecord(); ok {
+		out = append(out, record.ID)
+		if record.Name != nil {
+			out = append(out, *record.Name)
+		}
+	}
+	return out
+}
+
+func dref[T any](ptr *T) T {
+	var out T
+	if ptr != nil {
+		out = *ptr
+	}
+	return out
+}
+
+func nameOrID(name *string, id string) string {
+	if name == nil || *name == "" {
+		return id
+	}
+	return *name
+}
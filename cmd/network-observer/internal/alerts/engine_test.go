@@ -0,0 +1,279 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestEngine(t *testing.T, rules ...Rule) (*Engine, store.Interface, *fakeClock) {
+	t.Helper()
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	graph := collector.NewGraph(stor)
+	for i, rule := range rules {
+		rule, err := rule.withDefaults()
+		assert.Assert(t, err)
+		rules[i] = rule
+	}
+	clock := &fakeClock{now: time.Unix(1_700_000_000, 0)}
+	engine := New(slog.Default(), stor, graph, Config{Rules: rules})
+	engine.now = clock.Now
+	return engine, stor, clock
+}
+
+func TestSampleWindow(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	at := func(i int) time.Time { return start.Add(time.Duration(i) * time.Millisecond) }
+	var w sampleWindow
+	for i := 0; i < maxSamplesPerKey+10; i++ {
+		w.add(sample{At: at(i), Latency: uint64(i)})
+	}
+	assert.Equal(t, w.len(), maxSamplesPerKey)
+	samples := w.since(time.Time{})
+	assert.Equal(t, samples[0].Latency, uint64(10))
+	assert.Equal(t, samples[len(samples)-1].Latency, uint64(maxSamplesPerKey+9))
+
+	w.prune(at(maxSamplesPerKey))
+	assert.Equal(t, w.len(), 10)
+	// the window grows again once pruned samples are replaced
+	for i := maxSamplesPerKey + 10; i < maxSamplesPerKey+20; i++ {
+		w.add(sample{At: at(i), Latency: uint64(i)})
+	}
+	samples = w.since(at(maxSamplesPerKey + 5))
+	assert.Equal(t, len(samples), 15)
+	for i, s := range samples {
+		assert.Equal(t, s.Latency, uint64(maxSamplesPerKey+5+i))
+	}
+}
+
+func TestLatencyRule(t *testing.T) {
+	engine, _, clock := newTestEngine(t, Rule{
+		Name:       "slow-backend",
+		Type:       api.Latency,
+		RoutingKey: "backend",
+		MaxLatency: Duration(100 * time.Millisecond),
+		MinSamples: 5,
+		Window:     Duration(time.Minute),
+		For:        Duration(30 * time.Second),
+	})
+	for i := range 10 {
+		latency := uint64(20_000)
+		if i == 9 {
+			latency = 400_000
+		}
+		engine.addSample("backend", sample{At: clock.now, Latency: latency, Request: true})
+		engine.addSample("frontend", sample{At: clock.now, Latency: 900_000, Request: true})
+	}
+
+	assert.Equal(t, len(engine.Evaluate()), 0)
+	alerts := engine.Alerts()
+	assert.Equal(t, len(alerts), 1)
+	assert.Equal(t, alerts[0].Identity, "slow-backend@backend")
+	assert.Equal(t, alerts[0].State, api.Pending)
+	assert.Equal(t, alerts[0].Value, float64(400_000))
+	assert.Equal(t, alerts[0].Threshold, float64(100_000))
+
+	clock.Advance(30 * time.Second)
+	changed := engine.Evaluate()
+	assert.Equal(t, len(changed), 1)
+	assert.Equal(t, changed[0].State, api.Firing)
+	assert.Assert(t, changed[0].FiringTime != nil)
+
+	// samples age out of the window
+	clock.Advance(time.Minute)
+	changed = engine.Evaluate()
+	assert.Equal(t, len(changed), 1)
+	assert.Equal(t, changed[0].State, api.Resolved)
+	assert.Equal(t, len(engine.Alerts()), 0)
+}
+
+func TestLatencyRuleConnections(t *testing.T) {
+	engine, _, clock := newTestEngine(t, Rule{
+		Name:       "slow",
+		Type:       api.Latency,
+		MaxLatency: Duration(time.Millisecond),
+		MinSamples: 1,
+	})
+	flows := store.NewSyncMapStore(store.SyncMapStoreConfig{})
+	flows.Add(vanflow.TransportBiflowRecord{
+		BaseRecord: vanflow.NewBase("tf1", clock.now),
+		Latency:    ptrTo(uint64(5_000)),
+	}, store.SourceRef{})
+	engine.OnFlowCompleted(collector.ConnectionRecord{ID: "tf1", RoutingKey: "db", FlowStore: flows})
+
+	changed := engine.Evaluate()
+	assert.Equal(t, len(changed), 1)
+	assert.Equal(t, changed[0].Identity, "slow@db")
+	assert.Equal(t, *changed[0].RoutingKey, "db")
+}
+
+func TestErrorRateRule(t *testing.T) {
+	engine, _, clock := newTestEngine(t, Rule{
+		Name:            "errors",
+		Type:            api.ErrorRate,
+		MaxErrorPercent: 10,
+	})
+	for i := range 20 {
+		engine.addSample("backend", sample{At: clock.now, Request: true, Error: i%4 == 0})
+		engine.addSample("frontend", sample{At: clock.now, Request: true, Error: i == 0})
+		engine.addSample("tcp", sample{At: clock.now})
+	}
+	changed := engine.Evaluate()
+	assert.Equal(t, len(changed), 1)
+	assert.Equal(t, changed[0].SubjectId, "backend")
+	assert.Equal(t, changed[0].Value, float64(25))
+}
+
+func TestConnectorWithoutProcessRule(t *testing.T) {
+	engine, stor, clock := newTestEngine(t, Rule{
+		Name: "orphaned",
+		Type: api.ConnectorWithoutProcess,
+		For:  Duration(time.Minute),
+	})
+	stor.Replace(wrapRecords(
+		vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1")},
+		vanflow.RouterRecord{BaseRecord: vanflow.NewBase("router-1"), Parent: ptrTo("site-1")},
+		vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("proc-1"), Parent: ptrTo("site-1"), Name: ptrTo("server")},
+		vanflow.ConnectorRecord{BaseRecord: vanflow.NewBase("conn-1"), Parent: ptrTo("router-1"), ProcessID: ptrTo("proc-1"), Address: ptrTo("backend")},
+		vanflow.ConnectorRecord{BaseRecord: vanflow.NewBase("conn-2"), Parent: ptrTo("router-1"), Name: ptrTo("db"), Address: ptrTo("db")},
+	))
+	engine.graph.(interface{ Reset() }).Reset()
+
+	assert.Equal(t, len(engine.Evaluate()), 0)
+	alerts := engine.Alerts()
+	assert.Equal(t, len(alerts), 1)
+	assert.Equal(t, alerts[0].SubjectName, "db")
+
+	clock.Advance(time.Minute)
+	changed := engine.Evaluate()
+	assert.Equal(t, len(changed), 1)
+	assert.Equal(t, changed[0].State, api.Firing)
+	assert.Equal(t, changed[0].Value, float64(60))
+}
+
+func TestLinkDownRule(t *testing.T) {
+	engine, stor, clock := newTestEngine(t, Rule{
+		Name: "link-down",
+		Type: api.LinkDown,
+		For:  Duration(10 * time.Second),
+	})
+	stor.Replace(wrapRecords(
+		vanflow.LinkRecord{BaseRecord: vanflow.NewBase("link-1"), Name: ptrTo("west-east"), Status: ptrTo("down")},
+		vanflow.LinkRecord{BaseRecord: vanflow.NewBase("link-2"), Status: ptrTo("up")},
+	))
+	engine.Evaluate()
+	clock.Advance(10 * time.Second)
+	changed := engine.Evaluate()
+	assert.Equal(t, len(changed), 1)
+	assert.Equal(t, changed[0].Identity, "link-down@link-1")
+	assert.Equal(t, changed[0].Message, "router link west-east is down")
+
+	stor.Update(vanflow.LinkRecord{BaseRecord: vanflow.NewBase("link-1"), Status: ptrTo("up")})
+	changed = engine.Evaluate()
+	assert.Equal(t, len(changed), 1)
+	assert.Equal(t, changed[0].State, api.Resolved)
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	received := make(chan WebhookPayload, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload WebhookPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		received <- payload
+	}))
+	defer srv.Close()
+
+	engine, _, clock := newTestEngine(t, Rule{
+		Name:       "slow",
+		Type:       api.Latency,
+		MaxLatency: Duration(time.Millisecond),
+		MinSamples: 1,
+	})
+	engine.notifier = NewWebhookNotifier(srv.URL)
+	engine.interval = time.Millisecond
+	engine.addSample("backend", sample{At: clock.now, Latency: 10_000})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go engine.Run(ctx)
+	select {
+	case payload := <-received:
+		assert.Equal(t, len(payload.Alerts), 1)
+		assert.Equal(t, payload.Alerts[0].Identity, "slow@backend")
+		assert.Equal(t, payload.Alerts[0].State, api.Firing)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for webhook")
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rules.yaml")
+	assert.Assert(t, os.WriteFile(path, []byte(`
+rules:
+  - name: backend-p99
+    type: latency
+    routingKey: backend
+    maxLatency: 250ms
+    for: 1m
+  - name: links
+    type: link-down
+    severity: critical
+`), 0o644))
+	rules, err := LoadRules(path)
+	assert.Assert(t, err)
+	assert.Equal(t, len(rules), 2)
+	assert.Equal(t, rules[0].Quantile, 0.99)
+	assert.Equal(t, time.Duration(rules[0].MaxLatency), 250*time.Millisecond)
+	assert.Equal(t, time.Duration(rules[0].Window), 5*time.Minute)
+	assert.Equal(t, rules[0].Severity, "warning")
+	assert.Equal(t, rules[1].Severity, "critical")
+
+	for _, invalid := range []string{
+		"rules: [{name: a, type: latency}]",
+		"rules: [{name: a, type: unknown}]",
+		"rules: [{type: link-down}]",
+		"rules: [{name: a, type: link-down}, {name: a, type: link-down}]",
+		"rules: [{name: a, type: link-down, for: 10}]",
+		"rules: [{name: a, type: link-down, unknownField: true}]",
+	} {
+		assert.Assert(t, os.WriteFile(path, []byte(invalid), 0o644))
+		_, err := LoadRules(path)
+		assert.Assert(t, err != nil, invalid)
+	}
+}
+
+func wrapRecords(records ...vanflow.Record) []store.Entry {
+	out := make([]store.Entry, len(records))
+	for i := range records {
+		out[i].Record = records[i]
+	}
+	return out
+}
+
+func ptrTo[T any](c T) *T {
+	return &c
+}
@@ -0,0 +1,158 @@
+package alerts
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	defaultQuantile   = 0.99
+	defaultWindow     = 5 * time.Minute
+	defaultMinSamples = 10
+	defaultSeverity   = "warning"
+)
+
+// Duration is a time.Duration that is read from and written as a string
+// such as "30s" or "5m".
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("duration must be a string such as \"30s\": %s", err)
+	}
+	parsed, err := time.ParseDuration(raw)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Rule describes a condition that raises an alert for each subject it holds
+// for.
+//
+// Latency and error-rate rules are evaluated per routing key over the
+// connections and requests completed within Window. Latency rules use request
+// latencies when the routing key carries application requests and connection
+// latencies otherwise. Connector-without-process rules raise an alert for
+// each connector without a live target process and link-down rules for each
+// router link that is not up.
+type Rule struct {
+	Name     string            `json:"name"`
+	Type     api.AlertRuleType `json:"type"`
+	Severity string            `json:"severity,omitempty"`
+	// RoutingKey restricts the rule to a single routing key. When empty the
+	// rule applies to all routing keys.
+	RoutingKey string `json:"routingKey,omitempty"`
+	// For is how long the condition must hold before the alert fires.
+	For Duration `json:"for,omitempty"`
+
+	// Window is the period over which latency and error-rate samples are
+	// considered. Defaults to 5m.
+	Window Duration `json:"window,omitempty"`
+	// MinSamples is the number of samples within Window required before
+	// latency and error-rate rules are evaluated. Defaults to 10.
+	MinSamples int `json:"minSamples,omitempty"`
+
+	// Quantile of the latency distribution compared to MaxLatency. Defaults
+	// to 0.99.
+	Quantile   float64  `json:"quantile,omitempty"`
+	MaxLatency Duration `json:"maxLatency,omitempty"`
+
+	// MaxErrorPercent is the percentage of requests with a server error
+	// (5xx) result above which error-rate rules fire.
+	MaxErrorPercent float64 `json:"maxErrorPercent,omitempty"`
+}
+
+// RuleSet is the document containing alert rules
+type RuleSet struct {
+	Rules []Rule `json:"rules"`
+}
+
+// LoadRules reads a RuleSet from a YAML or JSON file and validates it.
+func LoadRules(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var set RuleSet
+	if err := yaml.UnmarshalStrict(data, &set); err != nil {
+		return nil, fmt.Errorf("error parsing alert rules: %s", err)
+	}
+	for i := range set.Rules {
+		rule, err := set.Rules[i].withDefaults()
+		if err != nil {
+			return nil, err
+		}
+		set.Rules[i] = rule
+	}
+	if err := validateUnique(set.Rules); err != nil {
+		return nil, err
+	}
+	return set.Rules, nil
+}
+
+func validateUnique(rules []Rule) error {
+	names := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		if names[rule.Name] {
+			return fmt.Errorf("duplicate alert rule name %q", rule.Name)
+		}
+		names[rule.Name] = true
+	}
+	return nil
+}
+
+func (r Rule) withDefaults() (Rule, error) {
+	if r.Name == "" {
+		return r, fmt.Errorf("alert rule must have a name")
+	}
+	if r.Severity == "" {
+		r.Severity = defaultSeverity
+	}
+	if r.For < 0 {
+		return r, fmt.Errorf("alert rule %q: for must not be negative", r.Name)
+	}
+	switch r.Type {
+	case api.Latency, api.ErrorRate:
+		if r.Window == 0 {
+			r.Window = Duration(defaultWindow)
+		}
+		if r.MinSamples == 0 {
+			r.MinSamples = defaultMinSamples
+		}
+		if r.Window < 0 || r.MinSamples < 0 {
+			return r, fmt.Errorf("alert rule %q: window and minSamples must not be negative", r.Name)
+		}
+	}
+	switch r.Type {
+	case api.Latency:
+		if r.Quantile == 0 {
+			r.Quantile = defaultQuantile
+		}
+		if r.Quantile < 0 || r.Quantile > 1 {
+			return r, fmt.Errorf("alert rule %q: quantile must be in the range (0, 1]", r.Name)
+		}
+		if r.MaxLatency <= 0 {
+			return r, fmt.Errorf("alert rule %q: latency rules require a positive maxLatency", r.Name)
+		}
+	case api.ErrorRate:
+		if r.MaxErrorPercent < 0 || r.MaxErrorPercent >= 100 {
+			return r, fmt.Errorf("alert rule %q: maxErrorPercent must be in the range [0, 100)", r.Name)
+		}
+	case api.ConnectorWithoutProcess, api.LinkDown:
+	default:
+		return r, fmt.Errorf("alert rule %q: unknown type %q", r.Name, r.Type)
+	}
+	return r, nil
+}
@@ -0,0 +1,121 @@
+package alerts
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+// maxSamplesPerKey bounds the memory used for each routing key. The oldest
+// samples are discarded first.
+const maxSamplesPerKey = 10_000
+
+type sample struct {
+	At      time.Time
+	Latency uint64
+	Request bool
+	Error   bool
+}
+
+// sampleWindow holds the completed connection and request samples of a
+// routing key in the order they were observed. Once maxSamplesPerKey samples
+// are held it is used as a ring buffer so that adding a sample overwrites the
+// oldest one without moving the others.
+type sampleWindow struct {
+	buf  []sample
+	head int
+	size int
+}
+
+func (w *sampleWindow) len() int {
+	return w.size
+}
+
+// at returns the i-th oldest sample
+func (w *sampleWindow) at(i int) sample {
+	return w.buf[(w.head+i)%len(w.buf)]
+}
+
+func (w *sampleWindow) add(s sample) {
+	switch {
+	case w.size < len(w.buf):
+		w.buf[(w.head+w.size)%len(w.buf)] = s
+		w.size++
+	case len(w.buf) < maxSamplesPerKey:
+		if w.head != 0 {
+			w.buf = slices.Concat(w.buf[w.head:], w.buf[:w.head])
+			w.head = 0
+		}
+		w.buf = append(w.buf, s)
+		w.size++
+	default:
+		w.buf[w.head] = s
+		w.head = (w.head + 1) % len(w.buf)
+	}
+}
+
+// search returns the number of samples observed before cutoff
+func (w *sampleWindow) search(cutoff time.Time) int {
+	return sort.Search(w.size, func(i int) bool {
+		return !w.at(i).At.Before(cutoff)
+	})
+}
+
+// prune discards samples observed before cutoff
+func (w *sampleWindow) prune(cutoff time.Time) {
+	if i := w.search(cutoff); i > 0 {
+		w.head = (w.head + i) % len(w.buf)
+		w.size -= i
+	}
+}
+
+// since returns the samples observed at or after cutoff
+func (w *sampleWindow) since(cutoff time.Time) []sample {
+	out := make([]sample, 0, w.size)
+	for i := w.search(cutoff); i < w.size; i++ {
+		out = append(out, w.at(i))
+	}
+	return out
+}
+
+// latencyQuantile returns the nearest rank quantile of the request latencies
+// in samples, or of the connection latencies when there are no requests.
+func latencyQuantile(samples []sample, q float64) (uint64, int) {
+	var requests, connections []uint64
+	for _, s := range samples {
+		if s.Request {
+			requests = append(requests, s.Latency)
+		} else {
+			connections = append(connections, s.Latency)
+		}
+	}
+	latencies := requests
+	if len(latencies) == 0 {
+		latencies = connections
+	}
+	if len(latencies) == 0 {
+		return 0, 0
+	}
+	slices.Sort(latencies)
+	rank := int(math.Ceil(q*float64(len(latencies)))) - 1
+	return latencies[max(rank, 0)], len(latencies)
+}
+
+// errorPercent returns the percentage of requests in samples that errored
+func errorPercent(samples []sample) (float64, int) {
+	var requests, errors int
+	for _, s := range samples {
+		if !s.Request {
+			continue
+		}
+		requests++
+		if s.Error {
+			errors++
+		}
+	}
+	if requests == 0 {
+		return 0, 0
+	}
+	return 100 * float64(errors) / float64(requests), requests
+}
@@ -0,0 +1,49 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+)
+
+// WebhookPayload is the JSON body posted to the webhook
+type WebhookPayload struct {
+	Alerts []api.AlertRecord `json:"alerts"`
+}
+
+// WebhookNotifier posts alert changes as JSON to a URL
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{URL: url, Client: http.DefaultClient}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, alerts []api.AlertRecord) error {
+	body, err := json.Marshal(WebhookPayload{Alerts: alerts})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
@@ -4,6 +4,21 @@ package api
 // Implements ResponseSetter and CollectionResponseSetter for the generated
 // response objects
 
+// SetCount
+func (r *AlertListResponse) SetCount(v int64) {
+	r.Count = v
+}
+
+// SetResults
+func (r *AlertListResponse) SetResults(v []AlertRecord) {
+	r.Results = v
+}
+
+// SetTimeRangeCount
+func (r *AlertListResponse) SetTimeRangeCount(v int64) {
+	r.TimeRangeCount = v
+}
+
 // SetCount
 func (r *ApplicationFlowResponse) SetCount(v int64) {
 	r.Count = v
@@ -234,6 +249,21 @@ func (r *SiteResponse) SetResults(v SiteRecord) {
 	r.Results = v
 }
 
+// SetCount
+func (r *TimeSeriesListResponse) SetCount(v int64) {
+	r.Count = v
+}
+
+// SetResults
+func (r *TimeSeriesListResponse) SetResults(v []TimeSeriesBucket) {
+	r.Results = v
+}
+
+// SetTimeRangeCount
+func (r *TimeSeriesListResponse) SetTimeRangeCount(v int64) {
+	r.TimeRangeCount = v
+}
+
 // SetCount
 func (r *CollectionResponse) SetCount(v int64) {
 	r.Count = v
@@ -246,6 +276,16 @@ func (r *CollectionResponse) SetTimeRangeCount(v int64) {
 
 // Implements Record interface for the generated record objects
 
+// GetEndTime
+func (r AlertRecord) GetEndTime() uint64 {
+	return r.EndTime
+}
+
+// GetStartTime
+func (r AlertRecord) GetStartTime() uint64 {
+	return r.StartTime
+}
+
 // GetEndTime
 func (r ApplicationFlowRecord) GetEndTime() uint64 {
 	return r.EndTime
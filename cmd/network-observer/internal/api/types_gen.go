@@ -23,6 +23,21 @@ const (
 	Remote   ProcessRecordRole = "remote"
 )
 
+// Defines values for AlertRuleType.
+const (
+	ConnectorWithoutProcess AlertRuleType = "connector-without-process"
+	ErrorRate               AlertRuleType = "error-rate"
+	Latency                 AlertRuleType = "latency"
+	LinkDown                AlertRuleType = "link-down"
+)
+
+// Defines values for AlertState.
+const (
+	Firing   AlertState = "firing"
+	Pending  AlertState = "pending"
+	Resolved AlertState = "resolved"
+)
+
 // Defines values for FlowAggregatePairType.
 const (
 	PROCESS      FlowAggregatePairType = "PROCESS"
@@ -58,6 +73,50 @@ const (
 	SitePlatformTypeUnknown    SitePlatformType = "unknown"
 )
 
+// AlertListResponse defines model for AlertListResponse.
+type AlertListResponse struct {
+	// Count number of results in response
+	Count   int64         `json:"count"`
+	Results []AlertRecord `json:"results"`
+
+	// TimeRangeCount number of results matching filtering and time range constraints before any limit or offset is applied.
+	TimeRangeCount int64 `json:"timeRangeCount"`
+}
+
+// AlertRecord defines model for AlertRecord.
+type AlertRecord struct {
+	// EndTime The end time in microseconds of the record in Unix timestamp format.
+	EndTime uint64 `json:"endTime"`
+
+	// FiringTime The time in microseconds the alert started firing in Unix timestamp format.
+	FiringTime *uint64 `json:"firingTime"`
+
+	// Identity The unique identifier for the record.
+	Identity   string  `json:"identity"`
+	Message    string  `json:"message"`
+	RoutingKey *string `json:"routingKey"`
+
+	// Rule The name of the rule that raised the alert
+	Rule     string        `json:"rule"`
+	RuleType AlertRuleType `json:"ruleType"`
+	Severity string        `json:"severity"`
+
+	// Sites The identities and names of the sites of a connector or link subject. Alerts are only visible to principals restricted to sites when one of these is granted.
+	Sites *[]string `json:"sites,omitempty"`
+
+	// StartTime The creation time in microseconds of the record in Unix timestamp format. The value 0 means that the record is not terminated
+	StartTime uint64     `json:"startTime"`
+	State     AlertState `json:"state"`
+
+	// SubjectId The routing key, connector or link identity the alert applies to
+	SubjectId   string  `json:"subjectId"`
+	SubjectName string  `json:"subjectName"`
+	Threshold   float64 `json:"threshold"`
+
+	// Value The observed value. Latency in microseconds, error rate as a percentage or the seconds the condition has held.
+	Value float64 `json:"value"`
+}
+
 // ApplicationFlowRecord defines model for ApplicationFlowRecord.
 type ApplicationFlowRecord struct {
 	ConnectionId    string  `json:"connectionId"`
@@ -71,8 +130,11 @@ type ApplicationFlowRecord struct {
 	EndTime uint64 `json:"endTime"`
 
 	// Identity The unique identifier for the record.
-	Identity          string `json:"identity"`
-	Method            string `json:"method"`
+	Identity string `json:"identity"`
+	Method   string `json:"method"`
+
+	// Network The name of the application network the record was collected from
+	Network           string `json:"network"`
 	OctetCount        uint64 `json:"octetCount"`
 	OctetReverseCount uint64 `json:"octetReverseCount"`
 	Protocol          string `json:"protocol"`
@@ -160,11 +222,14 @@ type ConnectionRecord struct {
 	EndTime uint64 `json:"endTime"`
 
 	// Identity The unique identifier for the record.
-	Identity          string  `json:"identity"`
-	Latency           uint64  `json:"latency"`
-	LatencyReverse    uint64  `json:"latencyReverse"`
-	ListenerError     *string `json:"listenerError"`
-	ListenerId        string  `json:"listenerId"`
+	Identity       string  `json:"identity"`
+	Latency        uint64  `json:"latency"`
+	LatencyReverse uint64  `json:"latencyReverse"`
+	ListenerError  *string `json:"listenerError"`
+	ListenerId     string  `json:"listenerId"`
+
+	// Network The name of the application network the record was collected from
+	Network           string  `json:"network"`
 	OctetCount        uint64  `json:"octetCount"`
 	OctetReverseCount uint64  `json:"octetReverseCount"`
 	ProcessPairId     *string `json:"processPairId"`
@@ -209,8 +274,11 @@ type ConnectorRecord struct {
 	EndTime uint64 `json:"endTime"`
 
 	// Identity The unique identifier for the record.
-	Identity   string  `json:"identity"`
-	Name       string  `json:"name"`
+	Identity string `json:"identity"`
+	Name     string `json:"name"`
+
+	// Network The name of the application network the record was collected from
+	Network    string  `json:"network"`
 	ProcessId  string  `json:"processId"`
 	Protocol   string  `json:"protocol"`
 	RouterId   string  `json:"routerId"`
@@ -256,7 +324,10 @@ type FlowAggregateRecord struct {
 	EndTime uint64 `json:"endTime"`
 
 	// Identity The unique identifier for the record.
-	Identity       string                `json:"identity"`
+	Identity string `json:"identity"`
+
+	// Network The name of the application network the record was collected from. Unset for component pairs, which may span networks.
+	Network        *string               `json:"network,omitempty"`
 	PairType       FlowAggregatePairType `json:"pairType"`
 	Protocol       string                `json:"protocol"`
 	RecordCount    uint64                `json:"recordCount"`
@@ -293,8 +364,11 @@ type ListenerRecord struct {
 	EndTime uint64 `json:"endTime"`
 
 	// Identity The unique identifier for the record.
-	Identity   string  `json:"identity"`
-	Name       string  `json:"name"`
+	Identity string `json:"identity"`
+	Name     string `json:"name"`
+
+	// Network The name of the application network the record was collected from
+	Network    string  `json:"network"`
 	Protocol   string  `json:"protocol"`
 	RouterId   string  `json:"routerId"`
 	RoutingKey string  `json:"routingKey"`
@@ -341,6 +415,9 @@ type ProcessRecord struct {
 	ImageName *string `json:"imageName"`
 	Name      string  `json:"name"`
 
+	// Network The name of the application network the record was collected from
+	Network string `json:"network"`
+
 	// Role Internal processes are processes related to Skupper. Remote processes are processes indirectly connected, such as a proxy
 	Role     ProcessRecordRole        `json:"role"`
 	Services *[]ServiceIdentifierType `json:"services"`
@@ -385,6 +462,8 @@ type RouterAccessRecord struct {
 	Name      string `json:"name"`
 	Role      string `json:"role"`
 	RouterId  string `json:"routerId"`
+	SiteId    string `json:"siteId"`
+	SiteName  string `json:"siteName"`
 
 	// StartTime The creation time in microseconds of the record in Unix timestamp format. The value 0 means that the record is not terminated
 	StartTime uint64 `json:"startTime"`
@@ -426,8 +505,11 @@ type RouterLinkRecord struct {
 	EndTime uint64 `json:"endTime"`
 
 	// Identity The unique identifier for the record.
-	Identity          string `json:"identity"`
-	Name              string `json:"name"`
+	Identity string `json:"identity"`
+	Name     string `json:"name"`
+
+	// Network The name of the application network the record was collected from
+	Network           string `json:"network"`
 	OctetCount        uint64 `json:"octetCount"`
 	OctetReverseCount uint64 `json:"octetReverseCount"`
 
@@ -476,7 +558,10 @@ type RouterRecord struct {
 	Mode         string  `json:"mode"`
 	Name         string  `json:"name"`
 	Namespace    *string `json:"namespace,omitempty"`
-	SiteId       string  `json:"siteId"`
+
+	// Network The name of the application network the record was collected from
+	Network string `json:"network"`
+	SiteId  string `json:"siteId"`
 
 	// StartTime The creation time in microseconds of the record in Unix timestamp format. The value 0 means that the record is not terminated
 	StartTime uint64 `json:"startTime"`
@@ -515,6 +600,9 @@ type ServiceRecord struct {
 	ListenerCount int    `json:"listenerCount"`
 	Name          string `json:"name"`
 
+	// Network The name of the application network the record was collected from
+	Network string `json:"network"`
+
 	// ObservedApplicationProtocols Array of the observed application level protocols
 	ObservedApplicationProtocols []string `json:"observedApplicationProtocols"`
 	Protocol                     string   `json:"protocol"`
@@ -548,6 +636,9 @@ type SiteRecord struct {
 	Name      string  `json:"name"`
 	Namespace *string `json:"namespace"`
 
+	// Network The name of the application network the record was collected from
+	Network string `json:"network"`
+
 	// Platform The platform used for the site.
 	Platform SitePlatformType `json:"platform"`
 
@@ -567,6 +658,48 @@ type SiteResponse struct {
 	Results SiteRecord `json:"results"`
 }
 
+// TimeSeriesBucket Traffic totals for connections and application flows that started within the bucket. Octet counts are those of connections, spread over the buckets spanned by their lifetime; the octets of application flows are counted by the connections carrying them.
+type TimeSeriesBucket struct {
+	// AverageRequestLatency Mean latency of application requests in microseconds
+	AverageRequestLatency *uint64 `json:"averageRequestLatency"`
+
+	// ConnectionCount Number of connections opened
+	ConnectionCount uint64 `json:"connectionCount"`
+
+	// ConnectionErrorCount Number of connections opened that reported a listener or connector error
+	ConnectionErrorCount uint64 `json:"connectionErrorCount"`
+
+	// EndTime The end of the bucket in microseconds in Unix timestamp format.
+	EndTime           uint64 `json:"endTime"`
+	OctetCount        uint64 `json:"octetCount"`
+	OctetReverseCount uint64 `json:"octetReverseCount"`
+
+	// RequestCount Number of application requests
+	RequestCount uint64 `json:"requestCount"`
+
+	// RequestErrorCount Number of application requests with a server error (5xx) result
+	RequestErrorCount uint64 `json:"requestErrorCount"`
+
+	// StartTime The start of the bucket in microseconds in Unix timestamp format.
+	StartTime uint64 `json:"startTime"`
+}
+
+// TimeSeriesListResponse defines model for TimeSeriesListResponse.
+type TimeSeriesListResponse struct {
+	// Count number of results in response
+	Count   int64              `json:"count"`
+	Results []TimeSeriesBucket `json:"results"`
+
+	// TimeRangeCount number of results matching filtering and time range constraints before any limit or offset is applied.
+	TimeRangeCount int64 `json:"timeRangeCount"`
+}
+
+// AlertRuleType defines model for alertRuleType.
+type AlertRuleType string
+
+// AlertState defines model for alertState.
+type AlertState string
+
 // BaseRecord defines model for baseRecord.
 type BaseRecord struct {
 	// EndTime The end time in microseconds of the record in Unix timestamp format.
@@ -606,6 +739,9 @@ type ServiceIdentifierType = AtmarkDelimitedString
 // SitePlatformType The platform used for the site.
 type SitePlatformType string
 
+// Filter defines model for filter.
+type Filter = string
+
 // PathID defines model for pathID.
 type PathID = string
 
@@ -615,6 +751,9 @@ type ErrorBadRequest = ErrorResponse
 // ErrorNotFound defines model for errorNotFound.
 type ErrorNotFound = ErrorResponse
 
+// GetAlerts defines model for getAlerts.
+type GetAlerts = AlertListResponse
+
 // GetApplicationFlows defines model for getApplicationFlows.
 type GetApplicationFlows = ApplicationFlowResponse
 
@@ -681,9 +820,156 @@ type GetSiteByID = SiteResponse
 // GetSites defines model for getSites.
 type GetSites = SiteListResponse
 
+// GetTimeSeries defines model for getTimeSeries.
+type GetTimeSeries = TimeSeriesListResponse
+
 // NotSupported defines model for notSupported.
 type NotSupported = ErrorResponse
 
+// AlertsParams defines parameters for Alerts.
+type AlertsParams struct {
+	// Filter Expression the returned records must match. Comparisons of record fields with values using ==, !=, <, <=, > and >= are combined with and, or, not and parentheses, for example `sourceSiteName == "east" and (latency > 10ms or protocol != "tcp")`. Values are double quoted strings, numbers, durations in microseconds such as 10ms, true, false and null. Filters on the routing key, site, process, protocol and network of connections and application flows that only reference their identity, network, protocol, routing key and source or destination site and process are evaluated by the record store, using its indexes of routing keys and site identities for equality comparisons.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
+
+// ApplicationflowsParams defines parameters for Applicationflows.
+type ApplicationflowsParams struct {
+	// Filter Expression the returned records must match. Comparisons of record fields with values using ==, !=, <, <=, > and >= are combined with and, or, not and parentheses, for example `sourceSiteName == "east" and (latency > 10ms or protocol != "tcp")`. Values are double quoted strings, numbers, durations in microseconds such as 10ms, true, false and null. Filters on the routing key, site, process, protocol and network of connections and application flows that only reference their identity, network, protocol, routing key and source or destination site and process are evaluated by the record store, using its indexes of routing keys and site identities for equality comparisons.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
+
+// ComponentpairsParams defines parameters for Componentpairs.
+type ComponentpairsParams struct {
+	// Filter Expression the returned records must match. Comparisons of record fields with values using ==, !=, <, <=, > and >= are combined with and, or, not and parentheses, for example `sourceSiteName == "east" and (latency > 10ms or protocol != "tcp")`. Values are double quoted strings, numbers, durations in microseconds such as 10ms, true, false and null. Filters on the routing key, site, process, protocol and network of connections and application flows that only reference their identity, network, protocol, routing key and source or destination site and process are evaluated by the record store, using its indexes of routing keys and site identities for equality comparisons.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
+
+// ComponentsParams defines parameters for Components.
+type ComponentsParams struct {
+	// Filter Expression the returned records must match. Comparisons of record fields with values using ==, !=, <, <=, > and >= are combined with and, or, not and parentheses, for example `sourceSiteName == "east" and (latency > 10ms or protocol != "tcp")`. Values are double quoted strings, numbers, durations in microseconds such as 10ms, true, false and null. Filters on the routing key, site, process, protocol and network of connections and application flows that only reference their identity, network, protocol, routing key and source or destination site and process are evaluated by the record store, using its indexes of routing keys and site identities for equality comparisons.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
+
+// ConnectionsParams defines parameters for Connections.
+type ConnectionsParams struct {
+	// Filter Expression the returned records must match. Comparisons of record fields with values using ==, !=, <, <=, > and >= are combined with and, or, not and parentheses, for example `sourceSiteName == "east" and (latency > 10ms or protocol != "tcp")`. Values are double quoted strings, numbers, durations in microseconds such as 10ms, true, false and null. Filters on the routing key, site, process, protocol and network of connections and application flows that only reference their identity, network, protocol, routing key and source or destination site and process are evaluated by the record store, using its indexes of routing keys and site identities for equality comparisons.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
+
+// ConnectorsParams defines parameters for Connectors.
+type ConnectorsParams struct {
+	// Filter Expression the returned records must match. Comparisons of record fields with values using ==, !=, <, <=, > and >= are combined with and, or, not and parentheses, for example `sourceSiteName == "east" and (latency > 10ms or protocol != "tcp")`. Values are double quoted strings, numbers, durations in microseconds such as 10ms, true, false and null. Filters on the routing key, site, process, protocol and network of connections and application flows that only reference their identity, network, protocol, routing key and source or destination site and process are evaluated by the record store, using its indexes of routing keys and site identities for equality comparisons.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
+
+// EventsParams defines parameters for Events.
+type EventsParams struct {
+	// Type Record types to stream, such as site, process, connection or applicationflow. Accepts a comma separated list.
+	Type *[]string `form:"type,omitempty" json:"type,omitempty"`
+
+	// Site Site identities to stream records for. Accepts a comma separated list.
+	Site *[]string `form:"site,omitempty" json:"site,omitempty"`
+
+	// Service Routing keys to stream records for. Accepts a comma separated list.
+	Service *[]string `form:"service,omitempty" json:"service,omitempty"`
+}
+
+// HostsParams defines parameters for Hosts.
+type HostsParams struct {
+	// Filter Expression the returned records must match. Comparisons of record fields with values using ==, !=, <, <=, > and >= are combined with and, or, not and parentheses, for example `sourceSiteName == "east" and (latency > 10ms or protocol != "tcp")`. Values are double quoted strings, numbers, durations in microseconds such as 10ms, true, false and null. Filters on the routing key, site, process, protocol and network of connections and application flows that only reference their identity, network, protocol, routing key and source or destination site and process are evaluated by the record store, using its indexes of routing keys and site identities for equality comparisons.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
+
+// ListenersParams defines parameters for Listeners.
+type ListenersParams struct {
+	// Filter Expression the returned records must match. Comparisons of record fields with values using ==, !=, <, <=, > and >= are combined with and, or, not and parentheses, for example `sourceSiteName == "east" and (latency > 10ms or protocol != "tcp")`. Values are double quoted strings, numbers, durations in microseconds such as 10ms, true, false and null. Filters on the routing key, site, process, protocol and network of connections and application flows that only reference their identity, network, protocol, routing key and source or destination site and process are evaluated by the record store, using its indexes of routing keys and site identities for equality comparisons.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
+
+// ProcessesParams defines parameters for Processes.
+type ProcessesParams struct {
+	// Filter Expression the returned records must match. Comparisons of record fields with values using ==, !=, <, <=, > and >= are combined with and, or, not and parentheses, for example `sourceSiteName == "east" and (latency > 10ms or protocol != "tcp")`. Values are double quoted strings, numbers, durations in microseconds such as 10ms, true, false and null. Filters on the routing key, site, process, protocol and network of connections and application flows that only reference their identity, network, protocol, routing key and source or destination site and process are evaluated by the record store, using its indexes of routing keys and site identities for equality comparisons.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
+
+// ProcesspairsParams defines parameters for Processpairs.
+type ProcesspairsParams struct {
+	// Filter Expression the returned records must match. Comparisons of record fields with values using ==, !=, <, <=, > and >= are combined with and, or, not and parentheses, for example `sourceSiteName == "east" and (latency > 10ms or protocol != "tcp")`. Values are double quoted strings, numbers, durations in microseconds such as 10ms, true, false and null. Filters on the routing key, site, process, protocol and network of connections and application flows that only reference their identity, network, protocol, routing key and source or destination site and process are evaluated by the record store, using its indexes of routing keys and site identities for equality comparisons.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
+
+// RouteraccessParams defines parameters for Routeraccess.
+type RouteraccessParams struct {
+	// Filter Expression the returned records must match. Comparisons of record fields with values using ==, !=, <, <=, > and >= are combined with and, or, not and parentheses, for example `sourceSiteName == "east" and (latency > 10ms or protocol != "tcp")`. Values are double quoted strings, numbers, durations in microseconds such as 10ms, true, false and null. Filters on the routing key, site, process, protocol and network of connections and application flows that only reference their identity, network, protocol, routing key and source or destination site and process are evaluated by the record store, using its indexes of routing keys and site identities for equality comparisons.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
+
+// RouterlinksParams defines parameters for Routerlinks.
+type RouterlinksParams struct {
+	// Filter Expression the returned records must match. Comparisons of record fields with values using ==, !=, <, <=, > and >= are combined with and, or, not and parentheses, for example `sourceSiteName == "east" and (latency > 10ms or protocol != "tcp")`. Values are double quoted strings, numbers, durations in microseconds such as 10ms, true, false and null. Filters on the routing key, site, process, protocol and network of connections and application flows that only reference their identity, network, protocol, routing key and source or destination site and process are evaluated by the record store, using its indexes of routing keys and site identities for equality comparisons.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
+
+// RoutersParams defines parameters for Routers.
+type RoutersParams struct {
+	// Filter Expression the returned records must match. Comparisons of record fields with values using ==, !=, <, <=, > and >= are combined with and, or, not and parentheses, for example `sourceSiteName == "east" and (latency > 10ms or protocol != "tcp")`. Values are double quoted strings, numbers, durations in microseconds such as 10ms, true, false and null. Filters on the routing key, site, process, protocol and network of connections and application flows that only reference their identity, network, protocol, routing key and source or destination site and process are evaluated by the record store, using its indexes of routing keys and site identities for equality comparisons.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
+
+// ServicesParams defines parameters for Services.
+type ServicesParams struct {
+	// Filter Expression the returned records must match. Comparisons of record fields with values using ==, !=, <, <=, > and >= are combined with and, or, not and parentheses, for example `sourceSiteName == "east" and (latency > 10ms or protocol != "tcp")`. Values are double quoted strings, numbers, durations in microseconds such as 10ms, true, false and null. Filters on the routing key, site, process, protocol and network of connections and application flows that only reference their identity, network, protocol, routing key and source or destination site and process are evaluated by the record store, using its indexes of routing keys and site identities for equality comparisons.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
+
+// ConnectionsByServiceParams defines parameters for ConnectionsByService.
+type ConnectionsByServiceParams struct {
+	// Filter Expression the returned records must match. Comparisons of record fields with values using ==, !=, <, <=, > and >= are combined with and, or, not and parentheses, for example `sourceSiteName == "east" and (latency > 10ms or protocol != "tcp")`. Values are double quoted strings, numbers, durations in microseconds such as 10ms, true, false and null. Filters on the routing key, site, process, protocol and network of connections and application flows that only reference their identity, network, protocol, routing key and source or destination site and process are evaluated by the record store, using its indexes of routing keys and site identities for equality comparisons.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
+
+// ProcessesByServiceParams defines parameters for ProcessesByService.
+type ProcessesByServiceParams struct {
+	// Filter Expression the returned records must match. Comparisons of record fields with values using ==, !=, <, <=, > and >= are combined with and, or, not and parentheses, for example `sourceSiteName == "east" and (latency > 10ms or protocol != "tcp")`. Values are double quoted strings, numbers, durations in microseconds such as 10ms, true, false and null. Filters on the routing key, site, process, protocol and network of connections and application flows that only reference their identity, network, protocol, routing key and source or destination site and process are evaluated by the record store, using its indexes of routing keys and site identities for equality comparisons.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
+
+// ProcessPairsByServiceParams defines parameters for ProcessPairsByService.
+type ProcessPairsByServiceParams struct {
+	// Filter Expression the returned records must match. Comparisons of record fields with values using ==, !=, <, <=, > and >= are combined with and, or, not and parentheses, for example `sourceSiteName == "east" and (latency > 10ms or protocol != "tcp")`. Values are double quoted strings, numbers, durations in microseconds such as 10ms, true, false and null. Filters on the routing key, site, process, protocol and network of connections and application flows that only reference their identity, network, protocol, routing key and source or destination site and process are evaluated by the record store, using its indexes of routing keys and site identities for equality comparisons.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
+
+// SitepairsParams defines parameters for Sitepairs.
+type SitepairsParams struct {
+	// Filter Expression the returned records must match. Comparisons of record fields with values using ==, !=, <, <=, > and >= are combined with and, or, not and parentheses, for example `sourceSiteName == "east" and (latency > 10ms or protocol != "tcp")`. Values are double quoted strings, numbers, durations in microseconds such as 10ms, true, false and null. Filters on the routing key, site, process, protocol and network of connections and application flows that only reference their identity, network, protocol, routing key and source or destination site and process are evaluated by the record store, using its indexes of routing keys and site identities for equality comparisons.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
+
+// SitesParams defines parameters for Sites.
+type SitesParams struct {
+	// Filter Expression the returned records must match. Comparisons of record fields with values using ==, !=, <, <=, > and >= are combined with and, or, not and parentheses, for example `sourceSiteName == "east" and (latency > 10ms or protocol != "tcp")`. Values are double quoted strings, numbers, durations in microseconds such as 10ms, true, false and null. Filters on the routing key, site, process, protocol and network of connections and application flows that only reference their identity, network, protocol, routing key and source or destination site and process are evaluated by the record store, using its indexes of routing keys and site identities for equality comparisons.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
+
+// HostsBySiteParams defines parameters for HostsBySite.
+type HostsBySiteParams struct {
+	// Filter Expression the returned records must match. Comparisons of record fields with values using ==, !=, <, <=, > and >= are combined with and, or, not and parentheses, for example `sourceSiteName == "east" and (latency > 10ms or protocol != "tcp")`. Values are double quoted strings, numbers, durations in microseconds such as 10ms, true, false and null. Filters on the routing key, site, process, protocol and network of connections and application flows that only reference their identity, network, protocol, routing key and source or destination site and process are evaluated by the record store, using its indexes of routing keys and site identities for equality comparisons.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
+
+// ProcessesBySiteParams defines parameters for ProcessesBySite.
+type ProcessesBySiteParams struct {
+	// Filter Expression the returned records must match. Comparisons of record fields with values using ==, !=, <, <=, > and >= are combined with and, or, not and parentheses, for example `sourceSiteName == "east" and (latency > 10ms or protocol != "tcp")`. Values are double quoted strings, numbers, durations in microseconds such as 10ms, true, false and null. Filters on the routing key, site, process, protocol and network of connections and application flows that only reference their identity, network, protocol, routing key and source or destination site and process are evaluated by the record store, using its indexes of routing keys and site identities for equality comparisons.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
+
+// RoutersBySiteParams defines parameters for RoutersBySite.
+type RoutersBySiteParams struct {
+	// Filter Expression the returned records must match. Comparisons of record fields with values using ==, !=, <, <=, > and >= are combined with and, or, not and parentheses, for example `sourceSiteName == "east" and (latency > 10ms or protocol != "tcp")`. Values are double quoted strings, numbers, durations in microseconds such as 10ms, true, false and null. Filters on the routing key, site, process, protocol and network of connections and application flows that only reference their identity, network, protocol, routing key and source or destination site and process are evaluated by the record store, using its indexes of routing keys and site identities for equality comparisons.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -757,111 +1043,141 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// Alerts request
+	Alerts(ctx context.Context, params *AlertsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// Applicationflows request
-	Applicationflows(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	Applicationflows(ctx context.Context, params *ApplicationflowsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// Componentpairs request
-	Componentpairs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	Componentpairs(ctx context.Context, params *ComponentpairsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// ComponentpairByID request
 	ComponentpairByID(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// Components request
-	Components(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	Components(ctx context.Context, params *ComponentsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// ComponentByID request
 	ComponentByID(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// Connections request
-	Connections(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	Connections(ctx context.Context, params *ConnectionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// Connectors request
-	Connectors(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	Connectors(ctx context.Context, params *ConnectorsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// ConnectorByID request
 	ConnectorByID(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// Events request
+	Events(ctx context.Context, params *EventsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// Hosts request
-	Hosts(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	Hosts(ctx context.Context, params *HostsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// HostsByID request
 	HostsByID(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// Listeners request
-	Listeners(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	Listeners(ctx context.Context, params *ListenersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// ListenerByID request
 	ListenerByID(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// Processes request
-	Processes(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	Processes(ctx context.Context, params *ProcessesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// ProcessById request
 	ProcessById(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// Processpairs request
-	Processpairs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	Processpairs(ctx context.Context, params *ProcesspairsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// ProcesspairByID request
 	ProcesspairByID(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// TimeSeriesByProcesspair request
+	TimeSeriesByProcesspair(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// Routeraccess request
-	Routeraccess(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	Routeraccess(ctx context.Context, params *RouteraccessParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// RouteraccessByID request
 	RouteraccessByID(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// Routerlinks request
-	Routerlinks(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	Routerlinks(ctx context.Context, params *RouterlinksParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// RouterlinkByID request
 	RouterlinkByID(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// Routers request
-	Routers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	Routers(ctx context.Context, params *RoutersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// RouterByID request
 	RouterByID(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// Services request
-	Services(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	Services(ctx context.Context, params *ServicesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// ServiceByID request
 	ServiceByID(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// ConnectionsByService request
-	ConnectionsByService(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
+	ConnectionsByService(ctx context.Context, id PathID, params *ConnectionsByServiceParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// ProcessesByService request
-	ProcessesByService(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
+	ProcessesByService(ctx context.Context, id PathID, params *ProcessesByServiceParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// ProcessPairsByService request
-	ProcessPairsByService(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
+	ProcessPairsByService(ctx context.Context, id PathID, params *ProcessPairsByServiceParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TimeSeriesByService request
+	TimeSeriesByService(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// Sitepairs request
-	Sitepairs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	Sitepairs(ctx context.Context, params *SitepairsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// SitepairByID request
 	SitepairByID(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// TimeSeriesBySitepair request
+	TimeSeriesBySitepair(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// Sites request
-	Sites(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	Sites(ctx context.Context, params *SitesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// SiteById request
 	SiteById(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// HostsBySite request
-	HostsBySite(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
+	HostsBySite(ctx context.Context, id PathID, params *HostsBySiteParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// ProcessesBySite request
-	ProcessesBySite(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
+	ProcessesBySite(ctx context.Context, id PathID, params *ProcessesBySiteParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// RoutersBySite request
-	RoutersBySite(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
+	RoutersBySite(ctx context.Context, id PathID, params *RoutersBySiteParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Topology request
+	Topology(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) Alerts(ctx context.Context, params *AlertsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAlertsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-func (c *Client) Applicationflows(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewApplicationflowsRequest(c.Server)
+func (c *Client) Applicationflows(ctx context.Context, params *ApplicationflowsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewApplicationflowsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -872,8 +1188,8 @@ func (c *Client) Applicationflows(ctx context.Context, reqEditors ...RequestEdit
 	return c.Client.Do(req)
 }
 
-func (c *Client) Componentpairs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewComponentpairsRequest(c.Server)
+func (c *Client) Componentpairs(ctx context.Context, params *ComponentpairsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewComponentpairsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -896,8 +1212,8 @@ func (c *Client) ComponentpairByID(ctx context.Context, id PathID, reqEditors ..
 	return c.Client.Do(req)
 }
 
-func (c *Client) Components(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewComponentsRequest(c.Server)
+func (c *Client) Components(ctx context.Context, params *ComponentsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewComponentsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -920,8 +1236,8 @@ func (c *Client) ComponentByID(ctx context.Context, id PathID, reqEditors ...Req
 	return c.Client.Do(req)
 }
 
-func (c *Client) Connections(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewConnectionsRequest(c.Server)
+func (c *Client) Connections(ctx context.Context, params *ConnectionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewConnectionsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -932,8 +1248,8 @@ func (c *Client) Connections(ctx context.Context, reqEditors ...RequestEditorFn)
 	return c.Client.Do(req)
 }
 
-func (c *Client) Connectors(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewConnectorsRequest(c.Server)
+func (c *Client) Connectors(ctx context.Context, params *ConnectorsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewConnectorsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -956,8 +1272,20 @@ func (c *Client) ConnectorByID(ctx context.Context, id PathID, reqEditors ...Req
 	return c.Client.Do(req)
 }
 
-func (c *Client) Hosts(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewHostsRequest(c.Server)
+func (c *Client) Events(ctx context.Context, params *EventsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewEventsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Hosts(ctx context.Context, params *HostsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewHostsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -980,8 +1308,8 @@ func (c *Client) HostsByID(ctx context.Context, id PathID, reqEditors ...Request
 	return c.Client.Do(req)
 }
 
-func (c *Client) Listeners(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewListenersRequest(c.Server)
+func (c *Client) Listeners(ctx context.Context, params *ListenersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListenersRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -1004,8 +1332,8 @@ func (c *Client) ListenerByID(ctx context.Context, id PathID, reqEditors ...Requ
 	return c.Client.Do(req)
 }
 
-func (c *Client) Processes(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewProcessesRequest(c.Server)
+func (c *Client) Processes(ctx context.Context, params *ProcessesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewProcessesRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -1028,8 +1356,8 @@ func (c *Client) ProcessById(ctx context.Context, id PathID, reqEditors ...Reque
 	return c.Client.Do(req)
 }
 
-func (c *Client) Processpairs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewProcesspairsRequest(c.Server)
+func (c *Client) Processpairs(ctx context.Context, params *ProcesspairsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewProcesspairsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -1052,8 +1380,20 @@ func (c *Client) ProcesspairByID(ctx context.Context, id PathID, reqEditors ...R
 	return c.Client.Do(req)
 }
 
-func (c *Client) Routeraccess(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewRouteraccessRequest(c.Server)
+func (c *Client) TimeSeriesByProcesspair(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTimeSeriesByProcesspairRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Routeraccess(ctx context.Context, params *RouteraccessParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRouteraccessRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -1076,8 +1416,8 @@ func (c *Client) RouteraccessByID(ctx context.Context, id PathID, reqEditors ...
 	return c.Client.Do(req)
 }
 
-func (c *Client) Routerlinks(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewRouterlinksRequest(c.Server)
+func (c *Client) Routerlinks(ctx context.Context, params *RouterlinksParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRouterlinksRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -1100,8 +1440,8 @@ func (c *Client) RouterlinkByID(ctx context.Context, id PathID, reqEditors ...Re
 	return c.Client.Do(req)
 }
 
-func (c *Client) Routers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewRoutersRequest(c.Server)
+func (c *Client) Routers(ctx context.Context, params *RoutersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRoutersRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -1124,8 +1464,8 @@ func (c *Client) RouterByID(ctx context.Context, id PathID, reqEditors ...Reques
 	return c.Client.Do(req)
 }
 
-func (c *Client) Services(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewServicesRequest(c.Server)
+func (c *Client) Services(ctx context.Context, params *ServicesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewServicesRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -1148,8 +1488,20 @@ func (c *Client) ServiceByID(ctx context.Context, id PathID, reqEditors ...Reque
 	return c.Client.Do(req)
 }
 
-func (c *Client) ConnectionsByService(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewConnectionsByServiceRequest(c.Server, id)
+func (c *Client) ConnectionsByService(ctx context.Context, id PathID, params *ConnectionsByServiceParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewConnectionsByServiceRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ProcessesByService(ctx context.Context, id PathID, params *ProcessesByServiceParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewProcessesByServiceRequest(c.Server, id, params)
 	if err != nil {
 		return nil, err
 	}
@@ -1160,8 +1512,8 @@ func (c *Client) ConnectionsByService(ctx context.Context, id PathID, reqEditors
 	return c.Client.Do(req)
 }
 
-func (c *Client) ProcessesByService(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewProcessesByServiceRequest(c.Server, id)
+func (c *Client) ProcessPairsByService(ctx context.Context, id PathID, params *ProcessPairsByServiceParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewProcessPairsByServiceRequest(c.Server, id, params)
 	if err != nil {
 		return nil, err
 	}
@@ -1172,8 +1524,8 @@ func (c *Client) ProcessesByService(ctx context.Context, id PathID, reqEditors .
 	return c.Client.Do(req)
 }
 
-func (c *Client) ProcessPairsByService(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewProcessPairsByServiceRequest(c.Server, id)
+func (c *Client) TimeSeriesByService(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTimeSeriesByServiceRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
@@ -1184,8 +1536,8 @@ func (c *Client) ProcessPairsByService(ctx context.Context, id PathID, reqEditor
 	return c.Client.Do(req)
 }
 
-func (c *Client) Sitepairs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewSitepairsRequest(c.Server)
+func (c *Client) Sitepairs(ctx context.Context, params *SitepairsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSitepairsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -1208,8 +1560,20 @@ func (c *Client) SitepairByID(ctx context.Context, id PathID, reqEditors ...Requ
 	return c.Client.Do(req)
 }
 
-func (c *Client) Sites(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewSitesRequest(c.Server)
+func (c *Client) TimeSeriesBySitepair(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTimeSeriesBySitepairRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Sites(ctx context.Context, params *SitesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSitesRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -1232,8 +1596,20 @@ func (c *Client) SiteById(ctx context.Context, id PathID, reqEditors ...RequestE
 	return c.Client.Do(req)
 }
 
-func (c *Client) HostsBySite(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewHostsBySiteRequest(c.Server, id)
+func (c *Client) HostsBySite(ctx context.Context, id PathID, params *HostsBySiteParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewHostsBySiteRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ProcessesBySite(ctx context.Context, id PathID, params *ProcessesBySiteParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewProcessesBySiteRequest(c.Server, id, params)
 	if err != nil {
 		return nil, err
 	}
@@ -1244,8 +1620,8 @@ func (c *Client) HostsBySite(ctx context.Context, id PathID, reqEditors ...Reque
 	return c.Client.Do(req)
 }
 
-func (c *Client) ProcessesBySite(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewProcessesBySiteRequest(c.Server, id)
+func (c *Client) RoutersBySite(ctx context.Context, id PathID, params *RoutersBySiteParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRoutersBySiteRequest(c.Server, id, params)
 	if err != nil {
 		return nil, err
 	}
@@ -1256,8 +1632,8 @@ func (c *Client) ProcessesBySite(ctx context.Context, id PathID, reqEditors ...R
 	return c.Client.Do(req)
 }
 
-func (c *Client) RoutersBySite(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewRoutersBySiteRequest(c.Server, id)
+func (c *Client) Topology(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTopologyRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -1268,8 +1644,57 @@ func (c *Client) RoutersBySite(ctx context.Context, id PathID, reqEditors ...Req
 	return c.Client.Do(req)
 }
 
+// NewAlertsRequest generates requests for Alerts
+func NewAlertsRequest(server string, params *AlertsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v2alpha1/alerts")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewApplicationflowsRequest generates requests for Applicationflows
-func NewApplicationflowsRequest(server string) (*http.Request, error) {
+func NewApplicationflowsRequest(server string, params *ApplicationflowsParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1287,6 +1712,28 @@ func NewApplicationflowsRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -1296,7 +1743,7 @@ func NewApplicationflowsRequest(server string) (*http.Request, error) {
 }
 
 // NewComponentpairsRequest generates requests for Componentpairs
-func NewComponentpairsRequest(server string) (*http.Request, error) {
+func NewComponentpairsRequest(server string, params *ComponentpairsParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1314,6 +1761,28 @@ func NewComponentpairsRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -1357,7 +1826,7 @@ func NewComponentpairByIDRequest(server string, id PathID) (*http.Request, error
 }
 
 // NewComponentsRequest generates requests for Components
-func NewComponentsRequest(server string) (*http.Request, error) {
+func NewComponentsRequest(server string, params *ComponentsParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1375,6 +1844,28 @@ func NewComponentsRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -1418,7 +1909,7 @@ func NewComponentByIDRequest(server string, id PathID) (*http.Request, error) {
 }
 
 // NewConnectionsRequest generates requests for Connections
-func NewConnectionsRequest(server string) (*http.Request, error) {
+func NewConnectionsRequest(server string, params *ConnectionsParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1436,6 +1927,28 @@ func NewConnectionsRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -1445,7 +1958,7 @@ func NewConnectionsRequest(server string) (*http.Request, error) {
 }
 
 // NewConnectorsRequest generates requests for Connectors
-func NewConnectorsRequest(server string) (*http.Request, error) {
+func NewConnectorsRequest(server string, params *ConnectorsParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1463,6 +1976,28 @@ func NewConnectorsRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -1505,8 +2040,8 @@ func NewConnectorByIDRequest(server string, id PathID) (*http.Request, error) {
 	return req, nil
 }
 
-// NewHostsRequest generates requests for Hosts
-func NewHostsRequest(server string) (*http.Request, error) {
+// NewEventsRequest generates requests for Events
+func NewEventsRequest(server string, params *EventsParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1514,7 +2049,7 @@ func NewHostsRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/api/v2alpha1/hosts")
+	operationPath := fmt.Sprintf("/api/v2alpha1/events")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1524,17 +2059,120 @@ func NewHostsRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
+	if params != nil {
+		queryValues := queryURL.Query()
 
-	return req, nil
-}
+		if params.Type != nil {
 
-// NewHostsByIDRequest generates requests for HostsByID
-func NewHostsByIDRequest(server string, id PathID) (*http.Request, error) {
-	var err error
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "type", runtime.ParamLocationQuery, *params.Type); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Site != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "site", runtime.ParamLocationQuery, *params.Site); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Service != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "service", runtime.ParamLocationQuery, *params.Service); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewHostsRequest generates requests for Hosts
+func NewHostsRequest(server string, params *HostsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v2alpha1/hosts")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewHostsByIDRequest generates requests for HostsByID
+func NewHostsByIDRequest(server string, id PathID) (*http.Request, error) {
+	var err error
 
 	var pathParam0 string
 
@@ -1567,7 +2205,7 @@ func NewHostsByIDRequest(server string, id PathID) (*http.Request, error) {
 }
 
 // NewListenersRequest generates requests for Listeners
-func NewListenersRequest(server string) (*http.Request, error) {
+func NewListenersRequest(server string, params *ListenersParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1585,6 +2223,28 @@ func NewListenersRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -1628,7 +2288,7 @@ func NewListenerByIDRequest(server string, id PathID) (*http.Request, error) {
 }
 
 // NewProcessesRequest generates requests for Processes
-func NewProcessesRequest(server string) (*http.Request, error) {
+func NewProcessesRequest(server string, params *ProcessesParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1646,6 +2306,28 @@ func NewProcessesRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -1689,7 +2371,7 @@ func NewProcessByIdRequest(server string, id PathID) (*http.Request, error) {
 }
 
 // NewProcesspairsRequest generates requests for Processpairs
-func NewProcesspairsRequest(server string) (*http.Request, error) {
+func NewProcesspairsRequest(server string, params *ProcesspairsParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1707,6 +2389,28 @@ func NewProcesspairsRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -1749,8 +2453,42 @@ func NewProcesspairByIDRequest(server string, id PathID) (*http.Request, error)
 	return req, nil
 }
 
+// NewTimeSeriesByProcesspairRequest generates requests for TimeSeriesByProcesspair
+func NewTimeSeriesByProcesspairRequest(server string, id PathID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v2alpha1/processpairs/%s/timeseries", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewRouteraccessRequest generates requests for Routeraccess
-func NewRouteraccessRequest(server string) (*http.Request, error) {
+func NewRouteraccessRequest(server string, params *RouteraccessParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1768,6 +2506,28 @@ func NewRouteraccessRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -1811,7 +2571,7 @@ func NewRouteraccessByIDRequest(server string, id PathID) (*http.Request, error)
 }
 
 // NewRouterlinksRequest generates requests for Routerlinks
-func NewRouterlinksRequest(server string) (*http.Request, error) {
+func NewRouterlinksRequest(server string, params *RouterlinksParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1829,6 +2589,28 @@ func NewRouterlinksRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -1872,7 +2654,7 @@ func NewRouterlinkByIDRequest(server string, id PathID) (*http.Request, error) {
 }
 
 // NewRoutersRequest generates requests for Routers
-func NewRoutersRequest(server string) (*http.Request, error) {
+func NewRoutersRequest(server string, params *RoutersParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1890,6 +2672,28 @@ func NewRoutersRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -1933,7 +2737,7 @@ func NewRouterByIDRequest(server string, id PathID) (*http.Request, error) {
 }
 
 // NewServicesRequest generates requests for Services
-func NewServicesRequest(server string) (*http.Request, error) {
+func NewServicesRequest(server string, params *ServicesParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1951,6 +2755,28 @@ func NewServicesRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -1994,7 +2820,7 @@ func NewServiceByIDRequest(server string, id PathID) (*http.Request, error) {
 }
 
 // NewConnectionsByServiceRequest generates requests for ConnectionsByService
-func NewConnectionsByServiceRequest(server string, id PathID) (*http.Request, error) {
+func NewConnectionsByServiceRequest(server string, id PathID, params *ConnectionsByServiceParams) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -2019,6 +2845,28 @@ func NewConnectionsByServiceRequest(server string, id PathID) (*http.Request, er
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -2028,7 +2876,7 @@ func NewConnectionsByServiceRequest(server string, id PathID) (*http.Request, er
 }
 
 // NewProcessesByServiceRequest generates requests for ProcessesByService
-func NewProcessesByServiceRequest(server string, id PathID) (*http.Request, error) {
+func NewProcessesByServiceRequest(server string, id PathID, params *ProcessesByServiceParams) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -2053,6 +2901,28 @@ func NewProcessesByServiceRequest(server string, id PathID) (*http.Request, erro
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -2062,7 +2932,7 @@ func NewProcessesByServiceRequest(server string, id PathID) (*http.Request, erro
 }
 
 // NewProcessPairsByServiceRequest generates requests for ProcessPairsByService
-func NewProcessPairsByServiceRequest(server string, id PathID) (*http.Request, error) {
+func NewProcessPairsByServiceRequest(server string, id PathID, params *ProcessPairsByServiceParams) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -2087,6 +2957,62 @@ func NewProcessPairsByServiceRequest(server string, id PathID) (*http.Request, e
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTimeSeriesByServiceRequest generates requests for TimeSeriesByService
+func NewTimeSeriesByServiceRequest(server string, id PathID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v2alpha1/services/%s/timeseries", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -2096,7 +3022,7 @@ func NewProcessPairsByServiceRequest(server string, id PathID) (*http.Request, e
 }
 
 // NewSitepairsRequest generates requests for Sitepairs
-func NewSitepairsRequest(server string) (*http.Request, error) {
+func NewSitepairsRequest(server string, params *SitepairsParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -2114,6 +3040,28 @@ func NewSitepairsRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -2156,8 +3104,42 @@ func NewSitepairByIDRequest(server string, id PathID) (*http.Request, error) {
 	return req, nil
 }
 
+// NewTimeSeriesBySitepairRequest generates requests for TimeSeriesBySitepair
+func NewTimeSeriesBySitepairRequest(server string, id PathID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v2alpha1/sitepairs/%s/timeseries", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewSitesRequest generates requests for Sites
-func NewSitesRequest(server string) (*http.Request, error) {
+func NewSitesRequest(server string, params *SitesParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -2175,6 +3157,28 @@ func NewSitesRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -2218,7 +3222,7 @@ func NewSiteByIdRequest(server string, id PathID) (*http.Request, error) {
 }
 
 // NewHostsBySiteRequest generates requests for HostsBySite
-func NewHostsBySiteRequest(server string, id PathID) (*http.Request, error) {
+func NewHostsBySiteRequest(server string, id PathID, params *HostsBySiteParams) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -2243,6 +3247,28 @@ func NewHostsBySiteRequest(server string, id PathID) (*http.Request, error) {
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -2252,7 +3278,7 @@ func NewHostsBySiteRequest(server string, id PathID) (*http.Request, error) {
 }
 
 // NewProcessesBySiteRequest generates requests for ProcessesBySite
-func NewProcessesBySiteRequest(server string, id PathID) (*http.Request, error) {
+func NewProcessesBySiteRequest(server string, id PathID, params *ProcessesBySiteParams) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -2277,6 +3303,28 @@ func NewProcessesBySiteRequest(server string, id PathID) (*http.Request, error)
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -2286,7 +3334,7 @@ func NewProcessesBySiteRequest(server string, id PathID) (*http.Request, error)
 }
 
 // NewRoutersBySiteRequest generates requests for RoutersBySite
-func NewRoutersBySiteRequest(server string, id PathID) (*http.Request, error) {
+func NewRoutersBySiteRequest(server string, id PathID, params *RoutersBySiteParams) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -2311,6 +3359,55 @@ func NewRoutersBySiteRequest(server string, id PathID) (*http.Request, error) {
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTopologyRequest generates requests for Topology
+func NewTopologyRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v2alpha1/topology")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -2362,107 +3459,148 @@ func WithBaseURL(baseURL string) ClientOption {
 
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
+	// AlertsWithResponse request
+	AlertsWithResponse(ctx context.Context, params *AlertsParams, reqEditors ...RequestEditorFn) (*AlertsResponse, error)
+
 	// ApplicationflowsWithResponse request
-	ApplicationflowsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ApplicationflowsResponse, error)
+	ApplicationflowsWithResponse(ctx context.Context, params *ApplicationflowsParams, reqEditors ...RequestEditorFn) (*ApplicationflowsResponse, error)
 
 	// ComponentpairsWithResponse request
-	ComponentpairsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ComponentpairsResponse, error)
+	ComponentpairsWithResponse(ctx context.Context, params *ComponentpairsParams, reqEditors ...RequestEditorFn) (*ComponentpairsResponse, error)
 
 	// ComponentpairByIDWithResponse request
 	ComponentpairByIDWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*ComponentpairByIDResponse, error)
 
 	// ComponentsWithResponse request
-	ComponentsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ComponentsResponse, error)
+	ComponentsWithResponse(ctx context.Context, params *ComponentsParams, reqEditors ...RequestEditorFn) (*ComponentsResponse, error)
 
 	// ComponentByIDWithResponse request
 	ComponentByIDWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*ComponentByIDResponse, error)
 
 	// ConnectionsWithResponse request
-	ConnectionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ConnectionsResponse, error)
+	ConnectionsWithResponse(ctx context.Context, params *ConnectionsParams, reqEditors ...RequestEditorFn) (*ConnectionsResponse, error)
 
 	// ConnectorsWithResponse request
-	ConnectorsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ConnectorsResponse, error)
+	ConnectorsWithResponse(ctx context.Context, params *ConnectorsParams, reqEditors ...RequestEditorFn) (*ConnectorsResponse, error)
 
 	// ConnectorByIDWithResponse request
 	ConnectorByIDWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*ConnectorByIDResponse, error)
 
+	// EventsWithResponse request
+	EventsWithResponse(ctx context.Context, params *EventsParams, reqEditors ...RequestEditorFn) (*EventsResponse, error)
+
 	// HostsWithResponse request
-	HostsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*HostsResponse, error)
+	HostsWithResponse(ctx context.Context, params *HostsParams, reqEditors ...RequestEditorFn) (*HostsResponse, error)
 
 	// HostsByIDWithResponse request
 	HostsByIDWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*HostsByIDResponse, error)
 
 	// ListenersWithResponse request
-	ListenersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListenersResponse, error)
+	ListenersWithResponse(ctx context.Context, params *ListenersParams, reqEditors ...RequestEditorFn) (*ListenersResponse, error)
 
 	// ListenerByIDWithResponse request
 	ListenerByIDWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*ListenerByIDResponse, error)
 
 	// ProcessesWithResponse request
-	ProcessesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ProcessesResponse, error)
+	ProcessesWithResponse(ctx context.Context, params *ProcessesParams, reqEditors ...RequestEditorFn) (*ProcessesResponse, error)
 
 	// ProcessByIdWithResponse request
 	ProcessByIdWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*ProcessByIdResponse, error)
 
 	// ProcesspairsWithResponse request
-	ProcesspairsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ProcesspairsResponse, error)
+	ProcesspairsWithResponse(ctx context.Context, params *ProcesspairsParams, reqEditors ...RequestEditorFn) (*ProcesspairsResponse, error)
 
 	// ProcesspairByIDWithResponse request
 	ProcesspairByIDWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*ProcesspairByIDResponse, error)
 
+	// TimeSeriesByProcesspairWithResponse request
+	TimeSeriesByProcesspairWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*TimeSeriesByProcesspairResponse, error)
+
 	// RouteraccessWithResponse request
-	RouteraccessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*RouteraccessResponse, error)
+	RouteraccessWithResponse(ctx context.Context, params *RouteraccessParams, reqEditors ...RequestEditorFn) (*RouteraccessResponse, error)
 
 	// RouteraccessByIDWithResponse request
 	RouteraccessByIDWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*RouteraccessByIDResponse, error)
 
 	// RouterlinksWithResponse request
-	RouterlinksWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*RouterlinksResponse, error)
+	RouterlinksWithResponse(ctx context.Context, params *RouterlinksParams, reqEditors ...RequestEditorFn) (*RouterlinksResponse, error)
 
 	// RouterlinkByIDWithResponse request
 	RouterlinkByIDWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*RouterlinkByIDResponse, error)
 
 	// RoutersWithResponse request
-	RoutersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*RoutersResponse, error)
+	RoutersWithResponse(ctx context.Context, params *RoutersParams, reqEditors ...RequestEditorFn) (*RoutersResponse, error)
 
 	// RouterByIDWithResponse request
 	RouterByIDWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*RouterByIDResponse, error)
 
 	// ServicesWithResponse request
-	ServicesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ServicesResponse, error)
+	ServicesWithResponse(ctx context.Context, params *ServicesParams, reqEditors ...RequestEditorFn) (*ServicesResponse, error)
 
 	// ServiceByIDWithResponse request
 	ServiceByIDWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*ServiceByIDResponse, error)
 
 	// ConnectionsByServiceWithResponse request
-	ConnectionsByServiceWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*ConnectionsByServiceResponse, error)
+	ConnectionsByServiceWithResponse(ctx context.Context, id PathID, params *ConnectionsByServiceParams, reqEditors ...RequestEditorFn) (*ConnectionsByServiceResponse, error)
 
 	// ProcessesByServiceWithResponse request
-	ProcessesByServiceWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*ProcessesByServiceResponse, error)
+	ProcessesByServiceWithResponse(ctx context.Context, id PathID, params *ProcessesByServiceParams, reqEditors ...RequestEditorFn) (*ProcessesByServiceResponse, error)
 
 	// ProcessPairsByServiceWithResponse request
-	ProcessPairsByServiceWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*ProcessPairsByServiceResponse, error)
+	ProcessPairsByServiceWithResponse(ctx context.Context, id PathID, params *ProcessPairsByServiceParams, reqEditors ...RequestEditorFn) (*ProcessPairsByServiceResponse, error)
+
+	// TimeSeriesByServiceWithResponse request
+	TimeSeriesByServiceWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*TimeSeriesByServiceResponse, error)
 
 	// SitepairsWithResponse request
-	SitepairsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*SitepairsResponse, error)
+	SitepairsWithResponse(ctx context.Context, params *SitepairsParams, reqEditors ...RequestEditorFn) (*SitepairsResponse, error)
 
 	// SitepairByIDWithResponse request
 	SitepairByIDWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*SitepairByIDResponse, error)
 
+	// TimeSeriesBySitepairWithResponse request
+	TimeSeriesBySitepairWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*TimeSeriesBySitepairResponse, error)
+
 	// SitesWithResponse request
-	SitesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*SitesResponse, error)
+	SitesWithResponse(ctx context.Context, params *SitesParams, reqEditors ...RequestEditorFn) (*SitesResponse, error)
 
 	// SiteByIdWithResponse request
 	SiteByIdWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*SiteByIdResponse, error)
 
 	// HostsBySiteWithResponse request
-	HostsBySiteWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*HostsBySiteResponse, error)
+	HostsBySiteWithResponse(ctx context.Context, id PathID, params *HostsBySiteParams, reqEditors ...RequestEditorFn) (*HostsBySiteResponse, error)
 
 	// ProcessesBySiteWithResponse request
-	ProcessesBySiteWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*ProcessesBySiteResponse, error)
+	ProcessesBySiteWithResponse(ctx context.Context, id PathID, params *ProcessesBySiteParams, reqEditors ...RequestEditorFn) (*ProcessesBySiteResponse, error)
 
 	// RoutersBySiteWithResponse request
-	RoutersBySiteWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*RoutersBySiteResponse, error)
+	RoutersBySiteWithResponse(ctx context.Context, id PathID, params *RoutersBySiteParams, reqEditors ...RequestEditorFn) (*RoutersBySiteResponse, error)
+
+	// TopologyWithResponse request
+	TopologyWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*TopologyResponse, error)
+}
+
+type AlertsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetAlerts
+	JSON400      *ErrorBadRequest
+}
+
+// Status returns HTTPResponse.Status
+func (r AlertsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AlertsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
 }
 
 type ApplicationflowsResponse struct {
@@ -2647,6 +3785,28 @@ func (r ConnectorByIDResponse) StatusCode() int {
 	return 0
 }
 
+type EventsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *ErrorNotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r EventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r EventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type HostsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -2830,6 +3990,30 @@ func (r ProcesspairByIDResponse) StatusCode() int {
 	return 0
 }
 
+type TimeSeriesByProcesspairResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetTimeSeries
+	JSON400      *ErrorBadRequest
+	JSON404      *ErrorNotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r TimeSeriesByProcesspairResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TimeSeriesByProcesspairResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type RouteraccessResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -3083,6 +4267,30 @@ func (r ProcessPairsByServiceResponse) StatusCode() int {
 	return 0
 }
 
+type TimeSeriesByServiceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetTimeSeries
+	JSON400      *ErrorBadRequest
+	JSON404      *ErrorNotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r TimeSeriesByServiceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TimeSeriesByServiceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type SitepairsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -3129,6 +4337,30 @@ func (r SitepairByIDResponse) StatusCode() int {
 	return 0
 }
 
+type TimeSeriesBySitepairResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetTimeSeries
+	JSON400      *ErrorBadRequest
+	JSON404      *ErrorNotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r TimeSeriesBySitepairResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TimeSeriesBySitepairResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type SitesResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -3220,15 +4452,37 @@ func (r ProcessesBySiteResponse) StatusCode() int {
 	return 0
 }
 
-type RoutersBySiteResponse struct {
+type RoutersBySiteResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetRouters
+	JSON404      *ErrorNotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r RoutersBySiteResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RoutersBySiteResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TopologyResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *GetRouters
-	JSON404      *ErrorNotFound
+	JSON400      *ErrorBadRequest
 }
 
 // Status returns HTTPResponse.Status
-func (r RoutersBySiteResponse) Status() string {
+func (r TopologyResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -3236,16 +4490,25 @@ func (r RoutersBySiteResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r RoutersBySiteResponse) StatusCode() int {
+func (r TopologyResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
+// AlertsWithResponse request returning *AlertsResponse
+func (c *ClientWithResponses) AlertsWithResponse(ctx context.Context, params *AlertsParams, reqEditors ...RequestEditorFn) (*AlertsResponse, error) {
+	rsp, err := c.Alerts(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAlertsResponse(rsp)
+}
+
 // ApplicationflowsWithResponse request returning *ApplicationflowsResponse
-func (c *ClientWithResponses) ApplicationflowsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ApplicationflowsResponse, error) {
-	rsp, err := c.Applicationflows(ctx, reqEditors...)
+func (c *ClientWithResponses) ApplicationflowsWithResponse(ctx context.Context, params *ApplicationflowsParams, reqEditors ...RequestEditorFn) (*ApplicationflowsResponse, error) {
+	rsp, err := c.Applicationflows(ctx, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -3253,8 +4516,8 @@ func (c *ClientWithResponses) ApplicationflowsWithResponse(ctx context.Context,
 }
 
 // ComponentpairsWithResponse request returning *ComponentpairsResponse
-func (c *ClientWithResponses) ComponentpairsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ComponentpairsResponse, error) {
-	rsp, err := c.Componentpairs(ctx, reqEditors...)
+func (c *ClientWithResponses) ComponentpairsWithResponse(ctx context.Context, params *ComponentpairsParams, reqEditors ...RequestEditorFn) (*ComponentpairsResponse, error) {
+	rsp, err := c.Componentpairs(ctx, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -3271,8 +4534,8 @@ func (c *ClientWithResponses) ComponentpairByIDWithResponse(ctx context.Context,
 }
 
 // ComponentsWithResponse request returning *ComponentsResponse
-func (c *ClientWithResponses) ComponentsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ComponentsResponse, error) {
-	rsp, err := c.Components(ctx, reqEditors...)
+func (c *ClientWithResponses) ComponentsWithResponse(ctx context.Context, params *ComponentsParams, reqEditors ...RequestEditorFn) (*ComponentsResponse, error) {
+	rsp, err := c.Components(ctx, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -3289,8 +4552,8 @@ func (c *ClientWithResponses) ComponentByIDWithResponse(ctx context.Context, id
 }
 
 // ConnectionsWithResponse request returning *ConnectionsResponse
-func (c *ClientWithResponses) ConnectionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ConnectionsResponse, error) {
-	rsp, err := c.Connections(ctx, reqEditors...)
+func (c *ClientWithResponses) ConnectionsWithResponse(ctx context.Context, params *ConnectionsParams, reqEditors ...RequestEditorFn) (*ConnectionsResponse, error) {
+	rsp, err := c.Connections(ctx, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -3298,8 +4561,8 @@ func (c *ClientWithResponses) ConnectionsWithResponse(ctx context.Context, reqEd
 }
 
 // ConnectorsWithResponse request returning *ConnectorsResponse
-func (c *ClientWithResponses) ConnectorsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ConnectorsResponse, error) {
-	rsp, err := c.Connectors(ctx, reqEditors...)
+func (c *ClientWithResponses) ConnectorsWithResponse(ctx context.Context, params *ConnectorsParams, reqEditors ...RequestEditorFn) (*ConnectorsResponse, error) {
+	rsp, err := c.Connectors(ctx, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -3315,9 +4578,18 @@ func (c *ClientWithResponses) ConnectorByIDWithResponse(ctx context.Context, id
 	return ParseConnectorByIDResponse(rsp)
 }
 
+// EventsWithResponse request returning *EventsResponse
+func (c *ClientWithResponses) EventsWithResponse(ctx context.Context, params *EventsParams, reqEditors ...RequestEditorFn) (*EventsResponse, error) {
+	rsp, err := c.Events(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEventsResponse(rsp)
+}
+
 // HostsWithResponse request returning *HostsResponse
-func (c *ClientWithResponses) HostsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*HostsResponse, error) {
-	rsp, err := c.Hosts(ctx, reqEditors...)
+func (c *ClientWithResponses) HostsWithResponse(ctx context.Context, params *HostsParams, reqEditors ...RequestEditorFn) (*HostsResponse, error) {
+	rsp, err := c.Hosts(ctx, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -3334,8 +4606,8 @@ func (c *ClientWithResponses) HostsByIDWithResponse(ctx context.Context, id Path
 }
 
 // ListenersWithResponse request returning *ListenersResponse
-func (c *ClientWithResponses) ListenersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListenersResponse, error) {
-	rsp, err := c.Listeners(ctx, reqEditors...)
+func (c *ClientWithResponses) ListenersWithResponse(ctx context.Context, params *ListenersParams, reqEditors ...RequestEditorFn) (*ListenersResponse, error) {
+	rsp, err := c.Listeners(ctx, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -3352,8 +4624,8 @@ func (c *ClientWithResponses) ListenerByIDWithResponse(ctx context.Context, id P
 }
 
 // ProcessesWithResponse request returning *ProcessesResponse
-func (c *ClientWithResponses) ProcessesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ProcessesResponse, error) {
-	rsp, err := c.Processes(ctx, reqEditors...)
+func (c *ClientWithResponses) ProcessesWithResponse(ctx context.Context, params *ProcessesParams, reqEditors ...RequestEditorFn) (*ProcessesResponse, error) {
+	rsp, err := c.Processes(ctx, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -3370,8 +4642,8 @@ func (c *ClientWithResponses) ProcessByIdWithResponse(ctx context.Context, id Pa
 }
 
 // ProcesspairsWithResponse request returning *ProcesspairsResponse
-func (c *ClientWithResponses) ProcesspairsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ProcesspairsResponse, error) {
-	rsp, err := c.Processpairs(ctx, reqEditors...)
+func (c *ClientWithResponses) ProcesspairsWithResponse(ctx context.Context, params *ProcesspairsParams, reqEditors ...RequestEditorFn) (*ProcesspairsResponse, error) {
+	rsp, err := c.Processpairs(ctx, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -3387,9 +4659,18 @@ func (c *ClientWithResponses) ProcesspairByIDWithResponse(ctx context.Context, i
 	return ParseProcesspairByIDResponse(rsp)
 }
 
+// TimeSeriesByProcesspairWithResponse request returning *TimeSeriesByProcesspairResponse
+func (c *ClientWithResponses) TimeSeriesByProcesspairWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*TimeSeriesByProcesspairResponse, error) {
+	rsp, err := c.TimeSeriesByProcesspair(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTimeSeriesByProcesspairResponse(rsp)
+}
+
 // RouteraccessWithResponse request returning *RouteraccessResponse
-func (c *ClientWithResponses) RouteraccessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*RouteraccessResponse, error) {
-	rsp, err := c.Routeraccess(ctx, reqEditors...)
+func (c *ClientWithResponses) RouteraccessWithResponse(ctx context.Context, params *RouteraccessParams, reqEditors ...RequestEditorFn) (*RouteraccessResponse, error) {
+	rsp, err := c.Routeraccess(ctx, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -3406,8 +4687,8 @@ func (c *ClientWithResponses) RouteraccessByIDWithResponse(ctx context.Context,
 }
 
 // RouterlinksWithResponse request returning *RouterlinksResponse
-func (c *ClientWithResponses) RouterlinksWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*RouterlinksResponse, error) {
-	rsp, err := c.Routerlinks(ctx, reqEditors...)
+func (c *ClientWithResponses) RouterlinksWithResponse(ctx context.Context, params *RouterlinksParams, reqEditors ...RequestEditorFn) (*RouterlinksResponse, error) {
+	rsp, err := c.Routerlinks(ctx, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -3424,8 +4705,8 @@ func (c *ClientWithResponses) RouterlinkByIDWithResponse(ctx context.Context, id
 }
 
 // RoutersWithResponse request returning *RoutersResponse
-func (c *ClientWithResponses) RoutersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*RoutersResponse, error) {
-	rsp, err := c.Routers(ctx, reqEditors...)
+func (c *ClientWithResponses) RoutersWithResponse(ctx context.Context, params *RoutersParams, reqEditors ...RequestEditorFn) (*RoutersResponse, error) {
+	rsp, err := c.Routers(ctx, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -3442,8 +4723,8 @@ func (c *ClientWithResponses) RouterByIDWithResponse(ctx context.Context, id Pat
 }
 
 // ServicesWithResponse request returning *ServicesResponse
-func (c *ClientWithResponses) ServicesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ServicesResponse, error) {
-	rsp, err := c.Services(ctx, reqEditors...)
+func (c *ClientWithResponses) ServicesWithResponse(ctx context.Context, params *ServicesParams, reqEditors ...RequestEditorFn) (*ServicesResponse, error) {
+	rsp, err := c.Services(ctx, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -3460,8 +4741,8 @@ func (c *ClientWithResponses) ServiceByIDWithResponse(ctx context.Context, id Pa
 }
 
 // ConnectionsByServiceWithResponse request returning *ConnectionsByServiceResponse
-func (c *ClientWithResponses) ConnectionsByServiceWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*ConnectionsByServiceResponse, error) {
-	rsp, err := c.ConnectionsByService(ctx, id, reqEditors...)
+func (c *ClientWithResponses) ConnectionsByServiceWithResponse(ctx context.Context, id PathID, params *ConnectionsByServiceParams, reqEditors ...RequestEditorFn) (*ConnectionsByServiceResponse, error) {
+	rsp, err := c.ConnectionsByService(ctx, id, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -3469,8 +4750,8 @@ func (c *ClientWithResponses) ConnectionsByServiceWithResponse(ctx context.Conte
 }
 
 // ProcessesByServiceWithResponse request returning *ProcessesByServiceResponse
-func (c *ClientWithResponses) ProcessesByServiceWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*ProcessesByServiceResponse, error) {
-	rsp, err := c.ProcessesByService(ctx, id, reqEditors...)
+func (c *ClientWithResponses) ProcessesByServiceWithResponse(ctx context.Context, id PathID, params *ProcessesByServiceParams, reqEditors ...RequestEditorFn) (*ProcessesByServiceResponse, error) {
+	rsp, err := c.ProcessesByService(ctx, id, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -3478,17 +4759,26 @@ func (c *ClientWithResponses) ProcessesByServiceWithResponse(ctx context.Context
 }
 
 // ProcessPairsByServiceWithResponse request returning *ProcessPairsByServiceResponse
-func (c *ClientWithResponses) ProcessPairsByServiceWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*ProcessPairsByServiceResponse, error) {
-	rsp, err := c.ProcessPairsByService(ctx, id, reqEditors...)
+func (c *ClientWithResponses) ProcessPairsByServiceWithResponse(ctx context.Context, id PathID, params *ProcessPairsByServiceParams, reqEditors ...RequestEditorFn) (*ProcessPairsByServiceResponse, error) {
+	rsp, err := c.ProcessPairsByService(ctx, id, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
 	return ParseProcessPairsByServiceResponse(rsp)
 }
 
+// TimeSeriesByServiceWithResponse request returning *TimeSeriesByServiceResponse
+func (c *ClientWithResponses) TimeSeriesByServiceWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*TimeSeriesByServiceResponse, error) {
+	rsp, err := c.TimeSeriesByService(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTimeSeriesByServiceResponse(rsp)
+}
+
 // SitepairsWithResponse request returning *SitepairsResponse
-func (c *ClientWithResponses) SitepairsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*SitepairsResponse, error) {
-	rsp, err := c.Sitepairs(ctx, reqEditors...)
+func (c *ClientWithResponses) SitepairsWithResponse(ctx context.Context, params *SitepairsParams, reqEditors ...RequestEditorFn) (*SitepairsResponse, error) {
+	rsp, err := c.Sitepairs(ctx, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -3504,9 +4794,18 @@ func (c *ClientWithResponses) SitepairByIDWithResponse(ctx context.Context, id P
 	return ParseSitepairByIDResponse(rsp)
 }
 
+// TimeSeriesBySitepairWithResponse request returning *TimeSeriesBySitepairResponse
+func (c *ClientWithResponses) TimeSeriesBySitepairWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*TimeSeriesBySitepairResponse, error) {
+	rsp, err := c.TimeSeriesBySitepair(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTimeSeriesBySitepairResponse(rsp)
+}
+
 // SitesWithResponse request returning *SitesResponse
-func (c *ClientWithResponses) SitesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*SitesResponse, error) {
-	rsp, err := c.Sites(ctx, reqEditors...)
+func (c *ClientWithResponses) SitesWithResponse(ctx context.Context, params *SitesParams, reqEditors ...RequestEditorFn) (*SitesResponse, error) {
+	rsp, err := c.Sites(ctx, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -3523,8 +4822,8 @@ func (c *ClientWithResponses) SiteByIdWithResponse(ctx context.Context, id PathI
 }
 
 // HostsBySiteWithResponse request returning *HostsBySiteResponse
-func (c *ClientWithResponses) HostsBySiteWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*HostsBySiteResponse, error) {
-	rsp, err := c.HostsBySite(ctx, id, reqEditors...)
+func (c *ClientWithResponses) HostsBySiteWithResponse(ctx context.Context, id PathID, params *HostsBySiteParams, reqEditors ...RequestEditorFn) (*HostsBySiteResponse, error) {
+	rsp, err := c.HostsBySite(ctx, id, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -3532,8 +4831,8 @@ func (c *ClientWithResponses) HostsBySiteWithResponse(ctx context.Context, id Pa
 }
 
 // ProcessesBySiteWithResponse request returning *ProcessesBySiteResponse
-func (c *ClientWithResponses) ProcessesBySiteWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*ProcessesBySiteResponse, error) {
-	rsp, err := c.ProcessesBySite(ctx, id, reqEditors...)
+func (c *ClientWithResponses) ProcessesBySiteWithResponse(ctx context.Context, id PathID, params *ProcessesBySiteParams, reqEditors ...RequestEditorFn) (*ProcessesBySiteResponse, error) {
+	rsp, err := c.ProcessesBySite(ctx, id, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -3541,14 +4840,56 @@ func (c *ClientWithResponses) ProcessesBySiteWithResponse(ctx context.Context, i
 }
 
 // RoutersBySiteWithResponse request returning *RoutersBySiteResponse
-func (c *ClientWithResponses) RoutersBySiteWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*RoutersBySiteResponse, error) {
-	rsp, err := c.RoutersBySite(ctx, id, reqEditors...)
+func (c *ClientWithResponses) RoutersBySiteWithResponse(ctx context.Context, id PathID, params *RoutersBySiteParams, reqEditors ...RequestEditorFn) (*RoutersBySiteResponse, error) {
+	rsp, err := c.RoutersBySite(ctx, id, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
 	return ParseRoutersBySiteResponse(rsp)
 }
 
+// TopologyWithResponse request returning *TopologyResponse
+func (c *ClientWithResponses) TopologyWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*TopologyResponse, error) {
+	rsp, err := c.Topology(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTopologyResponse(rsp)
+}
+
+// ParseAlertsResponse parses an HTTP response from a AlertsWithResponse call
+func ParseAlertsResponse(rsp *http.Response) (*AlertsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AlertsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetAlerts
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorBadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseApplicationflowsResponse parses an HTTP response from a ApplicationflowsWithResponse call
 func ParseApplicationflowsResponse(rsp *http.Response) (*ApplicationflowsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -3799,6 +5140,32 @@ func ParseConnectorByIDResponse(rsp *http.Response) (*ConnectorByIDResponse, err
 	return response, nil
 }
 
+// ParseEventsResponse parses an HTTP response from a EventsWithResponse call
+func ParseEventsResponse(rsp *http.Response) (*EventsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &EventsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorNotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseHostsResponse parses an HTTP response from a HostsWithResponse call
 func ParseHostsResponse(rsp *http.Response) (*HostsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -4056,6 +5423,46 @@ func ParseProcesspairByIDResponse(rsp *http.Response) (*ProcesspairByIDResponse,
 	return response, nil
 }
 
+// ParseTimeSeriesByProcesspairResponse parses an HTTP response from a TimeSeriesByProcesspairWithResponse call
+func ParseTimeSeriesByProcesspairResponse(rsp *http.Response) (*TimeSeriesByProcesspairResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TimeSeriesByProcesspairResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetTimeSeries
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorBadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorNotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseRouteraccessResponse parses an HTTP response from a RouteraccessWithResponse call
 func ParseRouteraccessResponse(rsp *http.Response) (*RouteraccessResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -4419,6 +5826,46 @@ func ParseProcessPairsByServiceResponse(rsp *http.Response) (*ProcessPairsByServ
 	return response, nil
 }
 
+// ParseTimeSeriesByServiceResponse parses an HTTP response from a TimeSeriesByServiceWithResponse call
+func ParseTimeSeriesByServiceResponse(rsp *http.Response) (*TimeSeriesByServiceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TimeSeriesByServiceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetTimeSeries
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorBadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorNotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseSitepairsResponse parses an HTTP response from a SitepairsWithResponse call
 func ParseSitepairsResponse(rsp *http.Response) (*SitepairsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -4485,6 +5932,46 @@ func ParseSitepairByIDResponse(rsp *http.Response) (*SitepairByIDResponse, error
 	return response, nil
 }
 
+// ParseTimeSeriesBySitepairResponse parses an HTTP response from a TimeSeriesBySitepairWithResponse call
+func ParseTimeSeriesBySitepairResponse(rsp *http.Response) (*TimeSeriesBySitepairResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TimeSeriesBySitepairResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetTimeSeries
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorBadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorNotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseSitesResponse parses an HTTP response from a SitesWithResponse call
 func ParseSitesResponse(rsp *http.Response) (*SitesResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -4643,110 +6130,154 @@ func ParseRoutersBySiteResponse(rsp *http.Response) (*RoutersBySiteResponse, err
 	return response, nil
 }
 
+// ParseTopologyResponse parses an HTTP response from a TopologyWithResponse call
+func ParseTopologyResponse(rsp *http.Response) (*TopologyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TopologyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorBadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 
+	// (GET /api/v2alpha1/alerts)
+	Alerts(w http.ResponseWriter, r *http.Request, params AlertsParams)
+
 	// (GET /api/v2alpha1/applicationflows)
-	Applicationflows(w http.ResponseWriter, r *http.Request)
+	Applicationflows(w http.ResponseWriter, r *http.Request, params ApplicationflowsParams)
 
 	// (GET /api/v2alpha1/componentpairs)
-	Componentpairs(w http.ResponseWriter, r *http.Request)
+	Componentpairs(w http.ResponseWriter, r *http.Request, params ComponentpairsParams)
 
 	// (GET /api/v2alpha1/componentpairs/{id})
 	ComponentpairByID(w http.ResponseWriter, r *http.Request, id PathID)
 
 	// (GET /api/v2alpha1/components)
-	Components(w http.ResponseWriter, r *http.Request)
+	Components(w http.ResponseWriter, r *http.Request, params ComponentsParams)
 
 	// (GET /api/v2alpha1/components/{id})
 	ComponentByID(w http.ResponseWriter, r *http.Request, id PathID)
 
 	// (GET /api/v2alpha1/connections)
-	Connections(w http.ResponseWriter, r *http.Request)
+	Connections(w http.ResponseWriter, r *http.Request, params ConnectionsParams)
 
 	// (GET /api/v2alpha1/connectors)
-	Connectors(w http.ResponseWriter, r *http.Request)
+	Connectors(w http.ResponseWriter, r *http.Request, params ConnectorsParams)
 
 	// (GET /api/v2alpha1/connectors/{id})
 	ConnectorByID(w http.ResponseWriter, r *http.Request, id PathID)
 
+	// (GET /api/v2alpha1/events)
+	Events(w http.ResponseWriter, r *http.Request, params EventsParams)
+
 	// (GET /api/v2alpha1/hosts)
-	Hosts(w http.ResponseWriter, r *http.Request)
+	Hosts(w http.ResponseWriter, r *http.Request, params HostsParams)
 
 	// (GET /api/v2alpha1/hosts/{id})
 	HostsByID(w http.ResponseWriter, r *http.Request, id PathID)
 
 	// (GET /api/v2alpha1/listeners)
-	Listeners(w http.ResponseWriter, r *http.Request)
+	Listeners(w http.ResponseWriter, r *http.Request, params ListenersParams)
 
 	// (GET /api/v2alpha1/listeners/{id})
 	ListenerByID(w http.ResponseWriter, r *http.Request, id PathID)
 
 	// (GET /api/v2alpha1/processes)
-	Processes(w http.ResponseWriter, r *http.Request)
+	Processes(w http.ResponseWriter, r *http.Request, params ProcessesParams)
 
 	// (GET /api/v2alpha1/processes/{id})
 	ProcessById(w http.ResponseWriter, r *http.Request, id PathID)
 
 	// (GET /api/v2alpha1/processpairs)
-	Processpairs(w http.ResponseWriter, r *http.Request)
+	Processpairs(w http.ResponseWriter, r *http.Request, params ProcesspairsParams)
 
 	// (GET /api/v2alpha1/processpairs/{id})
 	ProcesspairByID(w http.ResponseWriter, r *http.Request, id PathID)
 
+	// (GET /api/v2alpha1/processpairs/{id}/timeseries)
+	TimeSeriesByProcesspair(w http.ResponseWriter, r *http.Request, id PathID)
+
 	// (GET /api/v2alpha1/routeraccess)
-	Routeraccess(w http.ResponseWriter, r *http.Request)
+	Routeraccess(w http.ResponseWriter, r *http.Request, params RouteraccessParams)
 
 	// (GET /api/v2alpha1/routeraccess/{id})
 	RouteraccessByID(w http.ResponseWriter, r *http.Request, id PathID)
 
 	// (GET /api/v2alpha1/routerlinks)
-	Routerlinks(w http.ResponseWriter, r *http.Request)
+	Routerlinks(w http.ResponseWriter, r *http.Request, params RouterlinksParams)
 
 	// (GET /api/v2alpha1/routerlinks/{id})
 	RouterlinkByID(w http.ResponseWriter, r *http.Request, id PathID)
 
 	// (GET /api/v2alpha1/routers)
-	Routers(w http.ResponseWriter, r *http.Request)
+	Routers(w http.ResponseWriter, r *http.Request, params RoutersParams)
 
 	// (GET /api/v2alpha1/routers/{id})
 	RouterByID(w http.ResponseWriter, r *http.Request, id PathID)
 
 	// (GET /api/v2alpha1/services)
-	Services(w http.ResponseWriter, r *http.Request)
+	Services(w http.ResponseWriter, r *http.Request, params ServicesParams)
 
 	// (GET /api/v2alpha1/services/{id})
 	ServiceByID(w http.ResponseWriter, r *http.Request, id PathID)
 
 	// (GET /api/v2alpha1/services/{id}/connections)
-	ConnectionsByService(w http.ResponseWriter, r *http.Request, id PathID)
+	ConnectionsByService(w http.ResponseWriter, r *http.Request, id PathID, params ConnectionsByServiceParams)
 
 	// (GET /api/v2alpha1/services/{id}/processes)
-	ProcessesByService(w http.ResponseWriter, r *http.Request, id PathID)
+	ProcessesByService(w http.ResponseWriter, r *http.Request, id PathID, params ProcessesByServiceParams)
 
 	// (GET /api/v2alpha1/services/{id}/processpairs)
-	ProcessPairsByService(w http.ResponseWriter, r *http.Request, id PathID)
+	ProcessPairsByService(w http.ResponseWriter, r *http.Request, id PathID, params ProcessPairsByServiceParams)
+
+	// (GET /api/v2alpha1/services/{id}/timeseries)
+	TimeSeriesByService(w http.ResponseWriter, r *http.Request, id PathID)
 
 	// (GET /api/v2alpha1/sitepairs)
-	Sitepairs(w http.ResponseWriter, r *http.Request)
+	Sitepairs(w http.ResponseWriter, r *http.Request, params SitepairsParams)
 
 	// (GET /api/v2alpha1/sitepairs/{id})
 	SitepairByID(w http.ResponseWriter, r *http.Request, id PathID)
 
+	// (GET /api/v2alpha1/sitepairs/{id}/timeseries)
+	TimeSeriesBySitepair(w http.ResponseWriter, r *http.Request, id PathID)
+
 	// (GET /api/v2alpha1/sites)
-	Sites(w http.ResponseWriter, r *http.Request)
+	Sites(w http.ResponseWriter, r *http.Request, params SitesParams)
 
 	// (GET /api/v2alpha1/sites/{id})
 	SiteById(w http.ResponseWriter, r *http.Request, id PathID)
 
 	// (GET /api/v2alpha1/sites/{id}/hosts)
-	HostsBySite(w http.ResponseWriter, r *http.Request, id PathID)
+	HostsBySite(w http.ResponseWriter, r *http.Request, id PathID, params HostsBySiteParams)
 
 	// (GET /api/v2alpha1/sites/{id}/processes)
-	ProcessesBySite(w http.ResponseWriter, r *http.Request, id PathID)
+	ProcessesBySite(w http.ResponseWriter, r *http.Request, id PathID, params ProcessesBySiteParams)
 
 	// (GET /api/v2alpha1/sites/{id}/routers)
-	RoutersBySite(w http.ResponseWriter, r *http.Request, id PathID)
+	RoutersBySite(w http.ResponseWriter, r *http.Request, id PathID, params RoutersBySiteParams)
+
+	// (GET /api/v2alpha1/topology)
+	Topology(w http.ResponseWriter, r *http.Request)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -4758,11 +6289,51 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// Alerts operation middleware
+func (siw *ServerInterfaceWrapper) Alerts(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AlertsParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Alerts(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // Applicationflows operation middleware
 func (siw *ServerInterfaceWrapper) Applicationflows(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ApplicationflowsParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Applicationflows(w, r)
+		siw.Handler.Applicationflows(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4775,8 +6346,21 @@ func (siw *ServerInterfaceWrapper) Applicationflows(w http.ResponseWriter, r *ht
 // Componentpairs operation middleware
 func (siw *ServerInterfaceWrapper) Componentpairs(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ComponentpairsParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Componentpairs(w, r)
+		siw.Handler.Componentpairs(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4814,8 +6398,21 @@ func (siw *ServerInterfaceWrapper) ComponentpairByID(w http.ResponseWriter, r *h
 // Components operation middleware
 func (siw *ServerInterfaceWrapper) Components(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ComponentsParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Components(w, r)
+		siw.Handler.Components(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4853,8 +6450,21 @@ func (siw *ServerInterfaceWrapper) ComponentByID(w http.ResponseWriter, r *http.
 // Connections operation middleware
 func (siw *ServerInterfaceWrapper) Connections(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ConnectionsParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Connections(w, r)
+		siw.Handler.Connections(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4867,8 +6477,21 @@ func (siw *ServerInterfaceWrapper) Connections(w http.ResponseWriter, r *http.Re
 // Connectors operation middleware
 func (siw *ServerInterfaceWrapper) Connectors(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ConnectorsParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Connectors(w, r)
+		siw.Handler.Connectors(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4903,11 +6526,67 @@ func (siw *ServerInterfaceWrapper) ConnectorByID(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
+// Events operation middleware
+func (siw *ServerInterfaceWrapper) Events(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params EventsParams
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", r.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "site" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "site", r.URL.Query(), &params.Site)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "site", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "service" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "service", r.URL.Query(), &params.Service)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "service", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Events(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // Hosts operation middleware
 func (siw *ServerInterfaceWrapper) Hosts(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params HostsParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Hosts(w, r)
+		siw.Handler.Hosts(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4945,8 +6624,21 @@ func (siw *ServerInterfaceWrapper) HostsByID(w http.ResponseWriter, r *http.Requ
 // Listeners operation middleware
 func (siw *ServerInterfaceWrapper) Listeners(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListenersParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Listeners(w, r)
+		siw.Handler.Listeners(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4984,8 +6676,21 @@ func (siw *ServerInterfaceWrapper) ListenerByID(w http.ResponseWriter, r *http.R
 // Processes operation middleware
 func (siw *ServerInterfaceWrapper) Processes(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ProcessesParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Processes(w, r)
+		siw.Handler.Processes(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5023,8 +6728,21 @@ func (siw *ServerInterfaceWrapper) ProcessById(w http.ResponseWriter, r *http.Re
 // Processpairs operation middleware
 func (siw *ServerInterfaceWrapper) Processpairs(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ProcesspairsParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Processpairs(w, r)
+		siw.Handler.Processpairs(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5059,11 +6777,49 @@ func (siw *ServerInterfaceWrapper) ProcesspairByID(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
+// TimeSeriesByProcesspair operation middleware
+func (siw *ServerInterfaceWrapper) TimeSeriesByProcesspair(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id PathID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", mux.Vars(r)["id"], &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TimeSeriesByProcesspair(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // Routeraccess operation middleware
 func (siw *ServerInterfaceWrapper) Routeraccess(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RouteraccessParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Routeraccess(w, r)
+		siw.Handler.Routeraccess(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5101,8 +6857,21 @@ func (siw *ServerInterfaceWrapper) RouteraccessByID(w http.ResponseWriter, r *ht
 // Routerlinks operation middleware
 func (siw *ServerInterfaceWrapper) Routerlinks(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RouterlinksParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Routerlinks(w, r)
+		siw.Handler.Routerlinks(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5140,8 +6909,21 @@ func (siw *ServerInterfaceWrapper) RouterlinkByID(w http.ResponseWriter, r *http
 // Routers operation middleware
 func (siw *ServerInterfaceWrapper) Routers(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RoutersParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Routers(w, r)
+		siw.Handler.Routers(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5179,8 +6961,21 @@ func (siw *ServerInterfaceWrapper) RouterByID(w http.ResponseWriter, r *http.Req
 // Services operation middleware
 func (siw *ServerInterfaceWrapper) Services(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ServicesParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Services(w, r)
+		siw.Handler.Services(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5229,8 +7024,19 @@ func (siw *ServerInterfaceWrapper) ConnectionsByService(w http.ResponseWriter, r
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ConnectionsByServiceParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ConnectionsByService(w, r, id)
+		siw.Handler.ConnectionsByService(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5254,8 +7060,19 @@ func (siw *ServerInterfaceWrapper) ProcessesByService(w http.ResponseWriter, r *
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ProcessesByServiceParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ProcessesByService(w, r, id)
+		siw.Handler.ProcessesByService(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5279,8 +7096,44 @@ func (siw *ServerInterfaceWrapper) ProcessPairsByService(w http.ResponseWriter,
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ProcessPairsByServiceParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ProcessPairsByService(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// TimeSeriesByService operation middleware
+func (siw *ServerInterfaceWrapper) TimeSeriesByService(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id PathID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", mux.Vars(r)["id"], &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ProcessPairsByService(w, r, id)
+		siw.Handler.TimeSeriesByService(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5293,8 +7146,21 @@ func (siw *ServerInterfaceWrapper) ProcessPairsByService(w http.ResponseWriter,
 // Sitepairs operation middleware
 func (siw *ServerInterfaceWrapper) Sitepairs(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SitepairsParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Sitepairs(w, r)
+		siw.Handler.Sitepairs(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5329,11 +7195,49 @@ func (siw *ServerInterfaceWrapper) SitepairByID(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
+// TimeSeriesBySitepair operation middleware
+func (siw *ServerInterfaceWrapper) TimeSeriesBySitepair(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id PathID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", mux.Vars(r)["id"], &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TimeSeriesBySitepair(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // Sites operation middleware
 func (siw *ServerInterfaceWrapper) Sites(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SitesParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Sites(w, r)
+		siw.Handler.Sites(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5382,8 +7286,19 @@ func (siw *ServerInterfaceWrapper) HostsBySite(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params HostsBySiteParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.HostsBySite(w, r, id)
+		siw.Handler.HostsBySite(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5407,8 +7322,19 @@ func (siw *ServerInterfaceWrapper) ProcessesBySite(w http.ResponseWriter, r *htt
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ProcessesBySiteParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ProcessesBySite(w, r, id)
+		siw.Handler.ProcessesBySite(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5432,8 +7358,33 @@ func (siw *ServerInterfaceWrapper) RoutersBySite(w http.ResponseWriter, r *http.
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RoutersBySiteParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RoutersBySite(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Topology operation middleware
+func (siw *ServerInterfaceWrapper) Topology(w http.ResponseWriter, r *http.Request) {
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RoutersBySite(w, r, id)
+		siw.Handler.Topology(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5556,6 +7507,8 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.HandleFunc(options.BaseURL+"/api/v2alpha1/alerts", wrapper.Alerts).Methods("GET")
+
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/applicationflows", wrapper.Applicationflows).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/componentpairs", wrapper.Componentpairs).Methods("GET")
@@ -5572,6 +7525,8 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/connectors/{id}", wrapper.ConnectorByID).Methods("GET")
 
+	r.HandleFunc(options.BaseURL+"/api/v2alpha1/events", wrapper.Events).Methods("GET")
+
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/hosts", wrapper.Hosts).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/hosts/{id}", wrapper.HostsByID).Methods("GET")
@@ -5588,6 +7543,8 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/processpairs/{id}", wrapper.ProcesspairByID).Methods("GET")
 
+	r.HandleFunc(options.BaseURL+"/api/v2alpha1/processpairs/{id}/timeseries", wrapper.TimeSeriesByProcesspair).Methods("GET")
+
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/routeraccess", wrapper.Routeraccess).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/routeraccess/{id}", wrapper.RouteraccessByID).Methods("GET")
@@ -5610,10 +7567,14 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/services/{id}/processpairs", wrapper.ProcessPairsByService).Methods("GET")
 
+	r.HandleFunc(options.BaseURL+"/api/v2alpha1/services/{id}/timeseries", wrapper.TimeSeriesByService).Methods("GET")
+
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/sitepairs", wrapper.Sitepairs).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/sitepairs/{id}", wrapper.SitepairByID).Methods("GET")
 
+	r.HandleFunc(options.BaseURL+"/api/v2alpha1/sitepairs/{id}/timeseries", wrapper.TimeSeriesBySitepair).Methods("GET")
+
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/sites", wrapper.Sites).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/sites/{id}", wrapper.SiteById).Methods("GET")
@@ -5624,5 +7585,7 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/sites/{id}/routers", wrapper.RoutersBySite).Methods("GET")
 
+	r.HandleFunc(options.BaseURL+"/api/v2alpha1/topology", wrapper.Topology).Methods("GET")
+
 	return r
 }
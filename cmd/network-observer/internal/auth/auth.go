@@ -0,0 +1,161 @@
+// Package auth authenticates requests to the network observer API and scopes
+// the records an authenticated principal may see.
+package auth
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+)
+
+const (
+	MethodToken       = "token"
+	MethodCertificate = "certificate"
+	MethodJWT         = "jwt"
+)
+
+// ErrUnauthenticated is returned by an Authenticator when the credentials a
+// request presented are not valid.
+var ErrUnauthenticated = errors.New("invalid credentials")
+
+// Authenticator identifies the principal making a request. It returns false
+// when the request does not carry credentials of the kind it handles.
+type Authenticator interface {
+	Authenticate(r *http.Request) (Principal, bool, error)
+}
+
+// Role grants access to the records of the listed sites and routing keys.
+// A role without sites or routing keys is not restricted by them.
+type Role struct {
+	Name string `json:"name"`
+	// Sites by identity or name
+	Sites       []string `json:"sites,omitempty"`
+	RoutingKeys []string `json:"routingKeys,omitempty"`
+}
+
+func (r Role) unrestricted() bool {
+	return len(r.Sites) == 0 && len(r.RoutingKeys) == 0
+}
+
+func (r Role) allows(res Resource) bool {
+	return anyOf(r.Sites, res.Sites) && anyOf(r.RoutingKeys, res.RoutingKeys)
+}
+
+// anyOf reports whether one of values is granted. An empty grant or a nil set
+// of values (an attribute the resource does not have) is not restrictive.
+func anyOf(granted, values []string) bool {
+	if len(granted) == 0 || values == nil {
+		return true
+	}
+	for _, v := range values {
+		if v != "" && slices.Contains(granted, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Resource describes the sites and routing keys a record is associated with.
+// Sites should include both the identity and name of each site. A nil slice
+// means the record has no such association and is not restricted by it.
+type Resource struct {
+	Sites       []string
+	RoutingKeys []string
+}
+
+// Principal is an authenticated API client
+type Principal struct {
+	Name   string
+	Method string
+	Roles  []Role
+}
+
+// Unrestricted reports whether the principal may see every record
+func (p Principal) Unrestricted() bool {
+	return slices.ContainsFunc(p.Roles, Role.unrestricted)
+}
+
+// Allows reports whether any of the principal's roles grants access to res
+func (p Principal) Allows(res Resource) bool {
+	for _, role := range p.Roles {
+		if role.allows(res) {
+			return true
+		}
+	}
+	return false
+}
+
+type principalKey struct{}
+
+// NewContext returns a copy of ctx carrying the principal
+func NewContext(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal of an authenticated request. It returns
+// false when authentication is not enabled.
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// Allowed reports whether the request may access res. Requests are always
+// allowed when authentication is not enabled.
+func Allowed(r *http.Request, res Resource) bool {
+	p, ok := FromContext(r.Context())
+	return !ok || p.Allows(res)
+}
+
+// Unrestricted reports whether the request may access every record
+func Unrestricted(r *http.Request) bool {
+	p, ok := FromContext(r.Context())
+	return !ok || p.Unrestricted()
+}
+
+// Middleware returns a middleware that rejects requests that are not
+// authenticated by one of authenticators or whose principal has no roles.
+func Middleware(logger *slog.Logger, authenticators ...Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, authn := range authenticators {
+				principal, ok, err := authn.Authenticate(r)
+				if err != nil {
+					logger.Debug("authentication failed",
+						slog.String("endpoint", r.URL.Path),
+						slog.Any("error", err))
+					unauthorized(w)
+					return
+				}
+				if !ok {
+					continue
+				}
+				if len(principal.Roles) == 0 {
+					http.Error(w, "forbidden", http.StatusForbidden)
+					return
+				}
+				next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), principal)))
+				return
+			}
+			unauthorized(w)
+		})
+	}
+}
+
+// RequireUnrestricted wraps a handler serving data that cannot be scoped so
+// that it is only available to unrestricted principals.
+func RequireUnrestricted(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !Unrestricted(r) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func unauthorized(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="network-observer"`)
+	http.Error(w, "unauthorized", http.StatusUnauthorized)
+}
@@ -0,0 +1,175 @@
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestPrincipalAllows(t *testing.T) {
+	east := Role{Name: "east", Sites: []string{"site-east"}}
+	pizza := Role{Name: "pizza", RoutingKeys: []string{"pizza"}}
+	eastPizza := Role{Name: "east-pizza", Sites: []string{"site-east"}, RoutingKeys: []string{"pizza"}}
+	testcases := []struct {
+		Roles    []Role
+		Resource Resource
+		Expect   bool
+	}{
+		{Roles: []Role{{Name: "admin"}}, Resource: Resource{Sites: []string{"site-west"}}, Expect: true},
+		{Roles: nil, Resource: Resource{}, Expect: false},
+		{Roles: []Role{east}, Resource: Resource{Sites: []string{"site-east"}}, Expect: true},
+		{Roles: []Role{east}, Resource: Resource{Sites: []string{"site-west"}}, Expect: false},
+		{Roles: []Role{east}, Resource: Resource{Sites: []string{"site-west", "site-east"}}, Expect: true},
+		{Roles: []Role{east}, Resource: Resource{Sites: []string{}}, Expect: false},
+		{Roles: []Role{east}, Resource: Resource{RoutingKeys: []string{"pizza"}}, Expect: true},
+		{Roles: []Role{eastPizza}, Resource: Resource{Sites: []string{"site-east"}, RoutingKeys: []string{"waffles"}}, Expect: false},
+		{Roles: []Role{eastPizza}, Resource: Resource{Sites: []string{"site-east"}, RoutingKeys: []string{"pizza"}}, Expect: true},
+		{Roles: []Role{east, pizza}, Resource: Resource{Sites: []string{"site-west"}, RoutingKeys: []string{"pizza"}}, Expect: true},
+		{Roles: []Role{east, pizza}, Resource: Resource{Sites: []string{"site-west"}, RoutingKeys: []string{"waffles"}}, Expect: false},
+	}
+	for _, tc := range testcases {
+		p := Principal{Roles: tc.Roles}
+		assert.Equal(t, p.Allows(tc.Resource), tc.Expect, "roles %v resource %v", tc.Roles, tc.Resource)
+	}
+	assert.Assert(t, Principal{Roles: []Role{east, {Name: "admin"}}}.Unrestricted())
+	assert.Assert(t, !Principal{Roles: []Role{east, pizza}}.Unrestricted())
+}
+
+func TestMiddleware(t *testing.T) {
+	viewer := Role{Name: "viewer"}
+	tokens := NewTokenAuthenticator([]StaticToken{
+		{Subject: "ci", Token: "s3cr3t", Roles: []Role{viewer}},
+		{Subject: "nobody", Token: "no-roles"},
+	})
+	certs := NewCertificateAuthenticator(map[string][]Role{"alice": {viewer}})
+	handler := Middleware(slog.Default(), certs, tokens)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, ok := FromContext(r.Context())
+		assert.Assert(t, ok)
+		w.Write([]byte(p.Method + ":" + p.Name))
+	}))
+
+	testcases := []struct {
+		Authorization string
+		ClientCN      string
+		ExpectStatus  int
+		ExpectBody    string
+	}{
+		{ExpectStatus: http.StatusUnauthorized},
+		{Authorization: "Bearer wrong", ExpectStatus: http.StatusUnauthorized},
+		{Authorization: "Basic czNjcjN0", ExpectStatus: http.StatusUnauthorized},
+		{Authorization: "Bearer no-roles", ExpectStatus: http.StatusForbidden},
+		{Authorization: "Bearer s3cr3t", ExpectStatus: http.StatusOK, ExpectBody: "token:ci"},
+		{Authorization: "bearer  s3cr3t", ExpectStatus: http.StatusOK, ExpectBody: "token:ci"},
+		{ClientCN: "alice", ExpectStatus: http.StatusOK, ExpectBody: "certificate:alice"},
+		{ClientCN: "mallory", ExpectStatus: http.StatusForbidden},
+	}
+	for _, tc := range testcases {
+		req := httptest.NewRequest(http.MethodGet, "/api/v2alpha1/sites", nil)
+		if tc.Authorization != "" {
+			req.Header.Set("Authorization", tc.Authorization)
+		}
+		if tc.ClientCN != "" {
+			req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{
+				{{Subject: pkix.Name{CommonName: tc.ClientCN}}},
+			}}
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, rec.Code, tc.ExpectStatus, "%+v", tc)
+		if tc.ExpectBody != "" {
+			assert.Equal(t, rec.Body.String(), tc.ExpectBody)
+		}
+		if tc.ExpectStatus == http.StatusUnauthorized {
+			assert.Equal(t, rec.Header().Get("WWW-Authenticate"), `Bearer realm="network-observer"`)
+		}
+	}
+}
+
+func TestRequireUnrestricted(t *testing.T) {
+	handler := RequireUnrestricted(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	for _, tc := range []struct {
+		Principal    *Principal
+		ExpectStatus int
+	}{
+		{ExpectStatus: http.StatusOK},
+		{Principal: &Principal{Roles: []Role{{Name: "admin"}}}, ExpectStatus: http.StatusOK},
+		{Principal: &Principal{Roles: []Role{{Name: "east", Sites: []string{"east"}}}}, ExpectStatus: http.StatusForbidden},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v2alpha1/internal/prom/query", nil)
+		if tc.Principal != nil {
+			req = req.WithContext(NewContext(req.Context(), *tc.Principal))
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, rec.Code, tc.ExpectStatus)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "auth.yaml")
+	tokenPath := filepath.Join(dir, "token")
+	assert.Assert(t, os.WriteFile(tokenPath, []byte("from-file\n"), 0o600))
+	assert.Assert(t, os.WriteFile(path, []byte(`
+roles:
+  - name: admin
+  - name: east
+    sites: [site-east]
+    routingKeys: [backend]
+tokens:
+  - subject: ci
+    token: inline
+    roles: [admin]
+  - subject: dashboard
+    tokenFile: `+tokenPath+`
+    roles: [east]
+`), 0o644))
+	cfg, err := LoadConfig(path)
+	assert.Assert(t, err)
+	authenticators, err := cfg.Authenticators()
+	assert.Assert(t, err)
+	assert.Equal(t, len(authenticators), 1)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer from-file")
+	p, ok, err := authenticators[0].Authenticate(req)
+	assert.Assert(t, err)
+	assert.Assert(t, ok)
+	assert.Equal(t, p.Name, "dashboard")
+	assert.DeepEqual(t, p.Roles, []Role{{Name: "east", Sites: []string{"site-east"}, RoutingKeys: []string{"backend"}}})
+	pool, err := cfg.ClientCAs()
+	assert.Assert(t, err)
+	assert.Assert(t, pool == nil)
+
+	for _, invalid := range []string{
+		"roles: [{name: admin}]",
+		"roles: [{name: admin}, {name: admin}]\ntokens: [{subject: a, token: b, roles: [admin]}]",
+		"roles: [{sites: [a]}]\ntokens: [{subject: a, token: b}]",
+		"tokens: [{subject: a, token: b}]\nunknown: true",
+	} {
+		assert.Assert(t, os.WriteFile(path, []byte(invalid), 0o644))
+		_, err := LoadConfig(path)
+		assert.Assert(t, err != nil, invalid)
+	}
+	for _, invalid := range []string{
+		"tokens: [{subject: a, token: b, roles: [unknown]}]",
+		"tokens: [{subject: a}]",
+		"tokens: [{token: b}]",
+		"oidc: {issuer: https://example.com}",
+		"clientCertificates: {subjects: [{roles: []}]}",
+	} {
+		assert.Assert(t, os.WriteFile(path, []byte(invalid), 0o644))
+		cfg, err := LoadConfig(path)
+		assert.Assert(t, err)
+		_, err = cfg.Authenticators()
+		assert.Assert(t, err != nil, invalid)
+	}
+}
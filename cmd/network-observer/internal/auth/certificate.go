@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"net/http"
+)
+
+type certificateAuthenticator struct {
+	subjects map[string][]Role
+}
+
+// NewCertificateAuthenticator returns an Authenticator identifying clients by
+// the common name of a client certificate verified by the TLS server. Roles
+// are granted to the common names in subjects.
+func NewCertificateAuthenticator(subjects map[string][]Role) Authenticator {
+	return &certificateAuthenticator{subjects: subjects}
+}
+
+func (a *certificateAuthenticator) Authenticate(r *http.Request) (Principal, bool, error) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return Principal{}, false, nil
+	}
+	name := r.TLS.VerifiedChains[0][0].Subject.CommonName
+	return Principal{
+		Name:   name,
+		Method: MethodCertificate,
+		Roles:  a.subjects[name],
+	}, true, nil
+}
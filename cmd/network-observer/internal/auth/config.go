@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Config is the document configuring API authentication and the roles
+// granted to authenticated principals.
+type Config struct {
+	Roles              []Role                    `json:"roles"`
+	Tokens             []TokenConfig             `json:"tokens,omitempty"`
+	ClientCertificates *ClientCertificatesConfig `json:"clientCertificates,omitempty"`
+	OIDC               *OIDCConfig               `json:"oidc,omitempty"`
+}
+
+// TokenConfig is a static bearer token read inline or from TokenFile
+type TokenConfig struct {
+	Subject   string   `json:"subject"`
+	Token     string   `json:"token,omitempty"`
+	TokenFile string   `json:"tokenFile,omitempty"`
+	Roles     []string `json:"roles"`
+}
+
+// ClientCertificatesConfig enables authentication by TLS client certificates
+// issued by CA. Subjects grants roles to certificate common names.
+type ClientCertificatesConfig struct {
+	CA       string          `json:"ca"`
+	Subjects []SubjectConfig `json:"subjects"`
+}
+
+type SubjectConfig struct {
+	Subject string   `json:"subject"`
+	Roles   []string `json:"roles"`
+}
+
+// OIDCConfig enables authentication by JWT bearer tokens signed by an OIDC
+// provider whose keys are in JWKSFile.
+type OIDCConfig struct {
+	JWKSFile     string `json:"jwksFile"`
+	Issuer       string `json:"issuer,omitempty"`
+	Audience     string `json:"audience,omitempty"`
+	SubjectClaim string `json:"subjectClaim,omitempty"`
+	RolesClaim   string `json:"rolesClaim,omitempty"`
+}
+
+// LoadConfig reads a Config from a YAML or JSON file and validates it.
+func LoadConfig(path string) (Config, error) {
+	var cfg Config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("error parsing auth config: %s", err)
+	}
+	if len(cfg.Tokens) == 0 && cfg.ClientCertificates == nil && cfg.OIDC == nil {
+		return cfg, fmt.Errorf("auth config must enable tokens, clientCertificates or oidc")
+	}
+	if _, err := cfg.roles(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+func (c Config) roles() (map[string]Role, error) {
+	out := make(map[string]Role, len(c.Roles))
+	for _, role := range c.Roles {
+		if role.Name == "" {
+			return nil, fmt.Errorf("auth roles must have a name")
+		}
+		if _, ok := out[role.Name]; ok {
+			return nil, fmt.Errorf("duplicate auth role %q", role.Name)
+		}
+		out[role.Name] = role
+	}
+	return out, nil
+}
+
+func resolveRoles(roles map[string]Role, subject string, names []string) ([]Role, error) {
+	out := make([]Role, 0, len(names))
+	for _, name := range names {
+		role, ok := roles[name]
+		if !ok {
+			return nil, fmt.Errorf("subject %q is granted unknown role %q", subject, name)
+		}
+		out = append(out, role)
+	}
+	return out, nil
+}
+
+// Authenticators returns the configured authenticators in the order they
+// are tried: client certificates, static tokens then OIDC tokens.
+func (c Config) Authenticators() ([]Authenticator, error) {
+	roles, err := c.roles()
+	if err != nil {
+		return nil, err
+	}
+	var out []Authenticator
+	if c.ClientCertificates != nil {
+		subjects := make(map[string][]Role, len(c.ClientCertificates.Subjects))
+		for _, subject := range c.ClientCertificates.Subjects {
+			if subject.Subject == "" {
+				return nil, fmt.Errorf("client certificate subjects must not be empty")
+			}
+			granted, err := resolveRoles(roles, subject.Subject, subject.Roles)
+			if err != nil {
+				return nil, err
+			}
+			subjects[subject.Subject] = granted
+		}
+		out = append(out, NewCertificateAuthenticator(subjects))
+	}
+	if len(c.Tokens) > 0 {
+		tokens := make([]StaticToken, 0, len(c.Tokens))
+		for _, token := range c.Tokens {
+			if token.Subject == "" {
+				return nil, fmt.Errorf("tokens must have a subject")
+			}
+			value := token.Token
+			if token.TokenFile != "" {
+				data, err := os.ReadFile(token.TokenFile)
+				if err != nil {
+					return nil, fmt.Errorf("error reading token for %q: %s", token.Subject, err)
+				}
+				value = strings.TrimSpace(string(data))
+			}
+			if value == "" {
+				return nil, fmt.Errorf("token for %q is empty", token.Subject)
+			}
+			granted, err := resolveRoles(roles, token.Subject, token.Roles)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, StaticToken{Subject: token.Subject, Token: value, Roles: granted})
+		}
+		out = append(out, NewTokenAuthenticator(tokens))
+	}
+	if c.OIDC != nil {
+		if c.OIDC.JWKSFile == "" {
+			return nil, fmt.Errorf("oidc requires a jwksFile")
+		}
+		authn, err := NewJWTAuthenticator(JWTConfig{
+			JWKSFile:     c.OIDC.JWKSFile,
+			Issuer:       c.OIDC.Issuer,
+			Audience:     c.OIDC.Audience,
+			SubjectClaim: c.OIDC.SubjectClaim,
+			RolesClaim:   c.OIDC.RolesClaim,
+			Roles:        roles,
+		})
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, authn)
+	}
+	return out, nil
+}
+
+// ClientCAs returns the pool of CAs client certificates are verified
+// against, or nil when client certificate authentication is not enabled.
+func (c Config) ClientCAs() (*x509.CertPool, error) {
+	if c.ClientCertificates == nil {
+		return nil, nil
+	}
+	data, err := os.ReadFile(c.ClientCertificates.CA)
+	if err != nil {
+		return nil, fmt.Errorf("error reading client certificate ca: %s", err)
+	}
+	pool := x509.NewCertPool()
+	if ok := pool.AppendCertsFromPEM(data); !ok {
+		return nil, fmt.Errorf("failed to add client certificate ca to certificate pool")
+	}
+	return pool, nil
+}
@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+// clockSkew is the leeway allowed when checking token expiry
+const clockSkew = 30 * time.Second
+
+// JWTConfig configures validation of OIDC ID and access tokens
+type JWTConfig struct {
+	// JWKSFile is the path to a JSON Web Key Set containing the issuer's
+	// signing keys. It is reloaded when it changes.
+	JWKSFile string
+	// Issuer and Audience are checked when set
+	Issuer   string
+	Audience string
+	// SubjectClaim names the principal. Defaults to "sub".
+	SubjectClaim string
+	// RolesClaim is a string or list of strings naming the principal's roles.
+	// Defaults to "roles".
+	RolesClaim string
+	// Roles by name
+	Roles map[string]Role
+}
+
+type jwtAuthenticator struct {
+	cfg JWTConfig
+	now func() time.Time
+
+	mu      sync.Mutex
+	modTime time.Time
+	keys    []jose.JSONWebKey
+}
+
+// NewJWTAuthenticator returns an Authenticator validating JWT bearer tokens
+// against the keys in a local JWKS file.
+func NewJWTAuthenticator(cfg JWTConfig) (Authenticator, error) {
+	if cfg.SubjectClaim == "" {
+		cfg.SubjectClaim = "sub"
+	}
+	if cfg.RolesClaim == "" {
+		cfg.RolesClaim = "roles"
+	}
+	a := &jwtAuthenticator{cfg: cfg, now: time.Now}
+	if _, err := a.signingKeys(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *jwtAuthenticator) Authenticate(r *http.Request) (Principal, bool, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return Principal{}, false, nil
+	}
+	claims, err := a.verify(token)
+	if err != nil {
+		return Principal{}, true, fmt.Errorf("%w: %s", ErrUnauthenticated, err)
+	}
+	subject, _ := claims[a.cfg.SubjectClaim].(string)
+	if subject == "" {
+		return Principal{}, true, fmt.Errorf("%w: token has no %q claim", ErrUnauthenticated, a.cfg.SubjectClaim)
+	}
+	principal := Principal{Name: subject, Method: MethodJWT}
+	for _, name := range stringOrList(claims[a.cfg.RolesClaim]) {
+		if role, ok := a.cfg.Roles[name]; ok {
+			principal.Roles = append(principal.Roles, role)
+		}
+	}
+	return principal, true, nil
+}
+
+// signatureAlgorithms are the JWS algorithms accepted for tokens
+var signatureAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+}
+
+func (a *jwtAuthenticator) verify(token string) (map[string]any, error) {
+	parsed, err := jwt.ParseSigned(token, signatureAlgorithms)
+	if err != nil {
+		return nil, fmt.Errorf("malformed token: %s", err)
+	}
+	keys, err := a.signingKeys()
+	if err != nil {
+		return nil, err
+	}
+	header := parsed.Headers[0]
+	var (
+		claims     map[string]any
+		registered jwt.Claims
+		verified   bool
+	)
+	for _, key := range keys {
+		if header.KeyID != "" && key.KeyID != "" && key.KeyID != header.KeyID {
+			continue
+		}
+		if key.Algorithm != "" && key.Algorithm != header.Algorithm {
+			continue
+		}
+		if parsed.Claims(key.Key, &claims, &registered) == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, fmt.Errorf("signature not verified by any key")
+	}
+	if registered.Expiry == nil {
+		return nil, fmt.Errorf("token has no expiry")
+	}
+	expected := jwt.Expected{Issuer: a.cfg.Issuer, Time: a.now()}
+	if a.cfg.Audience != "" {
+		expected.AnyAudience = jwt.Audience{a.cfg.Audience}
+	}
+	if err := registered.ValidateWithLeeway(expected, clockSkew); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// signingKeys returns the public signing keys in the JWKS file, reading it
+// again when it has been modified.
+func (a *jwtAuthenticator) signingKeys() ([]jose.JSONWebKey, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	info, err := os.Stat(a.cfg.JWKSFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading jwks: %s", err)
+	}
+	if a.keys != nil && info.ModTime().Equal(a.modTime) {
+		return a.keys, nil
+	}
+	data, err := os.ReadFile(a.cfg.JWKSFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading jwks: %s", err)
+	}
+	var set jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("error parsing jwks %s: %s", a.cfg.JWKSFile, err)
+	}
+	keys := make([]jose.JSONWebKey, 0, len(set.Keys))
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		if !key.IsPublic() {
+			key = key.Public()
+		}
+		if !key.Valid() {
+			return nil, fmt.Errorf("error parsing jwks %s key %q: invalid key", a.cfg.JWKSFile, key.KeyID)
+		}
+		keys = append(keys, key)
+	}
+	a.keys, a.modTime = keys, info.ModTime()
+	return keys, nil
+}
+
+func stringOrList(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
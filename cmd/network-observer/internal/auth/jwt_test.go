@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func signJWT(t *testing.T, key crypto.Signer, alg, kid string, claims map[string]any) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	assert.Assert(t, err)
+	payload, err := json.Marshal(claims)
+	assert.Assert(t, err)
+	signed := b64(header) + "." + b64(payload)
+	digest := sha256.Sum256([]byte(signed))
+	var signature []byte
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		assert.Assert(t, err)
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		assert.Assert(t, err)
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	return signed + "." + b64(signature)
+}
+
+func writeJWKS(t *testing.T, path string, keys ...any) {
+	t.Helper()
+	var set struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	for i, key := range keys {
+		kid := string(rune('a' + i))
+		switch key := key.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, map[string]string{
+				"kty": "RSA", "kid": kid, "use": "sig",
+				"n": b64(key.N.Bytes()),
+				"e": b64(big.NewInt(int64(key.E)).Bytes()),
+			})
+		case *ecdsa.PublicKey:
+			set.Keys = append(set.Keys, map[string]string{
+				"kty": "EC", "kid": kid, "crv": "P-256",
+				"x": b64(key.X.FillBytes(make([]byte, 32))),
+				"y": b64(key.Y.FillBytes(make([]byte, 32))),
+			})
+		}
+	}
+	data, err := json.Marshal(set)
+	assert.Assert(t, err)
+	assert.Assert(t, os.WriteFile(path, data, 0o644))
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Assert(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Assert(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Assert(t, err)
+
+	jwksPath := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, jwksPath, &rsaKey.PublicKey, &ecKey.PublicKey)
+	now := time.Unix(1_700_000_000, 0)
+	authn, err := NewJWTAuthenticator(JWTConfig{
+		JWKSFile: jwksPath,
+		Issuer:   "https://idp.example.com",
+		Audience: "network-observer",
+		Roles: map[string]Role{
+			"viewer": {Name: "viewer"},
+			"east":   {Name: "east", Sites: []string{"site-east"}},
+		},
+	})
+	assert.Assert(t, err)
+	authn.(*jwtAuthenticator).now = func() time.Time { return now }
+
+	claims := func(overrides map[string]any) map[string]any {
+		out := map[string]any{
+			"sub":   "alice",
+			"iss":   "https://idp.example.com",
+			"aud":   []string{"network-observer", "other"},
+			"exp":   now.Add(time.Minute).Unix(),
+			"roles": []string{"east", "unknown"},
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(out, k)
+				continue
+			}
+			out[k] = v
+		}
+		return out
+	}
+	testcases := []struct {
+		Name        string
+		Token       string
+		ExpectError bool
+		ExpectRoles []Role
+	}{
+		{
+			Name:        "rsa",
+			Token:       signJWT(t, rsaKey, "RS256", "a", claims(nil)),
+			ExpectRoles: []Role{{Name: "east", Sites: []string{"site-east"}}},
+		}, {
+			Name:        "ecdsa",
+			Token:       signJWT(t, ecKey, "ES256", "b", claims(map[string]any{"roles": "viewer", "aud": "network-observer"})),
+			ExpectRoles: []Role{{Name: "viewer"}},
+		}, {
+			Name:        "no kid",
+			Token:       signJWT(t, ecKey, "ES256", "", claims(nil)),
+			ExpectRoles: []Role{{Name: "east", Sites: []string{"site-east"}}},
+		}, {
+			Name:        "unknown key",
+			Token:       signJWT(t, otherKey, "RS256", "a", claims(nil)),
+			ExpectError: true,
+		}, {
+			Name:        "algorithm mismatch",
+			Token:       signJWT(t, rsaKey, "ES256", "a", claims(nil)),
+			ExpectError: true,
+		}, {
+			Name:        "expired",
+			Token:       signJWT(t, rsaKey, "RS256", "a", claims(map[string]any{"exp": now.Add(-time.Minute).Unix()})),
+			ExpectError: true,
+		}, {
+			Name:        "no expiry",
+			Token:       signJWT(t, rsaKey, "RS256", "a", claims(map[string]any{"exp": nil})),
+			ExpectError: true,
+		}, {
+			Name:        "not yet valid",
+			Token:       signJWT(t, rsaKey, "RS256", "a", claims(map[string]any{"nbf": now.Add(time.Minute).Unix()})),
+			ExpectError: true,
+		}, {
+			Name:        "wrong issuer",
+			Token:       signJWT(t, rsaKey, "RS256", "a", claims(map[string]any{"iss": "https://evil.example.com"})),
+			ExpectError: true,
+		}, {
+			Name:        "wrong audience",
+			Token:       signJWT(t, rsaKey, "RS256", "a", claims(map[string]any{"aud": "other"})),
+			ExpectError: true,
+		}, {
+			Name:        "no subject",
+			Token:       signJWT(t, rsaKey, "RS256", "a", claims(map[string]any{"sub": nil})),
+			ExpectError: true,
+		}, {
+			Name:        "malformed",
+			Token:       "not.a.jwt",
+			ExpectError: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+tc.Token)
+			p, ok, err := authn.Authenticate(req)
+			assert.Assert(t, ok)
+			if tc.ExpectError {
+				assert.ErrorIs(t, err, ErrUnauthenticated)
+				return
+			}
+			assert.Assert(t, err)
+			assert.Equal(t, p.Name, "alice")
+			assert.Equal(t, p.Method, MethodJWT)
+			assert.DeepEqual(t, p.Roles, tc.ExpectRoles)
+		})
+	}
+
+	// rotated keys are picked up when the file changes
+	writeJWKS(t, jwksPath, &otherKey.PublicKey)
+	future := time.Now().Add(time.Hour)
+	assert.Assert(t, os.Chtimes(jwksPath, future, future))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+signJWT(t, otherKey, "RS256", "a", claims(nil)))
+	_, _, err = authn.Authenticate(req)
+	assert.Assert(t, err)
+
+	req.Header.Del("Authorization")
+	_, ok, err := authn.Authenticate(req)
+	assert.Assert(t, !ok && err == nil)
+}
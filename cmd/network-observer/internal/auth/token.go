@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// StaticToken is a bearer token issued to a named principal
+type StaticToken struct {
+	Subject string
+	Token   string
+	Roles   []Role
+}
+
+type tokenAuthenticator struct {
+	tokens []hashedToken
+}
+
+type hashedToken struct {
+	digest    [sha256.Size]byte
+	principal Principal
+}
+
+// NewTokenAuthenticator returns an Authenticator accepting the static bearer
+// tokens. Requests with other bearer tokens are left to the next
+// Authenticator.
+func NewTokenAuthenticator(tokens []StaticToken) Authenticator {
+	a := &tokenAuthenticator{tokens: make([]hashedToken, len(tokens))}
+	for i, token := range tokens {
+		a.tokens[i] = hashedToken{
+			digest: sha256.Sum256([]byte(token.Token)),
+			principal: Principal{
+				Name:   token.Subject,
+				Method: MethodToken,
+				Roles:  token.Roles,
+			},
+		}
+	}
+	return a
+}
+
+func (a *tokenAuthenticator) Authenticate(r *http.Request) (Principal, bool, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return Principal{}, false, nil
+	}
+	digest := sha256.Sum256([]byte(token))
+	var (
+		match Principal
+		found bool
+	)
+	// compare against every token to avoid leaking which one matched
+	for _, t := range a.tokens {
+		if subtle.ConstantTimeCompare(digest[:], t.digest[:]) == 1 {
+			match, found = t.principal, true
+		}
+	}
+	return match, found, nil
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
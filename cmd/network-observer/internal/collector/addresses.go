@@ -64,7 +64,10 @@ func (m *addressManager) run(ctx context.Context) func() error {
 						return
 					}
 					addressName := addr.Name
-					entries := m.stor.Index(IndexByAddress, store.Entry{Record: vanflow.ConnectorRecord{Address: &addressName}})
+					entries := m.stor.Index(IndexByAddress, store.Entry{
+						Record:   vanflow.ConnectorRecord{Address: &addressName},
+						Metadata: store.Metadata{Source: store.SourceRef{Network: addr.Network}},
+					})
 					if len(entries) > 0 {
 						return
 					}
@@ -82,7 +85,7 @@ func (m *addressManager) run(ctx context.Context) func() error {
 
 func (m *addressManager) handleChangeEvent(event changeEvent, stor readonly) {
 	if delEvent, ok := event.(deleteEvent); ok {
-		address, ok := m.addressFromRecord(delEvent.Record)
+		address, ok := m.addressFromRecord(delEvent.Record, delEvent.Source.Network)
 		if !ok {
 			return
 		}
@@ -96,7 +99,7 @@ func (m *addressManager) handleChangeEvent(event changeEvent, stor readonly) {
 	if !ok {
 		return
 	}
-	address, ok := m.addressFromRecord(entry.Record)
+	address, ok := m.addressFromRecord(entry.Record, entry.Source.Network)
 	if !ok {
 		return // incomplete
 	}
@@ -108,14 +111,15 @@ func (m *addressManager) handleChangeEvent(event changeEvent, stor readonly) {
 func (m *addressManager) hasAddress(address AddressRecord) bool {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	addressID := m.idp.ID("adr", address.Name, address.Protocol)
+	addressID := m.idp.ID("adr", NetworkScoped(address.Network, address.Name), address.Protocol)
 	_, ok := m.addresses[addressID]
 	return ok
 }
 
-func (m *addressManager) addressFromRecord(record vanflow.Record) (AddressRecord, bool) {
+func (m *addressManager) addressFromRecord(record vanflow.Record, network string) (AddressRecord, bool) {
 	var addr AddressRecord
 	addr.Start = time.Now()
+	addr.Network = network
 	switch r := record.(type) {
 	case vanflow.ListenerRecord:
 		if r.Address != nil {
@@ -143,6 +147,6 @@ func (m *addressManager) addressFromRecord(record vanflow.Record) (AddressRecord
 	if addr.Name == "" || addr.Protocol == "" {
 		return addr, false
 	}
-	addr.ID = m.idp.ID("adr", addr.Name, addr.Protocol)
+	addr.ID = m.idp.ID("adr", NetworkScoped(addr.Network, addr.Name), addr.Protocol)
 	return addr, true
 }
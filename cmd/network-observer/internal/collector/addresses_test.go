@@ -150,3 +150,46 @@ func wrapRecords(records ...vanflow.Record) []store.Entry {
 	}
 	return entries
 }
+
+func TestAddressManagerNetworks(t *testing.T) {
+	tlog := slog.New(slog.NewTextHandler(io.Discard, nil))
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{
+		Indexers: RecordIndexers(),
+	})
+	testCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	manager := newAddressManager(tlog, stor)
+	go manager.run(testCtx)()
+
+	east := store.SourceRef{ID: "router-east", Network: "east"}
+	west := store.SourceRef{ID: "router-west", Network: "west"}
+	stor.Add(vanflow.ConnectorRecord{BaseRecord: vanflow.NewBase("c1"), Address: ptrTo("db"), Protocol: ptrTo("tcp")}, east)
+	stor.Add(vanflow.ConnectorRecord{BaseRecord: vanflow.NewBase("c2"), Address: ptrTo("db"), Protocol: ptrTo("tcp")}, west)
+	for _, e := range stor.List() {
+		manager.handleChangeEvent(addEvent{Record: e.Record}, stor)
+	}
+	networks := func() map[string]string {
+		out := make(map[string]string)
+		for _, e := range stor.Index(store.TypeIndex, store.Entry{Record: AddressRecord{}}) {
+			addr := e.Record.(AddressRecord)
+			out[addr.Network] = addr.ID
+		}
+		return out
+	}
+	poll.WaitOn(t, func(t poll.LogT) poll.Result {
+		if ids := networks(); len(ids) != 2 || ids["east"] == ids["west"] {
+			return poll.Continue("expected distinct addresses for each network: %v", ids)
+		}
+		return poll.Success()
+	})
+
+	// the address remains while the connector in its own network does
+	stor.Delete("c2")
+	manager.handleChangeEvent(deleteEvent{Record: vanflow.ConnectorRecord{BaseRecord: vanflow.NewBase("c2"), Address: ptrTo("db"), Protocol: ptrTo("tcp")}, Source: west}, stor)
+	poll.WaitOn(t, func(t poll.LogT) poll.Result {
+		if ids := networks(); len(ids) != 1 || ids["east"] == "" {
+			return poll.Continue("expected only the east address: %v", ids)
+		}
+		return poll.Success()
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"maps"
 	"sync"
@@ -23,24 +24,61 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
-func New(logger *slog.Logger, factory session.ContainerFactory, reg *prometheus.Registry, flowRecordTTL time.Duration, flowLogger func(vanflow.RecordMessage)) *Collector {
-	sessionCtr := factory.Create()
+// defaultEventQueueSize is the number of store changes queued in order for
+// the collector's reactors when not configured with Backpressure.
+const defaultEventQueueSize = 1024
+
+// DefaultNetwork is the name of the network when the collector is attached
+// to a single router.
+const DefaultNetwork = "default"
+
+// Network is an independent application network the collector attaches to
+// through a router.
+type Network struct {
+	Name    string
+	Factory session.ContainerFactory
+}
+
+func New(logger *slog.Logger, networks []Network, reg *prometheus.Registry, flowRecordTTL time.Duration, flowLogger func(vanflow.RecordMessage), newStore StoreFactory) (*Collector, error) {
+	if len(networks) == 0 {
+		return nil, fmt.Errorf("at least one network is required")
+	}
+	sessions := make([]*networkSession, 0, len(networks))
+	names := make(map[string]bool, len(networks))
+	for _, network := range networks {
+		if network.Name == "" {
+			return nil, fmt.Errorf("network name must not be empty")
+		}
+		if names[network.Name] {
+			return nil, fmt.Errorf("duplicate network name %q", network.Name)
+		}
+		names[network.Name] = true
+		sessionCtr := network.Factory.Create()
+		sessions = append(sessions, &networkSession{
+			name:      network.Name,
+			session:   sessionCtr,
+			discovery: eventsource.NewDiscovery(sessionCtr, eventsource.DiscoveryOptions{}),
+		})
+	}
 
 	collector := &Collector{
 		logger:         logger,
 		flowRecordTTL:  flowRecordTTL,
-		session:        sessionCtr,
-		discovery:      eventsource.NewDiscovery(sessionCtr, eventsource.DiscoveryOptions{}),
-		sources:        make(map[string]eventSource),
-		events:         make(chan changeEvent, 1024),
+		networks:       sessions,
+		sources:        make(map[store.SourceRef]eventSource),
+		events:         newEventQueue(defaultEventQueueSize),
 		purgeQueue:     make(chan store.SourceRef, 8),
 		recordRouting:  make(eventsource.RecordStoreMap),
 		metrics:        register(reg),
+		clientMetrics:  eventsource.NewClientMetrics(reg),
 		metricsAdaptor: opmetrics.New(reg),
 		flowLogging:    flowLogger,
 	}
 
-	collector.Records = store.NewSyncMapStore(store.SyncMapStoreConfig{
+	if newStore == nil {
+		newStore = MemoryStore
+	}
+	records, err := newStore(store.SyncMapStoreConfig{
 		Handlers: store.EventHandlerFuncs{
 			OnAdd:    collector.handleStoreAdd,
 			OnChange: collector.handleStoreChange,
@@ -48,6 +86,11 @@ func New(logger *slog.Logger, factory session.ContainerFactory, reg *prometheus.
 		},
 		Indexers: RecordIndexers(),
 	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating record store: %w", err)
+	}
+	collector.Records = records
+	collector.metricsAdaptor.SiteNetwork = collector.siteNetwork
 	collector.graph = NewGraph(collector.Records).(*graph)
 	collector.processManager = newProcessManager(logger, collector.Records, collector.graph, newStableIdentityProvider(), collector.metrics)
 	collector.addressManager = newAddressManager(collector.logger, collector.Records)
@@ -56,7 +99,7 @@ func New(logger *slog.Logger, factory session.ContainerFactory, reg *prometheus.
 	for _, typ := range standardRecordTypes {
 		routerCfg[typ.String()] = collector.Records
 	}
-	return collector
+	return collector, nil
 }
 
 type Collector struct {
@@ -64,11 +107,13 @@ type Collector struct {
 	flowRecordTTL time.Duration
 	flowLogging   func(vanflow.RecordMessage)
 
-	session   session.Container
-	discovery *eventsource.Discovery
+	networks []*networkSession
 
 	mu      sync.Mutex
-	sources map[string]eventSource
+	sources map[store.SourceRef]eventSource
+	// restored holds the identities of the records restored from a
+	// snapshot for each source that has not been rediscovered yet
+	restored map[store.SourceRef]map[string]struct{}
 
 	Records       store.Interface
 	graph         *graph
@@ -79,8 +124,13 @@ type Collector struct {
 	pairManager    *pairManager
 	metricsAdaptor *opmetrics.Adaptor
 
-	events     chan changeEvent
-	purgeQueue chan store.SourceRef
+	events        *eventQueue
+	purgeQueue    chan store.SourceRef
+	backpressure  Backpressure
+	snapshots     *Snapshots
+	clientMetrics *eventsource.ClientMetrics
+	subscribers   subscribers
+	flowCompleted []FlowCompletedFunc
 
 	metrics metrics
 }
@@ -90,18 +140,82 @@ type eventSource struct {
 	manager *connectionManager
 }
 
+// networkSession is the router session and source discovery of a network
+type networkSession struct {
+	name      string
+	session   session.Container
+	discovery *eventsource.Discovery
+}
+
+func (n *networkSession) sourceRef(source eventsource.Info) store.SourceRef {
+	return store.SourceRef{
+		Version: fmt.Sprint(source.Version),
+		ID:      source.ID,
+		Network: n.name,
+	}
+}
+
 func (c *Collector) GetGraph() Graph {
 	return c.graph
 }
 
+// Backpressure configures how the collector buffers the records it receives
+// when it falls behind.
+type Backpressure struct {
+	// SourceQueueSize is the number of record messages buffered for each
+	// event source listener. When zero, records are stored as they are
+	// received, holding up receiving while the store is busy.
+	SourceQueueSize int
+	// ShedFlows drops flow records received while an event source queue is
+	// full rather than waiting for the queue to drain. Topology records are
+	// never dropped.
+	ShedFlows bool
+	// EventQueueSize is the number of store changes queued in order for the
+	// collector's reactors. Past that, changes to the same record are merged.
+	// Defaults to 1024.
+	EventQueueSize int
+}
+
+// ConfigureBackpressure configures the buffering of records. It must be
+// called before Run.
+func (c *Collector) ConfigureBackpressure(cfg Backpressure) {
+	if cfg.EventQueueSize <= 0 {
+		cfg.EventQueueSize = defaultEventQueueSize
+	}
+	c.backpressure = cfg
+	c.events = newEventQueue(cfg.EventQueueSize)
+}
+
 func (c *Collector) Run(ctx context.Context) error {
-	c.session.Start(ctx)
+	if closer, ok := c.Records.(io.Closer); ok {
+		defer func() {
+			if err := closer.Close(); err != nil {
+				c.logger.Error("error closing record store", slog.Any("error", err))
+			}
+		}()
+	}
+	var restored map[store.SourceRef]bool
+	if c.snapshots != nil {
+		var err error
+		restored, err = c.restoreSnapshot()
+		if err != nil {
+			c.logger.Error("error restoring snapshot", slog.Any("error", err))
+		}
+	}
+	for _, network := range c.networks {
+		network.session.Start(ctx)
+	}
 	g, ctx := errgroup.WithContext(ctx)
-	g.Go(c.runSession(ctx))
+	for _, network := range c.networks {
+		g.Go(c.runSession(ctx, network))
+		g.Go(c.runDiscovery(ctx, network))
+	}
 	g.Go(c.runWorkQueue(ctx))
 	g.Go(c.monitoring(ctx))
-	g.Go(c.runDiscovery(ctx))
 	g.Go(c.runRecordCleanup(ctx))
+	if c.snapshots != nil {
+		g.Go(c.runSnapshots(ctx, restored))
+	}
 	g.Go(c.processManager.run(ctx))
 	g.Go(c.addressManager.run(ctx))
 	g.Go(c.pairManager.run(ctx))
@@ -126,23 +240,20 @@ func (c *Collector) updateGraph(event changeEvent, stor readonly) {
 
 func (c *Collector) monitoring(ctx context.Context) func() error {
 	eventQueueSpace := c.metrics.internal.queueUtilization.WithLabelValues("records")
+	eventQueueDepth := c.metrics.internal.queueDepth.WithLabelValues("records")
 	return func() error {
 		defer func() {
 			c.logger.Info("collector monitoring shutdown complete")
 		}()
-		recordsCapacity := float64(cap(c.events))
-
-		utilization := func(queue chan changeEvent, capacity float64) float64 {
-			events := float64(len(queue))
-			return events / capacity
-		}
+		recordsCapacity := float64(c.events.capacity)
 		for {
 			select {
 			case <-ctx.Done():
 				return nil
 			case <-time.After(time.Second * 5):
-				recordsUtil := utilization(c.events, recordsCapacity)
-				eventQueueSpace.Set(recordsUtil)
+				depth := float64(c.events.Len())
+				eventQueueDepth.Set(depth)
+				eventQueueSpace.Set(depth / recordsCapacity)
 			}
 		}
 	}
@@ -187,29 +298,30 @@ func (c *Collector) runWorkQueue(ctx context.Context) func() error {
 			select {
 			case <-ctx.Done():
 				return nil
-			case event := <-c.events:
-				start := time.Now()
-				typ := event.GetTypeMeta()
-				event.ID()
-				for _, reactor := range reactors[typ] {
-					reactor(event, c.Records)
+			case <-c.events.Ready():
+				for _, event := range c.events.Take() {
+					start := time.Now()
+					typ := event.GetTypeMeta()
+					for _, reactor := range reactors[typ] {
+						reactor(event, c.Records)
+					}
+					c.metrics.internal.flowProcessingTime.WithLabelValues(typ.String()).Observe(time.Since(start).Seconds())
 				}
-				c.metrics.internal.flowProcessingTime.WithLabelValues(typ.String()).Observe(time.Since(start).Seconds())
 			}
 		}
 	}
 }
 
-func (c *Collector) runSession(ctx context.Context) func() error {
+func (c *Collector) runSession(ctx context.Context, network *networkSession) func() error {
 	return func() error {
 		defer func() {
-			c.logger.Info("session shutdown complete")
+			c.logger.Info("session shutdown complete", slog.String("network", network.name))
 		}()
 		sessionErrors := make(chan error, 1)
-		c.session.OnSessionError(func(err error) {
+		network.session.OnSessionError(func(err error) {
 			sessionErrors <- err
 		})
-		c.session.Start(ctx)
+		network.session.Start(ctx)
 		for {
 			select {
 			case <-ctx.Done():
@@ -217,9 +329,10 @@ func (c *Collector) runSession(ctx context.Context) func() error {
 			case err := <-sessionErrors:
 				retryable, ok := err.(session.RetryableError)
 				if !ok {
-					return fmt.Errorf("unrecoverable session error: %w", err)
+					return fmt.Errorf("unrecoverable session error on network %q: %w", network.name, err)
 				}
 				c.logger.Error("session error on collector container",
+					slog.String("network", network.name),
 					slog.Any("error", retryable),
 					slog.Duration("delay", retryable.Retry()),
 				)
@@ -229,14 +342,14 @@ func (c *Collector) runSession(ctx context.Context) func() error {
 	}
 }
 
-func (c *Collector) runDiscovery(ctx context.Context) func() error {
+func (c *Collector) runDiscovery(ctx context.Context, network *networkSession) func() error {
 	return func() error {
 		defer func() {
-			c.logger.Info("discovery shutdown complete")
+			c.logger.Info("discovery shutdown complete", slog.String("network", network.name))
 		}()
-		return c.discovery.Run(ctx, eventsource.DiscoveryHandlers{
-			Discovered: c.discoveryHandler(ctx),
-			Forgotten:  c.handleForgotten,
+		return network.discovery.Run(ctx, eventsource.DiscoveryHandlers{
+			Discovered: c.discoveryHandler(ctx, network),
+			Forgotten:  c.forgottenHandler(network),
 		})
 	}
 }
@@ -248,6 +361,7 @@ func (c *Collector) runRecordCleanup(ctx context.Context) func() error {
 		}()
 		ticker := time.NewTicker(10 * time.Second)
 		defer ticker.Stop()
+		compactor, _ := c.Records.(interface{ Compact() error })
 		terminatedExemplar := store.Entry{
 			Record: vanflow.SiteRecord{BaseRecord: vanflow.NewBase("", time.Unix(1, 0), time.Unix(2, 0))},
 		}
@@ -265,6 +379,11 @@ func (c *Collector) runRecordCleanup(ctx context.Context) func() error {
 						slog.Int("count", ct),
 					)
 				}
+				if compactor != nil {
+					if err := compactor.Compact(); err != nil {
+						c.logger.Error("error compacting record store", slog.Any("error", err))
+					}
+				}
 			case source := <-c.purgeQueue:
 				ct := c.purge(source)
 				c.logger.Info("purged records from forgotten source",
@@ -277,63 +396,80 @@ func (c *Collector) runRecordCleanup(ctx context.Context) func() error {
 }
 
 func (c *Collector) handleStoreAdd(e store.Entry) {
+	c.publish(Change{Kind: ChangeAdd, Record: e.Record})
 	switch e.Record.(type) {
 	case RequestRecord:
 		return
 	case ConnectionRecord:
 		return
 	}
-	select {
-	case c.events <- addEvent{Record: e.Record}:
-	default:
-		c.logger.Error("Store event queue full")
-	}
+	c.queueEvent(addEvent{Record: e.Record})
 }
 
 func (c *Collector) handleStoreChange(p, e store.Entry) {
+	c.publish(Change{Kind: ChangeUpdate, Record: e.Record})
 	switch e.Record.(type) {
 	case RequestRecord:
 		return
 	case ConnectionRecord:
 		return
 	}
-	select {
-	case c.events <- updateEvent{Prev: p.Record, Curr: e.Record}:
-	default:
-		c.logger.Error("Store event queue full")
-	}
+	c.queueEvent(updateEvent{Prev: p.Record, Curr: e.Record})
 }
 func (c *Collector) handleStoreDelete(e store.Entry) {
+	c.publish(Change{Kind: ChangeDelete, Record: e.Record})
 	switch e.Record.(type) {
 	case RequestRecord:
 		return
 	case ConnectionRecord:
 		return
 	}
-	select {
-	case c.events <- deleteEvent{Record: e.Record}:
-	default:
-		c.logger.Error("Store event queue full")
+	c.queueEvent(deleteEvent{Record: e.Record, Source: e.Source})
+}
+
+// queueEvent queues a store change for the reactors
+func (c *Collector) queueEvent(event changeEvent) {
+	if c.events.Push(event) {
+		c.metrics.internal.coalescedChanges.Inc()
 	}
 }
 
 func (c *Collector) purge(source store.SourceRef) int {
+	retain := retainsFlowHistory(c.Records)
 	matching := c.Records.Index(store.SourceIndex, store.Entry{Metadata: store.Metadata{Source: source}})
+	var ct int
 	for _, record := range matching {
+		// completed connections and requests, whether restored or
+		// collected from the source, are left for the store to evict
+		if retain && detachFlowHistory(c.Records, record.Record) {
+			continue
+		}
 		c.Records.Delete(record.Record.Identity())
+		ct++
 	}
-	return len(matching)
+	return ct
 }
 
-func (c *Collector) discoveryHandler(ctx context.Context) func(eventsource.Info) {
+func (c *Collector) discoveryHandler(ctx context.Context, network *networkSession) func(eventsource.Info) {
 	return func(source eventsource.Info) {
-		c.logger.Info("starting client for new source", slog.String("id", source.ID), slog.String("type", source.Type))
-		client := eventsource.NewClient(c.session, eventsource.ClientOptions{
-			Source: source,
+		c.logger.Info("starting client for new source",
+			slog.String("id", source.ID),
+			slog.String("type", source.Type),
+			slog.String("network", network.name))
+		overflow := eventsource.OverflowBlock
+		if c.backpressure.ShedFlows {
+			overflow = eventsource.OverflowDropFlows
+		}
+		client := eventsource.NewClient(network.session, eventsource.ClientOptions{
+			Source:    source,
+			QueueSize: c.backpressure.SourceQueueSize,
+			Overflow:  overflow,
+			Metrics:   c.clientMetrics,
 		})
+		ref := network.sourceRef(source)
 
 		// register client with discovery to update lastseen, and monitor for staleness
-		err := c.discovery.NewWatchClient(ctx, eventsource.WatchConfig{
+		err := network.discovery.NewWatchClient(ctx, eventsource.WatchConfig{
 			Client:      client,
 			ID:          source.ID,
 			Timeout:     time.Second * 30,
@@ -342,7 +478,7 @@ func (c *Collector) discoveryHandler(ctx context.Context) func(eventsource.Info)
 
 		if err != nil {
 			c.logger.Error("error creating watcher for discovered source", slog.Any("error", err))
-			c.discovery.Forget(source.ID)
+			network.discovery.Forget(source.ID)
 			return
 		}
 
@@ -356,7 +492,7 @@ func (c *Collector) discoveryHandler(ctx context.Context) func(eventsource.Info)
 
 		router := eventsource.RecordStoreRouter{
 			Stores: c.recordRouting,
-			Source: sourceRef(source),
+			Source: ref,
 		}
 
 		switch source.Type {
@@ -366,12 +502,15 @@ func (c *Collector) discoveryHandler(ctx context.Context) func(eventsource.Info)
 			addresses = append(addresses, eventsource.FromSourceAddressFlows()) // listen to .flows
 			sourceCtr.manager = newConnectionmanager(
 				ctx,
-				c.logger.With(slog.String("eventsource", fmt.Sprintf("%d/%s", source.Version, source.ID))),
-				sourceRef(source),
+				c.logger.With(
+					slog.String("eventsource", fmt.Sprintf("%d/%s", source.Version, source.ID)),
+					slog.String("network", network.name)),
+				ref,
 				c.Records,
 				c.graph,
 				c.metrics,
 				c.flowRecordTTL,
+				c.handleFlowCompleted,
 			)
 
 			// route flow records to source-specific stores
@@ -385,6 +524,10 @@ func (c *Collector) discoveryHandler(ctx context.Context) func(eventsource.Info)
 			client.OnRecord(c.flowLogging)
 		}
 		client.OnRecord(router.Route)
+		restored := c.trackRestored(ref)
+		if restored != nil {
+			client.OnRecord(restored.Record)
+		}
 
 		for _, address := range addresses {
 			client.Listen(ctx, address)
@@ -392,13 +535,13 @@ func (c *Collector) discoveryHandler(ctx context.Context) func(eventsource.Info)
 
 		c.mu.Lock()
 		defer c.mu.Unlock()
-		c.sources[source.ID] = sourceCtr
+		c.sources[ref] = sourceCtr
 
 		go func() {
-			ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+			flushCtx, cancel := context.WithTimeout(ctx, time.Second*5)
 			defer cancel()
-			if err := eventsource.FlushOnFirstMessage(ctx, client); err != nil {
-				if errors.Is(err, ctx.Err()) {
+			if err := eventsource.FlushOnFirstMessage(flushCtx, client); err != nil {
+				if errors.Is(err, flushCtx.Err()) {
 					sendCtx, sendCancel := context.WithTimeout(ctx, time.Second*5)
 					defer sendCancel()
 					c.logger.Info("timed out waiting for first message. sending flush anyways")
@@ -406,30 +549,41 @@ func (c *Collector) discoveryHandler(ctx context.Context) func(eventsource.Info)
 				}
 				if err != nil {
 					c.logger.Error("error sending flush", slog.Any("error", err))
+					return
 				}
 			}
+			if restored != nil {
+				c.purgeUnflushed(ctx, ref, restored)
+			}
 		}()
 	}
 }
 
-func (c *Collector) handleForgotten(source eventsource.Info) {
-	c.logger.Info("handling forgotten source", slog.String("id", source.ID))
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	s, ok := c.sources[source.ID]
-	if ok {
-		s.client.Close()
-		if s.manager != nil {
-			s.manager.Stop()
+func (c *Collector) forgottenHandler(network *networkSession) func(eventsource.Info) {
+	return func(source eventsource.Info) {
+		c.logger.Info("handling forgotten source",
+			slog.String("id", source.ID),
+			slog.String("network", network.name))
+		ref := network.sourceRef(source)
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		s, ok := c.sources[ref]
+		if ok {
+			s.client.Close()
+			if s.manager != nil {
+				s.manager.Stop()
+			}
+			delete(c.sources, ref)
 		}
-		delete(c.sources, source.ID)
+		c.purgeQueue <- ref
 	}
-	c.purgeQueue <- sourceRef(source)
 }
 
-func sourceRef(source eventsource.Info) store.SourceRef {
-	return store.SourceRef{
-		Version: fmt.Sprint(source.Version),
-		ID:      source.ID,
+// siteNetwork returns the network the site record was collected from
+func (c *Collector) siteNetwork(siteID string) string {
+	entry, ok := c.Records.Get(siteID)
+	if !ok {
+		return ""
 	}
+	return entry.Source.Network
 }
@@ -30,7 +30,9 @@ type connectionManager struct {
 	requestMetricsCache   map[labelSet]appMetrics
 	transportMetricsCache map[labelSet]transportMetrics
 
-	ttl time.Duration
+	ttl           time.Duration
+	retainHistory bool
+	onComplete    func(vanflow.Record)
 
 	transportProcessingTime prometheus.Observer
 	appProcessingTime       prometheus.Observer
@@ -47,7 +49,7 @@ type connectionManager struct {
 	routerCache     map[string]routerAttrs
 }
 
-func newConnectionmanager(ctx context.Context, log *slog.Logger, source store.SourceRef, records store.Interface, graph *graph, metrics metrics, ttl time.Duration) *connectionManager {
+func newConnectionmanager(ctx context.Context, log *slog.Logger, source store.SourceRef, records store.Interface, graph *graph, metrics metrics, ttl time.Duration, onComplete func(vanflow.Record)) *connectionManager {
 	m := &connectionManager{
 		logger:                  log,
 		records:                 records,
@@ -56,6 +58,8 @@ func newConnectionmanager(ctx context.Context, log *slog.Logger, source store.So
 		idp:                     newStableIdentityProvider(),
 		metrics:                 metrics,
 		ttl:                     ttl,
+		retainHistory:           retainsFlowHistory(records),
+		onComplete:              onComplete,
 		transportProcessingTime: metrics.internal.flowProcessingTime.WithLabelValues(vanflow.TransportBiflowRecord{}.GetTypeMeta().String()),
 		appProcessingTime:       metrics.internal.flowProcessingTime.WithLabelValues(vanflow.AppBiflowRecord{}.GetTypeMeta().String()),
 		transportFlows: &keyedLRUCache[transportState, *transportState]{
@@ -110,6 +114,7 @@ func (c *connectionManager) handleTransportFlow(record vanflow.TransportBiflowRe
 		if terminated {
 			state.Terminated = true
 			metrics.closed.Inc()
+			c.complete(record.ID)
 		}
 	}
 	if !state.LatencySet && record.Latency != nil && record.LatencyReverse != nil {
@@ -155,11 +160,23 @@ func (c *connectionManager) handleAppFlow(record vanflow.AppBiflowRecord) {
 				"method": normalizeHTTPMethod(record.Method),
 				"code":   normalizeHTTPResponseClass(record.Result),
 			}).Inc()
+			c.complete(record.ID)
 		}
 	}
 	c.appFlows.Push(record.ID, state)
 }
 
+// complete passes the reconciled record of a terminated flow to the
+// onComplete handler.
+func (c *connectionManager) complete(id string) {
+	if c.onComplete == nil {
+		return
+	}
+	if entry, ok := c.records.Get(id); ok {
+		c.onComplete(entry.Record)
+	}
+}
+
 func (c *connectionManager) handleAdd(e store.Entry) {
 	c.handleChange(e, e)
 }
@@ -207,16 +224,63 @@ func normalizeHTTPMethod(method *string) string {
 func (c *connectionManager) handleDelete(e store.Entry) {
 	switch record := e.Record.(type) {
 	case vanflow.TransportBiflowRecord:
-		c.transportFlows.Pop(record.ID)
+		state, _ := c.transportFlows.Pop(record.ID)
+		if c.retainHistory && state.Terminated && c.detachConnection(record) {
+			return
+		}
 		c.records.Delete(record.ID)
 	case vanflow.AppBiflowRecord:
-		c.appFlows.Pop(record.ID)
+		state, _ := c.appFlows.Pop(record.ID)
+		if c.retainHistory && state.Terminated && c.detachRequest(record) {
+			return
+		}
 		c.records.Delete(record.ID)
 	default:
 		// ignore
 	}
 }
 
+// detachConnection keeps the connection record for a purged transport flow
+// with a snapshot of the flow, leaving its retention to the record store.
+func (c *connectionManager) detachConnection(flow vanflow.TransportBiflowRecord) bool {
+	entry, ok := c.records.Get(flow.ID)
+	if !ok {
+		return false
+	}
+	record, ok := entry.Record.(ConnectionRecord)
+	if !ok {
+		return false
+	}
+	record.FlowStore = restoredFlowStore(flow)
+	return c.records.Update(record)
+}
+
+// detachRequest keeps the request record for a purged application flow with
+// a snapshot of the flow and its transport, leaving its retention to the
+// record store.
+func (c *connectionManager) detachRequest(flow vanflow.AppBiflowRecord) bool {
+	entry, ok := c.records.Get(flow.ID)
+	if !ok {
+		return false
+	}
+	record, ok := entry.Record.(RequestRecord)
+	if !ok {
+		return false
+	}
+	flows := []vanflow.Record{flow}
+	if transport, ok := record.GetTransport(); ok {
+		flows = append(flows, transport)
+	} else if entry, ok := c.records.Get(record.TransportID); ok {
+		if connection, ok := entry.Record.(ConnectionRecord); ok {
+			if transport, ok := connection.GetFlow(); ok {
+				flows = append(flows, transport)
+			}
+		}
+	}
+	record.stor = restoredFlowStore(flows...)
+	return c.records.Update(record)
+}
+
 type reconcileReason int
 
 const (
@@ -267,6 +331,7 @@ func (c *connectionManager) reconcileRequest(state appState) (RequestRecord, rec
 		TransportID:  transState.ID,
 		StartTime:    dref(record.StartTime).Time,
 		EndTime:      dref(record.EndTime).Time,
+		Network:      connRecord.Network,
 		RoutingKey:   connRecord.RoutingKey,
 		Protocol:     protocol,
 		Connector:    connRecord.Connector,
@@ -352,6 +417,7 @@ func (c *connectionManager) reconcile(state transportState) (ConnectionRecord, r
 		ID:            record.ID,
 		StartTime:     dref(record.StartTime).Time,
 		EndTime:       dref(record.EndTime).Time,
+		Network:       c.source.Network,
 		RoutingKey:    cnctr.Address,
 		Protocol:      cnctr.Protocol,
 		ConnectorHost: cnctr.Host,
@@ -581,13 +647,14 @@ func (c *connectionManager) run(ctx context.Context) {
 						continue
 					}
 
-					id := c.idp.ID("processpair", pair.Source, pair.Dest, pair.Protocol)
+					id := c.idp.ID("processpair", NetworkScoped(c.source.Network, pair.Source), pair.Dest, pair.Protocol)
 					if _, ok := c.records.Get(id); !ok {
 						record := ProcPairRecord{
 							ID:       id,
 							Source:   pair.Source,
 							Dest:     pair.Dest,
 							Protocol: pair.Protocol,
+							Network:  c.source.Network,
 							Start:    time.Now(),
 						}
 						c.logger.Info("Adding process pairs", slog.Any("id", id))
@@ -1,6 +1,8 @@
 package collector
 
 import (
+	"sync"
+
 	"github.com/skupperproject/skupper/pkg/vanflow"
 	"github.com/skupperproject/skupper/pkg/vanflow/store"
 )
@@ -19,6 +21,7 @@ func (i addEvent) GetTypeMeta() vanflow.TypeMeta { return i.Record.GetTypeMeta()
 
 type deleteEvent struct {
 	Record vanflow.Record
+	Source store.SourceRef
 }
 
 func (i deleteEvent) ID() string                    { return i.Record.Identity() }
@@ -38,3 +41,115 @@ type readonly interface {
 	Index(index string, exemplar store.Entry) []store.Entry
 	IndexValues(index string) []string
 }
+
+// eventQueue is the queue of store change events handled by the collector
+// work queue. Events are queued in order until the queue holds capacity
+// events. Past that, an event is merged into the queued event for the same
+// record when there is one, so that bursts of changes never drop a change to
+// the topology and the queue does not outgrow the number of records. Merged
+// events move to the tail of the queue so that they are never handled before
+// events that were queued ahead of the change.
+type eventQueue struct {
+	mu       sync.Mutex
+	capacity int
+	events   []changeEvent
+	overflow map[string]int
+	// merged is the number of events left behind as nil by merges
+	merged int
+	ready  chan struct{}
+}
+
+func newEventQueue(capacity int) *eventQueue {
+	return &eventQueue{
+		capacity: capacity,
+		overflow: make(map[string]int),
+		ready:    make(chan struct{}, 1),
+	}
+}
+
+// Push an event to the queue. Returns true when the event was merged with
+// an event already queued.
+func (q *eventQueue) Push(event changeEvent) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	defer func() {
+		select {
+		case q.ready <- struct{}{}:
+		default:
+		}
+	}()
+	if len(q.events) < q.capacity {
+		q.events = append(q.events, event)
+		return false
+	}
+	id := event.ID()
+	merged := false
+	if i, ok := q.overflow[id]; ok {
+		event = coalesce(q.events[i], event)
+		q.events[i] = nil
+		q.merged++
+		merged = true
+		if q.merged > len(q.overflow) {
+			q.compact()
+		}
+	}
+	q.overflow[id] = len(q.events)
+	q.events = append(q.events, event)
+	return merged
+}
+
+// compact removes the events left behind by merges from the overflow portion
+// of the queue.
+func (q *eventQueue) compact() {
+	events := q.events[:q.capacity]
+	for _, event := range q.events[q.capacity:] {
+		if event == nil {
+			continue
+		}
+		q.overflow[event.ID()] = len(events)
+		events = append(events, event)
+	}
+	clear(q.events[len(events):])
+	q.events = events
+	q.merged = 0
+}
+
+// Ready is signalled when there are events to take
+func (q *eventQueue) Ready() <-chan struct{} {
+	return q.ready
+}
+
+// Take all of the queued events
+func (q *eventQueue) Take() []changeEvent {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.merged > 0 {
+		q.compact()
+	}
+	events := q.events
+	q.events = nil
+	clear(q.overflow)
+	return events
+}
+
+// Len is the number of queued events
+func (q *eventQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.events) - q.merged
+}
+
+// coalesce two events for the same record into one
+func coalesce(prev, next changeEvent) changeEvent {
+	switch prev := prev.(type) {
+	case addEvent:
+		if next, ok := next.(updateEvent); ok {
+			return addEvent{Record: next.Curr}
+		}
+	case updateEvent:
+		if next, ok := next.(updateEvent); ok {
+			return updateEvent{Prev: prev.Prev, Curr: next.Curr}
+		}
+	}
+	return next
+}
@@ -0,0 +1,49 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"gotest.tools/v3/assert"
+)
+
+func TestEventQueue(t *testing.T) {
+	site := func(id, name string) vanflow.SiteRecord {
+		return vanflow.SiteRecord{BaseRecord: vanflow.NewBase(id), Name: &name}
+	}
+	queue := newEventQueue(2)
+	select {
+	case <-queue.Ready():
+		t.Fatal("expected empty queue not to be ready")
+	default:
+	}
+
+	// queued in order up to capacity
+	assert.Assert(t, !queue.Push(addEvent{Record: site("a", "1")}))
+	assert.Assert(t, !queue.Push(updateEvent{Prev: site("a", "1"), Curr: site("a", "2")}))
+	// then merged with events for the same record
+	assert.Assert(t, !queue.Push(addEvent{Record: site("b", "1")}))
+	assert.Assert(t, queue.Push(updateEvent{Prev: site("b", "1"), Curr: site("b", "2")}))
+	assert.Assert(t, !queue.Push(updateEvent{Prev: site("c", "1"), Curr: site("c", "2")}))
+	assert.Assert(t, queue.Push(updateEvent{Prev: site("c", "2"), Curr: site("c", "3")}))
+	assert.Assert(t, !queue.Push(addEvent{Record: site("d", "1")}))
+	assert.Assert(t, queue.Push(deleteEvent{Record: site("d", "1")}))
+	// merged events move behind the events queued after them
+	assert.Assert(t, queue.Push(updateEvent{Prev: site("b", "2"), Curr: site("b", "3")}))
+	assert.Equal(t, queue.Len(), 5)
+
+	<-queue.Ready()
+	assert.DeepEqual(t, queue.Take(), []changeEvent{
+		addEvent{Record: site("a", "1")},
+		updateEvent{Prev: site("a", "1"), Curr: site("a", "2")},
+		updateEvent{Prev: site("c", "1"), Curr: site("c", "3")},
+		deleteEvent{Record: site("d", "1")},
+		addEvent{Record: site("b", "3")},
+	})
+	assert.Equal(t, queue.Len(), 0)
+
+	// capacity is available again once taken
+	assert.Assert(t, !queue.Push(addEvent{Record: site("b", "3")}))
+	assert.Assert(t, !queue.Push(updateEvent{Prev: site("b", "3"), Curr: site("b", "4")}))
+	assert.Equal(t, queue.Len(), 2)
+}
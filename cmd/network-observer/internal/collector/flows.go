@@ -13,6 +13,7 @@ type ConnectionRecord struct {
 	ID        string
 	StartTime time.Time
 	EndTime   time.Time
+	Network   string
 
 	Connector     NamedReference
 	RoutingKey    string
@@ -33,12 +34,15 @@ type ConnectionRecord struct {
 	// FlowStore is the backing store containing the Biflow records. This was
 	// split from the main record store to keep high volume flow producers from
 	// affecting the rest of the event sources.
-	FlowStore store.Interface
+	FlowStore store.Interface `json:"-"`
 	metrics   transportMetrics
 }
 
 func (cr *ConnectionRecord) GetFlow() (vanflow.TransportBiflowRecord, bool) {
 	var record vanflow.TransportBiflowRecord
+	if cr.FlowStore == nil {
+		return record, false
+	}
 	ent, ok := cr.FlowStore.Get(cr.ID)
 	if !ok {
 		return record, false
@@ -59,6 +63,7 @@ func (r ConnectionRecord) GetTypeMeta() vanflow.TypeMeta {
 
 func (r ConnectionRecord) toLabelSet() labelSet {
 	return labelSet{
+		Network:             r.Network,
 		SourceSiteID:        r.SourceSite.ID,
 		DestSiteID:          r.DestSite.ID,
 		SourceSiteName:      r.SourceSite.Name,
@@ -74,6 +79,12 @@ func (r ConnectionRecord) toLabelSet() labelSet {
 	}
 }
 
+// MetricLabels returns the labels applied to the connection metrics for this
+// record.
+func (r ConnectionRecord) MetricLabels() map[string]string {
+	return r.toLabelSet().asLabels()
+}
+
 var _ vanflow.Record = (*RequestRecord)(nil)
 
 type RequestRecord struct {
@@ -81,6 +92,7 @@ type RequestRecord struct {
 	TransportID string
 	StartTime   time.Time
 	EndTime     time.Time
+	Network     string
 
 	RoutingKey string
 	Protocol   string
@@ -103,6 +115,9 @@ type RequestRecord struct {
 
 func (cr *RequestRecord) GetFlow() (vanflow.AppBiflowRecord, bool) {
 	var record vanflow.AppBiflowRecord
+	if cr.stor == nil {
+		return record, false
+	}
 	ent, ok := cr.stor.Get(cr.ID)
 	if !ok {
 		return record, false
@@ -112,6 +127,9 @@ func (cr *RequestRecord) GetFlow() (vanflow.AppBiflowRecord, bool) {
 }
 func (cr *RequestRecord) GetTransport() (vanflow.TransportBiflowRecord, bool) {
 	var record vanflow.TransportBiflowRecord
+	if cr.stor == nil {
+		return record, false
+	}
 	ent, ok := cr.stor.Get(cr.TransportID)
 	if !ok {
 		return record, false
@@ -132,6 +150,7 @@ func (r RequestRecord) GetTypeMeta() vanflow.TypeMeta {
 
 func (r RequestRecord) toLabelSet() labelSet {
 	return labelSet{
+		Network:             r.Network,
 		SourceSiteID:        r.SourceSite.ID,
 		DestSiteID:          r.DestSite.ID,
 		SourceSiteName:      r.SourceSite.Name,
@@ -147,6 +166,15 @@ func (r RequestRecord) toLabelSet() labelSet {
 	}
 }
 
+// MetricLabels returns the labels applied to the request metrics for this
+// record and its flow.
+func (r RequestRecord) MetricLabels(flow vanflow.AppBiflowRecord) map[string]string {
+	labels := r.toLabelSet().asLabels()
+	labels["method"] = normalizeHTTPMethod(flow.Method)
+	labels["code"] = normalizeHTTPResponseClass(flow.Result)
+	return labels
+}
+
 type NamedReference struct {
 	ID   string
 	Name string
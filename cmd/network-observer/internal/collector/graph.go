@@ -157,7 +157,7 @@ func (g *graph) reindex(in vanflow.Record, fullIndex bool) {
 			edges = append(edges, Router{g.newBase(*record.Parent)})
 		}
 		if record.Address != nil && record.Protocol != nil {
-			edges = append(edges, RoutingKey{g.newBase(RoutingKeyID(*record.Address, *record.Protocol))})
+			edges = append(edges, RoutingKey{g.newBase(RoutingKeyID(g.network(id), *record.Address, *record.Protocol))})
 		}
 		g.ensureParents(id, edges)
 	case vanflow.ProcessRecord:
@@ -193,7 +193,7 @@ func (g *graph) reindex(in vanflow.Record, fullIndex bool) {
 			edges = append(edges, Router{g.newBase(*record.Parent)})
 		}
 		if record.Address != nil && record.Protocol != nil {
-			edges = append(edges, RoutingKey{g.newBase(RoutingKeyID(*record.Address, *record.Protocol))})
+			edges = append(edges, RoutingKey{g.newBase(RoutingKeyID(g.network(id), *record.Address, *record.Protocol))})
 		}
 		if record.ProcessID != nil {
 			edges = append(edges, Process{g.newBase(*record.ProcessID)})
@@ -208,12 +208,22 @@ func (g *graph) reindex(in vanflow.Record, fullIndex bool) {
 	case AddressRecord:
 		addressVtx := Address{g.newBase(id)}
 		g.dag.AddVertex(addressVtx)
-		routingKeyID := RoutingKeyID(record.Name, record.Protocol)
+		routingKeyID := RoutingKeyID(record.Network, record.Name, record.Protocol)
 		g.dag.AddVertex(RoutingKey{g.newBase(routingKeyID)})
 		g.ensureParents(routingKeyID, []Node{addressVtx})
 	}
 }
 
+// network returns the network the record with the given id was collected
+// from
+func (g *graph) network(id string) string {
+	entry, ok := g.stor.Get(id)
+	if !ok {
+		return ""
+	}
+	return entry.Source.Network
+}
+
 func (g *graph) ensureParents(id string, nodes []Node) {
 	nm := make(map[string]Node, len(nodes))
 	for _, n := range nodes {
@@ -276,6 +286,16 @@ func (n Site) GetRecord() (record vanflow.SiteRecord, found bool) {
 	return getrecord[vanflow.SiteRecord](n)
 }
 
+// Network returns the name of the application network the site record was
+// collected from.
+func (n Site) Network() string {
+	e, ok := n.Get()
+	if !ok {
+		return ""
+	}
+	return e.Source.Network
+}
+
 func getrecord[R vanflow.Record, N Node](n N) (record R, found bool) {
 	e, ok := n.Get()
 	if !ok {
@@ -417,8 +437,19 @@ func (n Process) Connectors() []Connector {
 	return connectors
 }
 
-func RoutingKeyID(address, protocol string) string {
-	return fmt.Sprintf("%s:%s", protocol, address)
+// NetworkScoped qualifies key, derived from names that are only unique
+// within an application network, with the network it belongs to. Keys of
+// the network named DefaultNetwork are left unqualified so that identities
+// are unchanged for an observer attached to a single, default network.
+func NetworkScoped(network, key string) string {
+	if network == "" || network == DefaultNetwork {
+		return key
+	}
+	return network + "/" + key
+}
+
+func RoutingKeyID(network, address, protocol string) string {
+	return NetworkScoped(network, fmt.Sprintf("%s:%s", protocol, address))
 }
 
 type RoutingKey struct {
@@ -42,15 +42,21 @@ func RecordIndexers() map[string]store.Indexer {
 		IndexByLifecycleStatus: indexByLifecycleStatus,
 		IndexByTypeName:        indexByTypeName,
 		IndexFlowByAddress:     indexByTypeAndAddress,
+
+		// used by Select for queries on the fields of connections and
+		// requests
+		store.FieldIndex("RoutingKey"):    store.FieldIndexer("RoutingKey"),
+		store.FieldIndex("SourceSite.ID"): store.FieldIndexer("SourceSite.ID"),
+		store.FieldIndex("DestSite.ID"):   store.FieldIndexer("DestSite.ID"),
 	}
 }
 
 func indexByTypeAndAddress(e store.Entry) []string {
 	switch record := e.Record.(type) {
 	case ConnectionRecord:
-		return []string{fmt.Sprintf("%s/%s/%s", record.GetTypeMeta().String(), record.RoutingKey, record.Protocol)}
+		return []string{fmt.Sprintf("%s/%s/%s", record.GetTypeMeta().String(), NetworkScoped(record.Network, record.RoutingKey), record.Protocol)}
 	case RequestRecord:
-		return []string{fmt.Sprintf("%s/%s/%s", record.GetTypeMeta().String(), record.RoutingKey, record.Protocol)}
+		return []string{fmt.Sprintf("%s/%s/%s", record.GetTypeMeta().String(), NetworkScoped(record.Network, record.RoutingKey), record.Protocol)}
 	}
 	return nil
 }
@@ -116,7 +122,7 @@ func indexByTypeParent(e store.Entry) []string {
 func indexByAddress(e store.Entry) []string {
 	optionalSingle := func(s *string) []string {
 		if s != nil {
-			return []string{*s}
+			return []string{NetworkScoped(e.Source.Network, *s)}
 		}
 		return nil
 	}
@@ -20,7 +20,10 @@ type metricsInternal struct {
 	flowProcessingTime *prometheus.HistogramVec
 	reconcileTime      *prometheus.HistogramVec
 	queueUtilization   *prometheus.GaugeVec
+	queueDepth         *prometheus.GaugeVec
+	coalescedChanges   prometheus.Counter
 	pendingFlows       *prometheus.GaugeVec
+	droppedChanges     prometheus.Counter
 }
 
 func register(reg *prometheus.Registry) metrics {
@@ -86,11 +89,29 @@ func register(reg *prometheus.Registry) metrics {
 				Name:      "queue_utilization_percentage",
 				Help:      "Percentage of vanflow event processing queue full",
 			}, []string{"type"}),
+			queueDepth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: "skupper",
+				Subsystem: "internal",
+				Name:      "queue_depth",
+				Help:      "Number of vanflow events queued for processing",
+			}, []string{"type"}),
+			coalescedChanges: prometheus.NewCounter(prometheus.CounterOpts{
+				Namespace: "skupper",
+				Subsystem: "internal",
+				Name:      "coalesced_changes_total",
+				Help:      "Record changes merged with a queued change to the same record because the event queue was full",
+			}),
 			pendingFlows: prometheus.NewGaugeVec(prometheus.GaugeOpts{
 				Namespace: "skupper",
 				Subsystem: "internal",
 				Name:      "pending_flows",
 			}, []string{"type", "reason", "eventsource"}),
+			droppedChanges: prometheus.NewCounter(prometheus.CounterOpts{
+				Namespace: "skupper",
+				Subsystem: "internal",
+				Name:      "subscription_dropped_changes_total",
+				Help:      "Record change notifications dropped because a subscriber fell behind",
+			}),
 		},
 	}
 
@@ -104,8 +125,11 @@ func register(reg *prometheus.Registry) metrics {
 		m.internal.flowLatency,
 		m.internal.reconcileTime,
 		m.internal.queueUtilization,
+		m.internal.queueDepth,
+		m.internal.coalescedChanges,
 		m.internal.flowProcessingTime,
 		m.internal.pendingFlows,
+		m.internal.droppedChanges,
 	)
 	return m
 }
@@ -113,6 +137,7 @@ func register(reg *prometheus.Registry) metrics {
 var (
 	histBucketsFast  = []float64{0.001, 0.002, .005, .01, .025, .05, .1, .25, .5, 1, 2.5}
 	flowMetricLabels = []string{
+		"network",
 		"source_site_id",
 		"dest_site_id",
 		"source_site_name",
@@ -130,6 +155,7 @@ var (
 )
 
 type labelSet struct {
+	Network             string
 	SourceSiteID        string
 	DestSiteID          string
 	SourceSiteName      string
@@ -146,6 +172,7 @@ type labelSet struct {
 
 func (ls labelSet) asLabels() prometheus.Labels {
 	return map[string]string{
+		"network":               ls.Network,
 		"source_site_id":        ls.SourceSiteID,
 		"source_site_name":      ls.SourceSiteName,
 		"dest_site_id":          ls.DestSiteID,
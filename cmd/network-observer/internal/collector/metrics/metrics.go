@@ -10,6 +10,7 @@ var (
 		"site_id",
 		"name",
 		"version",
+		"network",
 	}
 	routerInfoMetricLabels = []string{
 		"site_id",
@@ -82,6 +83,10 @@ func New(reg prometheus.Registerer) *Adaptor {
 // Adaptor between vanflow events and prometheus metrics. Maintains a set of
 // coarse metrics pertaining to network topology.
 type Adaptor struct {
+	// SiteNetwork optionally resolves the name of the network a site belongs
+	// to for the site_info network label.
+	SiteNetwork func(siteID string) string
+
 	siteInfo          *prometheus.GaugeVec
 	routerInfo        *prometheus.GaugeVec
 	siteLinkInfo      *prometheus.GaugeVec
@@ -99,6 +104,7 @@ type Adaptor struct {
 	pendingRouter map[string]map[string]vanflow.Record
 
 	routerSitesCache map[string]string
+	siteNetworks     map[string]string
 }
 
 type counterMetricByItem struct {
@@ -142,15 +148,33 @@ func (m *gaugeMetricByID) Remove(id string) {
 	m.Gauge.Sub(1.0)
 }
 
-func toSiteInfo(site vanflow.SiteRecord) (siteInfo, bool) {
+func (a *Adaptor) toSiteInfo(site vanflow.SiteRecord) (siteInfo, bool) {
 	var info siteInfo
 	if site.Name == nil || site.Version == nil {
 		return info, false
 	}
 	info.ID, info.Name, info.Version = site.ID, *site.Name, *site.Version
+	info.Network = a.siteNetwork(site.ID)
 	return info, true
 }
 
+// siteNetwork resolves the network of a site once so that it is still known
+// when the site is removed.
+func (a *Adaptor) siteNetwork(siteID string) string {
+	if a.SiteNetwork == nil {
+		return ""
+	}
+	if a.siteNetworks == nil {
+		a.siteNetworks = make(map[string]string)
+	}
+	network, ok := a.siteNetworks[siteID]
+	if !ok {
+		network = a.SiteNetwork(siteID)
+		a.siteNetworks[siteID] = network
+	}
+	return network
+}
+
 func routerInfo(router vanflow.RouterRecord) (siteRouters, bool) {
 	if router.Parent == nil || router.Mode == nil {
 		return siteRouters{}, false
@@ -222,7 +246,7 @@ func (a *Adaptor) siteIDForRotuer(rID string) (siteID string, ok bool) {
 func (a *Adaptor) Add(record vanflow.Record) {
 	switch record := record.(type) {
 	case vanflow.SiteRecord:
-		info, ok := toSiteInfo(record)
+		info, ok := a.toSiteInfo(record)
 		if !ok {
 			return
 		}
@@ -371,7 +395,8 @@ func (a *Adaptor) Update(prev, curr vanflow.Record) {
 func (a *Adaptor) Remove(record vanflow.Record) {
 	switch record := record.(type) {
 	case vanflow.SiteRecord:
-		info, ok := toSiteInfo(record)
+		info, ok := a.toSiteInfo(record)
+		delete(a.siteNetworks, record.ID)
 		if !ok {
 			return
 		}
@@ -469,6 +494,7 @@ type siteInfo struct {
 	ID      string
 	Name    string
 	Version string
+	Network string
 }
 
 func (i siteInfo) asLabels() prometheus.Labels {
@@ -476,6 +502,7 @@ func (i siteInfo) asLabels() prometheus.Labels {
 		"site_id": i.ID,
 		"name":    i.Name,
 		"version": i.Version,
+		"network": i.Network,
 	}
 }
 
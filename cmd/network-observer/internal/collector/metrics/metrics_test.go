@@ -12,13 +12,14 @@ import (
 func TestSiteInfoMetrics(t *testing.T) {
 	reg := prometheus.NewPedanticRegistry()
 	metrics := New(reg)
+	metrics.SiteNetwork = func(siteID string) string { return "west" }
 
 	metrics.Add(vanflow.SiteRecord{BaseRecord: vanflow.NewBase("s1"), Name: ptrTo("x")})
 	metrics.Update(vanflow.SiteRecord{}, vanflow.SiteRecord{BaseRecord: vanflow.NewBase("s1"), Name: ptrTo("x"), Version: ptrTo("1.0")})
 	assert.Equal(t, prom_testutil.CollectAndCount(reg, "skupper_site_info"), 1)
-	assert.Equal(t, prom_testutil.ToFloat64(metrics.siteInfo.WithLabelValues("s1", "x", "1.0")), 1.0)
+	assert.Equal(t, prom_testutil.ToFloat64(metrics.siteInfo.WithLabelValues("s1", "x", "1.0", "west")), 1.0)
 	metrics.Remove(vanflow.SiteRecord{BaseRecord: vanflow.NewBase("s1"), Name: ptrTo("x"), Version: ptrTo("1.0")})
-	assert.Equal(t, prom_testutil.ToFloat64(metrics.siteInfo.WithLabelValues("s1", "x", "1.0")), 0.0)
+	assert.Equal(t, prom_testutil.ToFloat64(metrics.siteInfo.WithLabelValues("s1", "x", "1.0", "west")), 0.0)
 }
 
 func TestSiteRouterMetrics(t *testing.T) {
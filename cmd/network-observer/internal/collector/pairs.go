@@ -79,11 +79,12 @@ func (m *pairManager) run(ctx context.Context) func() error {
 					// add site and group pairs
 					if sourceID, destID := sourceN.ID(), destN.ID(); sourceID != "" && destID != "" {
 						hasSitePair = true
-						spid := m.idp.ID("sitepair", sourceID, destID, pair.Protocol)
+						spid := m.idp.ID("sitepair", NetworkScoped(pair.Network, sourceID), destID, pair.Protocol)
 						added := m.stor.Add(SitePairRecord{
 							ID:       spid,
 							Start:    time.Now(),
 							Protocol: pair.Protocol,
+							Network:  pair.Network,
 							Source:   sourceID,
 							Dest:     destID,
 						},
@@ -0,0 +1,185 @@
+package collector
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+)
+
+// StoreFactory creates the store backing Collector.Records.
+type StoreFactory func(cfg store.SyncMapStoreConfig) (store.Interface, error)
+
+// MemoryStore is a StoreFactory for the default in-memory record store.
+func MemoryStore(cfg store.SyncMapStoreConfig) (store.Interface, error) {
+	return store.NewSyncMapStore(cfg), nil
+}
+
+// FileStore returns a StoreFactory for a disk-backed record store that
+// persists connection and request history across restarts. The store owns
+// the retention of completed connections and requests: they are kept past
+// the flow record TTL and past their event source being forgotten, until
+// evicted by maxAge or maxSize. Topology records are kept in memory only
+// since they are re-sent by event sources on reconnect.
+func FileStore(path string, maxAge time.Duration, maxSize int64) StoreFactory {
+	return func(cfg store.SyncMapStoreConfig) (store.Interface, error) {
+		return store.NewFileStore(store.FileStoreConfig{
+			SyncMapStoreConfig: cfg,
+			Path:               path,
+			RecordTypes:        []vanflow.Record{ConnectionRecord{}, RequestRecord{}},
+			Persist:            isFlowHistory,
+			MaxAge:             maxAge,
+			MaxSize:            maxSize,
+		})
+	}
+}
+
+func isFlowHistory(e store.Entry) bool {
+	switch e.Record.(type) {
+	case ConnectionRecord, RequestRecord:
+		return true
+	default:
+		return false
+	}
+}
+
+// retainsFlowHistory returns true when the store is responsible for the
+// retention of completed connection and request records. Those records are
+// then detached from the flow store of their event source instead of being
+// deleted along with their flows, and are kept until the store evicts them.
+func retainsFlowHistory(stor store.Interface) bool {
+	_, ok := stor.(*store.FileStore)
+	return ok
+}
+
+// detachedFlowStore holds the flow records of a connection or request record
+// in place of the store of the event source that produced them, either
+// because the record was restored from disk or because its flows have since
+// been purged.
+type detachedFlowStore struct {
+	store.Interface
+}
+
+func restoredFlowStore(flows ...vanflow.Record) store.Interface {
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: map[string]store.Indexer{}})
+	for _, flow := range flows {
+		stor.Add(flow, store.SourceRef{})
+	}
+	return detachedFlowStore{stor}
+}
+
+func isDetached(stor store.Interface) bool {
+	_, ok := stor.(detachedFlowStore)
+	return ok
+}
+
+// detachFlowHistory detaches a completed connection or request record from
+// the flow store of its event source, returning false when the record is not
+// flow history or has not completed.
+func detachFlowHistory(stor store.Interface, record vanflow.Record) bool {
+	switch record := record.(type) {
+	case ConnectionRecord:
+		if isDetached(record.FlowStore) {
+			return true
+		}
+		flow, ok := record.GetFlow()
+		if !ok || flow.EndTime == nil {
+			return false
+		}
+		record.FlowStore = restoredFlowStore(flow)
+		return stor.Update(record)
+	case RequestRecord:
+		if isDetached(record.stor) {
+			return true
+		}
+		flow, ok := record.GetFlow()
+		if !ok || flow.EndTime == nil {
+			return false
+		}
+		flows := []vanflow.Record{flow}
+		if transport, ok := record.GetTransport(); ok {
+			flows = append(flows, transport)
+		}
+		record.stor = restoredFlowStore(flows...)
+		return stor.Update(record)
+	default:
+		return false
+	}
+}
+
+type persistedConnection ConnectionRecord
+
+// MarshalJSON includes a snapshot of the transport flow so that the
+// connection can be presented after it is restored from disk.
+func (r ConnectionRecord) MarshalJSON() ([]byte, error) {
+	out := struct {
+		persistedConnection
+		Flow *vanflow.TransportBiflowRecord `json:",omitempty"`
+	}{persistedConnection: persistedConnection(r)}
+	if r.FlowStore != nil {
+		if flow, ok := r.GetFlow(); ok {
+			out.Flow = &flow
+		}
+	}
+	return json.Marshal(out)
+}
+
+func (r *ConnectionRecord) UnmarshalJSON(data []byte) error {
+	var in struct {
+		persistedConnection
+		Flow *vanflow.TransportBiflowRecord
+	}
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	*r = ConnectionRecord(in.persistedConnection)
+	var flows []vanflow.Record
+	if in.Flow != nil {
+		flows = append(flows, *in.Flow)
+	}
+	r.FlowStore = restoredFlowStore(flows...)
+	return nil
+}
+
+type persistedRequest RequestRecord
+
+// MarshalJSON includes a snapshot of the application and transport flows so
+// that the request can be presented after it is restored from disk.
+func (r RequestRecord) MarshalJSON() ([]byte, error) {
+	out := struct {
+		persistedRequest
+		Flow      *vanflow.AppBiflowRecord       `json:",omitempty"`
+		Transport *vanflow.TransportBiflowRecord `json:",omitempty"`
+	}{persistedRequest: persistedRequest(r)}
+	if r.stor != nil {
+		if flow, ok := r.GetFlow(); ok {
+			out.Flow = &flow
+		}
+		if transport, ok := r.GetTransport(); ok {
+			out.Transport = &transport
+		}
+	}
+	return json.Marshal(out)
+}
+
+func (r *RequestRecord) UnmarshalJSON(data []byte) error {
+	var in struct {
+		persistedRequest
+		Flow      *vanflow.AppBiflowRecord
+		Transport *vanflow.TransportBiflowRecord
+	}
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	*r = RequestRecord(in.persistedRequest)
+	var flows []vanflow.Record
+	if in.Flow != nil {
+		flows = append(flows, *in.Flow)
+	}
+	if in.Transport != nil {
+		flows = append(flows, *in.Transport)
+	}
+	r.stor = restoredFlowStore(flows...)
+	return nil
+}
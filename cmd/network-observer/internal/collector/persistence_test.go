@@ -0,0 +1,133 @@
+package collector
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+)
+
+func TestFileStoreRestoresFlowHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.jsonl")
+	newStore := FileStore(path, time.Hour, 0)
+	stor, err := newStore(store.SyncMapStoreConfig{Indexers: RecordIndexers()})
+	if err != nil {
+		t.Fatalf("unexpected error creating store: %s", err)
+	}
+
+	source := store.SourceRef{ID: "router-1"}
+	start := time.UnixMicro(1_000_000)
+	flows := restoredFlowStore(
+		vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("tf1", start), Octets: ptrTo(uint64(512))},
+		vanflow.AppBiflowRecord{BaseRecord: vanflow.NewBase("af1", start), Method: ptrTo("GET")},
+	)
+	stor.Add(vanflow.SiteRecord{BaseRecord: vanflow.NewBase("s1", start)}, source)
+	stor.Add(ConnectionRecord{ID: "tf1", RoutingKey: "backend", SourceSite: NamedReference{ID: "s1", Name: "east"}, FlowStore: flows}, source)
+	stor.Add(RequestRecord{ID: "af1", TransportID: "tf1", RoutingKey: "backend", stor: flows}, source)
+	if err := stor.(*store.FileStore).Close(); err != nil {
+		t.Fatalf("unexpected error closing store: %s", err)
+	}
+
+	restored, err := newStore(store.SyncMapStoreConfig{Indexers: RecordIndexers()})
+	if err != nil {
+		t.Fatalf("unexpected error restoring store: %s", err)
+	}
+	if _, ok := restored.Get("s1"); ok {
+		t.Error("expected topology records not to be persisted")
+	}
+
+	entry, ok := restored.Get("tf1")
+	if !ok {
+		t.Fatal("expected connection to be restored")
+	}
+	conn := entry.Record.(ConnectionRecord)
+	if conn.RoutingKey != "backend" || conn.SourceSite.Name != "east" || entry.Source != source {
+		t.Errorf("unexpected restored connection: %+v", entry)
+	}
+	if flow, ok := conn.GetFlow(); !ok || dref(flow.Octets) != 512 {
+		t.Errorf("expected restored transport flow but got %+v", flow)
+	}
+
+	entry, ok = restored.Get("af1")
+	if !ok {
+		t.Fatal("expected request to be restored")
+	}
+	req := entry.Record.(RequestRecord)
+	if flow, ok := req.GetFlow(); !ok || dref(flow.Method) != "GET" {
+		t.Errorf("expected restored app flow but got %+v", flow)
+	}
+	if transport, ok := req.GetTransport(); !ok || transport.ID != "tf1" {
+		t.Errorf("expected restored transport flow but got %+v", transport)
+	}
+}
+
+func TestFileStoreRetainsPurgedFlowHistory(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	newStore := FileStore(filepath.Join(t.TempDir(), "records.jsonl"), time.Hour, 0)
+	stor, err := newStore(store.SyncMapStoreConfig{Indexers: RecordIndexers()})
+	if err != nil {
+		t.Fatalf("unexpected error creating store: %s", err)
+	}
+	source := store.SourceRef{ID: "router-1"}
+	manager := newConnectionmanager(ctx, slog.Default(), source, stor, NewGraph(stor).(*graph), register(prometheus.NewRegistry()), time.Minute, nil)
+	defer manager.Stop()
+
+	start, end := time.UnixMicro(1_000_000), time.UnixMicro(2_000_000)
+	manager.flows.Add(vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("completed", start, end), Octets: ptrTo(uint64(512))}, source)
+	manager.flows.Add(vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("stale", start)}, source)
+	manager.transportFlows.Push("completed", transportState{ID: "completed", Terminated: true})
+	manager.transportFlows.Push("stale", transportState{ID: "stale"})
+	stor.Add(ConnectionRecord{ID: "completed", RoutingKey: "backend", FlowStore: manager.flows}, source)
+	stor.Add(ConnectionRecord{ID: "stale", RoutingKey: "backend", FlowStore: manager.flows}, source)
+
+	manager.flows.Delete("completed")
+	manager.flows.Delete("stale")
+
+	if _, ok := stor.Get("stale"); ok {
+		t.Error("expected connection for stale flow to be deleted")
+	}
+	entry, ok := stor.Get("completed")
+	if !ok {
+		t.Fatal("expected completed connection to be retained")
+	}
+	conn := entry.Record.(ConnectionRecord)
+	if !isDetached(conn.FlowStore) {
+		t.Error("expected retained connection to be detached from the source flow store")
+	}
+	if flow, ok := conn.GetFlow(); !ok || dref(flow.Octets) != 512 {
+		t.Errorf("expected retained transport flow but got %+v", flow)
+	}
+
+	// completed records survive the source being forgotten while live ones
+	// do not
+	flows := store.NewSyncMapStore(store.SyncMapStoreConfig{})
+	flows.Add(vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("done", start, end)}, source)
+	flows.Add(vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("open", start)}, source)
+	for _, id := range []string{"done", "open"} {
+		stor.Add(ConnectionRecord{ID: id, FlowStore: flows}, source)
+	}
+	if !detachFlowHistory(stor, mustGet(t, stor, "done")) {
+		t.Error("expected completed connection to be detached")
+	}
+	if detachFlowHistory(stor, mustGet(t, stor, "open")) {
+		t.Error("expected open connection not to be detached")
+	}
+	if !detachFlowHistory(stor, mustGet(t, stor, "completed")) {
+		t.Error("expected already detached connection to be retained")
+	}
+}
+
+func mustGet(t *testing.T, stor store.Interface, id string) vanflow.Record {
+	t.Helper()
+	entry, ok := stor.Get(id)
+	if !ok {
+		t.Fatalf("expected record %q", id)
+	}
+	return entry.Record
+}
@@ -12,6 +12,7 @@ type AddressRecord struct {
 	ID       string
 	Name     string
 	Protocol string
+	Network  string
 	Start    time.Time
 }
 
@@ -46,6 +47,7 @@ func (r ProcessGroupRecord) GetTypeMeta() vanflow.TypeMeta {
 type SitePairRecord struct {
 	ID       string
 	Protocol string
+	Network  string
 	Source   string
 	Dest     string
 	Start    time.Time
@@ -90,6 +92,7 @@ type ProcPairRecord struct {
 	Source   string
 	Dest     string
 	Protocol string
+	Network  string
 }
 
 func (r ProcPairRecord) Identity() string {
@@ -0,0 +1,73 @@
+package collector
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/capture"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+)
+
+func TestCollectorReplaysCapture(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var buf bytes.Buffer
+	w := capture.NewWriter(&buf)
+	start := time.UnixMicro(1_000_000)
+	write := func(offset time.Duration, address string, msg vanflow.RecordMessage) {
+		t.Helper()
+		msg.To, msg.Subject = address, "RECORD"
+		encoded, err := msg.Encode()
+		if err != nil {
+			t.Fatalf("unexpected error encoding message: %s", err)
+		}
+		if err := w.Write(capture.Entry{Time: start.Add(offset), Address: address, Message: encoded}); err != nil {
+			t.Fatalf("unexpected error writing capture: %s", err)
+		}
+	}
+	beacon := vanflow.BeaconMessage{Version: 1, SourceType: "ROUTER", Address: "mc/sfe.r1", Direct: "sfe.r1", Identity: "r1"}
+	if err := w.Write(capture.Entry{Time: start, Address: "mc/sfe.all", Message: beacon.Encode()}); err != nil {
+		t.Fatalf("unexpected error writing capture: %s", err)
+	}
+	write(time.Second, "mc/sfe.r1", vanflow.RecordMessage{Records: []vanflow.Record{
+		vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1", start), Name: ptrTo("east")},
+		vanflow.RouterRecord{BaseRecord: vanflow.NewBase("router-1", start), Parent: ptrTo("site-1"), Name: ptrTo("east/router")},
+	}})
+
+	factory := session.NewMockContainerFactory()
+	c, err := New(slog.Default(), []Network{{Name: DefaultNetwork, Factory: factory}}, prometheus.NewRegistry(), time.Minute, nil, MemoryStore)
+	if err != nil {
+		t.Fatalf("unexpected error creating collector: %s", err)
+	}
+	go c.Run(ctx)
+
+	stats, err := capture.NewReplayer(factory.Create(), capture.NewReader(&buf), capture.ReplayOptions{}).Run(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error replaying capture: %s", err)
+	}
+	if stats.Sent != 2 {
+		t.Fatalf("expected 2 messages replayed: %+v", stats)
+	}
+	for {
+		if entry, ok := c.Records.Get("router-1"); ok {
+			if router := entry.Record.(vanflow.RouterRecord); *router.Parent != "site-1" {
+				t.Errorf("unexpected router parent: %s", *router.Parent)
+			}
+			if _, ok := c.Records.Get("site-1"); !ok {
+				t.Error("expected replayed site record")
+			}
+			return
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for replayed records")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+	"github.com/skupperproject/skupper/pkg/vanflow/simulator"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+)
+
+// runSimulatedCollector runs a collector observing a simulated network. Once
+// the collector has discovered the network it starts traffic and waits until
+// the expected number of connections have been reconciled.
+func runSimulatedCollector(tb testing.TB, cfg simulator.Config, timeout time.Duration) *Collector {
+	ctx, cancel := context.WithCancel(context.Background())
+	tb.Cleanup(cancel)
+	factory := session.NewMockContainerFactory()
+	start := make(chan struct{})
+	cfg.TrafficStart = start
+	sim, err := simulator.New(factory, cfg)
+	if err != nil {
+		tb.Fatalf("unexpected error creating simulator: %s", err)
+	}
+	c, err := New(slog.Default(), []Network{{Name: DefaultNetwork, Factory: factory}}, prometheus.NewRegistry(), time.Minute, nil, MemoryStore)
+	if err != nil {
+		tb.Fatalf("unexpected error creating collector: %s", err)
+	}
+	go c.Run(ctx)
+	go sim.Run(ctx)
+
+	deadline := time.After(timeout)
+	wait := func(done func() bool, message func() string) {
+		for !done() {
+			select {
+			case <-deadline:
+				tb.Fatal(message())
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}
+	records := sim.Records()
+	wait(func() bool {
+		for _, record := range records {
+			if _, ok := c.Records.Get(record.Identity()); !ok {
+				return false
+			}
+		}
+		return true
+	}, func() string { return "timed out waiting for the collector to discover the network" })
+
+	if b, ok := tb.(*testing.B); ok {
+		b.ResetTimer()
+	}
+	close(start)
+	var connections []store.Entry
+	wait(func() bool {
+		connections = c.Records.Index(store.TypeIndex, store.Entry{Record: ConnectionRecord{}})
+		return int64(len(connections)) >= cfg.FlowLimit
+	}, func() string {
+		return fmt.Sprintf("timed out with %d of %d connections reconciled: %+v", len(connections), cfg.FlowLimit, sim.Stats())
+	})
+	return c
+}
+
+func TestCollectorSimulatedNetwork(t *testing.T) {
+	c := runSimulatedCollector(t, simulator.Config{
+		Sites:             3,
+		Services:          2,
+		FlowRate:          1000,
+		FlowLimit:         10,
+		FlowDuration:      10 * time.Millisecond,
+		HeartbeatInterval: 50 * time.Millisecond,
+	}, 10*time.Second)
+	for _, entry := range c.Records.Index(store.TypeIndex, store.Entry{Record: ConnectionRecord{}}) {
+		connection := entry.Record.(ConnectionRecord)
+		if connection.RoutingKey != "service-0" && connection.RoutingKey != "service-1" {
+			t.Errorf("unexpected routing key %q", connection.RoutingKey)
+		}
+		if connection.SourceSite.ID == "" || connection.DestSite.ID == "" || connection.Dest.Name == "" {
+			t.Errorf("incomplete connection record: %+v", connection)
+		}
+	}
+}
+
+// BenchmarkCollectorSimulatedNetwork measures the time taken by the collector
+// to reconcile b.N connections made across a network of two hundred sites.
+// Discovery of the network is excluded from the measurement.
+func BenchmarkCollectorSimulatedNetwork(b *testing.B) {
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	defer slog.SetDefault(defaultLogger)
+
+	runSimulatedCollector(b, simulator.Config{
+		Sites:               200,
+		ProcessesPerSite:    4,
+		Services:            100,
+		ListenersPerService: 5,
+		FlowRate:            20_000,
+		FlowLimit:           int64(b.N),
+		FlowDuration:        100 * time.Millisecond,
+		RequestsPerFlow:     2,
+	}, 10*time.Minute)
+}
@@ -0,0 +1,250 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+)
+
+// snapshotRecordTypes are the record types kept in snapshots of the record
+// store, in the order they are restored. Records the collector derives from
+// them are rebuilt as they are restored, and flow history is persisted by
+// the FileStore instead.
+var snapshotRecordTypes = []vanflow.Record{
+	vanflow.SiteRecord{},
+	vanflow.RouterRecord{},
+	vanflow.RouterAccessRecord{},
+	vanflow.LinkRecord{},
+	vanflow.ProcessRecord{},
+	vanflow.ListenerRecord{},
+	vanflow.ConnectorRecord{},
+}
+
+// Snapshots configures periodic snapshots of the topology records collected
+// from event sources so that the collector can warm start from them.
+type Snapshots struct {
+	// Path of the snapshot file
+	Path string
+	// Interval between snapshots. Defaults to a minute.
+	Interval time.Duration
+	// GracePeriod is how long the event sources of restored records have to
+	// be rediscovered before their records are purged. Defaults to two
+	// minutes.
+	GracePeriod time.Duration
+	// FlushSettle is how long a rediscovered source has to go without
+	// sending records after it was sent a flush before the flush is
+	// considered complete. The restored records of the source that it did
+	// not send again are purged then. Defaults to five seconds.
+	FlushSettle time.Duration
+}
+
+// ConfigureSnapshots enables snapshots of the record store, restoring the
+// last one when the collector is run. It must be called before Run.
+func (c *Collector) ConfigureSnapshots(cfg Snapshots) {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Minute
+	}
+	if cfg.GracePeriod <= 0 {
+		cfg.GracePeriod = 2 * time.Minute
+	}
+	if cfg.FlushSettle <= 0 {
+		cfg.FlushSettle = 5 * time.Second
+	}
+	c.snapshots = &cfg
+}
+
+func isSnapshotRecord(e store.Entry) bool {
+	if e.Source.ID == "self" {
+		return false // derived by the collector
+	}
+	typ := e.Record.GetTypeMeta()
+	for _, exemplar := range snapshotRecordTypes {
+		if exemplar.GetTypeMeta() == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// restoreSnapshot adds the records in the last snapshot to the record store
+// and queues them for the reactors like newly added records. Returns the
+// event sources of the restored records.
+func (c *Collector) restoreSnapshot() (map[store.SourceRef]bool, error) {
+	snapshot, err := store.LoadSnapshot(c.snapshots.Path, snapshotRecordTypes)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	entries := c.Records.List()
+	present := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		present[e.Record.Identity()] = true
+	}
+	restored := make([]store.Entry, 0, len(snapshot.Entries))
+	for _, e := range snapshot.Entries {
+		if !present[e.Record.Identity()] {
+			restored = append(restored, e)
+		}
+	}
+	c.Records.Replace(append(entries, restored...))
+
+	sources := make(map[store.SourceRef]bool)
+	ids := make(map[store.SourceRef]map[string]struct{})
+	for _, exemplar := range snapshotRecordTypes {
+		typ := exemplar.GetTypeMeta()
+		for _, e := range restored {
+			if e.Record.GetTypeMeta() == typ {
+				c.queueEvent(addEvent{Record: e.Record})
+				sources[e.Source] = true
+				if ids[e.Source] == nil {
+					ids[e.Source] = make(map[string]struct{})
+				}
+				ids[e.Source][e.Record.Identity()] = struct{}{}
+			}
+		}
+	}
+	c.mu.Lock()
+	c.restored = ids
+	c.mu.Unlock()
+	c.logger.Info("restored records from snapshot",
+		slog.Int("count", len(restored)),
+		slog.Int("sources", len(sources)),
+		slog.Time("snapshot_time", snapshot.Time),
+	)
+	return sources, nil
+}
+
+// restoredRecords tracks the records restored for a source that the source
+// has not sent again since it was rediscovered.
+type restoredRecords struct {
+	mu         sync.Mutex
+	unsent     map[string]struct{}
+	lastRecord time.Time
+}
+
+// Record handles the record messages sent by the source
+func (r *restoredRecords) Record(msg vanflow.RecordMessage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, record := range msg.Records {
+		delete(r.unsent, record.Identity())
+	}
+	r.lastRecord = time.Now()
+}
+
+func (r *restoredRecords) state() (time.Time, []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lastRecord, slices.Collect(maps.Keys(r.unsent))
+}
+
+// trackRestored starts tracking the restored records of a rediscovered
+// source. Returns nil when no records were restored for it.
+func (c *Collector) trackRestored(source store.SourceRef) *restoredRecords {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ids, ok := c.restored[source]
+	if !ok {
+		return nil
+	}
+	delete(c.restored, source)
+	return &restoredRecords{unsent: ids}
+}
+
+// purgeUnflushed waits for the flush sent to a rediscovered source to
+// complete and purges the records restored for it that it did not send
+// again, as they no longer exist.
+func (c *Collector) purgeUnflushed(ctx context.Context, source store.SourceRef, restored *restoredRecords) {
+	flushed := time.Now()
+	ticker := time.NewTicker(c.snapshots.FlushSettle / 4)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			lastRecord, unsent := restored.state()
+			if lastRecord.Before(flushed) {
+				lastRecord = flushed
+			}
+			if now.Sub(lastRecord) < c.snapshots.FlushSettle {
+				continue
+			}
+			var ct int
+			for _, id := range unsent {
+				if entry, ok := c.Records.Get(id); ok && entry.Source == source {
+					c.Records.Delete(id)
+					ct++
+				}
+			}
+			c.logger.Info("purged restored records not sent again by rediscovered source",
+				slog.String("source", source.ID),
+				slog.Int("count", ct),
+			)
+			return
+		}
+	}
+}
+
+func (c *Collector) saveSnapshot() {
+	snapshot := store.TakeSnapshot(c.Records, isSnapshotRecord)
+	if err := store.SaveSnapshot(c.snapshots.Path, snapshot); err != nil {
+		c.logger.Error("error saving snapshot", slog.Any("error", err))
+		return
+	}
+	c.logger.Debug("saved snapshot", slog.Int("count", len(snapshot.Entries)))
+}
+
+// runSnapshots saves snapshots periodically and on shutdown, and purges the
+// records of restored sources that have not been rediscovered once the grace
+// period has passed.
+func (c *Collector) runSnapshots(ctx context.Context, restored map[store.SourceRef]bool) func() error {
+	return func() error {
+		defer func() {
+			c.logger.Info("snapshot worker shutdown complete")
+		}()
+		ticker := time.NewTicker(c.snapshots.Interval)
+		defer ticker.Stop()
+		var grace <-chan time.Time
+		if len(restored) > 0 {
+			timer := time.NewTimer(c.snapshots.GracePeriod)
+			defer timer.Stop()
+			grace = timer.C
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				c.saveSnapshot()
+				return nil
+			case <-ticker.C:
+				c.saveSnapshot()
+			case <-grace:
+				c.mu.Lock()
+				var stale []store.SourceRef
+				for source := range restored {
+					if _, ok := c.sources[source]; !ok {
+						stale = append(stale, source)
+					}
+				}
+				c.mu.Unlock()
+				for _, source := range stale {
+					ct := c.purge(source)
+					c.logger.Info("purged restored records from source that was not rediscovered",
+						slog.String("source", source.ID),
+						slog.Int("count", ct),
+					)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/poll"
+)
+
+func TestCollectorSnapshots(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	tlog := slog.New(slog.NewTextHandler(io.Discard, nil))
+	path := filepath.Join(t.TempDir(), "snapshot.jsonl")
+	snapshots := Snapshots{Path: path, Interval: time.Hour, GracePeriod: 500 * time.Millisecond}
+
+	newCollector := func() *Collector {
+		t.Helper()
+		factory := session.NewMockContainerFactory()
+		c, err := New(tlog, []Network{{Name: DefaultNetwork, Factory: factory}}, prometheus.NewRegistry(), time.Minute, nil, MemoryStore)
+		if err != nil {
+			t.Fatalf("unexpected error creating collector: %s", err)
+		}
+		c.ConfigureSnapshots(snapshots)
+		return c
+	}
+
+	source := store.SourceRef{ID: "router-1", Version: "1", Network: DefaultNetwork}
+	prev := newCollector()
+	prev.Records.Add(vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1"), Name: ptrTo("east")}, source)
+	prev.Records.Add(vanflow.ListenerRecord{
+		BaseRecord: vanflow.NewBase("listener-1"),
+		Parent:     ptrTo("router-1"),
+		Address:    ptrTo("backend"),
+		Protocol:   ptrTo("tcp"),
+	}, source)
+	prev.Records.Add(AddressRecord{ID: "derived", Name: "backend", Protocol: "tcp"}, store.SourceRef{ID: "self"})
+	prev.saveSnapshot()
+
+	snapshot, err := store.LoadSnapshot(path, snapshotRecordTypes)
+	if err != nil {
+		t.Fatalf("unexpected error loading snapshot: %s", err)
+	}
+	if len(snapshot.Entries) != 2 {
+		t.Fatalf("expected only the site and listener in the snapshot: %v", snapshot.Entries)
+	}
+
+	c := newCollector()
+	runCtx, stop := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() { done <- c.Run(runCtx) }()
+
+	poll.WaitOn(t, func(t poll.LogT) poll.Result {
+		for _, id := range []string{"site-1", "listener-1"} {
+			entry, ok := c.Records.Get(id)
+			if !ok {
+				return poll.Continue("waiting for %s to be restored", id)
+			}
+			if entry.Source != source {
+				return poll.Error(fmt.Errorf("expected %s to be restored with source %v: %v", id, source, entry.Source))
+			}
+		}
+		if addresses := c.Records.Index(store.TypeIndex, store.Entry{Record: AddressRecord{}}); len(addresses) != 1 {
+			return poll.Continue("waiting for the address of the restored listener")
+		}
+		return poll.Success()
+	}, poll.WithDelay(10*time.Millisecond), poll.WithTimeout(5*time.Second))
+
+	// purged once the grace period passes without the source being discovered
+	poll.WaitOn(t, func(t poll.LogT) poll.Result {
+		if _, ok := c.Records.Get("site-1"); ok {
+			return poll.Continue("waiting for restored records to be purged")
+		}
+		return poll.Success()
+	}, poll.WithDelay(10*time.Millisecond), poll.WithTimeout(5*time.Second))
+
+	stop()
+	<-done
+	snapshot, err = store.LoadSnapshot(path, snapshotRecordTypes)
+	if err != nil {
+		t.Fatalf("unexpected error loading snapshot: %s", err)
+	}
+	if len(snapshot.Entries) != 0 {
+		t.Errorf("expected snapshot saved on shutdown to be empty: %v", snapshot.Entries)
+	}
+}
+
+func TestCollectorPurgesUnflushedRecords(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	tlog := slog.New(slog.NewTextHandler(io.Discard, nil))
+	path := filepath.Join(t.TempDir(), "snapshot.jsonl")
+	snapshots := Snapshots{Path: path, FlushSettle: 100 * time.Millisecond}
+	newCollector := func() *Collector {
+		t.Helper()
+		factory := session.NewMockContainerFactory()
+		c, err := New(tlog, []Network{{Name: DefaultNetwork, Factory: factory}}, prometheus.NewRegistry(), time.Minute, nil, MemoryStore)
+		if err != nil {
+			t.Fatalf("unexpected error creating collector: %s", err)
+		}
+		c.ConfigureSnapshots(snapshots)
+		return c
+	}
+
+	source := store.SourceRef{ID: "router-1", Version: "1", Network: DefaultNetwork}
+	other := store.SourceRef{ID: "router-2", Version: "1", Network: DefaultNetwork}
+	prev := newCollector()
+	prev.Records.Add(vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1"), Name: ptrTo("east")}, source)
+	prev.Records.Add(vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("listener-1"), Parent: ptrTo("router-1")}, source)
+	prev.Records.Add(vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("listener-2"), Parent: ptrTo("router-2")}, other)
+	prev.saveSnapshot()
+
+	c := newCollector()
+	if _, err := c.restoreSnapshot(); err != nil {
+		t.Fatalf("unexpected error restoring snapshot: %s", err)
+	}
+	if restored := c.trackRestored(store.SourceRef{ID: "router-3"}); restored != nil {
+		t.Fatalf("expected no restored records for unknown source: %v", restored)
+	}
+	restored := c.trackRestored(source)
+	if restored == nil {
+		t.Fatal("expected restored records for source")
+	}
+	restored.Record(vanflow.RecordMessage{Records: []vanflow.Record{vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1")}}})
+	c.purgeUnflushed(ctx, source, restored)
+
+	for id, expected := range map[string]bool{"site-1": true, "listener-1": false, "listener-2": true} {
+		if _, ok := c.Records.Get(id); ok != expected {
+			t.Errorf("expected %s present after flush to be %v", id, expected)
+		}
+	}
+}
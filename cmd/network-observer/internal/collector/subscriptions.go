@@ -0,0 +1,81 @@
+package collector
+
+import (
+	"context"
+	"sync"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+)
+
+// ChangeKind classifies a Change
+type ChangeKind string
+
+const (
+	ChangeAdd    ChangeKind = "ADD"
+	ChangeUpdate ChangeKind = "UPDATE"
+	ChangeDelete ChangeKind = "DELETE"
+)
+
+// Change is a notification that a record in Collector.Records was added,
+// updated or deleted. For deletions Record holds the last known state.
+type Change struct {
+	Kind   ChangeKind
+	Record vanflow.Record
+}
+
+type subscribers struct {
+	mu    sync.RWMutex
+	chans map[chan Change]struct{}
+}
+
+// Subscribe returns a channel that receives a Change for every modification
+// of Collector.Records until ctx is cancelled, after which the channel is
+// closed. Changes are dropped rather than block the collector when the
+// subscriber does not keep up with the buffer.
+func (c *Collector) Subscribe(ctx context.Context, buffer int) <-chan Change {
+	ch := make(chan Change, buffer)
+	c.subscribers.mu.Lock()
+	if c.subscribers.chans == nil {
+		c.subscribers.chans = make(map[chan Change]struct{})
+	}
+	c.subscribers.chans[ch] = struct{}{}
+	c.subscribers.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		c.subscribers.mu.Lock()
+		defer c.subscribers.mu.Unlock()
+		delete(c.subscribers.chans, ch)
+		close(ch)
+	}()
+	return ch
+}
+
+func (c *Collector) publish(change Change) {
+	c.subscribers.mu.RLock()
+	defer c.subscribers.mu.RUnlock()
+	for ch := range c.subscribers.chans {
+		select {
+		case ch <- change:
+		default:
+			c.metrics.internal.droppedChanges.Inc()
+		}
+	}
+}
+
+// FlowCompletedFunc is called with the ConnectionRecord or RequestRecord of a
+// connection or request once its flow has ended.
+type FlowCompletedFunc func(record vanflow.Record)
+
+// OnFlowCompleted registers fn to be called as connections and requests
+// complete. It must be called before Run. fn is called synchronously while
+// processing flow records and must not block.
+func (c *Collector) OnFlowCompleted(fn FlowCompletedFunc) {
+	c.flowCompleted = append(c.flowCompleted, fn)
+}
+
+func (c *Collector) handleFlowCompleted(record vanflow.Record) {
+	for _, fn := range c.flowCompleted {
+		fn(record)
+	}
+}
@@ -0,0 +1,250 @@
+package flowlog
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+)
+
+const exportFlushInterval = time.Second
+
+// FlowEntry is the exported form of a completed connection or request.
+type FlowEntry struct {
+	Type        string    `json:"type"`
+	ID          string    `json:"id"`
+	TransportID string    `json:"transportId,omitempty"`
+	StartTime   time.Time `json:"startTime"`
+	EndTime     time.Time `json:"endTime"`
+
+	RoutingKey string `json:"routingKey"`
+	Protocol   string `json:"protocol"`
+
+	SourceSiteID      string `json:"sourceSiteId"`
+	SourceSiteName    string `json:"sourceSiteName"`
+	SourceProcessID   string `json:"sourceProcessId"`
+	SourceProcessName string `json:"sourceProcessName"`
+	SourceHost        string `json:"sourceHost,omitempty"`
+	SourcePort        string `json:"sourcePort,omitempty"`
+	DestSiteID        string `json:"destSiteId"`
+	DestSiteName      string `json:"destSiteName"`
+	DestProcessID     string `json:"destProcessId"`
+	DestProcessName   string `json:"destProcessName"`
+	ConnectorHost     string `json:"connectorHost,omitempty"`
+	ConnectorPort     string `json:"connectorPort,omitempty"`
+
+	Octets         uint64 `json:"octets"`
+	OctetsReverse  uint64 `json:"octetsReverse"`
+	Latency        uint64 `json:"latency,omitempty"`
+	LatencyReverse uint64 `json:"latencyReverse,omitempty"`
+	Error          string `json:"error,omitempty"`
+
+	Method string `json:"method,omitempty"`
+	Result string `json:"result,omitempty"`
+}
+
+var flowEntryColumns = []string{
+	"type", "id", "transportId", "startTime", "endTime",
+	"routingKey", "protocol",
+	"sourceSiteId", "sourceSiteName", "sourceProcessId", "sourceProcessName", "sourceHost", "sourcePort",
+	"destSiteId", "destSiteName", "destProcessId", "destProcessName", "connectorHost", "connectorPort",
+	"octets", "octetsReverse", "latency", "latencyReverse", "error",
+	"method", "result",
+}
+
+func (e FlowEntry) columns() []string {
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+	formatUint := func(v uint64) string {
+		return strconv.FormatUint(v, 10)
+	}
+	return []string{
+		e.Type, e.ID, e.TransportID, formatTime(e.StartTime), formatTime(e.EndTime),
+		e.RoutingKey, e.Protocol,
+		e.SourceSiteID, e.SourceSiteName, e.SourceProcessID, e.SourceProcessName, e.SourceHost, e.SourcePort,
+		e.DestSiteID, e.DestSiteName, e.DestProcessID, e.DestProcessName, e.ConnectorHost, e.ConnectorPort,
+		formatUint(e.Octets), formatUint(e.OctetsReverse), formatUint(e.Latency), formatUint(e.LatencyReverse), e.Error,
+		e.Method, e.Result,
+	}
+}
+
+// NewFlowEntry returns the FlowEntry for a collector ConnectionRecord or
+// RequestRecord.
+func NewFlowEntry(record vanflow.Record) (FlowEntry, bool) {
+	switch record := record.(type) {
+	case collector.ConnectionRecord:
+		entry := FlowEntry{
+			Type:              "connection",
+			ID:                record.ID,
+			StartTime:         record.StartTime,
+			EndTime:           record.EndTime,
+			RoutingKey:        record.RoutingKey,
+			Protocol:          record.Protocol,
+			SourceSiteID:      record.SourceSite.ID,
+			SourceSiteName:    record.SourceSite.Name,
+			SourceProcessID:   record.Source.ID,
+			SourceProcessName: record.Source.Name,
+			DestSiteID:        record.DestSite.ID,
+			DestSiteName:      record.DestSite.Name,
+			DestProcessID:     record.Dest.ID,
+			DestProcessName:   record.Dest.Name,
+			ConnectorHost:     record.ConnectorHost,
+			ConnectorPort:     record.ConnectorPort,
+		}
+		if flow, ok := record.GetFlow(); ok {
+			entry.StartTime = flowTime(flow.StartTime, entry.StartTime)
+			entry.EndTime = flowTime(flow.EndTime, entry.EndTime)
+			entry.SourceHost = dref(flow.SourceHost)
+			entry.SourcePort = dref(flow.SourcePort)
+			entry.Octets = dref(flow.Octets)
+			entry.OctetsReverse = dref(flow.OctetsReverse)
+			entry.Latency = dref(flow.Latency)
+			entry.LatencyReverse = dref(flow.LatencyReverse)
+			switch {
+			case flow.ErrorListener != nil:
+				entry.Error = *flow.ErrorListener
+			case flow.ErrorConnector != nil:
+				entry.Error = *flow.ErrorConnector
+			}
+		}
+		return entry, true
+	case collector.RequestRecord:
+		entry := FlowEntry{
+			Type:              "request",
+			ID:                record.ID,
+			TransportID:       record.TransportID,
+			StartTime:         record.StartTime,
+			EndTime:           record.EndTime,
+			RoutingKey:        record.RoutingKey,
+			Protocol:          record.Protocol,
+			SourceSiteID:      record.SourceSite.ID,
+			SourceSiteName:    record.SourceSite.Name,
+			SourceProcessID:   record.Source.ID,
+			SourceProcessName: record.Source.Name,
+			DestSiteID:        record.DestSite.ID,
+			DestSiteName:      record.DestSite.Name,
+			DestProcessID:     record.Dest.ID,
+			DestProcessName:   record.Dest.Name,
+		}
+		if flow, ok := record.GetFlow(); ok {
+			entry.StartTime = flowTime(flow.StartTime, entry.StartTime)
+			entry.EndTime = flowTime(flow.EndTime, entry.EndTime)
+			entry.Octets = dref(flow.Octets)
+			entry.OctetsReverse = dref(flow.OctetsReverse)
+			entry.Latency = dref(flow.Latency)
+			entry.Method = dref(flow.Method)
+			entry.Result = dref(flow.Result)
+		}
+		return entry, true
+	default:
+		return FlowEntry{}, false
+	}
+}
+
+// Sink is a destination for exported FlowEntries.
+type Sink interface {
+	// Write an entry to the sink. Writes may be buffered until Flush.
+	Write(FlowEntry) error
+	// Flush any buffered entries.
+	Flush() error
+	// Close flushes and releases the sink.
+	Close() error
+}
+
+// Exporter writes completed connection and request records to a Sink. Rules
+// select and sample records by the collector ConnectionRecord and
+// RequestRecord types.
+type Exporter struct {
+	logger *slog.Logger
+	rules  []Rule
+
+	resolved sync.Map
+
+	mu   sync.Mutex
+	sink Sink
+}
+
+// NewExporter creates an Exporter given a sink and a set of rules
+func NewExporter(logger *slog.Logger, sink Sink, rules []Rule) *Exporter {
+	return &Exporter{
+		logger: logger,
+		rules:  sortedRules(rules),
+		sink:   sink,
+	}
+}
+
+func (e *Exporter) resolve(typ vanflow.TypeMeta) SampleStrategy {
+	if r, ok := e.resolved.Load(typ); ok {
+		return r.(SampleStrategy)
+	}
+	strategy := resolveStrategy(e.rules, typ)
+	e.resolved.Store(typ, strategy)
+	return strategy
+}
+
+// Export writes the record to the sink when it is a ConnectionRecord or
+// RequestRecord sampled by the exporter's rules. It satisfies
+// collector.FlowCompletedFunc.
+func (e *Exporter) Export(record vanflow.Record) {
+	if !e.resolve(record.GetTypeMeta()).Sample(record) {
+		return
+	}
+	entry, ok := NewFlowEntry(record)
+	if !ok {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := e.sink.Write(entry); err != nil {
+		e.logger.Error("failed to export flow record",
+			slog.String("type", entry.Type),
+			slog.String("id", entry.ID),
+			slog.Any("error", err))
+	}
+}
+
+// Run periodically flushes the sink until ctx is cancelled, then closes it.
+func (e *Exporter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(exportFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			e.mu.Lock()
+			defer e.mu.Unlock()
+			return e.sink.Close()
+		case <-ticker.C:
+			e.mu.Lock()
+			err := e.sink.Flush()
+			e.mu.Unlock()
+			if err != nil {
+				e.logger.Error("failed to flush flow record export", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// flowTime returns the time reported by the flow record, falling back to the
+// time on the reconciled record.
+func flowTime(t *vanflow.Time, fallback time.Time) time.Time {
+	if t != nil && !t.IsZero() {
+		return t.Time
+	}
+	return fallback
+}
+
+func dref[T any](p *T) T {
+	var t T
+	if p != nil {
+		return *p
+	}
+	return t
+}
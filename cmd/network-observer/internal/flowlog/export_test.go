@@ -0,0 +1,158 @@
+package flowlog
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"gotest.tools/v3/assert"
+)
+
+func TestExporter(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	connection := func(i int) collector.ConnectionRecord {
+		return collector.ConnectionRecord{
+			ID:         fmt.Sprintf("tf%d", i),
+			StartTime:  start,
+			EndTime:    start.Add(time.Second),
+			RoutingKey: "backend",
+			Protocol:   "tcp",
+			SourceSite: collector.NamedReference{ID: "s1", Name: "west"},
+			DestSite:   collector.NamedReference{ID: "s2", Name: "east"},
+		}
+	}
+
+	t.Run("json lines", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "flows.jsonl")
+		sink, err := NewRotatingFileSink(RotatingFileConfig{Path: path, Format: FormatJSON})
+		assert.Assert(t, err)
+		exporter := NewExporter(slog.Default(), sink, []Rule{
+			{
+				Match:    NewRecordTypeSet(collector.ConnectionRecord{}, collector.RequestRecord{}),
+				Strategy: Unlimited(),
+			},
+		})
+		exporter.Export(connection(1))
+		exporter.Export(collector.RequestRecord{ID: "af1", TransportID: "tf1", RoutingKey: "backend"})
+		exporter.Export(collector.AddressRecord{ID: "a1"})
+		assert.Assert(t, sink.Close())
+
+		f, err := os.Open(path)
+		assert.Assert(t, err)
+		defer f.Close()
+		var entries []FlowEntry
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var entry FlowEntry
+			assert.Assert(t, json.Unmarshal(scanner.Bytes(), &entry))
+			entries = append(entries, entry)
+		}
+		assert.Equal(t, len(entries), 2)
+		assert.Equal(t, entries[0].Type, "connection")
+		assert.Equal(t, entries[0].SourceSiteName, "west")
+		assert.Equal(t, entries[0].EndTime, start.Add(time.Second))
+		assert.Equal(t, entries[1].Type, "request")
+		assert.Equal(t, entries[1].TransportID, "tf1")
+	})
+
+	t.Run("csv rotation", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "flows.csv")
+		// files that were not rotated by the sink are never pruned
+		backup := filepath.Join(dir, "flows-backup.csv")
+		assert.Assert(t, os.WriteFile(backup, nil, 0o644))
+		sink, err := NewRotatingFileSink(RotatingFileConfig{Path: path, Format: FormatCSV, MaxSize: 512, MaxFiles: 2})
+		assert.Assert(t, err)
+		exporter := NewExporter(slog.Default(), sink, []Rule{
+			{
+				Match:    NewRecordTypeSetAll(),
+				Strategy: Unlimited(),
+			},
+		})
+		for i := 0; i < 32; i++ {
+			exporter.Export(connection(i))
+		}
+		assert.Assert(t, sink.Close())
+
+		assert.Assert(t, os.Remove(backup))
+		files, err := filepath.Glob(filepath.Join(dir, "*.csv"))
+		assert.Assert(t, err)
+		assert.Equal(t, len(files), 3, "expected active file and two rotated files: %v", files)
+		for _, file := range files {
+			info, err := os.Stat(file)
+			assert.Assert(t, err)
+			assert.Assert(t, info.Size() <= 512)
+			f, err := os.Open(file)
+			assert.Assert(t, err)
+			rows, err := csv.NewReader(f).ReadAll()
+			f.Close()
+			assert.Assert(t, err)
+			assert.DeepEqual(t, rows[0], flowEntryColumns)
+			for _, row := range rows[1:] {
+				assert.Equal(t, len(row), len(flowEntryColumns))
+				assert.Equal(t, row[0], "connection")
+			}
+		}
+	})
+
+	t.Run("sampled by transport", func(t *testing.T) {
+		sink := &memorySink{}
+		exporter := NewExporter(slog.Default(), sink, []Rule{
+			{
+				Match:    NewRecordTypeSet(collector.ConnectionRecord{}, collector.RequestRecord{}),
+				Strategy: TransportFlowHash(0.5, nil),
+			},
+		})
+		for i := 0; i < 100; i++ {
+			exporter.Export(connection(i))
+			exporter.Export(collector.RequestRecord{ID: fmt.Sprintf("af%d", i), TransportID: fmt.Sprintf("tf%d", i)})
+		}
+		assert.Assert(t, len(sink.entries) > 0 && len(sink.entries) < 200)
+		transports := make(map[string]int)
+		for _, entry := range sink.entries {
+			switch entry.Type {
+			case "connection":
+				transports[entry.ID]++
+			case "request":
+				transports[entry.TransportID]++
+			}
+		}
+		for id, ct := range transports {
+			assert.Equal(t, ct, 2, "expected connection and request for %s to be sampled together", id)
+		}
+	})
+
+	t.Run("run closes sink", func(t *testing.T) {
+		sink := &memorySink{}
+		exporter := NewExporter(slog.Default(), sink, nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.Assert(t, exporter.Run(ctx))
+		assert.Assert(t, sink.closed)
+	})
+}
+
+type memorySink struct {
+	entries []FlowEntry
+	closed  bool
+}
+
+func (s *memorySink) Write(entry FlowEntry) error {
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *memorySink) Flush() error { return nil }
+
+func (s *memorySink) Close() error {
+	s.closed = true
+	return nil
+}
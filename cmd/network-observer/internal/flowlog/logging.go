@@ -10,6 +10,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
 	"github.com/skupperproject/skupper/pkg/vanflow"
 	"golang.org/x/time/rate"
 )
@@ -35,17 +36,8 @@ type MessageHandler func(vanflow.RecordMessage)
 func New(ctx context.Context, logFn func(msg string, args ...any), rules []Rule) MessageHandler {
 	handler := &handler{
 		logFn: logFn,
+		rules: sortedRules(rules),
 	}
-
-	for _, rule := range rules {
-		if rule.Strategy == nil || rule.Match == nil {
-			continue
-		}
-		handler.rules = append(handler.rules, rule)
-	}
-	slices.SortFunc(handler.rules, func(l, r Rule) int {
-		return l.Priority - r.Priority
-	})
 	go handler.report(ctx)
 	return handler.handle
 }
@@ -84,7 +76,8 @@ func (r rateLimited) Sample(vanflow.Record) bool {
 
 // TransportFlowHash uses a deterministic hash based on a TransportBiflow ID.
 // Uses the AppBiflow Parent field (Transport ID) so that ideally related flows
-// are sampled together.
+// are sampled together. Connection and Request records from the collector are
+// sampled by the same Transport ID.
 func TransportFlowHash(percent float64, parent SampleStrategy) SampleStrategy {
 	if percent < 0 || percent >= 1.0 {
 		panic("percent must be value in range [0, 1)")
@@ -115,6 +108,10 @@ func (h hashBasedSampler) Sample(r vanflow.Record) bool {
 			return false
 		}
 		transportID = *flow.Parent
+	case collector.ConnectionRecord:
+		transportID = flow.ID
+	case collector.RequestRecord:
+		transportID = flow.TransportID
 	default:
 		return false
 	}
@@ -197,19 +194,38 @@ func (h *handler) resolve(typ vanflow.TypeMeta) SampleStrategy {
 	if ok {
 		return r.(SampleStrategy)
 	}
-	var strategy SampleStrategy = doNotSample
-	for _, rule := range h.rules {
+	strategy := resolveStrategy(h.rules, typ)
+	h.resolved.Store(typ, strategy)
+	return strategy
+}
+
+// resolveStrategy returns the strategy of the first rule in a priority sorted
+// list of rules that matches typ.
+func resolveStrategy(rules []Rule, typ vanflow.TypeMeta) SampleStrategy {
+	for _, rule := range rules {
 		if rule.Match.matchesAll() {
-			strategy = rule.Strategy
-			break
+			return rule.Strategy
 		}
 		if _, ok := rule.Match[typ]; ok {
-			strategy = rule.Strategy
-			break
+			return rule.Strategy
 		}
 	}
-	h.resolved.Store(typ, strategy)
-	return strategy
+	return doNotSample
+}
+
+// sortedRules returns the valid rules ordered by priority
+func sortedRules(rules []Rule) []Rule {
+	var out []Rule
+	for _, rule := range rules {
+		if rule.Strategy == nil || rule.Match == nil {
+			continue
+		}
+		out = append(out, rule)
+	}
+	slices.SortFunc(out, func(l, r Rule) int {
+		return l.Priority - r.Priority
+	})
+	return out
 }
 
 func (h *handler) handle(msg vanflow.RecordMessage) {
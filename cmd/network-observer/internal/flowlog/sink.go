@@ -0,0 +1,233 @@
+package flowlog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Format of the entries written by a Sink
+type Format string
+
+const (
+	// FormatJSON writes one JSON object per line
+	FormatJSON Format = "json"
+	// FormatCSV writes comma separated values with a header row
+	FormatCSV Format = "csv"
+)
+
+func (f Format) encode(out *bytes.Buffer, entry FlowEntry, header bool) error {
+	switch f {
+	case FormatJSON:
+		enc := json.NewEncoder(out)
+		return enc.Encode(entry)
+	case FormatCSV:
+		w := csv.NewWriter(out)
+		if header {
+			w.Write(flowEntryColumns)
+		}
+		w.Write(entry.columns())
+		w.Flush()
+		return w.Error()
+	default:
+		return fmt.Errorf("unsupported flow export format %q", f)
+	}
+}
+
+type writerSink struct {
+	format  Format
+	out     *bufio.Writer
+	closer  io.Closer
+	header  bool
+	scratch bytes.Buffer
+}
+
+// NewWriterSink returns a Sink that writes entries to w in the given format.
+// When w is an io.Closer it is closed with the Sink.
+func NewWriterSink(w io.Writer, format Format) Sink {
+	s := &writerSink{
+		format: format,
+		out:    bufio.NewWriter(w),
+		header: format == FormatCSV,
+	}
+	if closer, ok := w.(io.Closer); ok {
+		s.closer = closer
+	}
+	return s
+}
+
+func (s *writerSink) Write(entry FlowEntry) error {
+	s.scratch.Reset()
+	if err := s.format.encode(&s.scratch, entry, s.header); err != nil {
+		return err
+	}
+	s.header = false
+	_, err := s.out.Write(s.scratch.Bytes())
+	return err
+}
+
+func (s *writerSink) Flush() error {
+	return s.out.Flush()
+}
+
+func (s *writerSink) Close() error {
+	err := s.out.Flush()
+	if s.closer != nil {
+		if cErr := s.closer.Close(); err == nil {
+			err = cErr
+		}
+	}
+	return err
+}
+
+// RotatingFileConfig configures a rotating file Sink
+type RotatingFileConfig struct {
+	// Path of the active export file. Rotated files are kept alongside it
+	// with a timestamp inserted ahead of the extension.
+	Path   string
+	Format Format
+	// MaxSize in bytes of a file before it is rotated. Zero disables
+	// rotation.
+	MaxSize int64
+	// MaxFiles is the number of rotated files to keep. Zero keeps all.
+	MaxFiles int
+}
+
+// rotatedTimeLayout formats the timestamp suffix of rotated files
+const rotatedTimeLayout = "20060102T150405.000000000"
+
+type rotatingFileSink struct {
+	cfg     RotatingFileConfig
+	file    *os.File
+	out     *bufio.Writer
+	size    int64
+	scratch bytes.Buffer
+}
+
+// NewRotatingFileSink returns a Sink writing to the file at cfg.Path,
+// appending to it when it already exists.
+func NewRotatingFileSink(cfg RotatingFileConfig) (Sink, error) {
+	if cfg.Format != FormatJSON && cfg.Format != FormatCSV {
+		return nil, fmt.Errorf("unsupported flow export format %q", cfg.Format)
+	}
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0o755); err != nil {
+		return nil, fmt.Errorf("error creating flow export directory: %w", err)
+	}
+	s := &rotatingFileSink{cfg: cfg}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *rotatingFileSink) open() error {
+	file, err := os.OpenFile(s.cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("error opening flow export file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("error opening flow export file: %w", err)
+	}
+	s.file = file
+	s.out = bufio.NewWriter(file)
+	s.size = info.Size()
+	return nil
+}
+
+func (s *rotatingFileSink) Write(entry FlowEntry) error {
+	s.scratch.Reset()
+	if err := s.cfg.Format.encode(&s.scratch, entry, s.cfg.Format == FormatCSV && s.size == 0); err != nil {
+		return err
+	}
+	if s.cfg.MaxSize > 0 && s.size > 0 && s.size+int64(s.scratch.Len()) > s.cfg.MaxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+		if s.cfg.Format == FormatCSV {
+			// new file needs a header
+			s.scratch.Reset()
+			if err := s.cfg.Format.encode(&s.scratch, entry, true); err != nil {
+				return err
+			}
+		}
+	}
+	n, err := s.out.Write(s.scratch.Bytes())
+	s.size += int64(n)
+	return err
+}
+
+func (s *rotatingFileSink) rotate() error {
+	ext := filepath.Ext(s.cfg.Path)
+	base := strings.TrimSuffix(s.cfg.Path, ext)
+	err := s.close()
+	if err == nil {
+		rotated := fmt.Sprintf("%s-%s%s", base, time.Now().UTC().Format(rotatedTimeLayout), ext)
+		if err = os.Rename(s.cfg.Path, rotated); err != nil {
+			err = fmt.Errorf("error rotating flow export file: %w", err)
+		}
+	}
+	if err != nil {
+		// continue appending to the current file rather than leaving the
+		// sink writing to a closed one
+		return errors.Join(err, s.open())
+	}
+	if err := s.open(); err != nil {
+		return err
+	}
+	return s.prune(base, ext)
+}
+
+// prune removes the oldest rotated files in excess of MaxFiles. Only files
+// named with a rotation timestamp are considered.
+func (s *rotatingFileSink) prune(base, ext string) error {
+	if s.cfg.MaxFiles <= 0 {
+		return nil
+	}
+	matches, err := filepath.Glob(base + "-*" + ext)
+	if err != nil {
+		return err
+	}
+	var rotated []string
+	for _, match := range matches {
+		stamp := strings.TrimSuffix(strings.TrimPrefix(match, base+"-"), ext)
+		if _, err := time.Parse(rotatedTimeLayout, stamp); err == nil {
+			rotated = append(rotated, match)
+		}
+	}
+	// timestamp suffixes sort chronologically
+	slices.Sort(rotated)
+	for len(rotated) > s.cfg.MaxFiles {
+		if err := os.Remove(rotated[0]); err != nil {
+			return fmt.Errorf("error removing rotated flow export file: %w", err)
+		}
+		rotated = rotated[1:]
+	}
+	return nil
+}
+
+func (s *rotatingFileSink) Flush() error {
+	return s.out.Flush()
+}
+
+func (s *rotatingFileSink) close() error {
+	if err := s.out.Flush(); err != nil {
+		s.file.Close()
+		return err
+	}
+	return s.file.Close()
+}
+
+func (s *rotatingFileSink) Close() error {
+	return s.close()
+}
@@ -0,0 +1,312 @@
+package otlp
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ProtocolGRPC = "grpc"
+	ProtocolHTTP = "http"
+
+	instrumentationName = "github.com/skupperproject/skupper/cmd/network-observer"
+	serviceName         = "skupper-network-observer"
+)
+
+// Config for an Exporter
+type Config struct {
+	// Endpoint is the host:port of the OTLP receiver
+	Endpoint string
+	// Protocol is either ProtocolGRPC or ProtocolHTTP
+	Protocol string
+	// Insecure disables TLS
+	Insecure bool
+	// MetricInterval is the period between metric exports
+	MetricInterval time.Duration
+}
+
+// Exporter exports completed connections and requests as OpenTelemetry
+// spans, and mirrors the prometheus connection and request metrics as OTLP
+// metrics.
+//
+// Spans are assigned IDs derived from the vanflow record identities so that
+// request spans are children of their connection span regardless of the order
+// in which they complete. Metrics are recorded as each connection or request
+// completes.
+type Exporter struct {
+	tracerProvider *sdktrace.TracerProvider
+	meterProvider  *sdkmetric.MeterProvider
+	tracer         trace.Tracer
+
+	closed   metric.Int64Counter
+	sent     metric.Int64Counter
+	received metric.Int64Counter
+	latency  metric.Float64Histogram
+	requests metric.Int64Counter
+}
+
+// New creates an Exporter sending to the OTLP receiver described by cfg.
+func New(ctx context.Context, cfg Config) (*Exporter, error) {
+	var (
+		spans   sdktrace.SpanExporter
+		metrics sdkmetric.Exporter
+		err     error
+	)
+	switch cfg.Protocol {
+	case ProtocolGRPC:
+		traceOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+		metricOpts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			traceOpts = append(traceOpts, otlptracegrpc.WithInsecure())
+			metricOpts = append(metricOpts, otlpmetricgrpc.WithInsecure())
+		}
+		if spans, err = otlptracegrpc.New(ctx, traceOpts...); err != nil {
+			return nil, fmt.Errorf("error creating otlp grpc trace exporter: %w", err)
+		}
+		if metrics, err = otlpmetricgrpc.New(ctx, metricOpts...); err != nil {
+			return nil, fmt.Errorf("error creating otlp grpc metric exporter: %w", err)
+		}
+	case ProtocolHTTP:
+		traceOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+		metricOpts := []otlpmetrichttp.Option{otlpmetrichttp.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			traceOpts = append(traceOpts, otlptracehttp.WithInsecure())
+			metricOpts = append(metricOpts, otlpmetrichttp.WithInsecure())
+		}
+		if spans, err = otlptracehttp.New(ctx, traceOpts...); err != nil {
+			return nil, fmt.Errorf("error creating otlp http trace exporter: %w", err)
+		}
+		if metrics, err = otlpmetrichttp.New(ctx, metricOpts...); err != nil {
+			return nil, fmt.Errorf("error creating otlp http metric exporter: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported otlp protocol %q: expected %q or %q", cfg.Protocol, ProtocolGRPC, ProtocolHTTP)
+	}
+
+	var readerOpts []sdkmetric.PeriodicReaderOption
+	if cfg.MetricInterval > 0 {
+		readerOpts = append(readerOpts, sdkmetric.WithInterval(cfg.MetricInterval))
+	}
+	return newExporter(spans, sdkmetric.NewPeriodicReader(metrics, readerOpts...))
+}
+
+func newExporter(spans sdktrace.SpanExporter, reader sdkmetric.Reader) (*Exporter, error) {
+	res := resource.NewSchemaless(attribute.String("service.name", serviceName))
+	e := &Exporter{
+		tracerProvider: sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(spans),
+			sdktrace.WithResource(res),
+			sdktrace.WithIDGenerator(flowIDGenerator{}),
+		),
+		meterProvider: sdkmetric.NewMeterProvider(
+			sdkmetric.WithReader(reader),
+			sdkmetric.WithResource(res),
+		),
+	}
+	e.tracer = e.tracerProvider.Tracer(instrumentationName)
+	meter := e.meterProvider.Meter(instrumentationName)
+
+	var err error
+	if e.closed, err = meter.Int64Counter("skupper.connections.closed",
+		metric.WithDescription("Number of connections opened through the application network that have been closed"),
+	); err != nil {
+		return nil, err
+	}
+	if e.sent, err = meter.Int64Counter("skupper.sent",
+		metric.WithUnit("By"),
+		metric.WithDescription("Bytes sent through the application network from client to service"),
+	); err != nil {
+		return nil, err
+	}
+	if e.received, err = meter.Int64Counter("skupper.received",
+		metric.WithUnit("By"),
+		metric.WithDescription("Bytes sent through the application network back from service to client"),
+	); err != nil {
+		return nil, err
+	}
+	if e.latency, err = meter.Float64Histogram("skupper.latency",
+		metric.WithUnit("s"),
+		metric.WithDescription("Latency observed measured as seconds difference between TTFB between listener and connector sides"),
+	); err != nil {
+		return nil, err
+	}
+	if e.requests, err = meter.Int64Counter("skupper.requests",
+		metric.WithDescription("Counter incremented for each request handled through the skupper network"),
+	); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// OnFlowCompleted exports a completed ConnectionRecord or RequestRecord. It
+// satisfies collector.FlowCompletedFunc.
+func (e *Exporter) OnFlowCompleted(record vanflow.Record) {
+	switch record := record.(type) {
+	case collector.ConnectionRecord:
+		if flow, ok := record.GetFlow(); ok {
+			e.ExportConnection(record, flow)
+		}
+	case collector.RequestRecord:
+		if flow, ok := record.GetFlow(); ok {
+			e.ExportRequest(record, flow)
+		}
+	}
+}
+
+// ExportConnection records a span and metrics for a completed connection.
+func (e *Exporter) ExportConnection(conn collector.ConnectionRecord, flow vanflow.TransportBiflowRecord) {
+	ctx := withSpanIDs(context.Background(), traceID(conn.ID), spanID(conn.ID))
+	attrs := append(flowAttributes(conn.RoutingKey, conn.Protocol, conn.Source, conn.SourceSite, conn.Dest, conn.DestSite),
+		attribute.String("skupper.connector.host", conn.ConnectorHost),
+		attribute.String("skupper.connector.port", conn.ConnectorPort),
+		attribute.Int64("skupper.octets", int64(dref(flow.Octets))),
+		attribute.Int64("skupper.octets_reverse", int64(dref(flow.OctetsReverse))),
+	)
+	_, span := e.tracer.Start(ctx, "connection "+conn.RoutingKey,
+		trace.WithNewRoot(),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithTimestamp(flowTime(flow.StartTime, conn.StartTime)),
+		trace.WithAttributes(attrs...),
+	)
+	var errs []string
+	if flow.ErrorListener != nil {
+		errs = append(errs, "listener: "+*flow.ErrorListener)
+	}
+	if flow.ErrorConnector != nil {
+		errs = append(errs, "connector: "+*flow.ErrorConnector)
+	}
+	if len(errs) > 0 {
+		span.SetStatus(codes.Error, strings.Join(errs, "; "))
+	}
+	span.End(trace.WithTimestamp(flowTime(flow.EndTime, conn.EndTime)))
+
+	opt := metric.WithAttributes(labelAttributes(conn.MetricLabels())...)
+	e.closed.Add(context.Background(), 1, opt)
+	e.sent.Add(context.Background(), int64(dref(flow.Octets)), opt)
+	e.received.Add(context.Background(), int64(dref(flow.OctetsReverse)), opt)
+	if flow.Latency != nil && flow.LatencyReverse != nil {
+		delta := time.Microsecond * time.Duration(int64(*flow.Latency)-int64(*flow.LatencyReverse))
+		e.latency.Record(context.Background(), delta.Seconds(), opt)
+	}
+}
+
+// ExportRequest records a span and metrics for a completed request. The span
+// is a child of the span for the connection the request was made over.
+func (e *Exporter) ExportRequest(req collector.RequestRecord, flow vanflow.AppBiflowRecord) {
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID(req.TransportID),
+		SpanID:     spanID(req.TransportID),
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), parent)
+	ctx = withSpanIDs(ctx, parent.TraceID(), spanID(req.ID))
+
+	method, result := dref(flow.Method), dref(flow.Result)
+	attrs := append(flowAttributes(req.RoutingKey, req.Protocol, req.Source, req.SourceSite, req.Dest, req.DestSite),
+		attribute.String("http.request.method", method),
+		attribute.String("skupper.result", result),
+		attribute.Int64("skupper.octets", int64(dref(flow.Octets))),
+		attribute.Int64("skupper.octets_reverse", int64(dref(flow.OctetsReverse))),
+	)
+	code, err := strconv.Atoi(result)
+	if err == nil {
+		attrs = append(attrs, attribute.Int("http.response.status_code", code))
+	}
+	name := req.RoutingKey
+	if method != "" {
+		name = method + " " + name
+	}
+	_, span := e.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithTimestamp(flowTime(flow.StartTime, req.StartTime)),
+		trace.WithAttributes(attrs...),
+	)
+	if code >= 500 {
+		span.SetStatus(codes.Error, result)
+	}
+	span.End(trace.WithTimestamp(flowTime(flow.EndTime, req.EndTime)))
+
+	e.requests.Add(context.Background(), 1, metric.WithAttributes(labelAttributes(req.MetricLabels(flow))...))
+}
+
+// Shutdown flushes pending spans and metrics and stops the exporter.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	return errors.Join(
+		e.tracerProvider.Shutdown(ctx),
+		e.meterProvider.Shutdown(ctx),
+	)
+}
+
+func flowAttributes(routingKey, protocol string, source, sourceSite, dest, destSite collector.NamedReference) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("skupper.routing_key", routingKey),
+		attribute.String("skupper.protocol", protocol),
+		attribute.String("skupper.source.process.id", source.ID),
+		attribute.String("skupper.source.process.name", source.Name),
+		attribute.String("skupper.source.site.id", sourceSite.ID),
+		attribute.String("skupper.source.site.name", sourceSite.Name),
+		attribute.String("skupper.dest.process.id", dest.ID),
+		attribute.String("skupper.dest.process.name", dest.Name),
+		attribute.String("skupper.dest.site.id", destSite.ID),
+		attribute.String("skupper.dest.site.name", destSite.Name),
+	}
+}
+
+func labelAttributes(labels map[string]string) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(labels))
+	for k, v := range labels {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+	return attrs
+}
+
+// flowTime returns the time reported by the flow record, falling back to the
+// time on the reconciled record.
+func flowTime(t *vanflow.Time, fallback time.Time) time.Time {
+	if t != nil && !t.IsZero() {
+		return t.Time
+	}
+	return fallback
+}
+
+func traceID(flowID string) trace.TraceID {
+	var id trace.TraceID
+	sum := sha256.Sum256([]byte("trace/" + flowID))
+	copy(id[:], sum[:])
+	return id
+}
+
+func spanID(flowID string) trace.SpanID {
+	var id trace.SpanID
+	sum := sha256.Sum256([]byte("span/" + flowID))
+	copy(id[:], sum[:])
+	return id
+}
+
+func dref[T any](p *T) T {
+	var t T
+	if p != nil {
+		return *p
+	}
+	return t
+}
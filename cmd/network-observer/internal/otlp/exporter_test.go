@@ -0,0 +1,147 @@
+package otlp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	metricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	v1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/v3/assert"
+)
+
+// receiver is a minimal stand-in for an OTLP/HTTP collector
+type receiver struct {
+	mu      sync.Mutex
+	spans   []*v1.Span
+	metrics []string
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch req.URL.Path {
+	case "/v1/traces":
+		var msg tracepb.ExportTraceServiceRequest
+		if err := proto.Unmarshal(body, &msg); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, rs := range msg.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				r.spans = append(r.spans, ss.Spans...)
+			}
+		}
+	case "/v1/metrics":
+		var msg metricspb.ExportMetricsServiceRequest
+		if err := proto.Unmarshal(body, &msg); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, rm := range msg.ResourceMetrics {
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					r.metrics = append(r.metrics, m.Name)
+				}
+			}
+		}
+	default:
+		http.NotFound(w, req)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestExporter(t *testing.T) {
+	recv := &receiver{}
+	srv := httptest.NewServer(recv)
+	defer srv.Close()
+
+	exporter, err := New(context.Background(), Config{
+		Endpoint: strings.TrimPrefix(srv.URL, "http://"),
+		Protocol: ProtocolHTTP,
+		Insecure: true,
+	})
+	assert.Assert(t, err)
+
+	start := time.Now().Add(-time.Second)
+	end := start.Add(500 * time.Millisecond)
+	conn := collector.ConnectionRecord{
+		ID:         "tf1",
+		RoutingKey: "backend",
+		Protocol:   "tcp",
+		Source:     collector.NamedReference{ID: "p1", Name: "client"},
+		SourceSite: collector.NamedReference{ID: "s1", Name: "west"},
+		Dest:       collector.NamedReference{ID: "p2", Name: "server"},
+		DestSite:   collector.NamedReference{ID: "s2", Name: "east"},
+	}
+	exporter.ExportConnection(conn, vanflow.TransportBiflowRecord{
+		BaseRecord: vanflow.NewBase("tf1", start, end),
+		Octets:     ptrTo(uint64(64)),
+	})
+	exporter.ExportRequest(collector.RequestRecord{
+		ID:          "af1",
+		TransportID: "tf1",
+		RoutingKey:  conn.RoutingKey,
+		Protocol:    "http1",
+		Source:      conn.Source,
+		SourceSite:  conn.SourceSite,
+		Dest:        conn.Dest,
+		DestSite:    conn.DestSite,
+	}, vanflow.AppBiflowRecord{
+		BaseRecord: vanflow.NewBase("af1", start, end),
+		Method:     ptrTo("GET"),
+		Result:     ptrTo("503"),
+	})
+	assert.Assert(t, exporter.Shutdown(context.Background()))
+
+	recv.mu.Lock()
+	defer recv.mu.Unlock()
+	assert.Equal(t, len(recv.spans), 2)
+	spans := make(map[string]*v1.Span)
+	for _, span := range recv.spans {
+		spans[span.Name] = span
+	}
+	connSpan, reqSpan := spans["connection backend"], spans["GET backend"]
+	assert.Assert(t, connSpan != nil)
+	assert.Assert(t, reqSpan != nil)
+	assert.DeepEqual(t, reqSpan.TraceId, connSpan.TraceId)
+	assert.DeepEqual(t, reqSpan.ParentSpanId, connSpan.SpanId)
+	assert.Equal(t, connSpan.StartTimeUnixNano, uint64(start.UnixNano()))
+	assert.Equal(t, connSpan.EndTimeUnixNano, uint64(end.UnixNano()))
+	assert.Equal(t, reqSpan.Status.Code, v1.Status_STATUS_CODE_ERROR)
+
+	attrs := make(map[string]string)
+	for _, kv := range reqSpan.Attributes {
+		attrs[kv.Key] = kv.Value.GetStringValue()
+	}
+	assert.Equal(t, attrs["skupper.routing_key"], "backend")
+	assert.Equal(t, attrs["skupper.source.site.name"], "west")
+	assert.Equal(t, attrs["skupper.dest.process.name"], "server")
+	assert.Equal(t, attrs["http.request.method"], "GET")
+	assert.Equal(t, attrs["skupper.result"], "503")
+
+	for _, expected := range []string{"skupper.connections.closed", "skupper.sent", "skupper.requests"} {
+		assert.Assert(t, slices.Contains(recv.metrics, expected), "missing metric %s in %v", expected, recv.metrics)
+	}
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
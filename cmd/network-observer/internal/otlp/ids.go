@@ -0,0 +1,47 @@
+package otlp
+
+import (
+	"context"
+	crand "crypto/rand"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type spanIDsKey struct{}
+
+type spanIDs struct {
+	TraceID trace.TraceID
+	SpanID  trace.SpanID
+}
+
+// withSpanIDs returns a context instructing flowIDGenerator to use the given
+// IDs for the next span started with it.
+func withSpanIDs(ctx context.Context, traceID trace.TraceID, spanID trace.SpanID) context.Context {
+	return context.WithValue(ctx, spanIDsKey{}, spanIDs{TraceID: traceID, SpanID: spanID})
+}
+
+// flowIDGenerator is a sdktrace.IDGenerator that uses the IDs set by
+// withSpanIDs, falling back to random IDs.
+type flowIDGenerator struct{}
+
+func (flowIDGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	if ids, ok := ctx.Value(spanIDsKey{}).(spanIDs); ok {
+		return ids.TraceID, ids.SpanID
+	}
+	var (
+		tid trace.TraceID
+		sid trace.SpanID
+	)
+	_, _ = crand.Read(tid[:])
+	_, _ = crand.Read(sid[:])
+	return tid, sid
+}
+
+func (flowIDGenerator) NewSpanID(ctx context.Context, traceID trace.TraceID) trace.SpanID {
+	if ids, ok := ctx.Value(spanIDsKey{}).(spanIDs); ok {
+		return ids.SpanID
+	}
+	var sid trace.SpanID
+	_, _ = crand.Read(sid[:])
+	return sid
+}
@@ -0,0 +1,30 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+)
+
+// AlertSource lists the current pending and firing alerts
+type AlertSource interface {
+	Alerts() []api.AlertRecord
+}
+
+// WithAlerts serves the alerts from source on the alerts endpoint
+func WithAlerts(source AlertSource) Option {
+	return func(s *server) {
+		s.alerts = source
+	}
+}
+
+// (GET /api/v2alpha1/alerts)
+func (s *server) Alerts(w http.ResponseWriter, r *http.Request, _ api.AlertsParams) {
+	results := []api.AlertRecord{}
+	if s.alerts != nil {
+		results = s.alerts.Alerts()
+	}
+	if err := handleCollection(w, r, &api.AlertListResponse{}, results); err != nil {
+		s.logWriteError(r, err)
+	}
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+)
+
+type staticAlerts []api.AlertRecord
+
+func (s staticAlerts) Alerts() []api.AlertRecord { return s }
+
+func TestAlerts(t *testing.T) {
+	tlog := slog.Default()
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{})
+	graph := collector.NewGraph(stor)
+
+	t.Run("disabled", func(t *testing.T) {
+		srv, c := requireTestClient(t, New(tlog, stor, graph))
+		defer srv.Close()
+		resp, err := c.AlertsWithResponse(context.TODO(), nil)
+		assert.Assert(t, err)
+		assert.Equal(t, resp.StatusCode(), 200)
+		assert.Equal(t, resp.JSON200.Count, int64(0))
+		assert.Assert(t, resp.JSON200.Results != nil)
+	})
+
+	source := staticAlerts{
+		{Identity: "errors@backend", StartTime: 1000, Rule: "errors", RuleType: api.ErrorRate, State: api.Firing, SubjectId: "backend"},
+		{Identity: "links@link-1", StartTime: 2000, Rule: "links", RuleType: api.LinkDown, State: api.Pending, SubjectId: "link-1"},
+	}
+	srv, c := requireTestClient(t, New(tlog, stor, graph, WithAlerts(source)))
+	defer srv.Close()
+
+	resp, err := c.AlertsWithResponse(context.TODO(), nil)
+	assert.Assert(t, err)
+	assert.Equal(t, resp.StatusCode(), 200)
+	assert.Equal(t, resp.JSON200.Count, int64(2))
+
+	resp, err = c.AlertsWithResponse(context.TODO(), nil, withParameters(map[string][]string{"ruleType": {"error-rate"}}))
+	assert.Assert(t, err)
+	assert.Equal(t, resp.StatusCode(), 200)
+	assert.Equal(t, resp.JSON200.Count, int64(1))
+	assert.Equal(t, resp.JSON200.Results[0].Identity, "errors@backend")
+}
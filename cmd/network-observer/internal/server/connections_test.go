@@ -140,6 +140,48 @@ func TestConnections(t *testing.T) {
 			ExpectOK:             true,
 			ExpectCount:          0,
 			ExpectTimeRangeCount: 3,
+		}, {
+			Records: wrapRecords(
+				collector.ConnectionRecord{ID: "flow:1", SourceSite: collector.NamedReference{ID: "site-a", Name: "east"}, FlowStore: flowStor},
+				collector.ConnectionRecord{ID: "flow:2", SourceSite: collector.NamedReference{ID: "site-a", Name: "east"}, FlowStore: flowStor},
+				collector.ConnectionRecord{ID: "flow:3", SourceSite: collector.NamedReference{ID: "site-b", Name: "west"}, FlowStore: flowStor},
+			),
+			Flows: wrapRecords(
+				vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow:1", timeline[0]), Latency: ptrTo(uint64(25_000))},
+				vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow:2", timeline[0]), Latency: ptrTo(uint64(500))},
+				vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow:3", timeline[0]), Latency: ptrTo(uint64(25_000))},
+			),
+			Parameters: map[string][]string{
+				"filter":         {`sourceSiteName == "east" and latency > 10ms`},
+				"timeRangeStart": {fmt.Sprint(t0.UnixMicro())},
+			},
+			ExpectOK:             true,
+			ExpectCount:          1,
+			ExpectTimeRangeCount: 1,
+			ExpectResults: func(t *testing.T, results []api.ConnectionRecord) {
+				assert.Equal(t, results[0].Identity, "flow:1")
+			},
+		}, {
+			// selected by the store using the field index
+			Records: wrapRecords(
+				collector.ConnectionRecord{ID: "flow:1", SourceSite: collector.NamedReference{ID: "site-a", Name: "east"}, RoutingKey: "soup", FlowStore: flowStor},
+				collector.ConnectionRecord{ID: "flow:2", SourceSite: collector.NamedReference{ID: "site-a", Name: "east"}, RoutingKey: "salad", FlowStore: flowStor},
+				collector.ConnectionRecord{ID: "flow:3", SourceSite: collector.NamedReference{ID: "site-b", Name: "west"}, RoutingKey: "soup", FlowStore: flowStor},
+			),
+			Flows: wrapRecords(
+				vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow:1", timeline[0])},
+				vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow:2", timeline[0])},
+				vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow:3", timeline[0])},
+			),
+			Parameters: map[string][]string{
+				"filter":         {`routingKey == "soup" and not (sourceSiteName == "west")`},
+				"timeRangeStart": {fmt.Sprint(t0.UnixMicro())},
+			},
+			ExpectOK:    true,
+			ExpectCount: 1,
+			ExpectResults: func(t *testing.T, results []api.ConnectionRecord) {
+				assert.Equal(t, results[0].Identity, "flow:1")
+			},
 		},
 	}
 
@@ -148,7 +190,7 @@ func TestConnections(t *testing.T) {
 			stor.Replace(tc.Records)
 			flowStor.Replace(tc.Flows)
 			graph.(reset).Reset()
-			resp, err := c.ConnectionsWithResponse(context.TODO(), withParameters(tc.Parameters))
+			resp, err := c.ConnectionsWithResponse(context.TODO(), nil, withParameters(tc.Parameters))
 			assert.Check(t, err)
 			if tc.ExpectOK {
 				assert.Equal(t, resp.StatusCode(), 200)
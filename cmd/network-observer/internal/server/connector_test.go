@@ -101,7 +101,7 @@ func TestConnectors(t *testing.T) {
 		t.Run("", func(t *testing.T) {
 			stor.Replace(tc.Records)
 			graph.(reset).Reset()
-			resp, err := c.ConnectorsWithResponse(context.TODO(), withParameters(tc.Parameters))
+			resp, err := c.ConnectorsWithResponse(context.TODO(), nil, withParameters(tc.Parameters))
 			assert.Check(t, err)
 			if tc.ExpectOK {
 				assert.Equal(t, resp.StatusCode(), 200)
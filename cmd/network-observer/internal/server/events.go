@@ -0,0 +1,261 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/server/views"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+)
+
+// SubscribeFunc returns a channel of record changes that is closed when ctx
+// is cancelled.
+type SubscribeFunc func(ctx context.Context, buffer int) <-chan collector.Change
+
+const (
+	eventStreamBuffer    = 256
+	eventStreamKeepalive = 15 * time.Second
+)
+
+// streamEvent is the payload of a server-sent event
+type streamEvent struct {
+	Type     string `json:"type"`
+	Identity string `json:"identity"`
+	Record   any    `json:"record"`
+
+	sites       []string
+	routingKeys []string
+}
+
+type eventFilter struct {
+	Types       map[string]bool
+	Sites       map[string]bool
+	RoutingKeys map[string]bool
+}
+
+func newEventFilter(r *http.Request) eventFilter {
+	set := func(key string) map[string]bool {
+		values := r.URL.Query()[key]
+		if len(values) == 0 {
+			return nil
+		}
+		out := make(map[string]bool)
+		for _, v := range values {
+			for _, part := range strings.Split(v, ",") {
+				if part = strings.TrimSpace(part); part != "" {
+					out[part] = true
+				}
+			}
+		}
+		return out
+	}
+	return eventFilter{
+		Types:       set("type"),
+		Sites:       set("site"),
+		RoutingKeys: set("service"),
+	}
+}
+
+// MatchesType returns false when events of typ are excluded. It is checked
+// before records are converted to events to avoid the cost of converting
+// records that are never sent.
+func (f eventFilter) MatchesType(typ string) bool {
+	return f.Types == nil || f.Types[typ]
+}
+
+func (f eventFilter) Matches(event streamEvent) bool {
+	anyOf := func(set map[string]bool, values []string) bool {
+		if set == nil {
+			return true
+		}
+		for _, v := range values {
+			if set[v] {
+				return true
+			}
+		}
+		return false
+	}
+	return anyOf(f.Sites, event.sites) && anyOf(f.RoutingKeys, event.routingKeys)
+}
+
+// NewEventStream returns a handler that streams changes to the records
+// exposed by the API as server-sent events. Clients may restrict the stream
+// with the type, site and service (routing key) query parameters, each
+// accepting a comma separated list of values.
+func NewEventStream(logger *slog.Logger, records store.Interface, graph collector.Graph, subscribe SubscribeFunc) http.Handler {
+	toEvent := newStreamEventProvider(records, graph)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+		filter := newEventFilter(r)
+		ctx := r.Context()
+		changes := subscribe(ctx, eventStreamBuffer)
+
+		// streams outlive the server write timeout
+		_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		keepalive := time.NewTicker(eventStreamKeepalive)
+		defer keepalive.Stop()
+		var seq uint64
+		for {
+			var err error
+			select {
+			case <-keepalive.C:
+				_, err = fmt.Fprint(w, ": keepalive\n\n")
+			case change, ok := <-changes:
+				if !ok {
+					return
+				}
+				if !filter.MatchesType(streamEventType(change.Record)) {
+					continue
+				}
+				event, ok := toEvent(change.Record)
+				if !ok || !filter.Matches(event) || !auth.Allowed(r, resourceOf(event.Record)) {
+					continue
+				}
+				data, encErr := json.Marshal(event)
+				if encErr != nil {
+					requestLogger(logger, r).Error("failed to encode event", slog.Any("error", encErr))
+					continue
+				}
+				seq++
+				_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", seq, change.Kind, data)
+			}
+			if err != nil {
+				requestLogger(logger, r).Debug("event stream closed", slog.Any("error", err))
+				return
+			}
+			flusher.Flush()
+		}
+	})
+}
+
+// (GET /api/v2alpha1/events)
+func (s *server) Events(w http.ResponseWriter, r *http.Request, params api.EventsParams) {
+	if s.events == nil {
+		if err := encodeResponse(w, http.StatusNotFound, api.ErrorNotFound{Code: "ErrNotFound"}); err != nil {
+			s.logWriteError(r, err)
+		}
+		return
+	}
+	s.events.ServeHTTP(w, r)
+}
+
+func newStreamEventProvider(records store.Interface, graph collector.Graph) func(vanflow.Record) (streamEvent, bool) {
+	site := views.NewSiteProvider(graph)
+	router := views.NewRouterProvider(graph)
+	process := views.NewProcessProvider(records, graph)
+	listener := views.NewListenerProvider(graph)
+	connector := views.NewConnectorProvider(graph)
+	link := views.NewRouterLinkProvider(graph)
+	sitePair := views.NewSitePairProvider(graph)
+	processPair := views.NewProcessPairProvider(graph)
+	connection := views.NewConnectionsProvider(records)
+	request := views.NewRequestProvider(records)
+	service := views.NewServiceProvider(records, graph)
+
+	return func(record vanflow.Record) (streamEvent, bool) {
+		event := streamEvent{Identity: record.Identity(), Type: streamEventType(record)}
+		switch record := record.(type) {
+		case vanflow.SiteRecord:
+			out := site(record)
+			event.Record = out
+			event.sites = []string{out.Identity}
+		case vanflow.RouterRecord:
+			out := router(record)
+			event.Record = out
+			event.sites = []string{out.SiteId}
+		case vanflow.LinkRecord:
+			out, _ := link(record)
+			event.Record = out
+			event.sites = []string{out.SourceSiteId, dref(out.DestinationSiteId)}
+		case vanflow.ProcessRecord:
+			out, _ := process(record)
+			event.Record = out
+			event.sites = []string{out.SiteId}
+		case vanflow.ListenerRecord:
+			out := listener(record)
+			event.Record = out
+			event.sites = []string{out.SiteId}
+			event.routingKeys = []string{out.RoutingKey}
+		case vanflow.ConnectorRecord:
+			out := connector(record)
+			event.Record = out
+			event.sites = []string{out.SiteId}
+			event.routingKeys = []string{out.RoutingKey}
+		case collector.AddressRecord:
+			out := service(record)
+			event.Record = out
+			event.routingKeys = []string{out.Name}
+		case collector.SitePairRecord:
+			out := sitePair(record)
+			event.Record = out
+			event.sites = []string{out.SourceId, out.DestinationId}
+		case collector.ProcPairRecord:
+			out := processPair(record)
+			event.Record = out
+			event.sites = []string{dref(out.SourceSiteId), dref(out.DestinationSiteId)}
+		case collector.ConnectionRecord:
+			out, _ := connection(record)
+			event.Record = out
+			event.sites = []string{record.SourceSite.ID, record.DestSite.ID}
+			event.routingKeys = []string{record.RoutingKey}
+		case collector.RequestRecord:
+			out, _ := request(record)
+			event.Record = out
+			event.sites = []string{record.SourceSite.ID, record.DestSite.ID}
+			event.routingKeys = []string{record.RoutingKey}
+		default:
+			return event, false
+		}
+		return event, true
+	}
+}
+
+// streamEventType returns the event type for record or an empty string when
+// changes to record are not streamed.
+func streamEventType(record vanflow.Record) string {
+	switch record.(type) {
+	case vanflow.SiteRecord:
+		return "site"
+	case vanflow.RouterRecord:
+		return "router"
+	case vanflow.LinkRecord:
+		return "routerlink"
+	case vanflow.ProcessRecord:
+		return "process"
+	case vanflow.ListenerRecord:
+		return "listener"
+	case vanflow.ConnectorRecord:
+		return "connector"
+	case collector.AddressRecord:
+		return "service"
+	case collector.SitePairRecord:
+		return "sitepair"
+	case collector.ProcPairRecord:
+		return "processpair"
+	case collector.ConnectionRecord:
+		return "connection"
+	case collector.RequestRecord:
+		return "applicationflow"
+	default:
+		return ""
+	}
+}
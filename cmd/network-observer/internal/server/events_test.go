@@ -0,0 +1,82 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+)
+
+func TestEventStream(t *testing.T) {
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	graph := collector.NewGraph(stor)
+	changes := make(chan collector.Change, 8)
+	subscribe := func(ctx context.Context, _ int) <-chan collector.Change {
+		out := make(chan collector.Change)
+		go func() {
+			defer close(out)
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case change := <-changes:
+					out <- change
+				}
+			}
+		}()
+		return out
+	}
+	srv := httptest.NewServer(NewEventStream(slog.Default(), stor, graph, subscribe))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"?type=site,router&site=site-a", nil)
+	assert.Assert(t, err)
+	resp, err := srv.Client().Do(req)
+	assert.Assert(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, resp.Header.Get("Content-Type"), "text/event-stream")
+
+	changes <- collector.Change{Kind: collector.ChangeAdd, Record: vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-b")}}
+	changes <- collector.Change{Kind: collector.ChangeAdd, Record: vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("p1"), Parent: ptrTo("site-a")}}
+	changes <- collector.Change{Kind: collector.ChangeAdd, Record: vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-a"), Name: ptrTo("a")}}
+	changes <- collector.Change{Kind: collector.ChangeDelete, Record: vanflow.RouterRecord{BaseRecord: vanflow.NewBase("r1"), Parent: ptrTo("site-a")}}
+
+	type received struct {
+		Kind  string
+		Event streamEvent
+	}
+	var events []received
+	scanner := bufio.NewScanner(resp.Body)
+	var current received
+	for len(events) < 2 && scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			current.Kind = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			assert.Assert(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &current.Event))
+		case line == "":
+			events = append(events, current)
+			current = received{}
+		}
+	}
+	assert.Assert(t, scanner.Err())
+	assert.Equal(t, len(events), 2)
+	assert.Equal(t, events[0].Kind, "ADD")
+	assert.Equal(t, events[0].Event.Type, "site")
+	assert.Equal(t, events[0].Event.Identity, "site-a")
+	assert.Equal(t, events[1].Kind, "DELETE")
+	assert.Equal(t, events[1].Event.Type, "router")
+	assert.Equal(t, events[1].Event.Identity, "r1")
+}
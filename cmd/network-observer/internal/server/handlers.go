@@ -13,17 +13,17 @@ import (
 var _ api.ServerInterface = (*server)(nil)
 
 // (GET /api/v2alpha1/connections)
-func (s *server) Connections(w http.ResponseWriter, r *http.Request) {
-	results := views.NewConnectionsSliceProvider(s.records)(listByType[collector.ConnectionRecord](s.records))
+func (s *server) Connections(w http.ResponseWriter, r *http.Request, _ api.ConnectionsParams) {
+	results := views.NewConnectionsSliceProvider(s.records)(selectByType[collector.ConnectionRecord](r, s.records, flowFields))
 	if err := handleCollection(w, r, &api.ConnectionListResponse{}, results); err != nil {
 		s.logWriteError(r, err)
 	}
 }
 
 // (GET /api/v2alpha1/services/{id}/connections)
-func (s *server) ConnectionsByService(w http.ResponseWriter, r *http.Request, id string) {
+func (s *server) ConnectionsByService(w http.ResponseWriter, r *http.Request, id string, _ api.ConnectionsByServiceParams) {
 	getExemplar := fetchAndMap(s.records, func(a collector.AddressRecord) store.Entry {
-		return store.Entry{Record: collector.ConnectionRecord{RoutingKey: a.Name, Protocol: a.Protocol}}
+		return store.Entry{Record: collector.ConnectionRecord{RoutingKey: a.Name, Protocol: a.Protocol, Network: a.Network}}
 	}, id)
 	if err := handleSubCollection(w, r, &api.ConnectionListResponse{}, getExemplar, func(exemplar store.Entry) []api.ConnectionRecord {
 		return views.NewConnectionsSliceProvider(s.records)(index(s.records, collector.IndexFlowByAddress, exemplar))
@@ -32,15 +32,15 @@ func (s *server) ConnectionsByService(w http.ResponseWriter, r *http.Request, id
 	}
 }
 
-func (s *server) Applicationflows(w http.ResponseWriter, r *http.Request) {
-	results := views.NewRequestSliceProvider(s.records)(listByType[collector.RequestRecord](s.records))
+func (s *server) Applicationflows(w http.ResponseWriter, r *http.Request, _ api.ApplicationflowsParams) {
+	results := views.NewRequestSliceProvider(s.records)(selectByType[collector.RequestRecord](r, s.records, flowFields))
 	if err := handleCollection(w, r, &api.ApplicationFlowResponse{}, results); err != nil {
 		s.logWriteError(r, err)
 	}
 }
 
 // (GET /api/v2alpha1/services)
-func (s *server) Services(w http.ResponseWriter, r *http.Request) {
+func (s *server) Services(w http.ResponseWriter, r *http.Request, _ api.ServicesParams) {
 	results := views.NewServiceSliceProvider(s.records, s.graph)(listByType[collector.AddressRecord](s.records))
 	if err := handleCollection(w, r, &api.ServiceListResponse{}, results); err != nil {
 		s.logWriteError(r, err)
@@ -56,7 +56,7 @@ func (s *server) ServiceByID(w http.ResponseWriter, r *http.Request, id string)
 }
 
 // (GET /api/v2alpha1/services/{id}/processes)
-func (s *server) ProcessesByService(w http.ResponseWriter, r *http.Request, id string) {
+func (s *server) ProcessesByService(w http.ResponseWriter, r *http.Request, id string, _ api.ProcessesByServiceParams) {
 	//todo(ck) find a way to more directly index this
 	anode := s.graph.Address(id)
 	if !anode.IsKnown() {
@@ -83,7 +83,7 @@ func (s *server) ProcessesByService(w http.ResponseWriter, r *http.Request, id s
 }
 
 // (GET /api/v2alpha1/services/{id}/processes)
-func (s *server) ProcessPairsByService(w http.ResponseWriter, r *http.Request, id string) {
+func (s *server) ProcessPairsByService(w http.ResponseWriter, r *http.Request, id string, _ api.ProcessPairsByServiceParams) {
 	//todo(ck) find a way to more directly index this
 	addr, ok := s.graph.Address(id).GetRecord()
 	if !ok {
@@ -100,6 +100,7 @@ func (s *server) ProcessPairsByService(w http.ResponseWriter, r *http.Request, i
 			Protocol: &addr.Protocol,
 			Address:  &addr.Name,
 		},
+		Metadata: store.Metadata{Source: store.SourceRef{Network: addr.Network}},
 	}
 	connectors := index(s.records, collector.IndexByAddress, exemplar)
 	targetProcessIDs := make(map[string]struct{})
@@ -123,7 +124,7 @@ func (s *server) ProcessPairsByService(w http.ResponseWriter, r *http.Request, i
 }
 
 // (GET /api/v2alpha1/connectors)
-func (s *server) Connectors(w http.ResponseWriter, r *http.Request) {
+func (s *server) Connectors(w http.ResponseWriter, r *http.Request, _ api.ConnectorsParams) {
 	results := views.NewConnectorSliceProvider(s.graph)(listByType[vanflow.ConnectorRecord](s.records))
 	if err := handleCollection(w, r, &api.ConnectorListResponse{}, results); err != nil {
 		s.logWriteError(r, err)
@@ -140,7 +141,7 @@ func (s *server) ConnectorByID(w http.ResponseWriter, r *http.Request, id string
 
 // Hosts deprecated
 // (GET /api/v2alpha1/hosts)
-func (s *server) Hosts(w http.ResponseWriter, r *http.Request) {
+func (s *server) Hosts(w http.ResponseWriter, r *http.Request, _ api.HostsParams) {
 	if err := handleCollection(w, r, &api.SiteListResponse{}, []api.SiteRecord{}); err != nil {
 		s.logWriteError(r, err)
 	}
@@ -157,7 +158,7 @@ func (s *server) HostsByID(w http.ResponseWriter, r *http.Request, id string) {
 }
 
 // (GET /api/v2alpha1/listeners)
-func (s *server) Listeners(w http.ResponseWriter, r *http.Request) {
+func (s *server) Listeners(w http.ResponseWriter, r *http.Request, _ api.ListenersParams) {
 	results := views.NewListenerSliceProvider(s.graph)(listByType[vanflow.ListenerRecord](s.records))
 	if err := handleCollection(w, r, &api.ListenerListResponse{}, results); err != nil {
 		s.logWriteError(r, err)
@@ -173,7 +174,7 @@ func (s *server) ListenerByID(w http.ResponseWriter, r *http.Request, id string)
 }
 
 // (GET /api/v2alpha1/processes)
-func (s *server) Processes(w http.ResponseWriter, r *http.Request) {
+func (s *server) Processes(w http.ResponseWriter, r *http.Request, _ api.ProcessesParams) {
 	results := views.NewProcessSliceProvider(s.records, s.graph)(listByType[vanflow.ProcessRecord](s.records))
 	if err := handleCollection(w, r, &api.ProcessListResponse{}, results); err != nil {
 		s.logWriteError(r, err)
@@ -189,7 +190,7 @@ func (s *server) ProcessById(w http.ResponseWriter, r *http.Request, id string)
 }
 
 // (GET /api/v2alpha1/componentpairs)
-func (s *server) Componentpairs(w http.ResponseWriter, r *http.Request) {
+func (s *server) Componentpairs(w http.ResponseWriter, r *http.Request, _ api.ComponentpairsParams) {
 	results := views.NewComponentPairSliceProvider()(listByType[collector.ProcGroupPairRecord](s.records))
 	if err := handleCollection(w, r, &api.FlowAggregateListResponse{}, results); err != nil {
 		s.logWriteError(r, err)
@@ -205,7 +206,7 @@ func (s *server) ComponentpairByID(w http.ResponseWriter, r *http.Request, id st
 }
 
 // (GET /api/v2alpha1/components)
-func (s *server) Components(w http.ResponseWriter, r *http.Request) {
+func (s *server) Components(w http.ResponseWriter, r *http.Request, _ api.ComponentsParams) {
 	results := views.NewComponentSliceProvider(s.records)(listByType[collector.ProcessGroupRecord](s.records))
 	if err := handleCollection(w, r, &api.ComponentListResponse{}, results); err != nil {
 		s.logWriteError(r, err)
@@ -221,7 +222,7 @@ func (s *server) ComponentByID(w http.ResponseWriter, r *http.Request, id string
 }
 
 // (GET /api/v2alpha1/processpairs)
-func (s *server) Processpairs(w http.ResponseWriter, r *http.Request) {
+func (s *server) Processpairs(w http.ResponseWriter, r *http.Request, _ api.ProcesspairsParams) {
 	results := views.NewProcessPairSliceProvider(s.graph)(listByType[collector.ProcPairRecord](s.records))
 	if err := handleCollection(w, r, &api.FlowAggregateListResponse{}, results); err != nil {
 		s.logWriteError(r, err)
@@ -237,8 +238,8 @@ func (s *server) ProcesspairByID(w http.ResponseWriter, r *http.Request, id stri
 }
 
 // (GET /api/v2alpha1/routeraccess)
-func (s *server) Routeraccess(w http.ResponseWriter, r *http.Request) {
-	results := views.RouterAccessList(listByType[vanflow.RouterAccessRecord](s.records))
+func (s *server) Routeraccess(w http.ResponseWriter, r *http.Request, _ api.RouteraccessParams) {
+	results := views.NewRouterAccessSliceProvider(s.graph)(listByType[vanflow.RouterAccessRecord](s.records))
 	if err := handleCollection(w, r, &api.RouterAccessListResponse{}, results); err != nil {
 		s.logWriteError(r, err)
 	}
@@ -246,14 +247,14 @@ func (s *server) Routeraccess(w http.ResponseWriter, r *http.Request) {
 
 // (GET /api/v2alpha1/routeraccess/{id})
 func (s *server) RouteraccessByID(w http.ResponseWriter, r *http.Request, id string) {
-	getRecord := fetchAndMap(s.records, views.RouterAccess, id)
+	getRecord := fetchAndMap(s.records, views.NewRouterAccessProvider(s.graph), id)
 	if err := handleSingle(w, r, &api.RouterAccessResponse{}, getRecord); err != nil {
 		s.logWriteError(r, err)
 	}
 }
 
 // (GET /api/v2alpha1/routerlinks)
-func (s *server) Routerlinks(w http.ResponseWriter, r *http.Request) {
+func (s *server) Routerlinks(w http.ResponseWriter, r *http.Request, _ api.RouterlinksParams) {
 	results := views.NewRotuerLinkSliceProvider(s.graph)(listByType[vanflow.LinkRecord](s.records))
 	if err := handleCollection(w, r, &api.RouterLinkListResponse{}, results); err != nil {
 		s.logWriteError(r, err)
@@ -269,8 +270,8 @@ func (s *server) RouterlinkByID(w http.ResponseWriter, r *http.Request, id strin
 }
 
 // (GET /api/v2alpha1/routers)
-func (s *server) Routers(w http.ResponseWriter, r *http.Request) {
-	results := views.Routers(listByType[vanflow.RouterRecord](s.records))
+func (s *server) Routers(w http.ResponseWriter, r *http.Request, _ api.RoutersParams) {
+	results := views.NewRouterSliceProvider(s.graph)(listByType[vanflow.RouterRecord](s.records))
 	if err := handleCollection(w, r, &api.RouterListResponse{}, results); err != nil {
 		s.logWriteError(r, err)
 	}
@@ -278,14 +279,14 @@ func (s *server) Routers(w http.ResponseWriter, r *http.Request) {
 
 // (GET /api/v2alpha1/routers/{id})
 func (s *server) RouterByID(w http.ResponseWriter, r *http.Request, id string) {
-	getRecord := fetchAndMap(s.records, views.Router, id)
+	getRecord := fetchAndMap(s.records, views.NewRouterProvider(s.graph), id)
 	if err := handleSingle(w, r, &api.RouterResponse{}, getRecord); err != nil {
 		s.logWriteError(r, err)
 	}
 }
 
 // (GET /api/v2alpha1/sitepairs)
-func (s *server) Sitepairs(w http.ResponseWriter, r *http.Request) {
+func (s *server) Sitepairs(w http.ResponseWriter, r *http.Request, _ api.SitepairsParams) {
 	results := views.NewSitePairSliceProvider(s.graph)(listByType[collector.SitePairRecord](s.records))
 	if err := handleCollection(w, r, &api.FlowAggregateListResponse{}, results); err != nil {
 		s.logWriteError(r, err)
@@ -297,7 +298,7 @@ func (s *server) SitepairByID(w http.ResponseWriter, r *http.Request, id string)
 }
 
 // (GET /api/v2alpha1/sites)
-func (s *server) Sites(w http.ResponseWriter, r *http.Request) {
+func (s *server) Sites(w http.ResponseWriter, r *http.Request, _ api.SitesParams) {
 	results := views.NewSiteSliceProvider(s.graph)(listByType[vanflow.SiteRecord](s.records))
 	if err := handleCollection(w, r, &api.SiteListResponse{}, results); err != nil {
 		s.logWriteError(r, err)
@@ -313,7 +314,7 @@ func (s *server) SiteById(w http.ResponseWriter, r *http.Request, id string) {
 }
 
 // (GET /api/v2alpha1/sites/{id}/hosts)
-func (s *server) HostsBySite(w http.ResponseWriter, r *http.Request, id string) {
+func (s *server) HostsBySite(w http.ResponseWriter, r *http.Request, id string, _ api.HostsBySiteParams) {
 	//TODO(ck) implement
 	if err := handleCollection(w, r, &api.SiteListResponse{}, []api.SiteRecord{}); err != nil {
 		s.logWriteError(r, err)
@@ -321,7 +322,7 @@ func (s *server) HostsBySite(w http.ResponseWriter, r *http.Request, id string)
 }
 
 // (GET /api/v2alpha1/sites/{id}/processes)
-func (s *server) ProcessesBySite(w http.ResponseWriter, r *http.Request, id string) {
+func (s *server) ProcessesBySite(w http.ResponseWriter, r *http.Request, id string, _ api.ProcessesBySiteParams) {
 	exemplar := store.Entry{Record: vanflow.ProcessRecord{Parent: &id}}
 	results := views.NewProcessSliceProvider(s.records, s.graph)(index(s.records, collector.IndexByTypeParent, exemplar))
 	if err := handleCollection(w, r, &api.ProcessListResponse{}, results); err != nil {
@@ -330,10 +331,52 @@ func (s *server) ProcessesBySite(w http.ResponseWriter, r *http.Request, id stri
 }
 
 // (GET /api/v2alpha1/sites/{id}/routers)
-func (s *server) RoutersBySite(w http.ResponseWriter, r *http.Request, id string) {
+func (s *server) RoutersBySite(w http.ResponseWriter, r *http.Request, id string, _ api.RoutersBySiteParams) {
 	exemplar := store.Entry{Record: vanflow.RouterRecord{Parent: &id}}
-	results := views.Routers(index(s.records, collector.IndexByTypeParent, exemplar))
+	results := views.NewRouterSliceProvider(s.graph)(index(s.records, collector.IndexByTypeParent, exemplar))
 	if err := handleCollection(w, r, &api.RouterListResponse{}, results); err != nil {
 		s.logWriteError(r, err)
 	}
 }
+
+// (GET /api/v2alpha1/services/{id}/timeseries)
+func (s *server) TimeSeriesByService(w http.ResponseWriter, r *http.Request, id string) {
+	getEntries := fetchAndMap(s.records, func(a collector.AddressRecord) []store.Entry {
+		entries := index(s.records, collector.IndexFlowByAddress, store.Entry{Record: collector.ConnectionRecord{RoutingKey: a.Name, Protocol: a.Protocol, Network: a.Network}})
+		// requests are recorded with their application protocol (e.g.
+		// http1) rather than the protocol of the service's listeners
+		for _, entry := range index(s.records, store.FieldIndex("RoutingKey"), store.Entry{Record: collector.RequestRecord{RoutingKey: a.Name}}) {
+			if req, ok := entry.Record.(collector.RequestRecord); ok && req.Network == a.Network {
+				entries = append(entries, entry)
+			}
+		}
+		return entries
+	}, id)
+	if err := handleTimeSeries(w, r, s.history, getEntries); err != nil {
+		s.logWriteError(r, err)
+	}
+}
+
+// (GET /api/v2alpha1/sitepairs/{id}/timeseries)
+func (s *server) TimeSeriesBySitepair(w http.ResponseWriter, r *http.Request, id string) {
+	getEntries := fetchAndMap(s.records, func(p collector.SitePairRecord) []store.Entry {
+		return flowsMatching(s.records, func(peers flowPeers) bool {
+			return peers.Network == p.Network && peers.Protocol == p.Protocol && peers.SourceSite == p.Source && peers.DestSite == p.Dest
+		})
+	}, id)
+	if err := handleTimeSeries(w, r, s.history, getEntries); err != nil {
+		s.logWriteError(r, err)
+	}
+}
+
+// (GET /api/v2alpha1/processpairs/{id}/timeseries)
+func (s *server) TimeSeriesByProcesspair(w http.ResponseWriter, r *http.Request, id string) {
+	getEntries := fetchAndMap(s.records, func(p collector.ProcPairRecord) []store.Entry {
+		return flowsMatching(s.records, func(peers flowPeers) bool {
+			return peers.Network == p.Network && peers.Protocol == p.Protocol && peers.Source == p.Source && peers.Dest == p.Dest
+		})
+	}, id)
+	if err := handleTimeSeries(w, r, s.history, getEntries); err != nil {
+		s.logWriteError(r, err)
+	}
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"net/http"
 	"sort"
 	"strings"
 
@@ -43,6 +44,38 @@ func listByType[T vanflow.Record](stor store.Interface) []store.Entry {
 	return ordered(stor.Index(store.TypeIndex, store.Entry{Record: r}))
 }
 
+// flowFields maps the fields of the connection and application flow API
+// records that are copied from collector.ConnectionRecord and
+// collector.RequestRecord to the fields they are copied from.
+var flowFields = map[string]string{
+	"identity":          "ID",
+	"network":           "Network",
+	"protocol":          "Protocol",
+	"routingKey":        "RoutingKey",
+	"sourceProcessId":   "Source.ID",
+	"sourceProcessName": "Source.Name",
+	"sourceSiteId":      "SourceSite.ID",
+	"sourceSiteName":    "SourceSite.Name",
+	"destProcessId":     "Dest.ID",
+	"destProcessName":   "Dest.Name",
+	"destSiteId":        "DestSite.ID",
+	"destSiteName":      "DestSite.Name",
+}
+
+// selectByType lists the records of type T. When the filter query parameter
+// only references API record fields in fields, the store selects the records
+// it matches, looking them up in its field indexes where it can. The filter
+// is still applied to the API records by handleCollection.
+func selectByType[T vanflow.Record](r *http.Request, stor store.Interface, fields map[string]string) []store.Entry {
+	var exemplar T
+	if expr := r.URL.Query().Get("filter"); expr != "" {
+		if q, err := store.CompileQueryFields(expr, exemplar, fields); err == nil {
+			return ordered(stor.Select(q))
+		}
+	}
+	return listByType[T](stor)
+}
+
 func index(stor store.Interface, index string, exemplar store.Entry) []store.Entry {
 	return ordered(stor.Index(index, exemplar))
 }
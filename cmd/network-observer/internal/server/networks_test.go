@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+)
+
+func TestNetworks(t *testing.T) {
+	tlog := slog.Default()
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	graph := collector.NewGraph(stor)
+	srv, c := requireTestClient(t, New(tlog, stor, graph))
+	defer srv.Close()
+
+	east := store.SourceRef{ID: "controller-east", Network: "east"}
+	west := store.SourceRef{ID: "controller-west", Network: "west"}
+	stor.Add(vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-east")}, east)
+	stor.Add(vanflow.RouterRecord{BaseRecord: vanflow.NewBase("router-east"), Parent: ptrTo("site-east")}, east)
+	stor.Add(vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("proc-east"), Parent: ptrTo("site-east")}, east)
+	stor.Add(vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-west")}, west)
+	stor.Add(vanflow.RouterRecord{BaseRecord: vanflow.NewBase("router-west"), Parent: ptrTo("site-west")}, west)
+	stor.Add(vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("proc-west"), Parent: ptrTo("site-west")}, west)
+	graph.(reset).Reset()
+
+	sites, err := c.SitesWithResponse(context.TODO(), nil)
+	assert.Assert(t, err)
+	assert.Equal(t, sites.JSON200.Count, int64(2))
+	assert.Equal(t, sites.JSON200.Results[0].Network, "east")
+	assert.Equal(t, sites.JSON200.Results[1].Network, "west")
+
+	eastOnly := withParameters(map[string][]string{"network": {"east"}})
+	routers, err := c.RoutersWithResponse(context.TODO(), nil, eastOnly)
+	assert.Assert(t, err)
+	assert.Equal(t, routers.JSON200.Count, int64(1))
+	assert.Equal(t, routers.JSON200.Results[0].Identity, "router-east")
+
+	processes, err := c.ProcessesWithResponse(context.TODO(), nil, withParameters(map[string][]string{"network": {"west"}}))
+	assert.Assert(t, err)
+	assert.Equal(t, processes.JSON200.Count, int64(1))
+	assert.Equal(t, processes.JSON200.Results[0].Identity, "proc-west")
+
+	stor.Add(collector.AddressRecord{ID: "addr-east", Name: "db", Protocol: "tcp", Network: "east", Start: time.Now()}, store.SourceRef{ID: "self"})
+	stor.Add(collector.AddressRecord{ID: "addr-west", Name: "db", Protocol: "tcp", Network: "west", Start: time.Now()}, store.SourceRef{ID: "self"})
+	stor.Add(collector.SitePairRecord{ID: "sp-east", Source: "site-east", Dest: "site-east", Protocol: "tcp", Network: "east", Start: time.Now()}, store.SourceRef{ID: "self"})
+	stor.Add(collector.ProcPairRecord{ID: "pp-west", Source: "proc-west", Dest: "proc-west", Protocol: "tcp", Network: "west", Start: time.Now()}, store.SourceRef{ID: "self"})
+
+	services, err := c.ServicesWithResponse(context.TODO(), nil, eastOnly)
+	assert.Assert(t, err)
+	assert.Equal(t, services.JSON200.Count, int64(1))
+	assert.Equal(t, services.JSON200.Results[0].Identity, "addr-east")
+	assert.Equal(t, services.JSON200.Results[0].Network, "east")
+
+	sitepairs, err := c.SitepairsWithResponse(context.TODO(), nil, eastOnly)
+	assert.Assert(t, err)
+	assert.Equal(t, sitepairs.JSON200.Count, int64(1))
+	assert.Equal(t, dref(sitepairs.JSON200.Results[0].Network), "east")
+
+	processpairs, err := c.ProcesspairsWithResponse(context.TODO(), nil, eastOnly)
+	assert.Assert(t, err)
+	assert.Equal(t, processpairs.JSON200.Count, int64(0))
+}
@@ -144,7 +144,7 @@ func TestProcesses(t *testing.T) {
 		t.Run("", func(t *testing.T) {
 			stor.Replace(tc.Records)
 			graph.(reset).Reset()
-			resp, err := c.ProcessesWithResponse(context.TODO(), withParameters(tc.Parameters))
+			resp, err := c.ProcessesWithResponse(context.TODO(), nil, withParameters(tc.Parameters))
 			assert.Check(t, err)
 			if tc.ExpectOK {
 				assert.Equal(t, resp.StatusCode(), 200)
@@ -39,7 +39,7 @@ func TestProcessPairsByAddress(t *testing.T) {
 		t.Run("", func(t *testing.T) {
 			stor.Replace(tc.Records)
 			graph.(reset).Reset()
-			resp, err := c.ProcessPairsByServiceWithResponse(context.TODO(), tc.ID, withParameters(tc.Parameters))
+			resp, err := c.ProcessPairsByServiceWithResponse(context.TODO(), tc.ID, nil, withParameters(tc.Parameters))
 			assert.Check(t, err)
 			if tc.ExpectOK {
 				assert.Equal(t, resp.StatusCode(), 200)
@@ -10,11 +10,13 @@ import (
 	"time"
 
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
 
 func filterAndOrderResults[T api.Record](r *http.Request, results []T) ([]T, int64, error) {
+	results = inScope(r, results)
 	var (
 		out            []T = results
 		isCopy         bool
@@ -23,6 +25,14 @@ func filterAndOrderResults[T api.Record](r *http.Request, results []T) ([]T, int
 
 	qp := getQueryParams(r)
 
+	var filter *store.Query
+	if qp.Filter != "" {
+		var err error
+		if filter, err = store.CompileQuery(qp.Filter, *new(T)); err != nil {
+			return nil, 0, fmt.Errorf("invalid filter parameter %q: %s", qp.Filter, err)
+		}
+	}
+
 	filterFields := make(map[string]fieldIndex[T], len(qp.FilterFields))
 	for path := range qp.FilterFields {
 		m, err := indexerForField[T](path)
@@ -33,9 +43,9 @@ func filterAndOrderResults[T api.Record](r *http.Request, results []T) ([]T, int
 	}
 
 	for i, item := range results {
-		matches := true
+		matches := filter == nil || filter.Match(item)
 		for path, values := range qp.FilterFields {
-			if !filterFields[path].MatchesFilter(item, values) {
+			if matches && !filterFields[path].MatchesFilter(item, values) {
 				matches = false
 				break
 			}
@@ -289,6 +299,7 @@ type queryParams struct {
 	SortField          string
 	SortDescending     bool
 	FilterFields       map[string][]string
+	Filter             string
 	TimeRangeStart     uint64
 	TimeRangeEnd       uint64
 	TimeRangeOperation timeRangeRelation
@@ -358,6 +369,8 @@ func getQueryParams(r *http.Request) queryParams {
 			default:
 				qp.TimeRangeOperation = intersects
 			}
+		case "filter":
+			qp.Filter = v[0]
 		case "state":
 			recordState := v[0]
 			switch recordState {
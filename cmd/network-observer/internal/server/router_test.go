@@ -63,7 +63,7 @@ func TestRouters(t *testing.T) {
 		t.Run("", func(t *testing.T) {
 			stor.Replace(tc.Records)
 			graph.(reset).Reset()
-			resp, err := c.RoutersWithResponse(context.TODO(), withParameters(tc.Parameters))
+			resp, err := c.RoutersWithResponse(context.TODO(), nil, withParameters(tc.Parameters))
 			assert.Check(t, err)
 			if tc.ExpectOK {
 				assert.Equal(t, resp.StatusCode(), 200)
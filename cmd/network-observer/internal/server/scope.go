@@ -0,0 +1,119 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+)
+
+// inScope returns the records the principal making the request may access
+func inScope[T any](r *http.Request, records []T) []T {
+	if auth.Unrestricted(r) {
+		return records
+	}
+	out := make([]T, 0, len(records))
+	for _, record := range records {
+		if auth.Allowed(r, resourceOf(record)) {
+			out = append(out, record)
+		}
+	}
+	return out
+}
+
+// resourceOf returns the sites and routing keys an API or collector record
+// is associated with. Access to records of other types is denied to
+// principals restricted by site or routing key.
+func resourceOf(record any) auth.Resource {
+	sites := func(refs ...string) []string {
+		out := make([]string, 0, len(refs))
+		for _, ref := range refs {
+			if ref != "" {
+				out = append(out, ref)
+			}
+		}
+		return out
+	}
+	keys := func(keys ...string) []string { return keys }
+	switch r := record.(type) {
+	case api.SiteRecord:
+		return auth.Resource{Sites: sites(r.Identity, r.Name)}
+	case api.RouterRecord:
+		return auth.Resource{Sites: sites(r.SiteId)}
+	case api.RouterAccessRecord:
+		return auth.Resource{Sites: sites(r.SiteId, r.SiteName)}
+	case api.RouterLinkRecord:
+		return auth.Resource{Sites: sites(r.SourceSiteId, r.SourceSiteName, dref(r.DestinationSiteId), dref(r.DestinationSiteName))}
+	case api.ProcessRecord:
+		// processes are scoped by the routing keys of the services they are
+		// bound to
+		bound := []string{}
+		if r.Services != nil {
+			for _, service := range *r.Services {
+				name, _, _ := strings.Cut(string(service), "@")
+				bound = append(bound, name)
+			}
+		}
+		return auth.Resource{Sites: sites(r.SiteId, r.SiteName), RoutingKeys: bound}
+	case api.ListenerRecord:
+		return auth.Resource{Sites: sites(r.SiteId, r.SiteName), RoutingKeys: keys(r.RoutingKey)}
+	case api.ConnectorRecord:
+		return auth.Resource{Sites: sites(r.SiteId, r.SiteName), RoutingKeys: keys(r.RoutingKey)}
+	case api.ServiceRecord:
+		return auth.Resource{RoutingKeys: keys(r.Name)}
+	case api.ConnectionRecord:
+		return auth.Resource{
+			Sites:       sites(r.SourceSiteId, r.SourceSiteName, r.DestSiteId, r.DestSiteName),
+			RoutingKeys: keys(r.RoutingKey),
+		}
+	case api.ApplicationFlowRecord:
+		return auth.Resource{
+			Sites:       sites(r.SourceSiteId, r.SourceSiteName, r.DestSiteId, r.DestSiteName),
+			RoutingKeys: keys(r.RoutingKey),
+		}
+	case api.FlowAggregateRecord:
+		// pairs whose sites are not known, including component pairs that
+		// span sites, are only visible to principals not restricted by site
+		if r.PairType == api.SITE {
+			return auth.Resource{Sites: sites(r.SourceId, r.SourceName, r.DestinationId, r.DestinationName)}
+		}
+		return auth.Resource{Sites: sites(dref(r.SourceSiteId), dref(r.SourceSiteName), dref(r.DestinationSiteId), dref(r.DestinationSiteName))}
+	case api.AlertRecord:
+		res := auth.Resource{Sites: sites(dref(r.Sites)...)}
+		if r.RoutingKey != nil {
+			res.RoutingKeys = keys(*r.RoutingKey)
+			if r.Sites == nil {
+				// routing key alerts are not associated with a site
+				res.Sites = nil
+			}
+		}
+		return res
+	case collector.AddressRecord:
+		return auth.Resource{RoutingKeys: keys(r.Name)}
+	case collector.ConnectionRecord:
+		return auth.Resource{
+			Sites:       sites(r.SourceSite.ID, r.SourceSite.Name, r.DestSite.ID, r.DestSite.Name),
+			RoutingKeys: keys(r.RoutingKey),
+		}
+	case collector.RequestRecord:
+		return auth.Resource{
+			Sites:       sites(r.SourceSite.ID, r.SourceSite.Name, r.DestSite.ID, r.DestSite.Name),
+			RoutingKeys: keys(r.RoutingKey),
+		}
+	}
+	// records without a case, such as process groups, are not attributed to
+	// sites or routing keys and are only visible to unrestricted principals
+	return auth.Resource{Sites: []string{}, RoutingKeys: []string{}}
+}
+
+// requireUnrestricted writes a forbidden response and returns false when the
+// principal making the request may not access every record.
+func requireUnrestricted(w http.ResponseWriter, r *http.Request) bool {
+	if auth.Unrestricted(r) {
+		return true
+	}
+	http.Error(w, "forbidden", http.StatusForbidden)
+	return false
+}
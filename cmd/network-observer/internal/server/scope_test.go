@@ -0,0 +1,177 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+)
+
+func TestScopedPrincipal(t *testing.T) {
+	tlog := slog.Default()
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	flowStor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	graph := collector.NewGraph(stor)
+	principal := auth.Principal{
+		Name: "alice",
+		Roles: []auth.Role{
+			{Name: "east", Sites: []string{"site east"}},
+			{Name: "pizza", RoutingKeys: []string{"pizza"}},
+		},
+	}
+	htsrv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api.Handler(New(tlog, stor, graph)).ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), principal)))
+	}))
+	defer htsrv.Close()
+	c, err := api.NewClientWithResponses(htsrv.URL, api.WithHTTPClient(htsrv.Client()))
+	assert.Assert(t, err)
+
+	begin := time.Now().Add(-time.Minute)
+	stor.Replace(wrapRecords(
+		vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-east"), Name: ptrTo("site east")},
+		vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("proc-east"), Parent: ptrTo("site-east"), Name: ptrTo("east")},
+		vanflow.RouterRecord{BaseRecord: vanflow.NewBase("router-east"), Parent: ptrTo("site-east")},
+		vanflow.RouterAccessRecord{BaseRecord: vanflow.NewBase("access-east"), Parent: ptrTo("router-east")},
+		vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-west"), Name: ptrTo("site west")},
+		vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("proc-west"), Parent: ptrTo("site-west"), Name: ptrTo("west")},
+		vanflow.RouterRecord{BaseRecord: vanflow.NewBase("router-west"), Parent: ptrTo("site-west")},
+		vanflow.RouterAccessRecord{BaseRecord: vanflow.NewBase("access-west"), Parent: ptrTo("router-west")},
+		collector.AddressRecord{ID: "addr-1", Name: "pizza", Protocol: "tcp", Start: begin},
+		collector.AddressRecord{ID: "addr-2", Name: "waffles", Protocol: "tcp", Start: begin},
+		collector.ConnectionRecord{
+			ID:         "east-waffles",
+			StartTime:  begin,
+			SourceSite: collector.NamedReference{ID: "site-east", Name: "site east"},
+			DestSite:   collector.NamedReference{ID: "site-west", Name: "site west"},
+			RoutingKey: "waffles",
+			FlowStore:  flowStor,
+		},
+		collector.ConnectionRecord{
+			ID:         "west-pizza",
+			StartTime:  begin,
+			SourceSite: collector.NamedReference{ID: "site-west", Name: "site west"},
+			DestSite:   collector.NamedReference{ID: "site-west", Name: "site west"},
+			RoutingKey: "pizza",
+			FlowStore:  flowStor,
+		},
+		collector.ConnectionRecord{
+			ID:         "west-waffles",
+			StartTime:  begin,
+			SourceSite: collector.NamedReference{ID: "site-west", Name: "site west"},
+			DestSite:   collector.NamedReference{ID: "site-west", Name: "site west"},
+			RoutingKey: "waffles",
+			FlowStore:  flowStor,
+		},
+	))
+	flowStor.Replace(wrapRecords(
+		vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("east-waffles", begin)},
+		vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("west-pizza", begin)},
+		vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("west-waffles", begin)},
+	))
+	graph.(reset).Reset()
+
+	processes, err := c.ProcessesWithResponse(context.TODO(), nil)
+	assert.Assert(t, err)
+	assert.Equal(t, processes.JSON200.Count, int64(1))
+	assert.Equal(t, processes.JSON200.Results[0].Identity, "proc-east")
+
+	process, err := c.ProcessByIdWithResponse(context.TODO(), "proc-west")
+	assert.Assert(t, err)
+	assert.Equal(t, process.StatusCode(), http.StatusNotFound)
+
+	connections, err := c.ConnectionsWithResponse(context.TODO(), nil)
+	assert.Assert(t, err)
+	assert.Equal(t, connections.JSON200.Count, int64(2))
+	assert.Equal(t, connections.JSON200.Results[0].Identity, "east-waffles")
+	assert.Equal(t, connections.JSON200.Results[1].Identity, "west-pizza")
+
+	// services have no site and are only restricted by routing key
+	services, err := c.ServicesWithResponse(context.TODO(), nil)
+	assert.Assert(t, err)
+	assert.Equal(t, services.JSON200.Count, int64(2))
+
+	topo, err := c.TopologyWithResponse(context.TODO())
+	assert.Assert(t, err)
+	assert.Equal(t, topo.StatusCode(), http.StatusForbidden)
+
+	principal.Roles = principal.Roles[1:]
+	services, err = c.ServicesWithResponse(context.TODO(), nil)
+	assert.Assert(t, err)
+	assert.Equal(t, services.JSON200.Count, int64(1))
+	assert.Equal(t, services.JSON200.Results[0].Name, "pizza")
+
+	// processes are scoped by the services they are bound to
+	processes, err = c.ProcessesWithResponse(context.TODO(), nil)
+	assert.Assert(t, err)
+	assert.Equal(t, processes.JSON200.Count, int64(0))
+
+	// router accesses are scoped by the site of their router
+	principal.Roles = []auth.Role{{Name: "east", Sites: []string{"site east"}}}
+	accesses, err := c.RouteraccessWithResponse(context.TODO(), nil)
+	assert.Assert(t, err)
+	assert.Equal(t, accesses.JSON200.Count, int64(1))
+	assert.Equal(t, accesses.JSON200.Results[0].Identity, "access-east")
+	assert.Equal(t, accesses.JSON200.Results[0].SiteName, "site east")
+
+	access, err := c.RouteraccessByIDWithResponse(context.TODO(), "access-west")
+	assert.Assert(t, err)
+	assert.Equal(t, access.StatusCode(), http.StatusNotFound)
+}
+
+func TestResourceOfUnknownSites(t *testing.T) {
+	east := auth.Principal{Roles: []auth.Role{{Name: "east", Sites: []string{"site-east"}}}}
+	testcases := []struct {
+		Name    string
+		Record  any
+		Allowed bool
+	}{
+		{
+			Name:   "process pair with unknown sites",
+			Record: api.FlowAggregateRecord{PairType: api.PROCESS, SourceId: "proc-a", DestinationId: "proc-b"},
+		}, {
+			Name:    "process pair in site",
+			Record:  api.FlowAggregateRecord{PairType: api.PROCESS, SourceSiteId: ptrTo("site-east")},
+			Allowed: true,
+		}, {
+			Name:   "process group pair",
+			Record: api.FlowAggregateRecord{PairType: api.PROCESSGROUP, SourceId: "group-a", DestinationId: "group-b"},
+		}, {
+			Name:   "link alert with unknown sites",
+			Record: api.AlertRecord{RuleType: api.LinkDown, Sites: &[]string{}},
+		}, {
+			Name:    "link alert in site",
+			Record:  api.AlertRecord{RuleType: api.LinkDown, Sites: &[]string{"site-west", "site-east"}},
+			Allowed: true,
+		}, {
+			Name:   "alert without subject site",
+			Record: api.AlertRecord{RuleType: api.LinkDown},
+		}, {
+			Name:    "routing key alert",
+			Record:  api.AlertRecord{RuleType: api.Latency, RoutingKey: ptrTo("pizza")},
+			Allowed: true,
+		}, {
+			Name:   "router access with unknown site",
+			Record: api.RouterAccessRecord{RouterId: "router-east", SiteId: "unknown", SiteName: "unknown"},
+		}, {
+			Name:   "process group",
+			Record: api.ComponentRecord{Name: "group-a"},
+		}, {
+			Name:   "record without a case",
+			Record: vanflow.RouterAccessRecord{BaseRecord: vanflow.NewBase("access-east"), Parent: ptrTo("router-east")},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, east.Allows(resourceOf(tc.Record)), tc.Allowed)
+		})
+	}
+}
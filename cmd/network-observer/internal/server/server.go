@@ -6,24 +6,52 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
 	"github.com/skupperproject/skupper/pkg/vanflow/store"
 )
 
-func New(logger *slog.Logger, records store.Interface, graph collector.Graph) api.ServerInterface {
-	return &server{
+func New(logger *slog.Logger, records store.Interface, graph collector.Graph, opts ...Option) api.ServerInterface {
+	s := &server{
 		logger:  logger,
 		records: records,
 		graph:   graph,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
+// Option configures optional server features
+type Option func(*server)
+
 type server struct {
 	logger  *slog.Logger
 	records store.Interface
 	graph   collector.Graph
+	alerts  AlertSource
+	history time.Duration
+	events  http.Handler
+}
+
+// WithEventStream serves the event stream from handler
+func WithEventStream(handler http.Handler) Option {
+	return func(s *server) {
+		s.events = handler
+	}
+}
+
+// WithHistoryRetention sets how long connections and requests are retained,
+// beyond which time series ranges are rejected. Zero when retention is
+// unbounded.
+func WithHistoryRetention(retention time.Duration) Option {
+	return func(s *server) {
+		s.history = retention
+	}
 }
 
 func (c *server) logWriteError(r *http.Request, err error) {
@@ -80,13 +108,13 @@ func handleSubCollection[T api.Record](w http.ResponseWriter, r *http.Request, r
 	}
 	return nil
 }
-func handleSingle[T any](w http.ResponseWriter, _ *http.Request, response api.ResponseSetter[T], getter func() (T, bool)) error {
+func handleSingle[T any](w http.ResponseWriter, r *http.Request, response api.ResponseSetter[T], getter func() (T, bool)) error {
 	var (
 		out    any = response
 		status     = http.StatusOK
 	)
 
-	if record, ok := getter(); ok {
+	if record, ok := getter(); ok && auth.Allowed(r, resourceOf(record)) {
 		response.SetResults(record)
 	} else {
 		status = http.StatusNotFound
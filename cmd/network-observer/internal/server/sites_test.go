@@ -65,13 +65,27 @@ func TestSites(t *testing.T) {
 			Parameters:  map[string][]string{"fizz": {"baz", "buz"}},
 			ExpectError: "invalid filter",
 		},
+		{
+			Records: wrapRecords(
+				vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1"), Namespace: ptrTo("a")},
+				vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-2"), Namespace: ptrTo("b")},
+				vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-3"), Namespace: ptrTo("c")},
+			),
+			ExpectOK:    true,
+			ExpectCount: 2,
+			Parameters:  map[string][]string{"filter": {`namespace != "b"`}},
+		},
+		{
+			Parameters:  map[string][]string{"filter": {`namespace ==`}},
+			ExpectError: "invalid filter parameter",
+		},
 	}
 
 	for _, tc := range testcases {
 		t.Run("", func(t *testing.T) {
 			stor.Replace(tc.Records)
 			graph.(reset).Reset()
-			resp, err := c.SitesWithResponse(context.TODO(), withParameters(tc.Parameters))
+			resp, err := c.SitesWithResponse(context.TODO(), nil, withParameters(tc.Parameters))
 			assert.Check(t, err)
 			if tc.ExpectOK {
 				assert.Equal(t, resp.StatusCode(), 200)
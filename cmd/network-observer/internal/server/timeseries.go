@@ -0,0 +1,283 @@
+package server
+
+import (
+	"fmt"
+	"math/bits"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+)
+
+const (
+	defaultBucketWidth = time.Minute
+	maxBuckets         = 1440
+)
+
+// timeSeriesQuery describes the buckets requested through the timeRangeStart,
+// timeRangeEnd and bucketWidth query parameters.
+type timeSeriesQuery struct {
+	Start uint64
+	End   uint64
+	Width uint64
+}
+
+// getTimeSeriesQuery parses the time series query parameters. When retention
+// is set, ranges whose first bucket ends before the oldest connection and
+// request history retained by the observer are rejected rather than answered
+// with empty buckets.
+func getTimeSeriesQuery(r *http.Request, retention time.Duration, now time.Time) (timeSeriesQuery, error) {
+	qp := getQueryParams(r)
+	q := timeSeriesQuery{
+		Start: qp.TimeRangeStart,
+		End:   qp.TimeRangeEnd,
+		Width: uint64(defaultBucketWidth / time.Microsecond),
+	}
+	if raw := r.URL.Query().Get("bucketWidth"); raw != "" {
+		width, err := time.ParseDuration(raw)
+		if err != nil {
+			return q, fmt.Errorf("invalid bucketWidth parameter %q: %s", raw, err)
+		}
+		if width < time.Second {
+			return q, fmt.Errorf("invalid bucketWidth parameter %q: must be at least 1s", raw)
+		}
+		q.Width = uint64(width / time.Microsecond)
+	}
+	if q.End <= q.Start {
+		return q, fmt.Errorf("timeRangeEnd must be after timeRangeStart")
+	}
+	if ct := (q.End - q.Start + q.Width - 1) / q.Width; ct > maxBuckets {
+		return q, fmt.Errorf("time range and bucketWidth would produce %d buckets: the maximum is %d", ct, maxBuckets)
+	}
+	if retention > 0 {
+		horizon := uint64(now.Add(-retention).UnixMicro())
+		if q.Start+q.Width <= horizon {
+			return q, fmt.Errorf("timeRangeStart is more than %s ago: connections and requests are only retained for %s", retention, retention)
+		}
+	}
+	return q, nil
+}
+
+// timeSeries accumulates connection and request records into fixed width
+// buckets. Records are counted in the bucket they started in. The octets of
+// connections are spread evenly over the buckets spanned by their lifetime,
+// up to now for those still open. The octets of requests are not counted, as
+// they are already counted by the connections carrying them.
+type timeSeries struct {
+	query        timeSeriesQuery
+	now          time.Time
+	buckets      []api.TimeSeriesBucket
+	latencySums  []uint64
+	latencyCount []uint64
+}
+
+func newTimeSeries(q timeSeriesQuery, now time.Time) *timeSeries {
+	ct := int((q.End - q.Start + q.Width - 1) / q.Width)
+	ts := &timeSeries{
+		query:        q,
+		now:          now,
+		buckets:      make([]api.TimeSeriesBucket, ct),
+		latencySums:  make([]uint64, ct),
+		latencyCount: make([]uint64, ct),
+	}
+	for i := range ts.buckets {
+		start := q.Start + uint64(i)*q.Width
+		ts.buckets[i].StartTime = start
+		ts.buckets[i].EndTime = min(start+q.Width, q.End)
+	}
+	return ts
+}
+
+func (ts *timeSeries) bucket(start time.Time) (int, bool) {
+	if start.IsZero() {
+		return 0, false
+	}
+	t := uint64(start.UnixMicro())
+	if t < ts.query.Start || t >= ts.query.End {
+		return 0, false
+	}
+	return int((t - ts.query.Start) / ts.query.Width), true
+}
+
+// spread distributes octets over the buckets overlapping start to end in
+// proportion to the overlap.
+func (ts *timeSeries) spread(start time.Time, end *vanflow.Time, octets, octetsReverse uint64) {
+	from := uint64(start.UnixMicro())
+	to := uint64(ts.now.UnixMicro())
+	if end != nil && !end.Time.Before(start) {
+		to = uint64(end.Time.UnixMicro())
+	}
+	if to <= from {
+		if idx, ok := ts.bucket(start); ok {
+			ts.buckets[idx].OctetCount += octets
+			ts.buckets[idx].OctetReverseCount += octetsReverse
+		}
+		return
+	}
+	// share returns the portion of total transferred by t, assuming a
+	// constant rate. The product can exceed 64 bits while the quotient,
+	// being at most total, cannot.
+	share := func(total, t uint64) uint64 {
+		hi, lo := bits.Mul64(total, min(max(t, from), to)-from)
+		q, _ := bits.Div64(hi, lo, to-from)
+		return q
+	}
+	for i := range ts.buckets {
+		b := &ts.buckets[i]
+		if b.EndTime <= from || b.StartTime >= to {
+			continue
+		}
+		b.OctetCount += share(octets, b.EndTime) - share(octets, b.StartTime)
+		b.OctetReverseCount += share(octetsReverse, b.EndTime) - share(octetsReverse, b.StartTime)
+	}
+}
+
+func (ts *timeSeries) AddConnection(conn collector.ConnectionRecord) {
+	flow, ok := conn.GetFlow()
+	if !ok || flow.StartTime == nil {
+		return
+	}
+	ts.spread(flow.StartTime.Time, flow.EndTime, dref(flow.Octets), dref(flow.OctetsReverse))
+	idx, ok := ts.bucket(flow.StartTime.Time)
+	if !ok {
+		return
+	}
+	b := &ts.buckets[idx]
+	b.ConnectionCount++
+	if flow.ErrorListener != nil || flow.ErrorConnector != nil {
+		b.ConnectionErrorCount++
+	}
+}
+
+func (ts *timeSeries) AddRequest(req collector.RequestRecord) {
+	flow, ok := req.GetFlow()
+	if !ok || flow.StartTime == nil {
+		return
+	}
+	idx, ok := ts.bucket(flow.StartTime.Time)
+	if !ok {
+		return
+	}
+	b := &ts.buckets[idx]
+	b.RequestCount++
+	if strings.HasPrefix(dref(flow.Result), "5") {
+		b.RequestErrorCount++
+	}
+	if flow.Latency != nil {
+		ts.latencySums[idx] += *flow.Latency
+		ts.latencyCount[idx]++
+	}
+}
+
+func (ts *timeSeries) Results() []api.TimeSeriesBucket {
+	for i := range ts.buckets {
+		if ct := ts.latencyCount[i]; ct > 0 {
+			avg := ts.latencySums[i] / ct
+			ts.buckets[i].AverageRequestLatency = &avg
+		}
+	}
+	return ts.buckets
+}
+
+// handleTimeSeries responds with the time series built from the connection and
+// request entries returned by getEntries. getEntries returns false when the
+// subject of the time series does not exist.
+func handleTimeSeries(w http.ResponseWriter, r *http.Request, retention time.Duration, getEntries func() ([]store.Entry, bool)) error {
+	var (
+		response     = &api.TimeSeriesListResponse{}
+		out      any = response
+		status       = http.StatusOK
+		now          = time.Now()
+	)
+	query, err := getTimeSeriesQuery(r, retention, now)
+	entries, found := getEntries()
+	switch {
+	case err != nil:
+		status = http.StatusBadRequest
+		out = api.ErrorBadRequest{
+			Message: err.Error(),
+		}
+	case !found:
+		status = http.StatusNotFound
+		out = api.ErrorNotFound{
+			Code: "ErrNotFound",
+		}
+	default:
+		ts := newTimeSeries(query, now)
+		for _, entry := range entries {
+			if !auth.Allowed(r, resourceOf(entry.Record)) {
+				continue
+			}
+			switch record := entry.Record.(type) {
+			case collector.ConnectionRecord:
+				ts.AddConnection(record)
+			case collector.RequestRecord:
+				ts.AddRequest(record)
+			}
+		}
+		results := ts.Results()
+		response.SetResults(results)
+		response.SetCount(int64(len(results)))
+		response.SetTimeRangeCount(int64(len(results)))
+	}
+	if err := encodeResponse(w, status, out); err != nil {
+		return fmt.Errorf("response write error: %s", err)
+	}
+	return nil
+}
+
+// flowPeers identifies the endpoints of a connection or request record.
+type flowPeers struct {
+	Network    string
+	Protocol   string
+	Source     string
+	Dest       string
+	SourceSite string
+	DestSite   string
+}
+
+// flowsMatching returns the connection and request entries whose peers
+// satisfy match. Requests are matched both with their application protocol
+// and with the protocol of the connection carrying them.
+func flowsMatching(stor store.Interface, match func(flowPeers) bool) []store.Entry {
+	var out []store.Entry
+	for _, entry := range listByType[collector.ConnectionRecord](stor) {
+		c, ok := entry.Record.(collector.ConnectionRecord)
+		if ok && match(flowPeers{c.Network, c.Protocol, c.Source.ID, c.Dest.ID, c.SourceSite.ID, c.DestSite.ID}) {
+			out = append(out, entry)
+		}
+	}
+	for _, entry := range listByType[collector.RequestRecord](stor) {
+		r, ok := entry.Record.(collector.RequestRecord)
+		if !ok {
+			continue
+		}
+		peers := flowPeers{r.Network, r.Protocol, r.Source.ID, r.Dest.ID, r.SourceSite.ID, r.DestSite.ID}
+		if match(peers) {
+			out = append(out, entry)
+			continue
+		}
+		if transport, ok := stor.Get(r.TransportID); ok {
+			if c, ok := transport.Record.(collector.ConnectionRecord); ok {
+				peers.Protocol = c.Protocol
+				if match(peers) {
+					out = append(out, entry)
+				}
+			}
+		}
+	}
+	return out
+}
+
+func dref[T any](p *T) T {
+	var t T
+	if p != nil {
+		return *p
+	}
+	return t
+}
@@ -0,0 +1,282 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+)
+
+func TestTimeSeriesBySitepair(t *testing.T) {
+	tlog := slog.Default()
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	flowStor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	graph := collector.NewGraph(stor)
+	srv, c := requireTestClient(t, New(tlog, stor, graph, WithHistoryRetention(2*time.Hour)))
+	defer srv.Close()
+
+	t0 := time.Now().Add(-time.Hour).Truncate(time.Minute)
+	stor.Add(collector.SitePairRecord{ID: "sp1", Source: "site-a", Dest: "site-b", Protocol: "tcp"}, store.SourceRef{})
+	connections := []struct {
+		Start    time.Time
+		Duration time.Duration
+		Octets   uint64
+		Dest     string
+		Error    bool
+	}{
+		{Start: t0, Octets: 10, Dest: "site-b"},
+		{Start: t0.Add(30 * time.Second), Octets: 20, Dest: "site-b", Error: true},
+		{Start: t0.Add(2 * time.Minute), Octets: 40, Dest: "site-b"},
+		{Start: t0.Add(2 * time.Minute), Octets: 80, Dest: "site-c"},
+		{Start: t0.Add(10 * time.Minute), Octets: 160, Dest: "site-b"},
+		// long lived connections have their octets spread over their lifetime
+		{Start: t0.Add(time.Minute), Duration: 2 * time.Minute, Octets: 200, Dest: "site-b"},
+		{Start: t0.Add(-time.Minute), Duration: 2 * time.Minute, Octets: 60, Dest: "site-b"},
+	}
+	for i, conn := range connections {
+		id := fmt.Sprintf("flow:%d", i)
+		flow := vanflow.TransportBiflowRecord{
+			BaseRecord: vanflow.NewBase(id, conn.Start, conn.Start.Add(conn.Duration)),
+			Octets:     ptrTo(conn.Octets),
+		}
+		if conn.Error {
+			flow.ErrorConnector = ptrTo("connection refused")
+		}
+		flowStor.Add(flow, store.SourceRef{})
+		stor.Add(collector.ConnectionRecord{
+			ID:         id,
+			Protocol:   "tcp",
+			SourceSite: collector.NamedReference{ID: "site-a"},
+			DestSite:   collector.NamedReference{ID: conn.Dest},
+			FlowStore:  flowStor,
+		}, store.SourceRef{})
+	}
+
+	rangeParams := map[string][]string{
+		"timeRangeStart": {fmt.Sprint(t0.UnixMicro())},
+		"timeRangeEnd":   {fmt.Sprint(t0.Add(4 * time.Minute).UnixMicro())},
+		"bucketWidth":    {"1m"},
+	}
+	resp, err := c.TimeSeriesBySitepairWithResponse(context.TODO(), "sp1", withParameters(rangeParams))
+	assert.Assert(t, err)
+	assert.Equal(t, resp.StatusCode(), 200)
+	results := resp.JSON200.Results
+	assert.Equal(t, len(results), 4)
+	assert.Equal(t, results[0].StartTime, uint64(t0.UnixMicro()))
+	assert.Equal(t, results[3].EndTime, uint64(t0.Add(4*time.Minute).UnixMicro()))
+	var (
+		connectionCounts []uint64
+		errorCounts      []uint64
+		octetCounts      []uint64
+	)
+	for _, b := range results {
+		connectionCounts = append(connectionCounts, b.ConnectionCount)
+		errorCounts = append(errorCounts, b.ConnectionErrorCount)
+		octetCounts = append(octetCounts, b.OctetCount)
+	}
+	assert.DeepEqual(t, connectionCounts, []uint64{2, 1, 1, 0})
+	assert.DeepEqual(t, errorCounts, []uint64{1, 0, 0, 0})
+	assert.DeepEqual(t, octetCounts, []uint64{60, 100, 140, 0})
+
+	notFound, err := c.TimeSeriesBySitepairWithResponse(context.TODO(), "sp2", withParameters(rangeParams))
+	assert.Assert(t, err)
+	assert.Equal(t, notFound.StatusCode(), 404)
+
+	badWidth, err := c.TimeSeriesBySitepairWithResponse(context.TODO(), "sp1", withParameters(map[string][]string{
+		"bucketWidth": {"1ms"},
+	}))
+	assert.Assert(t, err)
+	assert.Equal(t, badWidth.StatusCode(), 400)
+
+	tooMany, err := c.TimeSeriesBySitepairWithResponse(context.TODO(), "sp1", withParameters(map[string][]string{
+		"timeRangeStart": {fmt.Sprint(t0.Add(-48 * time.Hour).UnixMicro())},
+		"bucketWidth":    {"1m"},
+	}))
+	assert.Assert(t, err)
+	assert.Equal(t, tooMany.StatusCode(), 400)
+
+	beyondRetention, err := c.TimeSeriesBySitepairWithResponse(context.TODO(), "sp1", withParameters(map[string][]string{
+		"timeRangeStart": {fmt.Sprint(t0.Add(-2 * time.Hour).UnixMicro())},
+		"bucketWidth":    {"10m"},
+	}))
+	assert.Assert(t, err)
+	assert.Equal(t, beyondRetention.StatusCode(), 400)
+}
+
+// requestWithFlow returns the request backed by the app flow, restored as it
+// would be from a file store snapshot.
+func requestWithFlow(t *testing.T, request collector.RequestRecord, flow vanflow.AppBiflowRecord) collector.RequestRecord {
+	t.Helper()
+	raw, err := json.Marshal(request)
+	assert.Assert(t, err)
+	var fields map[string]any
+	assert.Assert(t, json.Unmarshal(raw, &fields))
+	fields["Flow"] = flow
+	raw, err = json.Marshal(fields)
+	assert.Assert(t, err)
+	var out collector.RequestRecord
+	assert.Assert(t, json.Unmarshal(raw, &out))
+	return out
+}
+
+// addTimeSeriesFlows adds two connections for the backend routing key from
+// process p1 to p2, starting at t0 and a minute later, and the http1
+// requests they carry. Requests for another routing key and another network
+// are added too.
+func addTimeSeriesFlows(t *testing.T, stor, flowStor store.Interface, t0 time.Time) {
+	t.Helper()
+	connections := []struct {
+		Start  time.Time
+		Octets uint64
+	}{
+		{Start: t0, Octets: 1000},
+		{Start: t0.Add(time.Minute), Octets: 500},
+	}
+	for i, conn := range connections {
+		id := fmt.Sprintf("flow:%d", i)
+		flowStor.Add(vanflow.TransportBiflowRecord{
+			BaseRecord: vanflow.NewBase(id, conn.Start, conn.Start),
+			Octets:     ptrTo(conn.Octets),
+		}, store.SourceRef{})
+		stor.Add(collector.ConnectionRecord{
+			ID:         id,
+			RoutingKey: "backend",
+			Protocol:   "tcp",
+			Source:     collector.NamedReference{ID: "p1"},
+			Dest:       collector.NamedReference{ID: "p2"},
+			FlowStore:  flowStor,
+		}, store.SourceRef{})
+	}
+	requests := []struct {
+		Start      time.Time
+		Transport  string
+		RoutingKey string
+		Network    string
+		Result     string
+		Latency    uint64
+	}{
+		{Start: t0.Add(10 * time.Second), Transport: "flow:0", RoutingKey: "backend", Result: "200", Latency: 10},
+		{Start: t0.Add(20 * time.Second), Transport: "flow:0", RoutingKey: "backend", Result: "503", Latency: 30},
+		{Start: t0.Add(70 * time.Second), Transport: "flow:1", RoutingKey: "backend", Result: "200", Latency: 5},
+		{Start: t0.Add(30 * time.Second), Transport: "other", RoutingKey: "frontend", Result: "200", Latency: 100},
+		{Start: t0.Add(40 * time.Second), Transport: "other", RoutingKey: "backend", Network: "west", Result: "200", Latency: 100},
+	}
+	for i, req := range requests {
+		id := fmt.Sprintf("request:%d", i)
+		stor.Add(requestWithFlow(t, collector.RequestRecord{
+			ID:          id,
+			TransportID: req.Transport,
+			RoutingKey:  req.RoutingKey,
+			Network:     req.Network,
+			Protocol:    "http1",
+			Source:      collector.NamedReference{ID: "p1"},
+			Dest:        collector.NamedReference{ID: "p2"},
+		}, vanflow.AppBiflowRecord{
+			BaseRecord: vanflow.NewBase(id, req.Start, req.Start),
+			Result:     ptrTo(req.Result),
+			Latency:    ptrTo(req.Latency),
+			// carried by the connection and so not counted again
+			Octets: ptrTo(uint64(300)),
+		}), store.SourceRef{})
+	}
+}
+
+type timeSeriesCounts struct {
+	Connections   []uint64
+	Requests      []uint64
+	RequestErrors []uint64
+	Latencies     []uint64
+	Octets        []uint64
+}
+
+func countTimeSeries(t *testing.T, buckets []api.TimeSeriesBucket) timeSeriesCounts {
+	t.Helper()
+	var counts timeSeriesCounts
+	for _, b := range buckets {
+		counts.Connections = append(counts.Connections, b.ConnectionCount)
+		counts.Requests = append(counts.Requests, b.RequestCount)
+		counts.RequestErrors = append(counts.RequestErrors, b.RequestErrorCount)
+		counts.Latencies = append(counts.Latencies, dref(b.AverageRequestLatency))
+		counts.Octets = append(counts.Octets, b.OctetCount)
+	}
+	return counts
+}
+
+func TestTimeSeriesByService(t *testing.T) {
+	tlog := slog.Default()
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	flowStor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	graph := collector.NewGraph(stor)
+	srv, c := requireTestClient(t, New(tlog, stor, graph))
+	defer srv.Close()
+
+	t0 := time.Now().Add(-time.Hour).Truncate(time.Minute)
+	stor.Add(collector.AddressRecord{ID: "svc1", Name: "backend", Protocol: "tcp"}, store.SourceRef{})
+	addTimeSeriesFlows(t, stor, flowStor, t0)
+
+	resp, err := c.TimeSeriesByServiceWithResponse(context.TODO(), "svc1", withParameters(map[string][]string{
+		"timeRangeStart": {fmt.Sprint(t0.UnixMicro())},
+		"timeRangeEnd":   {fmt.Sprint(t0.Add(3 * time.Minute).UnixMicro())},
+		"bucketWidth":    {"1m"},
+	}))
+	assert.Assert(t, err)
+	assert.Equal(t, resp.StatusCode(), 200)
+	assert.DeepEqual(t, countTimeSeries(t, resp.JSON200.Results), timeSeriesCounts{
+		Connections:   []uint64{1, 1, 0},
+		Requests:      []uint64{2, 1, 0},
+		RequestErrors: []uint64{1, 0, 0},
+		Latencies:     []uint64{20, 5, 0},
+		Octets:        []uint64{1000, 500, 0},
+	})
+}
+
+func TestTimeSeriesByProcesspair(t *testing.T) {
+	tlog := slog.Default()
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	flowStor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	graph := collector.NewGraph(stor)
+	srv, c := requireTestClient(t, New(tlog, stor, graph))
+	defer srv.Close()
+
+	t0 := time.Now().Add(-time.Hour).Truncate(time.Minute)
+	stor.Add(collector.ProcPairRecord{ID: "pp-tcp", Source: "p1", Dest: "p2", Protocol: "tcp"}, store.SourceRef{})
+	stor.Add(collector.ProcPairRecord{ID: "pp-http1", Source: "p1", Dest: "p2", Protocol: "http1"}, store.SourceRef{})
+	addTimeSeriesFlows(t, stor, flowStor, t0)
+
+	rangeParams := map[string][]string{
+		"timeRangeStart": {fmt.Sprint(t0.UnixMicro())},
+		"timeRangeEnd":   {fmt.Sprint(t0.Add(3 * time.Minute).UnixMicro())},
+		"bucketWidth":    {"1m"},
+	}
+	// the transport pair includes the requests its connections carry
+	resp, err := c.TimeSeriesByProcesspairWithResponse(context.TODO(), "pp-tcp", withParameters(rangeParams))
+	assert.Assert(t, err)
+	assert.Equal(t, resp.StatusCode(), 200)
+	assert.DeepEqual(t, countTimeSeries(t, resp.JSON200.Results), timeSeriesCounts{
+		Connections:   []uint64{1, 1, 0},
+		Requests:      []uint64{2, 1, 0},
+		RequestErrors: []uint64{1, 0, 0},
+		Latencies:     []uint64{20, 5, 0},
+		Octets:        []uint64{1000, 500, 0},
+	})
+
+	// the application pair has no connections and so no octets
+	resp, err = c.TimeSeriesByProcesspairWithResponse(context.TODO(), "pp-http1", withParameters(rangeParams))
+	assert.Assert(t, err)
+	assert.Equal(t, resp.StatusCode(), 200)
+	assert.DeepEqual(t, countTimeSeries(t, resp.JSON200.Results), timeSeriesCounts{
+		Connections:   []uint64{0, 0, 0},
+		Requests:      []uint64{3, 1, 0},
+		RequestErrors: []uint64{1, 0, 0},
+		Latencies:     []uint64{46, 5, 0},
+		Octets:        []uint64{0, 0, 0},
+	})
+}
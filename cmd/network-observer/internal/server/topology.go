@@ -0,0 +1,160 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/server/views"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/topology"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+)
+
+const (
+	topologyViewSite    = "site"
+	topologyViewRouter  = "router"
+	topologyViewProcess = "process"
+)
+
+// (GET /api/v2alpha1/topology)
+func (s *server) Topology(w http.ResponseWriter, r *http.Request) {
+	if !requireUnrestricted(w, r) {
+		return
+	}
+	query := r.URL.Query()
+	view, format := query.Get("view"), topology.Format(query.Get("format"))
+	if view == "" {
+		view = topologyViewSite
+	}
+	if format == "" {
+		format = topology.FormatDOT
+	}
+
+	var (
+		graph topology.Graph
+		err   error
+	)
+	switch view {
+	case topologyViewSite:
+		graph = s.siteTopology()
+	case topologyViewRouter:
+		graph = s.routerTopology()
+	case topologyViewProcess:
+		graph = s.processTopology()
+	default:
+		err = fmt.Errorf("invalid view parameter %q: expected one of site, router or process", view)
+	}
+	var buf bytes.Buffer
+	if err == nil {
+		err = topology.Render(&buf, graph, format)
+	}
+	if err != nil {
+		if err := encodeResponse(w, http.StatusBadRequest, api.ErrorBadRequest{Message: err.Error()}); err != nil {
+			s.logWriteError(r, err)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", format.ContentType())
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		s.logWriteError(r, err)
+	}
+}
+
+func (s *server) siteTopology() topology.Graph {
+	out := topology.Graph{Name: "sites"}
+	for _, entry := range listByType[vanflow.SiteRecord](s.records) {
+		site := entry.Record.(vanflow.SiteRecord)
+		out.Nodes = append(out.Nodes, topology.Node{
+			ID:    site.ID,
+			Label: nameOrID(site.Name, site.ID),
+			Kind:  "site",
+		})
+	}
+	for _, link := range s.routerLinks() {
+		if link.DestinationSiteId == nil || *link.DestinationSiteId == link.SourceSiteId {
+			continue
+		}
+		out.Edges = append(out.Edges, topology.Edge{
+			Source: link.SourceSiteId,
+			Target: *link.DestinationSiteId,
+			Label:  link.Name,
+		})
+	}
+	return out
+}
+
+func (s *server) routerTopology() topology.Graph {
+	out := topology.Graph{Name: "routers", Groups: s.siteGroups()}
+	for _, entry := range listByType[vanflow.RouterRecord](s.records) {
+		router := entry.Record.(vanflow.RouterRecord)
+		out.Nodes = append(out.Nodes, topology.Node{
+			ID:    router.ID,
+			Label: nameOrID(router.Name, router.ID),
+			Kind:  "router",
+			Group: dref(router.Parent),
+		})
+	}
+	for _, link := range s.routerLinks() {
+		if link.DestinationRouterId == nil {
+			continue
+		}
+		out.Edges = append(out.Edges, topology.Edge{
+			Source: link.RouterId,
+			Target: *link.DestinationRouterId,
+			Label:  link.Name,
+		})
+	}
+	return out
+}
+
+func (s *server) processTopology() topology.Graph {
+	out := topology.Graph{Name: "processes", Groups: s.siteGroups()}
+	for _, entry := range listByType[vanflow.ProcessRecord](s.records) {
+		process := entry.Record.(vanflow.ProcessRecord)
+		out.Nodes = append(out.Nodes, topology.Node{
+			ID:    process.ID,
+			Label: nameOrID(process.Name, process.ID),
+			Kind:  "process",
+			Group: dref(process.Parent),
+		})
+	}
+	for _, entry := range listByType[collector.ProcPairRecord](s.records) {
+		pair := entry.Record.(collector.ProcPairRecord)
+		out.Edges = append(out.Edges, topology.Edge{
+			Source: pair.Source,
+			Target: pair.Dest,
+			Label:  pair.Protocol,
+		})
+	}
+	return out
+}
+
+func (s *server) siteGroups() []topology.Group {
+	var groups []topology.Group
+	for _, entry := range listByType[vanflow.SiteRecord](s.records) {
+		site := entry.Record.(vanflow.SiteRecord)
+		groups = append(groups, topology.Group{ID: site.ID, Label: nameOrID(site.Name, site.ID)})
+	}
+	return groups
+}
+
+func (s *server) routerLinks() []api.RouterLinkRecord {
+	toLink := views.NewRouterLinkProvider(s.graph)
+	var out []api.RouterLinkRecord
+	for _, entry := range listByType[vanflow.LinkRecord](s.records) {
+		if link, ok := toLink(entry.Record.(vanflow.LinkRecord)); ok {
+			out = append(out, link)
+		}
+	}
+	return out
+}
+
+func nameOrID(name *string, id string) string {
+	if name == nil || *name == "" {
+		return id
+	}
+	return *name
+}
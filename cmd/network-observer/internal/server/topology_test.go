@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+)
+
+func TestTopology(t *testing.T) {
+	tlog := slog.Default()
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	graph := collector.NewGraph(stor)
+	srv, c := requireTestClient(t, New(tlog, stor, graph))
+	defer srv.Close()
+
+	stor.Replace(wrapRecords(
+		vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-a"), Name: ptrTo("west")},
+		vanflow.RouterRecord{BaseRecord: vanflow.NewBase("router-a"), Name: ptrTo("router-west"), Parent: ptrTo("site-a")},
+		vanflow.RouterAccessRecord{BaseRecord: vanflow.NewBase("access-a"), Parent: ptrTo("router-a")},
+		vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("proc-a"), Name: ptrTo("client"), Parent: ptrTo("site-a")},
+		vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-b"), Name: ptrTo("east")},
+		vanflow.RouterRecord{BaseRecord: vanflow.NewBase("router-b"), Name: ptrTo("router-east"), Parent: ptrTo("site-b")},
+		vanflow.LinkRecord{BaseRecord: vanflow.NewBase("link-1"), Name: ptrTo("east-to-west"), Parent: ptrTo("router-b"), Peer: ptrTo("access-a")},
+		vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("proc-b"), Name: ptrTo("server"), Parent: ptrTo("site-b")},
+		collector.ProcPairRecord{ID: "pair-1", Source: "proc-a", Dest: "proc-b", Protocol: "tcp"},
+	))
+	graph.(reset).Reset()
+
+	testcases := []struct {
+		Parameters   map[string][]string
+		ExpectStatus int
+		ExpectType   string
+		Expect       []string
+	}{
+		{
+			ExpectStatus: 200,
+			ExpectType:   "text/vnd.graphviz",
+			Expect:       []string{`"site-a" [label="west", class="site"];`, `"site-b" -> "site-a" [label="east-to-west"];`},
+		}, {
+			Parameters:   map[string][]string{"view": {"router"}, "format": {"mermaid"}},
+			ExpectStatus: 200,
+			ExpectType:   "text/plain",
+			Expect:       []string{`subgraph g1["east"]`, `n1["router-east"]`, `n1 -->|"east-to-west"| n0`},
+		}, {
+			Parameters:   map[string][]string{"view": {"process"}, "format": {"graphml"}},
+			ExpectStatus: 200,
+			ExpectType:   "application/graphml+xml",
+			Expect:       []string{`<node id="proc-a">`, `<edge source="proc-a" target="proc-b">`},
+		}, {
+			Parameters:   map[string][]string{"view": {"services"}},
+			ExpectStatus: 400,
+		}, {
+			Parameters:   map[string][]string{"format": {"svg"}},
+			ExpectStatus: 400,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run("", func(t *testing.T) {
+			resp, err := c.TopologyWithResponse(context.TODO(), withParameters(tc.Parameters))
+			assert.Assert(t, err)
+			assert.Equal(t, resp.StatusCode(), tc.ExpectStatus)
+			if tc.ExpectStatus != 200 {
+				assert.Assert(t, resp.JSON400 != nil)
+				return
+			}
+			assert.Assert(t, strings.HasPrefix(resp.HTTPResponse.Header.Get("Content-Type"), tc.ExpectType))
+			body := string(resp.Body)
+			for _, expected := range tc.Expect {
+				assert.Assert(t, strings.Contains(body, expected), "expected %q in:\n%s", expected, body)
+			}
+		})
+	}
+}
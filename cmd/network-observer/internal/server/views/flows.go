@@ -63,6 +63,7 @@ func NewConnectionsProvider(stor store.Interface) func(collector.ConnectionRecor
 		}
 		out.TraceRouters, out.TraceSites = traceProvider(conn.SourceSite.Name, conn.SourceRouter.Name, conn.DestSite.Name, conn.DestRouter.Name, trace)
 
+		out.Network = conn.Network
 		out.Protocol = conn.Protocol
 		out.RoutingKey = conn.RoutingKey
 		out.SourceProcessId = conn.Source.ID
@@ -216,6 +217,7 @@ func NewRequestProvider(stor store.Interface) func(collector.RequestRecord) (api
 		}
 		out.TraceRouters, out.TraceSites = traceProvider(request.SourceSite.Name, request.SourceRouter.Name, request.DestSite.Name, request.DestRouter.Name, trace)
 		out.ConnectionId = request.TransportID
+		out.Network = request.Network
 		out.Protocol = request.Protocol
 		out.RoutingKey = request.RoutingKey
 		out.SourceProcessId = request.Source.ID
@@ -36,6 +36,7 @@ func NewSitePairProvider(graph collector.Graph) func(collector.SitePairRecord) a
 		out.SourceId = record.Source
 		out.DestinationId = record.Dest
 		out.Protocol = record.Protocol
+		out.Network = &record.Network
 
 		if proc, ok := graph.Site(record.Source).GetRecord(); ok {
 			setOpt(&out.SourceName, proc.Name)
@@ -68,6 +69,7 @@ func NewProcessPairProvider(graph collector.Graph) func(collector.ProcPairRecord
 		out.SourceId = record.Source
 		out.DestinationId = record.Dest
 		out.Protocol = record.Protocol
+		out.Network = &record.Network
 
 		if proc, ok := graph.Process(record.Source).GetRecord(); ok {
 			setOpt(&out.SourceName, proc.Name)
@@ -150,8 +152,10 @@ func NewListenerProvider(graph collector.Graph) func(vanflow.ListenerRecord) api
 		if addressID := node.Address().ID(); addressID != "" {
 			out.ServiceId = &addressID
 		}
-		if site, ok := node.Parent().Parent().GetRecord(); ok {
+		siteNode := node.Parent().Parent()
+		if site, ok := siteNode.GetRecord(); ok {
 			out.SiteId = site.ID
+			out.Network = siteNode.Network()
 			setOpt(&out.SiteName, site.Name)
 		}
 		return out
@@ -207,8 +211,10 @@ func NewConnectorProvider(graph collector.Graph) func(vanflow.ConnectorRecord) a
 			out.Target = proc.Name
 			out.ProcessId = proc.ID
 		}
-		if site, ok := node.Parent().Parent().GetRecord(); ok {
+		siteNode := node.Parent().Parent()
+		if site, ok := siteNode.GetRecord(); ok {
 			out.SiteId = site.ID
+			out.Network = siteNode.Network()
 			setOpt(&out.SiteName, site.Name)
 		}
 		return out
@@ -301,10 +307,12 @@ func NewProcessProvider(stor store.Interface, graph collector.Graph) func(vanflo
 		if record.Parent == nil {
 			return out, false
 		}
-		site, ok := graph.Site(*record.Parent).GetRecord()
+		siteNode := graph.Site(*record.Parent)
+		site, ok := siteNode.GetRecord()
 		if !ok {
 			return out, false
 		}
+		out.Network = siteNode.Network()
 		setOpt(&out.SiteName, site.Name)
 		out.StartTime, out.EndTime = vanflowTimes(record.BaseRecord)
 		out.ImageName = record.ImageName
@@ -379,27 +387,35 @@ func defaultProcess(id string) api.ProcessRecord {
 		Role:          api.External,
 	}
 }
-func RouterAccessList(entries []store.Entry) []api.RouterAccessRecord {
-	results := make([]api.RouterAccessRecord, 0, len(entries))
-	for _, e := range entries {
-		record, ok := e.Record.(vanflow.RouterAccessRecord)
-		if !ok {
-			continue
+func NewRouterAccessSliceProvider(graph collector.Graph) func(entries []store.Entry) []api.RouterAccessRecord {
+	provider := NewRouterAccessProvider(graph)
+	return func(entries []store.Entry) []api.RouterAccessRecord {
+		results := make([]api.RouterAccessRecord, 0, len(entries))
+		for _, e := range entries {
+			record, ok := e.Record.(vanflow.RouterAccessRecord)
+			if !ok {
+				continue
+			}
+			results = append(results, provider(record))
 		}
-		results = append(results, RouterAccess(record))
+		return results
 	}
-	return results
 }
 
-func RouterAccess(record vanflow.RouterAccessRecord) api.RouterAccessRecord {
-	out := defaultRouterAccess(record.ID)
-	out.StartTime, out.EndTime = vanflowTimes(record.BaseRecord)
-	setOpt(&out.RouterId, record.Parent)
-	setOpt(&out.Name, record.Name)
-	setOpt(&out.Role, record.Role)
-	setOpt(&out.LinkCount, record.LinkCount)
-
-	return out
+func NewRouterAccessProvider(graph collector.Graph) func(vanflow.RouterAccessRecord) api.RouterAccessRecord {
+	return func(record vanflow.RouterAccessRecord) api.RouterAccessRecord {
+		out := defaultRouterAccess(record.ID)
+		out.StartTime, out.EndTime = vanflowTimes(record.BaseRecord)
+		setOpt(&out.RouterId, record.Parent)
+		setOpt(&out.Name, record.Name)
+		setOpt(&out.Role, record.Role)
+		setOpt(&out.LinkCount, record.LinkCount)
+		if site, ok := graph.RouterAccess(record.ID).Parent().Parent().GetRecord(); ok {
+			out.SiteId = site.ID
+			setOpt(&out.SiteName, site.Name)
+		}
+		return out
+	}
 }
 
 func defaultRouterAccess(id string) api.RouterAccessRecord {
@@ -408,6 +424,8 @@ func defaultRouterAccess(id string) api.RouterAccessRecord {
 		Name:     unknownStr,
 		Role:     unknownStr,
 		RouterId: unknownStr,
+		SiteId:   unknownStr,
+		SiteName: unknownStr,
 	}
 }
 func NewRotuerLinkSliceProvider(graph collector.Graph) func(entries []store.Entry) []api.RouterLinkRecord {
@@ -447,6 +465,7 @@ func NewRouterLinkProvider(graph collector.Graph) func(vanflow.LinkRecord) (api.
 
 		out.RouterId = sourceRouter.ID
 		out.SourceSiteId = sourceSite.ID
+		out.Network = siteNode.Network()
 		setOpt(&out.RouterName, sourceRouter.Name)
 		setOpt(&out.SourceSiteName, sourceSite.Name)
 		setOpt(&out.Name, link.Name)
@@ -548,7 +567,7 @@ func NewServiceProvider(stor store.Interface, graph collector.Graph) func(collec
 		connectorCt := len(node.Connectors())
 
 		protocols := make([]string, 0, 2)
-		for proto := range addressAppProtocols[record.Name] {
+		for proto := range addressAppProtocols[collector.NetworkScoped(record.Network, record.Name)] {
 			protocols = append(protocols, proto)
 		}
 		return api.ServiceRecord{
@@ -557,6 +576,7 @@ func NewServiceProvider(stor store.Interface, graph collector.Graph) func(collec
 			Protocol:                     record.Protocol,
 			ObservedApplicationProtocols: protocols,
 			Name:                         record.Name,
+			Network:                      record.Network,
 			ListenerCount:                listenerCt,
 			HasListener:                  listenerCt > 0,
 			ConnectorCount:               connectorCt,
@@ -565,30 +585,37 @@ func NewServiceProvider(stor store.Interface, graph collector.Graph) func(collec
 	}
 }
 
-func Routers(entries []store.Entry) []api.RouterRecord {
-	results := make([]api.RouterRecord, 0, len(entries))
-	for _, e := range entries {
-		record, ok := e.Record.(vanflow.RouterRecord)
-		if !ok {
-			continue
+func NewRouterSliceProvider(graph collector.Graph) func(entries []store.Entry) []api.RouterRecord {
+	provider := NewRouterProvider(graph)
+	return func(entries []store.Entry) []api.RouterRecord {
+		results := make([]api.RouterRecord, 0, len(entries))
+		for _, e := range entries {
+			record, ok := e.Record.(vanflow.RouterRecord)
+			if !ok {
+				continue
+			}
+			results = append(results, provider(record))
 		}
-		results = append(results, Router(record))
+		return results
 	}
-	return results
 }
 
-func Router(record vanflow.RouterRecord) api.RouterRecord {
-	out := defaultRouter(record.ID)
-	out.StartTime, out.EndTime = vanflowTimes(record.BaseRecord)
-	out.Namespace = record.Namespace
-	setOpt(&out.SiteId, record.Parent)
-	setOpt(&out.HostName, record.Hostname)
-	setOpt(&out.ImageName, record.ImageName)
-	setOpt(&out.ImageVersion, record.ImageVersion)
-	setOpt(&out.Mode, record.Mode)
-	setOpt(&out.Name, record.Name)
-
-	return out
+func NewRouterProvider(graph collector.Graph) func(vanflow.RouterRecord) api.RouterRecord {
+	return func(record vanflow.RouterRecord) api.RouterRecord {
+		out := defaultRouter(record.ID)
+		out.StartTime, out.EndTime = vanflowTimes(record.BaseRecord)
+		out.Namespace = record.Namespace
+		setOpt(&out.SiteId, record.Parent)
+		setOpt(&out.HostName, record.Hostname)
+		setOpt(&out.ImageName, record.ImageName)
+		setOpt(&out.ImageVersion, record.ImageVersion)
+		setOpt(&out.Mode, record.Mode)
+		setOpt(&out.Name, record.Name)
+		if record.Parent != nil {
+			out.Network = graph.Site(*record.Parent).Network()
+		}
+		return out
+	}
 }
 
 func defaultRouter(id string) api.RouterRecord {
@@ -640,7 +667,9 @@ func NewSiteProvider(graph collector.Graph) func(vanflow.SiteRecord) api.SiteRec
 				s.Platform = api.SitePlatformTypeLinux
 			}
 		}
-		s.RouterCount = len(graph.Site(site.ID).Routers())
+		siteNode := graph.Site(site.ID)
+		s.RouterCount = len(siteNode.Routers())
+		s.Network = siteNode.Network()
 		return s
 	}
 }
@@ -0,0 +1,297 @@
+// Package topology renders simple directed graphs of the application network
+// in formats understood by common diagramming tools.
+package topology
+
+import (
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Format of a rendered Graph
+type Format string
+
+const (
+	// FormatDOT is the Graphviz DOT language
+	FormatDOT Format = "dot"
+	// FormatGraphML is the XML based GraphML format
+	FormatGraphML Format = "graphml"
+	// FormatMermaid is a Mermaid flowchart
+	FormatMermaid Format = "mermaid"
+)
+
+// ContentType returns the media type of the rendered format
+func (f Format) ContentType() string {
+	switch f {
+	case FormatDOT:
+		return "text/vnd.graphviz; charset=utf-8"
+	case FormatGraphML:
+		return "application/graphml+xml; charset=utf-8"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
+
+// Group is a cluster of nodes, for example the routers or processes in a site
+type Group struct {
+	ID    string
+	Label string
+}
+
+// Node is a vertex in the graph. Nodes with a Group are drawn within it.
+type Node struct {
+	ID    string
+	Label string
+	Kind  string
+	Group string
+}
+
+// Edge is a directed edge between two nodes
+type Edge struct {
+	Source string
+	Target string
+	Label  string
+}
+
+// Graph is a directed graph with optional node groupings
+type Graph struct {
+	Name   string
+	Groups []Group
+	Nodes  []Node
+	Edges  []Edge
+}
+
+// Render writes the graph to out in the given format. Output is
+// deterministic: groups, nodes and edges are written in a sorted order. Edges
+// between nodes not in the graph are omitted.
+func Render(out io.Writer, g Graph, format Format) error {
+	g = g.normalized()
+	switch format {
+	case FormatDOT:
+		return writeDOT(out, g)
+	case FormatGraphML:
+		return writeGraphML(out, g)
+	case FormatMermaid:
+		return writeMermaid(out, g)
+	default:
+		return fmt.Errorf("unsupported topology format %q", format)
+	}
+}
+
+func (g Graph) normalized() Graph {
+	out := Graph{
+		Name:   g.Name,
+		Groups: slices.Clone(g.Groups),
+		Nodes:  slices.Clone(g.Nodes),
+	}
+	known := make(map[string]bool, len(g.Nodes))
+	for _, n := range g.Nodes {
+		known[n.ID] = true
+	}
+	for _, e := range g.Edges {
+		if known[e.Source] && known[e.Target] {
+			out.Edges = append(out.Edges, e)
+		}
+	}
+	slices.SortFunc(out.Groups, func(a, b Group) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	slices.SortFunc(out.Nodes, func(a, b Node) int {
+		return cmp.Or(cmp.Compare(a.Group, b.Group), cmp.Compare(a.ID, b.ID))
+	})
+	slices.SortFunc(out.Edges, func(a, b Edge) int {
+		return cmp.Or(
+			cmp.Compare(a.Source, b.Source),
+			cmp.Compare(a.Target, b.Target),
+			cmp.Compare(a.Label, b.Label),
+		)
+	})
+	return out
+}
+
+func (g Graph) nodesByGroup() map[string][]Node {
+	out := make(map[string][]Node)
+	for _, n := range g.Nodes {
+		out[n.Group] = append(out[n.Group], n)
+	}
+	return out
+}
+
+func writeDOT(out io.Writer, g Graph) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote(g.Name))
+	b.WriteString("  node [shape=box];\n")
+	byGroup := g.nodesByGroup()
+	writeNode := func(indent string, n Node) {
+		fmt.Fprintf(&b, "%s%s [label=%s", indent, dotQuote(n.ID), dotQuote(n.Label))
+		if n.Kind != "" {
+			fmt.Fprintf(&b, ", class=%s", dotQuote(n.Kind))
+		}
+		b.WriteString("];\n")
+	}
+	for i, group := range g.Groups {
+		fmt.Fprintf(&b, "  subgraph %s {\n", dotQuote(fmt.Sprintf("cluster_%d", i)))
+		fmt.Fprintf(&b, "    label=%s;\n", dotQuote(group.Label))
+		for _, n := range byGroup[group.ID] {
+			writeNode("    ", n)
+		}
+		b.WriteString("  }\n")
+		delete(byGroup, group.ID)
+	}
+	for _, n := range g.Nodes {
+		if _, ungrouped := byGroup[n.Group]; ungrouped {
+			writeNode("  ", n)
+		}
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "  %s -> %s", dotQuote(e.Source), dotQuote(e.Target))
+		if e.Label != "" {
+			fmt.Fprintf(&b, " [label=%s]", dotQuote(e.Label))
+		}
+		b.WriteString(";\n")
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+func dotQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
+
+func writeMermaid(out io.Writer, g Graph) error {
+	// mermaid identifiers are restricted so nodes are referred to by their
+	// position and labelled with their name.
+	ids := make(map[string]string, len(g.Nodes))
+	for i, n := range g.Nodes {
+		ids[n.ID] = fmt.Sprintf("n%d", i)
+	}
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	byGroup := g.nodesByGroup()
+	writeNode := func(indent string, n Node) {
+		fmt.Fprintf(&b, "%s%s[%s]\n", indent, ids[n.ID], mermaidQuote(n.Label))
+	}
+	for i, group := range g.Groups {
+		fmt.Fprintf(&b, "  subgraph g%d[%s]\n", i, mermaidQuote(group.Label))
+		for _, n := range byGroup[group.ID] {
+			writeNode("    ", n)
+		}
+		b.WriteString("  end\n")
+		delete(byGroup, group.ID)
+	}
+	for _, n := range g.Nodes {
+		if _, ungrouped := byGroup[n.Group]; ungrouped {
+			writeNode("  ", n)
+		}
+	}
+	for _, e := range g.Edges {
+		source, target := ids[e.Source], ids[e.Target]
+		if e.Label != "" {
+			fmt.Fprintf(&b, "  %s -->|%s| %s\n", source, mermaidQuote(e.Label), target)
+		} else {
+			fmt.Fprintf(&b, "  %s --> %s\n", source, target)
+		}
+	}
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID    string        `xml:"id,attr"`
+	Data  []graphMLData `xml:"data"`
+	Graph *graphMLGraph `xml:"graph,omitempty"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+func writeGraphML(out io.Writer, g Graph) error {
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "label", For: "all", AttrName: "label", AttrType: "string"},
+			{ID: "kind", For: "node", AttrName: "kind", AttrType: "string"},
+		},
+		Graph: graphMLGraph{ID: g.Name, EdgeDefault: "directed"},
+	}
+	toNode := func(n Node) graphMLNode {
+		data := []graphMLData{{Key: "label", Value: n.Label}}
+		if n.Kind != "" {
+			data = append(data, graphMLData{Key: "kind", Value: n.Kind})
+		}
+		return graphMLNode{ID: n.ID, Data: data}
+	}
+	byGroup := g.nodesByGroup()
+	for _, group := range g.Groups {
+		nested := &graphMLGraph{ID: group.ID + "::", EdgeDefault: "directed"}
+		for _, n := range byGroup[group.ID] {
+			nested.Nodes = append(nested.Nodes, toNode(n))
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID:    group.ID,
+			Data:  []graphMLData{{Key: "label", Value: group.Label}, {Key: "kind", Value: "group"}},
+			Graph: nested,
+		})
+		delete(byGroup, group.ID)
+	}
+	for _, n := range g.Nodes {
+		if _, ungrouped := byGroup[n.Group]; ungrouped {
+			doc.Graph.Nodes = append(doc.Graph.Nodes, toNode(n))
+		}
+	}
+	for _, e := range g.Edges {
+		edge := graphMLEdge{Source: e.Source, Target: e.Target}
+		if e.Label != "" {
+			edge.Data = []graphMLData{{Key: "label", Value: e.Label}}
+		}
+		doc.Graph.Edges = append(doc.Graph.Edges, edge)
+	}
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(out)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(out, "\n")
+	return err
+}
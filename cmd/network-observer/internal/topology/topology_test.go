@@ -0,0 +1,78 @@
+package topology
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+var testGraph = Graph{
+	Name:   "routers",
+	Groups: []Group{{ID: "site-b", Label: "east"}, {ID: "site-a", Label: "west"}},
+	Nodes: []Node{
+		{ID: "router-b", Label: "east-router", Kind: "router", Group: "site-b"},
+		{ID: "router-a", Label: `west "main"`, Kind: "router", Group: "site-a"},
+		{ID: "orphan", Label: "orphan", Kind: "router"},
+	},
+	Edges: []Edge{
+		{Source: "router-a", Target: "router-b", Label: "link-1"},
+		{Source: "router-a", Target: "router-unknown", Label: "link-2"},
+	},
+}
+
+func TestRenderDOT(t *testing.T) {
+	var out strings.Builder
+	assert.Assert(t, Render(&out, testGraph, FormatDOT))
+	assert.Equal(t, out.String(), `digraph "routers" {
+  node [shape=box];
+  subgraph "cluster_0" {
+    label="west";
+    "router-a" [label="west \"main\"", class="router"];
+  }
+  subgraph "cluster_1" {
+    label="east";
+    "router-b" [label="east-router", class="router"];
+  }
+  "orphan" [label="orphan", class="router"];
+  "router-a" -> "router-b" [label="link-1"];
+}
+`)
+}
+
+func TestRenderMermaid(t *testing.T) {
+	var out strings.Builder
+	assert.Assert(t, Render(&out, testGraph, FormatMermaid))
+	assert.Equal(t, out.String(), `flowchart LR
+  subgraph g0["west"]
+    n1["west #quot;main#quot;"]
+  end
+  subgraph g1["east"]
+    n2["east-router"]
+  end
+  n0["orphan"]
+  n1 -->|"link-1"| n2
+`)
+}
+
+func TestRenderGraphML(t *testing.T) {
+	var out strings.Builder
+	assert.Assert(t, Render(&out, testGraph, FormatGraphML))
+	var doc graphML
+	assert.Assert(t, xml.Unmarshal([]byte(out.String()), &doc))
+	assert.Equal(t, doc.Graph.EdgeDefault, "directed")
+	assert.Equal(t, len(doc.Graph.Nodes), 3)
+	assert.Equal(t, doc.Graph.Nodes[0].ID, "site-a")
+	assert.Equal(t, doc.Graph.Nodes[0].Graph.Nodes[0].ID, "router-a")
+	assert.Equal(t, doc.Graph.Nodes[0].Graph.Nodes[0].Data[0].Value, `west "main"`)
+	assert.Equal(t, doc.Graph.Nodes[2].ID, "orphan")
+	assert.Equal(t, len(doc.Graph.Edges), 1)
+	assert.Equal(t, doc.Graph.Edges[0].Source, "router-a")
+	assert.Equal(t, doc.Graph.Edges[0].Target, "router-b")
+}
+
+func TestRenderUnsupported(t *testing.T) {
+	var out strings.Builder
+	assert.ErrorContains(t, Render(&out, testGraph, Format("svg")), "unsupported")
+}
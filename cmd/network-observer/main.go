@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"flag"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	_ "net/http/pprof"
 	"os"
 	"os/signal"
+	"strings"
 	"time"
 
 	"github.com/gorilla/handlers"
@@ -17,13 +19,17 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/alerts"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/cmd"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/flowlog"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/otlp"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/server"
 	"github.com/skupperproject/skupper/internal/version"
 	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/capture"
 	"github.com/skupperproject/skupper/pkg/vanflow/session"
 )
 
@@ -42,9 +48,32 @@ func run(cfg Config) error {
 		return fmt.Errorf("could not load spec filesystem: %s", err)
 	}
 
-	sessionConfig, err := configureSession(cfg.RouterTLS)
+	networkSpecs, err := cfg.networks()
 	if err != nil {
-		return fmt.Errorf("failed to load router tls configuration: %s", err)
+		return fmt.Errorf("failed to load networks: %s", err)
+	}
+	networks := make([]collector.Network, 0, len(networkSpecs))
+	var replayFactory session.ContainerFactory
+	if cfg.ReplayFile != "" {
+		// observe a capture in place of a live network
+		replayFactory = session.NewMockContainerFactory()
+		networks = append(networks, collector.Network{Name: cfg.NetworkName, Factory: replayFactory})
+		networkSpecs = nil
+	}
+	for _, spec := range networkSpecs {
+		sessionConfig, err := configureSession(spec.RouterTLS)
+		if err != nil {
+			return fmt.Errorf("failed to load router tls configuration for network %q: %s", spec.Name, err)
+		}
+		addresses := spec.routerAddresses()
+		if len(addresses) == 0 {
+			return fmt.Errorf("no router endpoint configured for network %q", spec.Name)
+		}
+		sessionConfig.FailoverAddresses = addresses[1:]
+		networks = append(networks, collector.Network{
+			Name:    spec.Name,
+			Factory: session.NewContainerFactory(addresses[0], sessionConfig),
+		})
 	}
 
 	flowLogger := func(vanflow.RecordMessage) {}
@@ -61,23 +90,149 @@ func run(cfg Config) error {
 		return fmt.Errorf("unknown logging profile: %s", cfg.VanflowLoggingProfile)
 	}
 
-	collector := collector.New(
+	var (
+		recordStore collector.StoreFactory
+		retention   time.Duration
+	)
+	switch cfg.RecordStore {
+	case "memory":
+		recordStore = collector.MemoryStore
+		retention = cfg.FlowRecordTTL
+	case "file":
+		recordStore = collector.FileStore(cfg.RecordStorePath, cfg.RecordStoreMaxAge, cfg.RecordStoreMaxSize)
+		retention = cfg.RecordStoreMaxAge
+	default:
+		return fmt.Errorf("unknown record store: %s", cfg.RecordStore)
+	}
+
+	backpressure := collector.Backpressure{
+		SourceQueueSize: cfg.SourceQueueSize,
+		ShedFlows:       cfg.ShedFlows,
+		EventQueueSize:  cfg.EventQueueSize,
+	}
+	snapshots := collector.Snapshots{
+		Path:        cfg.SnapshotPath,
+		Interval:    cfg.SnapshotInterval,
+		GracePeriod: cfg.SnapshotGracePeriod,
+	}
+	collector, err := collector.New(
 		logger.With(slog.String("component", "collector")),
-		session.NewContainerFactory(cfg.RouterURL, sessionConfig),
+		networks,
 		reg,
 		cfg.FlowRecordTTL,
 		flowLogger,
+		recordStore,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create collector: %s", err)
+	}
+	collector.ConfigureBackpressure(backpressure)
+	if snapshots.Path != "" {
+		collector.ConfigureSnapshots(snapshots)
+	}
+
+	var flowExporter *flowlog.Exporter
+	if cfg.FlowExportPath != "" {
+		if cfg.FlowExportSample <= 0 || cfg.FlowExportSample > 1 {
+			return fmt.Errorf("flow export sample must be in the range (0, 1]: %v", cfg.FlowExportSample)
+		}
+		sink, err := flowlog.NewRotatingFileSink(flowlog.RotatingFileConfig{
+			Path:     cfg.FlowExportPath,
+			Format:   flowlog.Format(cfg.FlowExportFormat),
+			MaxSize:  cfg.FlowExportMaxSize,
+			MaxFiles: cfg.FlowExportMaxFiles,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create flow export sink: %s", err)
+		}
+		flowExporter = flowlog.NewExporter(logger.With(slog.String("component", "flow_export")), sink, flowExportRules(cfg.FlowExportSample))
+		collector.OnFlowCompleted(flowExporter.Export)
+	}
+
+	if cfg.OTLPEndpoint != "" {
+		exporter, err := otlp.New(ctx, otlp.Config{
+			Endpoint:       cfg.OTLPEndpoint,
+			Protocol:       cfg.OTLPProtocol,
+			Insecure:       cfg.OTLPInsecure,
+			MetricInterval: cfg.OTLPMetricInterval,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create otlp exporter: %s", err)
+		}
+		defer func() {
+			sCtx, sCancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer sCancel()
+			if err := exporter.Shutdown(sCtx); err != nil {
+				logger.Error("error shutting down otlp exporter", slog.Any("error", err))
+			}
+		}()
+		collector.OnFlowCompleted(exporter.OnFlowCompleted)
+	}
+
+	var (
+		alertEngine *alerts.Engine
+		serverOpts  = []server.Option{server.WithHistoryRetention(retention)}
 	)
+	if cfg.AlertRules != "" {
+		rules, err := alerts.LoadRules(cfg.AlertRules)
+		if err != nil {
+			return fmt.Errorf("failed to load alert rules: %s", err)
+		}
+		alertCfg := alerts.Config{
+			Rules:    rules,
+			Interval: cfg.AlertInterval,
+		}
+		if cfg.AlertWebhookURL != "" {
+			alertCfg.Notifier = alerts.NewWebhookNotifier(cfg.AlertWebhookURL)
+		}
+		alertEngine = alerts.New(logger.With(slog.String("component", "alerts")), collector.Records, collector.GetGraph(), alertCfg)
+		collector.OnFlowCompleted(alertEngine.OnFlowCompleted)
+		serverOpts = append(serverOpts, server.WithAlerts(alertEngine))
+	}
 
+	serverOpts = append(serverOpts, server.WithEventStream(server.NewEventStream(
+		logger.With(slog.String("component", "api")),
+		collector.Records,
+		collector.GetGraph(),
+		collector.Subscribe,
+	)))
 	collectorAPI := server.New(
 		logger.With(slog.String("component", "api")),
 		collector.Records,
 		collector.GetGraph(),
+		serverOpts...,
 	)
 
 	var mux = mux.NewRouter().StrictSlash(true)
+	var authConfig *auth.Config
+	metrics := handleMetrics(reg)
+	if cfg.AuthConfig != "" {
+		loaded, err := auth.LoadConfig(cfg.AuthConfig)
+		if err != nil {
+			return fmt.Errorf("failed to load auth config: %s", err)
+		}
+		authenticators, err := loaded.Authenticators()
+		if err != nil {
+			return fmt.Errorf("failed to configure api authentication: %s", err)
+		}
+		authConfig = &loaded
+		authenticate := auth.Middleware(logger.With(slog.String("component", "auth")), authenticators...)
+		// metrics cannot be scoped to a role's sites or routing keys
+		metrics = authenticate(auth.RequireUnrestricted(metrics))
+		// the console assets and swagger docs stay public
+		mux.Use(func(next http.Handler) http.Handler {
+			authenticated := authenticate(next)
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasPrefix(r.URL.Path, "/api/") {
+					authenticated.ServeHTTP(w, r)
+					return
+				}
+				next.ServeHTTP(w, r)
+			})
+		})
+	}
 	promSubrouter := mux.PathPrefix("/api/v2alpha1/internal/prom")
-	mux.Handle("/metrics", handleMetrics(reg))
+	mux.Handle("/metrics", metrics)
 	mux.PathPrefix("/swagger").Handler(handleSwagger("/swagger", specFS))
 	apiMux := mux.PathPrefix("/").Subrouter()
 	if cfg.CORSAllowAll {
@@ -95,7 +250,7 @@ func run(cfg Config) error {
 		// add unspec'd api routes
 		apiMux.Path("/api/v2alpha1/user").Handler(handleGetUser())
 		apiMux.Path("/api/v2alpha1/logout").Handler(handleUserLogout())
-		promSubrouter.Handler(handleProxyPrometheusAPI("/api/v2alpha1/internal/prom", promAPI))
+		promSubrouter.Handler(auth.RequireUnrestricted(handleProxyPrometheusAPI("/api/v2alpha1/internal/prom", promAPI)))
 
 		apiMux.PathPrefix("/").Handler(handleSecuredConsoleAssets(cfg.ConsoleLocation))
 	}
@@ -119,12 +274,23 @@ func run(cfg Config) error {
 			return fmt.Errorf("could not set up certs for api server: %s", err)
 		}
 	}
+	if authConfig != nil && authConfig.ClientCertificates != nil {
+		if !tlsEnabled {
+			return fmt.Errorf("client certificate authentication requires tls-cert and tls-key")
+		}
+		s.TLSConfig.ClientCAs, err = authConfig.ClientCAs()
+		if err != nil {
+			return fmt.Errorf("could not set up client certificate authentication: %s", err)
+		}
+		s.TLSConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
 
 	g, runCtx := errgroup.WithContext(ctx)
 	g.Go(func() error {
 		logger.Info("Starting Network Console API Server",
 			slog.String("address", cfg.APIListenAddress),
 			slog.Bool("tls", tlsEnabled),
+			slog.Bool("auth", authConfig != nil),
 			slog.Bool("console", cfg.EnableConsole))
 		var err error
 		if tlsEnabled {
@@ -149,6 +315,32 @@ func run(cfg Config) error {
 		return nil
 	})
 
+	if replayFactory != nil {
+		replayFile, err := os.Open(cfg.ReplayFile)
+		if err != nil {
+			return fmt.Errorf("failed to open replay file: %s", err)
+		}
+		defer replayFile.Close()
+		replayer := capture.NewReplayer(replayFactory.Create(), capture.NewReader(replayFile), capture.ReplayOptions{
+			Speed:       cfg.ReplaySpeed,
+			SendTimeout: 5 * time.Second,
+			Hold:        true,
+		})
+		g.Go(func() error {
+			logger.Info("Replaying vanflow capture",
+				slog.String("file", cfg.ReplayFile),
+				slog.Float64("speed", cfg.ReplaySpeed))
+			stats, err := replayer.Run(runCtx)
+			if err != nil && !errors.Is(err, runCtx.Err()) {
+				return fmt.Errorf("error replaying capture: %s", err)
+			}
+			logger.Info("Vanflow capture replay complete",
+				slog.Int("sent", stats.Sent),
+				slog.Int("skipped", stats.Skipped))
+			return nil
+		})
+	}
+
 	if cfg.EnableProfile {
 		// serve only over localhost loopback
 		const pprofAddr = "localhost:9970"
@@ -181,7 +373,7 @@ func run(cfg Config) error {
 	// serve metrics on a separate server if listen-metrics is set
 	if cfg.MetricsListenAddress != "" {
 		metricsMux := http.NewServeMux()
-		metricsMux.Handle("/metrics", handleMetrics(reg))
+		metricsMux.Handle("/metrics", metrics)
 		metricsSrv := &http.Server{
 			Addr:         cfg.MetricsListenAddress,
 			Handler:      metricsMux,
@@ -211,6 +403,24 @@ func run(cfg Config) error {
 		})
 	}
 
+	if flowExporter != nil {
+		g.Go(func() error {
+			if err := flowExporter.Run(runCtx); err != nil {
+				return fmt.Errorf("flow export error: %w", err)
+			}
+			return nil
+		})
+	}
+
+	if alertEngine != nil {
+		g.Go(func() error {
+			if err := alertEngine.Run(runCtx); err != nil {
+				return fmt.Errorf("alerting error: %w", err)
+			}
+			return nil
+		})
+	}
+
 	g.Go(func() error {
 		logger.Debug("Starting Network Observer Collector")
 		if err := collector.Run(runCtx); err != nil {
@@ -231,27 +441,57 @@ func main() {
 	// if -version used, report and exit
 	isVersion := flags.Bool("version", false, "Report the version of skupper the Network Observer was built against")
 
-	flags.StringVar(&cfg.RouterURL, "router-endpoint", "amqps://skupper-router-local", "URL to the skupper router amqp(s) endpoint")
+	flags.StringVar(&cfg.RouterURL, "router-endpoint", "amqps://skupper-router-local", "URL to the skupper router amqp(s) endpoint. A comma separated list of the endpoints of an HA site's routers fails over between them")
 	flags.StringVar(&cfg.RouterTLS.Cert, "router-tls-cert", "", "Path to the client certificate for the router endpoint")
 	flags.StringVar(&cfg.RouterTLS.Key, "router-tls-key", "", "Path to the client key for the router endpoint")
 	flags.StringVar(&cfg.RouterTLS.CA, "router-tls-ca", "", "Path to the CA certificate file for the router endpoint")
 	flags.BoolVar(&cfg.RouterTLS.SkipVerify, "router-tls-insecure", false, "Set to skip verification of the router certificate and host name")
+	flags.StringVar(&cfg.NetworkName, "network-name", collector.DefaultNetwork, "Name of the application network reached through router-endpoint")
+	flags.StringVar(&cfg.ReplayFile, "replay", "", "Path to a vanflow capture file to observe in place of the router endpoints. Replayed sources are kept alive once the capture is exhausted")
+	flags.Float64Var(&cfg.ReplaySpeed, "replay-speed", 1, "Replay speed relative to the capture. 1 replays at the original speed, 10 ten times faster and 0 as fast as possible")
+	flags.StringVar(&cfg.NetworksFile, "networks", "", "Path to a YAML file listing the name, router endpoint and tls configuration of each application network to observe. Overrides the router-endpoint, router-tls and network-name flags when set")
 
 	flags.StringVar(&cfg.APIListenAddress, "listen", ":8080", "The address that the API Server will listen on")
 	flags.BoolVar(&cfg.APIEnableAccessLogs, "enable-access-logs", false, "Enable access logging for the API Server")
 	flags.StringVar(&cfg.APITLS.Cert, "tls-cert", "", "Path to the API Server certificate file")
 	flags.StringVar(&cfg.APITLS.Key, "tls-key", "", "Path to the API Server certificate key file matching tls-cert")
 
+	flags.StringVar(&cfg.AuthConfig, "auth-config", "", "Path to a YAML file configuring authentication of API requests and the roles granted to clients. The API is open when empty")
+
 	flags.BoolVar(&cfg.EnableConsole, "enable-console", true, "Enables the web console")
 	flags.StringVar(&cfg.ConsoleLocation, "console-location", "/app/console", "Location where the console assets are installed")
 	flags.StringVar(&cfg.PrometheusAPI, "prometheus-api", "http://127.0.0.1:9090", "Prometheus API HTTP endpoint for console")
 
 	flags.DurationVar(&cfg.FlowRecordTTL, "flow-record-ttl", 15*time.Minute, "How long to retain flow records in memory")
+	flags.IntVar(&cfg.SourceQueueSize, "source-queue-size", 256, "Number of vanflow record messages buffered for each event source while the collector is busy. Zero to process records as they are received")
+	flags.BoolVar(&cfg.ShedFlows, "shed-flows", false, "Set to drop flow records rather than wait when an event source queue is full. Topology records are never dropped")
+	flags.IntVar(&cfg.EventQueueSize, "event-queue-size", 1024, "Number of record changes queued in order for processing before changes to the same record are merged")
+	flags.StringVar(&cfg.RecordStore, "record-store", "memory", "Where to keep connection and request history. Options are memory and file")
+	flags.StringVar(&cfg.RecordStorePath, "record-store-path", "/var/lib/network-observer/records.jsonl", "Path to the record store file when record-store is file")
+	flags.DurationVar(&cfg.RecordStoreMaxAge, "record-store-max-age", 24*time.Hour, "How long persisted records are kept after their last update. Zero to disable")
+	flags.Int64Var(&cfg.RecordStoreMaxSize, "record-store-max-size", 256<<20, "Size in bytes the record store file may grow to before compaction evicts the oldest records. Zero to disable")
+	flags.StringVar(&cfg.SnapshotPath, "snapshot-path", "", "Path to periodically save a snapshot of the network topology to and warm start from on restart. Snapshots are disabled when empty")
+	flags.DurationVar(&cfg.SnapshotInterval, "snapshot-interval", time.Minute, "How often the topology snapshot is saved")
+	flags.DurationVar(&cfg.SnapshotGracePeriod, "snapshot-grace-period", 2*time.Minute, "How long event sources restored from a snapshot have to reconnect before their records are removed")
 	flags.BoolVar(&cfg.CORSAllowAll, "cors-allow-all", false, "Development option to allow all origins")
 	flags.BoolVar(&cfg.EnableProfile, "profile", false, "Exposes the runtime profiling facilities from net/http/pprof on http://localhost:9970")
 
 	flags.StringVar(&cfg.VanflowLoggingProfile, "vanflow-logging-profile", "silent", "Controls low level vanflow record logging. Options are silent, minimal, moderate and all")
 
+	flags.StringVar(&cfg.FlowExportPath, "flow-export-path", "", "Path to a file completed connections and requests are exported to. Export is disabled when empty")
+	flags.StringVar(&cfg.FlowExportFormat, "flow-export-format", "json", "Format of the flow export file. Options are json (one record per line) and csv")
+	flags.Int64Var(&cfg.FlowExportMaxSize, "flow-export-max-size", 100<<20, "Size in bytes the flow export file may grow to before it is rotated. Zero to disable rotation")
+	flags.IntVar(&cfg.FlowExportMaxFiles, "flow-export-max-files", 10, "Number of rotated flow export files to keep. Zero to keep all")
+	flags.Float64Var(&cfg.FlowExportSample, "flow-export-sample", 1.0, "Fraction of connections (and their requests) to export, in the range (0, 1]")
+
+	flags.StringVar(&cfg.OTLPEndpoint, "otlp-endpoint", "", "The host:port of an OpenTelemetry collector to export connection and request traces and metrics to. Export is disabled when empty")
+	flags.StringVar(&cfg.OTLPProtocol, "otlp-protocol", "grpc", "The OTLP protocol used to export to otlp-endpoint. Options are grpc and http")
+	flags.BoolVar(&cfg.OTLPInsecure, "otlp-insecure", false, "Set to export to otlp-endpoint without TLS")
+	flags.DurationVar(&cfg.OTLPMetricInterval, "otlp-metric-interval", time.Minute, "How often metrics are exported to otlp-endpoint")
+	flags.StringVar(&cfg.AlertRules, "alert-rules", "", "Path to a YAML file of alert rules to evaluate. Alerting is disabled when empty")
+	flags.StringVar(&cfg.AlertWebhookURL, "alert-webhook-url", "", "URL to POST firing and resolved alerts to as JSON")
+	flags.DurationVar(&cfg.AlertInterval, "alert-interval", 15*time.Second, "How often alert rules are evaluated")
+
 	flags.StringVar(&cfg.MetricsListenAddress, "listen-metrics", "", "The address that the Metrics Server will listen on.")
 
 	flags.Parse(os.Args[1:])
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/flowlog"
 	"github.com/skupperproject/skupper/pkg/vanflow"
 )
@@ -53,3 +54,18 @@ var (
 		},
 	}
 )
+
+// flowExportRules exports all completed connections and requests, or a
+// sample of them (by transport) when percent is less than one.
+func flowExportRules(percent float64) []flowlog.Rule {
+	strategy := flowlog.Unlimited()
+	if percent < 1.0 {
+		strategy = flowlog.TransportFlowHash(percent, nil)
+	}
+	return []flowlog.Rule{
+		{
+			Match:    flowlog.NewRecordTypeSet(collector.ConnectionRecord{}, collector.RequestRecord{}),
+			Strategy: strategy,
+		},
+	}
+}
@@ -0,0 +1,431 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/encoding"
+	"github.com/skupperproject/skupper/pkg/vanflow/eventsource"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// watchSource keeps the last seen time of a discovered source up to date
+// from its messages, forgetting it once it goes quiet.
+func watchSource(ctx context.Context, discovery *eventsource.Discovery, client *eventsource.Client, source eventsource.Info) {
+	err := discovery.NewWatchClient(ctx, eventsource.WatchConfig{
+		Client:      client,
+		ID:          source.ID,
+		Timeout:     time.Second * 30,
+		GracePeriod: time.Second * 30,
+	})
+	if err != nil {
+		slog.Error("error watching event source", slog.String("id", source.ID), slog.Any("error", err))
+	}
+}
+
+const sourcesDesc string = `Listens for event source beacons for the wait duration and then lists the
+sources that were discovered. When -watch is set, prints each source as it is
+discovered or forgotten until interrupted instead.`
+
+type sourcesOptions struct {
+	Wait   time.Duration
+	Watch  bool
+	Output string
+}
+
+func runSources(ctx context.Context, args []string) error {
+	var (
+		router routerFlags
+		opts   sourcesOptions
+	)
+	flags := flag.NewFlagSet(args[0], flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage of %s %s [options...]\n", os.Args[0], args[0])
+		fmt.Fprintln(flags.Output(), sourcesDesc)
+		flags.PrintDefaults()
+	}
+	router.register(flags)
+	flags.DurationVar(&opts.Wait, "wait", 15*time.Second, "How long to listen for event sources before listing them. Sources send a beacon every ten seconds")
+	flags.BoolVar(&opts.Watch, "watch", false, "Set to print sources as they are discovered or forgotten until interrupted")
+	flags.StringVar(&opts.Output, "output", outputText, "Output format. One of text or json")
+	flags.Parse(args[1:])
+	if opts.Output != outputText && opts.Output != outputJSON {
+		fmt.Fprintf(flags.Output(), "unsupported output format %q\n", opts.Output)
+		flags.Usage()
+		os.Exit(1)
+	}
+
+	factory, err := router.factory()
+	if err != nil {
+		return fmt.Errorf("error configuring router connection: %w", err)
+	}
+	return listSources(ctx, factory, opts, os.Stdout)
+}
+
+func listSources(ctx context.Context, factory session.ContainerFactory, opts sourcesOptions, out io.Writer) error {
+	if !opts.Watch {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Wait)
+		defer cancel()
+	}
+	container := factory.Create()
+	container.Start(ctx)
+	discovery := eventsource.NewDiscovery(container, eventsource.DiscoveryOptions{})
+
+	var mu sync.Mutex
+	handlers := eventsource.DiscoveryHandlers{
+		Discovered: func(source eventsource.Info) {
+			client := eventsource.NewClient(container, eventsource.ClientOptions{Source: source})
+			client.Listen(ctx, eventsource.FromSourceAddress())
+			if source.Type == "CONTROLLER" {
+				client.Listen(ctx, eventsource.FromSourceAddressHeartbeats())
+			}
+			watchSource(ctx, discovery, client, source)
+			if opts.Watch {
+				mu.Lock()
+				defer mu.Unlock()
+				printSourceEvent(out, opts.Output, "discovered", source)
+			}
+		},
+		Forgotten: func(source eventsource.Info) {
+			if opts.Watch {
+				mu.Lock()
+				defer mu.Unlock()
+				printSourceEvent(out, opts.Output, "forgotten", source)
+			}
+		},
+	}
+	err := discovery.Run(ctx, handlers)
+	if !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	if opts.Watch {
+		return nil
+	}
+	sources := discovery.List()
+	slices.SortFunc(sources, func(a, b eventsource.Info) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return printSources(out, opts.Output, sources, time.Now())
+}
+
+type sourceView struct {
+	Event    string    `json:"event,omitempty"`
+	ID       string    `json:"id"`
+	Type     string    `json:"type"`
+	Version  int       `json:"version"`
+	Address  string    `json:"address"`
+	Direct   string    `json:"direct"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+func asSourceView(source eventsource.Info) sourceView {
+	return sourceView{
+		ID:       source.ID,
+		Type:     source.Type,
+		Version:  source.Version,
+		Address:  source.Address,
+		Direct:   source.Direct,
+		LastSeen: source.LastSeen,
+	}
+}
+
+func printSources(out io.Writer, format string, sources []eventsource.Info, now time.Time) error {
+	if format == outputJSON {
+		views := make([]sourceView, 0, len(sources))
+		for _, source := range sources {
+			views = append(views, asSourceView(source))
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(views)
+	}
+	tw := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTYPE\tVERSION\tADDRESS\tDIRECT\tLAST SEEN")
+	for _, source := range sources {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%s ago\n",
+			source.ID, source.Type, source.Version, source.Address, source.Direct,
+			now.Sub(source.LastSeen).Round(time.Second))
+	}
+	return tw.Flush()
+}
+
+func printSourceEvent(out io.Writer, format string, event string, source eventsource.Info) {
+	if format == outputJSON {
+		view := asSourceView(source)
+		view.Event = event
+		json.NewEncoder(out).Encode(view)
+		return
+	}
+	fmt.Fprintf(out, "%s %s %s id=%s version=%d address=%s direct=%s\n",
+		time.Now().Format(time.RFC3339), event, source.Type,
+		source.ID, source.Version, source.Address, source.Direct)
+}
+
+const tailDesc string = `Prints the records sent by event sources as they arrive until interrupted.
+Records can be filtered by the source that sent them, by record type and by
+attribute value. Attributes are matched by their field name or codepoint, for
+example -attr SourceHost=10.0.0.1 or -attr 14=10.0.0.1.`
+
+type tailOptions struct {
+	Filter recordFilter
+	Flush  bool
+	Limit  int
+	Output string
+}
+
+// stringsFlag is a flag that can be repeated
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringsFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+func runTail(ctx context.Context, args []string) error {
+	var (
+		router     routerFlags
+		opts       tailOptions
+		sources    string
+		types      string
+		attributes stringsFlag
+	)
+	flags := flag.NewFlagSet(args[0], flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage of %s %s [options...]\n", os.Args[0], args[0])
+		fmt.Fprintln(flags.Output(), tailDesc)
+		flags.PrintDefaults()
+	}
+	router.register(flags)
+	flags.StringVar(&sources, "sources", "", "Comma separated list of event source IDs to print records from. Prints records from all sources when empty")
+	flags.StringVar(&types, "types", "", "Comma separated list of record types to print, such as TransportBiflowRecord or Listener. Prints all types when empty")
+	flags.Var(&attributes, "attr", "Only print records with an attribute set to a value given as name=value. Can be repeated to match several attributes")
+	flags.BoolVar(&opts.Flush, "flush", false, "Set to request that each source sends all of its records once discovered so that current records are printed and not only changes")
+	flags.IntVar(&opts.Limit, "limit", 0, "Stop after printing this many records. Prints records until interrupted when zero")
+	flags.StringVar(&opts.Output, "output", outputText, "Output format. One of text or json")
+	flags.Parse(args[1:])
+	if opts.Output != outputText && opts.Output != outputJSON {
+		fmt.Fprintf(flags.Output(), "unsupported output format %q\n", opts.Output)
+		flags.Usage()
+		os.Exit(1)
+	}
+	filter, err := newRecordFilter(sources, types, attributes)
+	if err != nil {
+		fmt.Fprintln(flags.Output(), err)
+		flags.Usage()
+		os.Exit(1)
+	}
+	opts.Filter = filter
+
+	factory, err := router.factory()
+	if err != nil {
+		return fmt.Errorf("error configuring router connection: %w", err)
+	}
+	return tailRecords(ctx, factory, opts, os.Stdout)
+}
+
+func tailRecords(ctx context.Context, factory session.ContainerFactory, opts tailOptions, out io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	container := factory.Create()
+	container.Start(ctx)
+	discovery := eventsource.NewDiscovery(container, eventsource.DiscoveryOptions{})
+	printer := &recordPrinter{out: out, format: opts.Output}
+
+	var printed atomic.Int64
+	handleRecords := func(source eventsource.Info) eventsource.RecordMessageHandler {
+		return func(msg vanflow.RecordMessage) {
+			for _, record := range msg.Records {
+				attributes, err := encoding.Attributes(record)
+				if err != nil {
+					slog.Error("error reading record attributes", slog.String("source", source.ID), slog.Any("error", err))
+					continue
+				}
+				if !opts.Filter.matchRecord(record, attributes) {
+					continue
+				}
+				n := printed.Add(1)
+				if opts.Limit > 0 && n > int64(opts.Limit) {
+					return
+				}
+				printer.print(source.ID, record, attributes)
+				if n == int64(opts.Limit) {
+					cancel()
+				}
+			}
+		}
+	}
+
+	err := discovery.Run(ctx, eventsource.DiscoveryHandlers{
+		Discovered: func(source eventsource.Info) {
+			if !opts.Filter.matchSource(source.ID) {
+				return
+			}
+			client := eventsource.NewClient(container, eventsource.ClientOptions{Source: source})
+			client.OnRecord(handleRecords(source))
+			client.Listen(ctx, eventsource.FromSourceAddress())
+			switch source.Type {
+			case "ROUTER":
+				client.Listen(ctx, eventsource.FromSourceAddressFlows())
+			case "CONTROLLER":
+				client.Listen(ctx, eventsource.FromSourceAddressHeartbeats())
+			}
+			watchSource(ctx, discovery, client, source)
+			if opts.Flush {
+				go flushSource(ctx, client, source)
+			}
+		},
+	})
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+func flushSource(ctx context.Context, client *eventsource.Client, source eventsource.Info) {
+	flushCtx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+	err := eventsource.FlushOnFirstMessage(flushCtx, client)
+	if errors.Is(err, flushCtx.Err()) && ctx.Err() == nil {
+		err = client.SendFlush(ctx)
+	}
+	if err != nil && ctx.Err() == nil {
+		slog.Error("error sending flush", slog.String("source", source.ID), slog.Any("error", err))
+	}
+}
+
+// recordFilter matches records by the source that sent them, their type
+// and the values of their attributes
+type recordFilter struct {
+	Sources    map[string]bool
+	Types      []string
+	Attributes []attributeMatch
+}
+
+type attributeMatch struct {
+	Name  string
+	Value string
+}
+
+func newRecordFilter(sources string, types string, attributes []string) (recordFilter, error) {
+	var filter recordFilter
+	if sources != "" {
+		filter.Sources = make(map[string]bool)
+		for _, id := range strings.Split(sources, ",") {
+			filter.Sources[strings.TrimSpace(id)] = true
+		}
+	}
+	if types != "" {
+		for _, typ := range strings.Split(types, ",") {
+			filter.Types = append(filter.Types, strings.TrimSpace(typ))
+		}
+	}
+	for _, attr := range attributes {
+		name, value, ok := strings.Cut(attr, "=")
+		if !ok || name == "" {
+			return filter, fmt.Errorf("invalid attribute filter %q: expected name=value", attr)
+		}
+		filter.Attributes = append(filter.Attributes, attributeMatch{Name: name, Value: value})
+	}
+	return filter, nil
+}
+
+func (f recordFilter) matchSource(id string) bool {
+	return len(f.Sources) == 0 || f.Sources[id]
+}
+
+func (f recordFilter) matchRecord(record vanflow.Record, attributes []encoding.Attribute) bool {
+	if len(f.Types) > 0 {
+		typ := record.GetTypeMeta().Type
+		if !slices.ContainsFunc(f.Types, func(t string) bool {
+			return strings.EqualFold(t, typ) || strings.EqualFold(t+"Record", typ)
+		}) {
+			return false
+		}
+	}
+	for _, match := range f.Attributes {
+		if !slices.ContainsFunc(attributes, match.matches) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m attributeMatch) matches(attr encoding.Attribute) bool {
+	if !strings.EqualFold(m.Name, attr.Name) && m.Name != strconv.FormatUint(uint64(attr.Codepoint), 10) {
+		return false
+	}
+	return formatAttribute(attr.Value) == m.Value
+}
+
+func formatAttribute(value any) string {
+	if t, ok := value.(vanflow.Time); ok {
+		return t.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(value)
+}
+
+// recordPrinter writes records to out as text or as json lines
+type recordPrinter struct {
+	mu     sync.Mutex
+	out    io.Writer
+	format string
+}
+
+type recordView struct {
+	Time       time.Time      `json:"time"`
+	Source     string         `json:"source"`
+	Type       string         `json:"type"`
+	Attributes map[string]any `json:"attributes"`
+}
+
+func (p *recordPrinter) print(source string, record vanflow.Record, attributes []encoding.Attribute) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	typ := record.GetTypeMeta().Type
+	if p.format == outputJSON {
+		view := recordView{
+			Time:       now,
+			Source:     source,
+			Type:       typ,
+			Attributes: make(map[string]any, len(attributes)),
+		}
+		for _, attr := range attributes {
+			view.Attributes[attr.Name] = attr.Value
+		}
+		if err := json.NewEncoder(p.out).Encode(view); err != nil {
+			slog.Error("error writing record", slog.Any("error", err))
+		}
+		return
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %s %s\n", now.Format(time.RFC3339Nano), source, typ)
+	for _, attr := range attributes {
+		fmt.Fprintf(&sb, "    %s(%d): %s\n", attr.Name, attr.Codepoint, formatAttribute(attr.Value))
+	}
+	io.WriteString(p.out, sb.String())
+}
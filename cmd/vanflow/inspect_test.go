@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/encoding"
+	"github.com/skupperproject/skupper/pkg/vanflow/eventsource"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+)
+
+func ptrTo[T any](t T) *T {
+	return &t
+}
+
+func TestRecordFilter(t *testing.T) {
+	listener := vanflow.ListenerRecord{
+		BaseRecord: vanflow.NewBase("l1"),
+		Address:    ptrTo("backend"),
+		DestPort:   ptrTo("8080"),
+	}
+	testCases := []struct {
+		Name       string
+		Types      string
+		Attributes []string
+		Expected   bool
+	}{
+		{Name: "no filter", Expected: true},
+		{Name: "type", Types: "ListenerRecord", Expected: true},
+		{Name: "type without suffix", Types: "connector,listener", Expected: true},
+		{Name: "other type", Types: "ConnectorRecord", Expected: false},
+		{Name: "attribute by name", Attributes: []string{"address=backend"}, Expected: true},
+		{Name: "attribute by codepoint", Attributes: []string{"1=l1", "DestPort=8080"}, Expected: true},
+		{Name: "attribute mismatch", Attributes: []string{"Address=backend", "DestPort=9090"}, Expected: false},
+		{Name: "attribute not set", Attributes: []string{"Protocol=tcp"}, Expected: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			filter, err := newRecordFilter("", tc.Types, tc.Attributes)
+			assert.Assert(t, err)
+			attributes, err := encoding.Attributes(listener)
+			assert.Assert(t, err)
+			assert.Equal(t, filter.matchRecord(listener, attributes), tc.Expected)
+		})
+	}
+
+	filter, err := newRecordFilter("a, b", "", nil)
+	assert.Assert(t, err)
+	assert.Assert(t, filter.matchSource("b"))
+	assert.Assert(t, !filter.matchSource("c"))
+	_, err = newRecordFilter("", "", []string{"Address"})
+	assert.ErrorContains(t, err, "expected name=value")
+}
+
+func runSource(t *testing.T, ctx context.Context, factory session.ContainerFactory, info eventsource.Info, records ...vanflow.Record) {
+	t.Helper()
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{})
+	for _, record := range records {
+		stor.Add(record, store.SourceRef{ID: info.ID})
+	}
+	container := factory.Create()
+	container.Start(ctx)
+	manager := eventsource.NewManager(container, eventsource.ManagerConfig{
+		Source:            info,
+		Stores:            []store.Interface{stor},
+		BeaconInterval:    50 * time.Millisecond,
+		HeartbeatInterval: 50 * time.Millisecond,
+	})
+	go manager.Run(ctx)
+}
+
+func TestTailRecords(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	factory := session.NewMockContainerFactory()
+	runSource(t, ctx, factory, eventsource.Info{
+		ID: "router-a", Version: 1, Type: "ROUTER", Address: "mc/sfe.router-a", Direct: "sfe.router-a",
+	},
+		vanflow.RouterRecord{BaseRecord: vanflow.NewBase("router-a"), Name: ptrTo("a")},
+		vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("l1"), Parent: ptrTo("router-a"), Address: ptrTo("frontend")},
+		vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("l2"), Parent: ptrTo("router-a"), Address: ptrTo("backend")},
+	)
+	runSource(t, ctx, factory, eventsource.Info{
+		ID: "router-b", Version: 1, Type: "ROUTER", Address: "mc/sfe.router-b", Direct: "sfe.router-b",
+	},
+		vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("l3"), Parent: ptrTo("router-b"), Address: ptrTo("backend")},
+	)
+
+	filter, err := newRecordFilter("router-a", "Listener", []string{"Address=backend"})
+	assert.Assert(t, err)
+	var out bytes.Buffer
+	err = tailRecords(ctx, factory, tailOptions{
+		Filter: filter,
+		Flush:  true,
+		Limit:  1,
+		Output: outputJSON,
+	}, &out)
+	assert.Assert(t, err)
+
+	var view recordView
+	assert.Assert(t, json.Unmarshal(out.Bytes(), &view))
+	assert.Equal(t, view.Source, "router-a")
+	assert.Equal(t, view.Type, "ListenerRecord")
+	assert.DeepEqual(t, view.Attributes, map[string]any{
+		"ID":      "l2",
+		"Parent":  "router-a",
+		"Address": "backend",
+	})
+}
+
+func TestListSources(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	factory := session.NewMockContainerFactory()
+	for _, id := range []string{"site-b", "site-a"} {
+		runSource(t, ctx, factory, eventsource.Info{
+			ID: id, Version: 1, Type: "CONTROLLER", Address: "mc/sfe." + id, Direct: "sfe." + id,
+		})
+	}
+
+	var out bytes.Buffer
+	err := listSources(ctx, factory, sourcesOptions{Wait: 200 * time.Millisecond, Output: outputText}, &out)
+	assert.Assert(t, err)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, len(lines), 3, out.String())
+	assert.Assert(t, strings.HasPrefix(lines[0], "ID "))
+	assert.Assert(t, strings.HasPrefix(lines[1], "site-a "))
+	assert.Assert(t, strings.Contains(lines[2], "CONTROLLER"))
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/utils/tlscfg"
+	"github.com/skupperproject/skupper/internal/version"
+	"github.com/skupperproject/skupper/pkg/vanflow/capture"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+)
+
+const usage string = `Usage of vanflow <command> [options...]:
+Tools for working with vanflow event sources.
+
+	capture:
+		Records the vanflow messages sent by the event sources of a
+		network to a capture file.
+	replay:
+		Sends the messages in a capture file to a router as they were
+		originally captured.
+	sources:
+		Lists the event sources discovered on the network and when each
+		was last seen.
+	tail:
+		Prints the records sent by event sources as they arrive,
+		optionally filtered by source, record type and attribute.
+	version:
+		Reports the version of skupper the tool was built against.`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println(usage)
+		os.Exit(1)
+	}
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	var err error
+	switch os.Args[1] {
+	case "capture":
+		err = runCapture(ctx, os.Args[1:])
+	case "replay":
+		err = runReplay(ctx, os.Args[1:])
+	case "sources":
+		err = runSources(ctx, os.Args[1:])
+	case "tail":
+		err = runTail(ctx, os.Args[1:])
+	case "version":
+		fmt.Println(version.Version)
+	case "help", "-h", "-help", "--help":
+		fmt.Println(usage)
+	default:
+		fmt.Printf("Unknown command %q\n", os.Args[1])
+		fmt.Println(usage)
+		os.Exit(1)
+	}
+	if err != nil {
+		slog.Error("vanflow "+os.Args[1]+" error", slog.Any("error", err))
+		os.Exit(1)
+	}
+}
+
+type routerFlags struct {
+	URL        string
+	CA         string
+	Cert       string
+	Key        string
+	SkipVerify bool
+}
+
+func (r *routerFlags) register(flags *flag.FlagSet) {
+	flags.StringVar(&r.URL, "router-endpoint", "amqps://skupper-router-local", "URL to the skupper router amqp(s) endpoint")
+	flags.StringVar(&r.Cert, "router-tls-cert", "", "Path to the client certificate for the router endpoint")
+	flags.StringVar(&r.Key, "router-tls-key", "", "Path to the client key for the router endpoint")
+	flags.StringVar(&r.CA, "router-tls-ca", "", "Path to the CA certificate file for the router endpoint")
+	flags.BoolVar(&r.SkipVerify, "router-tls-insecure", false, "Set to skip verification of the router certificate and host name")
+}
+
+func (r *routerFlags) factory() (session.ContainerFactory, error) {
+	var cfg session.ContainerConfig
+	if strings.HasPrefix(r.URL, "amqps://") {
+		tlsCfg := tlscfg.Modern()
+		tlsCfg.InsecureSkipVerify = r.SkipVerify
+		if r.CA != "" && !r.SkipVerify {
+			data, err := os.ReadFile(r.CA)
+			if err != nil {
+				return nil, err
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(data) {
+				return nil, fmt.Errorf("failed to add CA to certificate pool")
+			}
+			tlsCfg.RootCAs = pool
+		}
+		if r.Cert != "" {
+			cert, err := tls.LoadX509KeyPair(r.Cert, r.Key)
+			if err != nil {
+				return nil, err
+			}
+			tlsCfg.Certificates = []tls.Certificate{cert}
+			cfg.SASLType = session.SASLTypeExternal
+		}
+		cfg.TLSConfig = tlsCfg
+	}
+	return session.NewContainerFactory(r.URL, cfg), nil
+}
+
+const captureDesc string = `Records the vanflow messages sent by event sources to <file> until
+interrupted or until the duration elapses. Writes to stdout when <file> is -.`
+
+func runCapture(ctx context.Context, args []string) error {
+	var (
+		router   routerFlags
+		sources  string
+		flush    bool
+		duration time.Duration
+	)
+	flags := flag.NewFlagSet(args[0], flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage of %s %s [options...] <file>\n", os.Args[0], args[0])
+		fmt.Fprintln(flags.Output(), captureDesc)
+		flags.PrintDefaults()
+	}
+	router.register(flags)
+	flags.StringVar(&sources, "sources", "", "Comma separated list of event source IDs to capture. Captures all sources when empty")
+	flags.BoolVar(&flush, "flush", true, "Set to request that each source sends all of its records once discovered so that the capture begins with the complete state of the network")
+	flags.DurationVar(&duration, "duration", 0, "How long to capture for. Captures until interrupted when zero")
+	flags.Parse(args[1:])
+	if flags.NArg() != 1 {
+		fmt.Fprintf(flags.Output(), "expected argument for capture file\n")
+		flags.Usage()
+		os.Exit(1)
+	}
+
+	out := os.Stdout
+	if path := flags.Arg(0); path != "-" {
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+	factory, err := router.factory()
+	if err != nil {
+		return fmt.Errorf("error configuring router connection: %w", err)
+	}
+	container := factory.Create()
+	if duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, duration)
+		defer cancel()
+	}
+	container.Start(ctx)
+
+	var count atomic.Int64
+	opts := capture.RecorderOptions{
+		Flush:   flush,
+		OnEntry: func(capture.Entry) { count.Add(1) },
+	}
+	if sources != "" {
+		opts.Sources = strings.Split(sources, ",")
+	}
+	err = capture.NewRecorder(container, capture.NewWriter(out), opts).Run(ctx)
+	slog.Info("capture complete", slog.Int64("messages", count.Load()))
+	return err
+}
+
+const replayDesc string = `Sends the messages in capture <file> to the router at the addresses they
+were captured on. Reads from stdin when <file> is -.`
+
+func runReplay(ctx context.Context, args []string) error {
+	var (
+		router      routerFlags
+		speed       float64
+		sendTimeout time.Duration
+		hold        bool
+	)
+	flags := flag.NewFlagSet(args[0], flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage of %s %s [options...] <file>\n", os.Args[0], args[0])
+		fmt.Fprintln(flags.Output(), replayDesc)
+		flags.PrintDefaults()
+	}
+	router.register(flags)
+	flags.Float64Var(&speed, "speed", 1, "Replay speed relative to the capture. 1 replays at the original speed, 10 ten times faster and 0 as fast as possible")
+	flags.DurationVar(&sendTimeout, "send-timeout", 5*time.Second, "How long to wait for each message to be sent before skipping it. Waits indefinitely when zero")
+	flags.BoolVar(&hold, "hold", false, "Set to keep the replayed event sources alive after the capture is exhausted until interrupted")
+	flags.Parse(args[1:])
+	if flags.NArg() != 1 {
+		fmt.Fprintf(flags.Output(), "expected argument for capture file\n")
+		flags.Usage()
+		os.Exit(1)
+	}
+
+	in := os.Stdin
+	if path := flags.Arg(0); path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+	factory, err := router.factory()
+	if err != nil {
+		return fmt.Errorf("error configuring router connection: %w", err)
+	}
+	container := factory.Create()
+	container.Start(ctx)
+
+	stats, err := capture.NewReplayer(container, capture.NewReader(in), capture.ReplayOptions{
+		Speed:       speed,
+		SendTimeout: sendTimeout,
+		Hold:        hold,
+	}).Run(ctx)
+	slog.Info("replay complete", slog.Int("sent", stats.Sent), slog.Int("skipped", stats.Skipped))
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
@@ -23,6 +23,7 @@ const (
 	Certificates      string = "Certificate"
 	SecuredAccesses   string = "SecuredAccess"
 	MultiKeyListeners string = "MultiKeyListener"
+	TrafficPolicies   string = "TrafficPolicy"
 )
 
 const (
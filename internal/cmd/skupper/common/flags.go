@@ -92,8 +92,28 @@ for other Kubernetes flavors, loadbalancer is the default.`
 
 	FlagNameReloadType = "reload-type"
 	FlagDescReloadType = "Specify the type of reload to perform. Choices: manual, auto"
+
+	FlagNameObserverURL        = "observer-url"
+	FlagDescObserverURL        = "The URL of the network observer API"
+	FlagNameTopologyView       = "view"
+	FlagDescTopologyView       = "The level of detail to render. Choices: site, router, process"
+	FlagNameTopologyFormat     = "format"
+	FlagDescTopologyFormat     = "The diagram format. Choices: dot, graphml, mermaid"
+	FlagNameTopologyFile       = "file"
+	FlagDescTopologyFile       = "Write the diagram to the named file instead of the console"
+	FlagNameInsecureSkipVerify = "insecure-skip-tls-verify"
+	FlagDescInsecureSkipVerify = "Do not verify the network observer server certificate"
 )
 
+type CommandTopologyFlags struct {
+	ObserverURL        string
+	View               string
+	Format             string
+	File               string
+	InsecureSkipVerify bool
+	Timeout            time.Duration
+}
+
 type CommandSiteCreateFlags struct {
 	EnableLinkAccess bool
 	LinkAccessType   string
@@ -306,6 +306,11 @@ func (cmd *CmdDebug) writeSiteStateResources(siteState *api.SiteState, source st
 	for _, mkl := range siteState.MultiKeyListeners {
 		writeObjectToTarball(mkl, path.Join(basePath, source, "MultiKeyListener-"+mkl.Name), tb)
 	}
+
+	// TrafficPolicies
+	for _, policy := range siteState.TrafficPolicies {
+		writeObjectToTarball(policy, path.Join(basePath, source, "TrafficPolicy-"+policy.Name), tb)
+	}
 }
 
 func (cmd *CmdDebug) collectRawYamlFiles(sourcePath string, tarPath string, tb *pkgutils.Tarball) {
@@ -10,6 +10,7 @@ import (
 	"github.com/skupperproject/skupper/internal/cmd/skupper/site"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/system"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/token"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/topology"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/version"
 	"github.com/skupperproject/skupper/internal/config"
 	"github.com/spf13/cobra"
@@ -37,6 +38,7 @@ func NewSkupperRootCommand() *cobra.Command {
 	rootCmd.AddCommand(manifest.NewCmdManifest())
 	rootCmd.AddCommand(debug.NewCmdDebug())
 	rootCmd.AddCommand(system.NewCmdSystem())
+	rootCmd.AddCommand(topology.NewCmdTopology())
 
 	rootCmd.SetHelpCommand(&cobra.Command{Hidden: true})
 
@@ -27,6 +27,7 @@ type CmdSystemApply struct {
 	connectorHandler        *fs.ConnectorHandler
 	listenerHandler         *fs.ListenerHandler
 	multiKeyListenerHandler *fs.MultiKeyListenerHandler
+	trafficPolicyHandler    *fs.TrafficPolicyHandler
 	linkHandler             *fs.LinkHandler
 	routerAccessHandler     *fs.RouterAccessHandler
 	accessTokenHandler      *fs.AccessTokenHandler
@@ -54,6 +55,7 @@ func (cmd *CmdSystemApply) NewClient(cobraCommand *cobra.Command, args []string)
 	cmd.connectorHandler = fs.NewConnectorHandler(cmd.Namespace)
 	cmd.listenerHandler = fs.NewListenerHandler(cmd.Namespace)
 	cmd.multiKeyListenerHandler = fs.NewMultiKeyListenerHandler(cmd.Namespace)
+	cmd.trafficPolicyHandler = fs.NewTrafficPolicyHandler(cmd.Namespace)
 	cmd.linkHandler = fs.NewLinkHandler(cmd.Namespace)
 	cmd.routerAccessHandler = fs.NewRouterAccessHandler(cmd.Namespace)
 	cmd.accessTokenHandler = fs.NewAccessTokenHandler(cmd.Namespace)
@@ -175,6 +177,16 @@ func (cmd *CmdSystemApply) Run() error {
 		}
 	}
 
+	for _, trafficPolicy := range parsedInput.TrafficPolicy {
+		err := cmd.trafficPolicyHandler.Add(trafficPolicy)
+		if err != nil {
+			cmd.logger.Error("Error while adding traffic policy", slog.String("trafficpolicy", trafficPolicy.Name), slog.Any("error", err))
+		} else {
+			crApplied = true
+			fmt.Printf("TrafficPolicy %s added\n", trafficPolicy.Name)
+		}
+	}
+
 	for _, link := range parsedInput.Link {
 		err := cmd.linkHandler.Add(link)
 		if err != nil {
@@ -27,6 +27,7 @@ type CmdSystemDelete struct {
 	connectorHandler        *fs.ConnectorHandler
 	listenerHandler         *fs.ListenerHandler
 	multiKeyListenerHandler *fs.MultiKeyListenerHandler
+	trafficPolicyHandler    *fs.TrafficPolicyHandler
 	linkHandler             *fs.LinkHandler
 	routerAccessHandler     *fs.RouterAccessHandler
 	accessTokenHandler      *fs.AccessTokenHandler
@@ -54,6 +55,7 @@ func (cmd *CmdSystemDelete) NewClient(cobraCommand *cobra.Command, args []string
 	cmd.connectorHandler = fs.NewConnectorHandler(cmd.Namespace)
 	cmd.listenerHandler = fs.NewListenerHandler(cmd.Namespace)
 	cmd.multiKeyListenerHandler = fs.NewMultiKeyListenerHandler(cmd.Namespace)
+	cmd.trafficPolicyHandler = fs.NewTrafficPolicyHandler(cmd.Namespace)
 	cmd.linkHandler = fs.NewLinkHandler(cmd.Namespace)
 	cmd.routerAccessHandler = fs.NewRouterAccessHandler(cmd.Namespace)
 	cmd.accessTokenHandler = fs.NewAccessTokenHandler(cmd.Namespace)
@@ -182,6 +184,18 @@ func (cmd *CmdSystemDelete) Run() error {
 		}
 	}
 
+	for _, trafficPolicy := range parsedInput.TrafficPolicy {
+		if trafficPolicy.Name != "" {
+			err := cmd.trafficPolicyHandler.Delete(trafficPolicy.Name)
+			if err != nil {
+				cmd.logger.Error("Error while deleting traffic policy", slog.String("trafficpolicy", trafficPolicy.Name), slog.Any("error", err))
+			} else {
+				crDeleted = true
+				fmt.Printf("TrafficPolicy %s deleted\n", trafficPolicy.Name)
+			}
+		}
+	}
+
 	for _, link := range parsedInput.Link {
 		if link.Name != "" {
 			err := cmd.linkHandler.Delete(link.Name)
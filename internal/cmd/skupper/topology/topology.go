@@ -0,0 +1,99 @@
+package topology
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+)
+
+const topologyPath = "/api/v2alpha1/topology"
+
+func NewCmdTopology() *cobra.Command {
+	flags := common.CommandTopologyFlags{}
+	cmd := &cobra.Command{
+		Use:   "topology",
+		Short: "Render the network topology as a diagram",
+		Long: `Fetch the topology of the application network from the network observer and
+render it as a Graphviz DOT, GraphML or Mermaid diagram. The site view shows
+sites and the links between them, the router view shows routers grouped by site
+and the process view shows processes grouped by site and the traffic between them.`,
+		Example: `skupper topology --observer-url http://localhost:8080 --view router --format mermaid
+skupper topology --format dot --file network.dot`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(cmd.Context(), flags.Timeout)
+			defer cancel()
+			diagram, err := fetchTopology(ctx, flags)
+			if err != nil {
+				return err
+			}
+			if flags.File == "" {
+				_, err = cmd.OutOrStdout().Write(diagram)
+				return err
+			}
+			if err := os.WriteFile(flags.File, diagram, 0o644); err != nil {
+				return fmt.Errorf("error writing topology: %w", err)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&flags.ObserverURL, common.FlagNameObserverURL, "http://localhost:8080", common.FlagDescObserverURL)
+	cmd.Flags().StringVar(&flags.View, common.FlagNameTopologyView, "site", common.FlagDescTopologyView)
+	cmd.Flags().StringVar(&flags.Format, common.FlagNameTopologyFormat, "dot", common.FlagDescTopologyFormat)
+	cmd.Flags().StringVar(&flags.File, common.FlagNameTopologyFile, "", common.FlagDescTopologyFile)
+	cmd.Flags().BoolVar(&flags.InsecureSkipVerify, common.FlagNameInsecureSkipVerify, false, common.FlagDescInsecureSkipVerify)
+	cmd.Flags().DurationVar(&flags.Timeout, common.FlagNameTimeout, 30*time.Second, common.FlagDescTimeout)
+	return cmd
+}
+
+func fetchTopology(ctx context.Context, flags common.CommandTopologyFlags) ([]byte, error) {
+	base, err := url.Parse(flags.ObserverURL)
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("invalid network observer url %q", flags.ObserverURL)
+	}
+	target := base.JoinPath(topologyPath)
+	target.RawQuery = url.Values{
+		"view":   {flags.View},
+		"format": {flags.Format},
+	}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{}
+	if flags.InsecureSkipVerify {
+		client.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error contacting network observer: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading topology: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+			return nil, fmt.Errorf("network observer rejected request: %s", apiErr.Message)
+		}
+		return nil, fmt.Errorf("network observer responded with %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
@@ -0,0 +1,86 @@
+package topology
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdTopology(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != topologyPath {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("view") == "services" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":"ErrBadRequest","message":"invalid view parameter \"services\""}`))
+			return
+		}
+		w.Write([]byte(r.URL.Query().Get("view") + "/" + r.URL.Query().Get("format")))
+	}))
+	defer srv.Close()
+
+	testcases := []struct {
+		name        string
+		args        []string
+		toFile      bool
+		expect      string
+		expectError string
+	}{
+		{
+			name:   "defaults",
+			expect: "site/dot",
+		}, {
+			name:   "router mermaid",
+			args:   []string{"--view", "router", "--format", "mermaid"},
+			expect: "router/mermaid",
+		}, {
+			name:   "to file",
+			args:   []string{"--format", "graphml"},
+			toFile: true,
+			expect: "site/graphml",
+		}, {
+			name:        "bad request",
+			args:        []string{"--view", "services"},
+			expectError: `network observer rejected request: invalid view parameter "services"`,
+		}, {
+			name:        "invalid url",
+			args:        []string{"--observer-url", "localhost"},
+			expectError: `invalid network observer url "localhost"`,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := NewCmdTopology()
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			args := append([]string{"--observer-url", srv.URL}, tc.args...)
+			file := filepath.Join(t.TempDir(), "topology")
+			if tc.toFile {
+				args = append(args, "--file", file)
+			}
+			cmd.SetArgs(args)
+			err := cmd.Execute()
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.Assert(t, err)
+			if tc.toFile {
+				content, err := os.ReadFile(file)
+				assert.Assert(t, err)
+				assert.Equal(t, string(content), tc.expect)
+				assert.Equal(t, out.String(), "")
+				return
+			}
+			assert.Equal(t, out.String(), tc.expect)
+		})
+	}
+}
@@ -416,7 +416,7 @@ func (s *StatusSync) handleDiscovery(source eventsource.Info) {
 		Source: sourceRef(source),
 	}
 	client.OnRecord(router.Route)
-	client.Listen(s.ctx, eventsource.FromSourceAddress())
+	client.Listen(s.ctx, eventsource.WithRecordFilter(eventsource.FromSourceAddress(), eventsource.RecordFilter{Types: recordTypes}))
 	if source.Type == "CONTROLLER" {
 		client.Listen(s.ctx, eventsource.FromSourceAddressHeartbeats())
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"testing"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/skupperproject/skupper/pkg/vanflow"
 	"github.com/skupperproject/skupper/pkg/vanflow/eventsource"
 	"github.com/skupperproject/skupper/pkg/vanflow/session"
+	"github.com/skupperproject/skupper/pkg/vanflow/simulator"
 	"github.com/skupperproject/skupper/pkg/vanflow/store"
 	"golang.org/x/time/rate"
 	"gotest.tools/v3/poll"
@@ -169,6 +171,45 @@ func TestStatusSyncEndToEnd(t *testing.T) {
 	}
 }
 
+// BenchmarkStatusSyncBuild measures building the network status of a
+// simulated network of hundreds of sites.
+func BenchmarkStatusSyncBuild(b *testing.B) {
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	defer slog.SetDefault(defaultLogger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	factory := session.NewMockContainerFactory()
+	sim, err := simulator.New(factory, simulator.Config{
+		Sites:               200,
+		ProcessesPerSite:    4,
+		Services:            100,
+		ListenersPerService: 5,
+	})
+	if err != nil {
+		b.Fatalf("unexpected error creating simulator: %s", err)
+	}
+	ss := NewStatusSync(factory, nil, fakeCmClient(), "test")
+	go ss.Run(ctx)
+	go sim.Run(ctx)
+
+	expected := len(sim.Records())
+	deadline := time.After(time.Minute)
+	for len(ss.records.List()) < expected {
+		select {
+		case <-deadline:
+			b.Fatalf("timed out with %d of %d records synced", len(ss.records.List()), expected)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ss.build()
+	}
+}
+
 type fakeKubeStatusSyncClient struct {
 	cm corev1.ConfigMapInterface
 }
@@ -2,6 +2,8 @@ package adaptor
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"log/slog"
 	"os"
@@ -73,7 +75,7 @@ func siteCollector(ctx context.Context, cli *internalclient.KubeClient) {
 		os.Exit(1)
 	}
 
-	factory := session.NewContainerFactory("amqp://localhost:5672", session.ContainerConfig{ContainerID: "kube-flow-collector"})
+	factory := session.NewContainerFactory("amqp://localhost:5672", localRouterConfig(ctx, cli, "kube-flow-collector"))
 	statusSyncClient := &StatusSyncClient{
 		client: cli.Kube.CoreV1().ConfigMaps(cli.Namespace),
 	}
@@ -96,7 +98,7 @@ func startFlowController(ctx context.Context, cli *internalclient.KubeClient) er
 	informer := corev1informer.NewPodInformer(cli.Kube, cli.Namespace, time.Minute*5, cache.Indexers{})
 	platform := "kubernetes"
 	fc := kubeflow.NewController(kubeflow.ControllerConfig{
-		Factory:  session.NewContainerFactory("amqp://localhost:5672", session.ContainerConfig{ContainerID: "kube-flow-controller"}),
+		Factory:  session.NewContainerFactory("amqp://localhost:5672", localRouterConfig(ctx, cli, "kube-flow-controller")),
 		Informer: informer,
 		Site: vanflow.SiteRecord{
 			BaseRecord: vanflow.NewBase(siteID, deployment.ObjectMeta.CreationTimestamp.Time),
@@ -119,6 +121,43 @@ func startFlowController(ctx context.Context, cli *internalclient.KubeClient) er
 	return nil
 }
 
+// localRouterConfig configures containers connecting to the router in this
+// pod to fail over to the other routers of the site through the local router
+// service, so that an HA site keeps reporting while one router is down.
+func localRouterConfig(ctx context.Context, cli *internalclient.KubeClient, containerID string) session.ContainerConfig {
+	config := session.ContainerConfig{ContainerID: containerID}
+	tlsConfig, err := localClientTLSConfig(ctx, cli)
+	if err != nil {
+		slog.Warn("Router failover disabled: unable to load local client credentials",
+			slog.String("container", containerID), slog.Any("error", err))
+		return config
+	}
+	config.TLSConfig = tlsConfig
+	config.SASLType = session.SASLTypeExternal
+	config.FailoverAddresses = []string{fmt.Sprintf("amqps://%s:%d", types.LocalTransportServiceName, int(types.AmqpsDefaultPort))}
+	return config
+}
+
+func localClientTLSConfig(ctx context.Context, cli *internalclient.KubeClient) (*tls.Config, error) {
+	secret, err := cli.Kube.CoreV1().Secrets(cli.Namespace).Get(ctx, types.LocalClientSecret, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	cert, err := tls.X509KeyPair(secret.Data["tls.crt"], secret.Data["tls.key"])
+	if err != nil {
+		return nil, err
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(secret.Data["ca.crt"]) {
+		return nil, fmt.Errorf("no CA certificates found in secret %s", types.LocalClientSecret)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      roots,
+		MinVersion:   tls.VersionTLS13,
+	}, nil
+}
+
 func runLeaderElection(lock *resourcelock.LeaseLock, id string, cli *internalclient.KubeClient) {
 	var (
 		mu              sync.Mutex
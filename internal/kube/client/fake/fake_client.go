@@ -139,6 +139,14 @@ func fakedApiResources() []*metav1.APIResourceList {
 					Version:      "v2alpha1",
 					Kind:         "MultiKeyListener",
 				},
+				{
+					Name:         "trafficpolicies",
+					SingularName: "trafficpolicy",
+					Namespaced:   true,
+					Group:        "skupper.io",
+					Version:      "v2alpha1",
+					Kind:         "TrafficPolicy",
+				},
 			},
 		},
 	}
@@ -39,6 +39,7 @@ type Controller struct {
 	listenerWatcher         *watchers.ListenerWatcher
 	connectorWatcher        *watchers.ConnectorWatcher
 	multiKeyListenerWatcher *watchers.MultiKeyListenerWatcher
+	trafficPolicyWatcher    *watchers.TrafficPolicyWatcher
 	linkAccessWatcher       *watchers.RouterAccessWatcher
 	grantWatcher            *watchers.AccessGrantWatcher
 	serviceWatcher          *watchers.ServiceWatcher
@@ -135,6 +136,7 @@ func NewController(cli internalclient.Clients, config *Config, options ...watche
 	controller.eventProcessor.WatchServices(listenerServices(), config.WatchNamespace, filter(controller, controller.checkListenerService))
 	controller.serviceWatcher = controller.eventProcessor.WatchServices(sansSkupperListenerServices(), config.WatchNamespace, filter(controller, controller.checkObservedService))
 	controller.connectorWatcher = controller.eventProcessor.WatchConnectors(config.WatchNamespace, filter(controller, controller.checkConnector))
+	controller.trafficPolicyWatcher = controller.eventProcessor.WatchTrafficPolicies(config.WatchNamespace, filter(controller, controller.checkTrafficPolicy))
 	controller.linkAccessWatcher = controller.eventProcessor.WatchRouterAccesses(config.WatchNamespace, filter(controller, controller.checkRouterAccess))
 	controller.eventProcessor.WatchAttachedConnectors(config.WatchNamespace, filter(controller, controller.checkAttachedConnector))
 	controller.eventProcessor.WatchAttachedConnectorBindings(config.WatchNamespace, filter(controller, controller.checkAttachedConnectorBinding))
@@ -268,6 +270,20 @@ func (c *Controller) init(stopCh <-chan struct{}) error {
 			)
 		}
 	}
+	// recover traffic policies before the bindings they apply to
+	if c.trafficPolicyWatcher != nil {
+		for _, policy := range c.trafficPolicyWatcher.List() {
+			if !c.namespaces.isControlled(policy.Namespace) {
+				continue
+			}
+			site := c.getSite(policy.ObjectMeta.Namespace)
+			c.log.Info("Recovering traffic policy",
+				slog.String("namespace", policy.Namespace),
+				slog.String("name", policy.Name),
+			)
+			site.CheckTrafficPolicy(policy.ObjectMeta.Name, policy)
+		}
+	}
 	for _, connector := range c.connectorWatcher.List() {
 		if !c.namespaces.isControlled(connector.Namespace) {
 			continue
@@ -421,6 +437,15 @@ func (c *Controller) checkListener(key string, listener *skupperv2alpha1.Listene
 	return nil
 }
 
+func (c *Controller) checkTrafficPolicy(key string, policy *skupperv2alpha1.TrafficPolicy) error {
+	c.log.Debug("checkTrafficPolicy", slog.String("key", key))
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	return c.getSite(namespace).CheckTrafficPolicy(name, policy)
+}
+
 func (c *Controller) checkMultiKeyListener(key string, mkl *skupperv2alpha1.MultiKeyListener) error {
 	c.log.Debug("checkMultiKeyListener", slog.String("key", key))
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
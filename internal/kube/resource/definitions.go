@@ -37,6 +37,14 @@ func MultiKeyListenerResource() schema.GroupVersionResource {
 	}
 }
 
+func TrafficPolicyResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    "skupper.io",
+		Version:  "v2alpha1",
+		Resource: "trafficpolicies",
+	}
+}
+
 func DeploymentResource() schema.GroupVersionResource {
 	return schema.GroupVersionResource{
 		Group:    "apps",
@@ -315,6 +315,12 @@ func (a *AttachedConnector) updateBridgeConfig(siteId string, config *qdr.Bridge
 			TlsCredentials: definition.Spec.TlsCredentials,
 		},
 	}
+	if a.parent != nil {
+		if a.parent.CheckConnector(connector) != nil {
+			return updated
+		}
+		connector.Spec.RoutingKey = site.ConnectorAddress(a.parent.RoutingKeyScope(), connector.Spec.RoutingKey)
+	}
 	for _, pod := range a.watcher.pods() {
 		if site.UpdateBridgeConfigForConnectorToPod(siteId, connector, pod, a.binding.Spec.ExposePodsByName, config) {
 			updated = true
@@ -6,20 +6,23 @@ import (
 	"strings"
 
 	internalclient "github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/site"
 	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 )
 
 type BindingStatus struct {
 	connectors map[string][]string
 	listeners  map[string][]string
+	scope      *skupperv2alpha1.RoutingKeyScope
 	client     internalclient.Clients
 	errors     []string
 	logger     *slog.Logger
 }
 
-func newBindingStatus(client internalclient.Clients, network []skupperv2alpha1.SiteRecord) *BindingStatus {
+func newBindingStatus(client internalclient.Clients, network []skupperv2alpha1.SiteRecord, scope *skupperv2alpha1.RoutingKeyScope) *BindingStatus {
 	s := &BindingStatus{
 		client:     client,
+		scope:      scope,
 		connectors: map[string][]string{},
 		listeners:  map[string][]string{},
 		logger: slog.New(slog.Default().Handler()).With(
@@ -49,7 +52,7 @@ func (s *BindingStatus) populate(network []skupperv2alpha1.SiteRecord) {
 }
 
 func (s *BindingStatus) updateMatchingListenerCount(connector *skupperv2alpha1.Connector) *skupperv2alpha1.Connector {
-	if connector.SetHasMatchingListener(len(s.listeners[connector.Spec.RoutingKey]) > 0) {
+	if connector.SetHasMatchingListener(len(s.listeners[site.ConnectorAddress(s.scope, connector.Spec.RoutingKey)]) > 0) {
 		updated, err := updateConnectorStatus(s.client, connector)
 		if err != nil {
 			s.logger.Error("Failed to update status for connector",
@@ -64,7 +67,7 @@ func (s *BindingStatus) updateMatchingListenerCount(connector *skupperv2alpha1.C
 }
 
 func (s *BindingStatus) updateMatchingConnectorCount(listener *skupperv2alpha1.Listener) *skupperv2alpha1.Listener {
-	if listener.SetHasMatchingConnector(len(s.connectors[listener.Spec.RoutingKey]) > 0) {
+	if listener.SetHasMatchingConnector(len(s.connectors[site.ListenerAddress(s.scope, listener.Spec.RoutingKey)]) > 0) {
 		updated, err := updateListenerStatus(s.client, listener)
 		if err != nil {
 			s.logger.Error("Failed to update status for listener",
@@ -80,7 +83,7 @@ func (s *BindingStatus) updateMatchingConnectorCount(listener *skupperv2alpha1.L
 
 func (s *BindingStatus) updateMatchingListenerCountForAttachedConnector(connector *AttachedConnector) {
 	if connector.binding != nil {
-		connector.setMatchingListenerCount(len(s.listeners[connector.binding.Spec.RoutingKey]))
+		connector.setMatchingListenerCount(len(s.listeners[site.ConnectorAddress(s.scope, connector.binding.Spec.RoutingKey)]))
 	}
 }
 
@@ -90,7 +93,7 @@ func (s *BindingStatus) updateMultiKeyListenerDestination(mkl *skupperv2alpha1.M
 	// Find which routing keys have matching connectors, preserving priority order
 	var reachable []string
 	for _, key := range routingKeys {
-		if len(s.connectors[key]) > 0 {
+		if len(s.connectors[site.ListenerAddress(s.scope, key)]) > 0 {
 			reachable = append(reachable, key)
 		}
 	}
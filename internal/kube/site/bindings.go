@@ -68,6 +68,7 @@ func (w *TargetSelectionImpl) Updated(pods []skupperv2alpha1.PodDetails) error {
 		bindings_logger.Error("Error looking up connector for pod event", w.Attr())
 		return nil
 	}
+	w.site.monitorConnectorHealth(w.name, connector)
 	if err != nil {
 		return w.site.updateConnectorConfiguredStatus(connector, err)
 	}
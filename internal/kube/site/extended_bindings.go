@@ -161,6 +161,21 @@ func (a *ExtendedBindings) ListenerDeleted(listener *skupperv2alpha1.Listener) {
 	}
 }
 
+// connectorTargets returns the hosts a connector currently forwards to:
+// either the configured host or the IPs of the selected pods.
+func (a *ExtendedBindings) connectorTargets(connector *skupperv2alpha1.Connector) []string {
+	if connector.Spec.Host != "" {
+		return []string{connector.Spec.Host}
+	}
+	var hosts []string
+	if selector, ok := a.selectors[connector.Name]; ok {
+		for _, pod := range selector.List() {
+			hosts = append(hosts, pod.IP)
+		}
+	}
+	return hosts
+}
+
 func (a *ExtendedBindings) updateBridgeConfigForConnector(siteId string, connector *skupperv2alpha1.Connector, config *qdr.BridgeConfig) {
 	if connector.Spec.Host != "" {
 		site.UpdateBridgeConfigForConnector(siteId, connector, config)
@@ -370,6 +385,41 @@ func (b *ExtendedBindings) UpdateMultiKeyListener(name string, mkl *skupperv2alp
 	return b, errors.Join(errs...)
 }
 
+func (b *ExtendedBindings) UpdateTrafficPolicy(name string, policy *skupperv2alpha1.TrafficPolicy) qdr.ConfigUpdate {
+	if b.bindings.UpdateTrafficPolicy(name, policy) == nil {
+		return nil
+	}
+	return b
+}
+
+func (b *ExtendedBindings) CheckListener(listener *skupperv2alpha1.Listener) error {
+	return b.bindings.CheckListener(listener)
+}
+
+func (b *ExtendedBindings) CheckConnector(connector *skupperv2alpha1.Connector) error {
+	return b.bindings.CheckConnector(connector)
+}
+
+func (b *ExtendedBindings) SetRoutingKeyScope(scope *skupperv2alpha1.RoutingKeyScope) bool {
+	return b.bindings.SetRoutingKeyScope(scope)
+}
+
+func (b *ExtendedBindings) RoutingKeyScope() *skupperv2alpha1.RoutingKeyScope {
+	return b.bindings.RoutingKeyScope()
+}
+
+func (b *ExtendedBindings) CheckMultiKeyListener(mkl *skupperv2alpha1.MultiKeyListener) error {
+	return b.bindings.CheckMultiKeyListener(mkl)
+}
+
+func (b *ExtendedBindings) updateNetworkListeners(network []skupperv2alpha1.SiteRecord) bool {
+	return b.bindings.UpdateNetwork(network)
+}
+
+func (b *ExtendedBindings) MapOverTrafficPolicies(pf site.TrafficPolicyFunction) {
+	b.bindings.MapOverTrafficPolicies(pf)
+}
+
 func (b *ExtendedBindings) GetMultiKeyListener(name string) *skupperv2alpha1.MultiKeyListener {
 	return b.bindings.GetMultiKeyListener(name)
 }
@@ -406,7 +456,10 @@ func (b *ExtendedBindings) Apply(config *qdr.RouterConfig) bool {
 		}
 	}
 	for _, ptl := range b.perTargetListeners {
-		if ptl.updateBridgeConfig(b.bindings.SiteId, &desired) {
+		if b.bindings.CheckListener(ptl.definition) != nil {
+			continue
+		}
+		if ptl.updateBridgeConfig(b.bindings.SiteId, b.bindings.RoutingKeyScope(), &desired) {
 			updated = true
 		}
 	}
@@ -451,6 +504,8 @@ func (b *ExtendedBindings) AddSslProfiles(config *qdr.RouterConfig, definitions
 
 func (b *ExtendedBindings) SetSite(site *Site) {
 	b.bindings.SetSiteId(site.site.GetSiteId())
+	b.bindings.SetSiteName(site.site.Name)
+	b.bindings.SetRoutingKeyScope(site.site.Spec.RoutingKeyScope)
 	b.site = site
 }
 
@@ -527,7 +582,7 @@ func (b *ExtendedBindings) attachedConnectorUnreferenced(namespace string, name
 func (b *ExtendedBindings) networkUpdated(network []skupperv2alpha1.SiteRecord) qdr.ConfigUpdate {
 	changed := false
 	for _, ptl := range b.perTargetListeners {
-		update, err := ptl.extractTargets(network, b.mapping, b.exposed, b.context)
+		update, err := ptl.extractTargets(network, b.bindings.RoutingKeyScope(), b.mapping, b.exposed, b.context)
 		if err != nil {
 			if err := b.site.updateListenerStatus(ptl.definition, err); err != nil {
 				bindings_logger.Error("Error handling network update for listener",
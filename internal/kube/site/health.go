@@ -0,0 +1,63 @@
+package site
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/kube/watchers"
+	"github.com/skupperproject/skupper/internal/site"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+// connectorHealthChange handles the events raised, keyed by connector
+// name, when the health of a connector's targets changes. This ensures
+// those changes are processed on the same go routine as resource events.
+type connectorHealthChange struct {
+	site *Site
+}
+
+func (h *connectorHealthChange) Handle(event watchers.ResourceChange) error {
+	return h.site.connectorHealthChanged(event.Key)
+}
+
+func (h *connectorHealthChange) Describe(event watchers.ResourceChange) string {
+	return fmt.Sprintf("Health of targets for connector %s/%s", h.site.namespace, event.Key)
+}
+
+func (h *connectorHealthChange) Kind() string {
+	return "ConnectorHealth"
+}
+
+func (s *Site) newHealthMonitor() *site.HealthMonitor {
+	handler := &connectorHealthChange{site: s}
+	return site.NewHealthMonitor(func(name string) {
+		s.clients.Enqueue(name, handler)
+	})
+}
+
+func (s *Site) monitorConnectorHealth(name string, connector *skupperv2alpha1.Connector) {
+	if connector == nil || connector.Spec.HealthCheck == nil || site.ValidateHealthCheck(connector) != nil {
+		s.health.Remove(name)
+		if connector != nil && len(connector.Status.Targets) > 0 {
+			// clear the health recorded while checks were enabled
+			s.clients.Enqueue(name, &connectorHealthChange{site: s})
+		}
+		return
+	}
+	s.health.Update(name, connector.Spec.HealthCheck, connector.Spec.Port, s.bindings.connectorTargets(connector))
+}
+
+func (s *Site) connectorHealthChanged(name string) error {
+	connector := s.bindings.GetConnector(name)
+	if connector == nil || s.site == nil {
+		return nil
+	}
+	if !connector.SetTargetHealth(s.health.Targets(name)) {
+		return nil
+	}
+	err := s.updateRouterConfig(s.bindings)
+	if err == nil {
+		err = s.bindings.CheckConnector(connector)
+	}
+	connector.SetConfigured(err)
+	return s.updateConnectorStatus(connector)
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/skupperproject/skupper/internal/qdr"
+	"github.com/skupperproject/skupper/internal/site"
 	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 )
 
@@ -30,11 +31,11 @@ func (p *PerTargetListener) updateListener(l *skupperv2alpha1.Listener) bool {
 	return changed
 }
 
-func (p *PerTargetListener) extractTargets(network []skupperv2alpha1.SiteRecord, mapping *qdr.PortMapping, exposedPorts ExposedPorts, context BindingContext) (bool, error) {
+func (p *PerTargetListener) extractTargets(network []skupperv2alpha1.SiteRecord, scope *skupperv2alpha1.RoutingKeyScope, mapping *qdr.PortMapping, exposedPorts ExposedPorts, context BindingContext) (bool, error) {
 	p.logger.Debug("Extracting targets for listener",
 		slog.String("namespace", p.definition.Namespace),
 		slog.String("listener", p.definition.Name))
-	targets := extractTargets(p.address(""), network)
+	targets := extractTargets(p.routerAddress(scope, ""), network)
 	changed := false
 	stale := map[string]bool{}
 	for key, _ := range p.targets {
@@ -71,6 +72,12 @@ func (p *PerTargetListener) address(target string) string {
 	return p.definition.Spec.RoutingKey + "." + target
 }
 
+// routerAddress returns the address of the target qualified by the
+// routing key scope of the site, if any.
+func (p *PerTargetListener) routerAddress(scope *skupperv2alpha1.RoutingKeyScope, target string) string {
+	return site.ListenerAddress(scope, p.definition.Spec.RoutingKey) + "." + target
+}
+
 func (p *PerTargetListener) expose(mapping *qdr.PortMapping, exposedPorts ExposedPorts, context BindingContext) error {
 	for target, allocatedRouterPort := range p.targets {
 		port := Port{
@@ -125,7 +132,7 @@ func (p *PerTargetListener) unexpose(target string, mapping *qdr.PortMapping, ex
 	return nil
 }
 
-func (p *PerTargetListener) updateBridgeConfig(siteId string, config *qdr.BridgeConfig) bool {
+func (p *PerTargetListener) updateBridgeConfig(siteId string, scope *skupperv2alpha1.RoutingKeyScope, config *qdr.BridgeConfig) bool {
 	var updated bool
 	for target, port := range p.targets {
 		if p.definition.Spec.Type == "tcp" || p.definition.Spec.Type == "" {
@@ -133,7 +140,7 @@ func (p *PerTargetListener) updateBridgeConfig(siteId string, config *qdr.Bridge
 				Name:       qdr.TcpListenerNamePrefix + p.definition.Name + "@" + target,
 				SiteId:     siteId,
 				Port:       strconv.Itoa(port),
-				Address:    p.address(target),
+				Address:    p.routerAddress(scope, target),
 				SslProfile: p.definition.Spec.TlsCredentials,
 			}) {
 				updated = true
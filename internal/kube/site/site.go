@@ -64,6 +64,7 @@ type Site struct {
 	profiles      *secrets.ProfilesWatcher
 	disableSecCtx bool
 	leadListeners map[string]string
+	health        *site.HealthMonitor
 }
 
 func NewSite(namespace string, eventProcessor *watchers.EventProcessor, certs certificates.CertificateManager, access SecuredAccessFactory, sizes *sizing.Registry, labelling Labelling, disableSecCtx bool) *Site {
@@ -86,6 +87,7 @@ func NewSite(namespace string, eventProcessor *watchers.EventProcessor, certs ce
 		disableSecCtx: disableSecCtx,
 		leadListeners: map[string]string{},
 	}
+	site.health = site.newHealthMonitor()
 	site.profiles = secrets.NewProfilesWatcher(
 		sslSecretsWatcher(namespace, eventProcessor),
 		eventProcessor.GetKubeClient(),
@@ -182,6 +184,11 @@ func (s *Site) reconcile(siteDef *skupperv2alpha1.Site, inRecovery bool) error {
 		s.currentGroups = s.groups()
 		s.bindings.init(s, routerConfig)
 		s.setBindingsConfiguredStatus(nil)
+		s.setTrafficPoliciesConfiguredStatus(nil)
+		s.bindings.Map(func(connector *skupperv2alpha1.Connector) *skupperv2alpha1.Connector {
+			s.monitorConnectorHealth(connector.Name, connector)
+			return nil
+		}, nil)
 		s.checkSecuredAccess()
 	} else if len(s.currentGroups) != len(s.groups()) {
 		s.logger.Info("EnableHA setting changed for site",
@@ -202,6 +209,11 @@ func (s *Site) reconcile(siteDef *skupperv2alpha1.Site, inRecovery bool) error {
 			return err
 		}
 	}
+	if s.bindings.SetRoutingKeyScope(siteDef.Spec.RoutingKeyScope) {
+		if err := s.updateRouterConfig(s.bindings); err != nil {
+			return err
+		}
+	}
 	ctxt := context.TODO()
 	// 2. service account (optional)
 	if s.site.Spec.ServiceAccount == "" {
@@ -867,6 +879,9 @@ func (s *Site) updateConnectorStatus(connector *skupperv2alpha1.Connector) error
 }
 
 func (s *Site) updateConnectorConfiguredStatus(connector *skupperv2alpha1.Connector, err error) error {
+	if err == nil {
+		err = s.bindings.CheckConnector(connector)
+	}
 	if connector.SetConfigured(err) {
 		return s.updateConnectorStatus(connector)
 	}
@@ -881,7 +896,7 @@ func (s *Site) updateConnectorConfiguredStatusWithSelectedPods(connector *skuppe
 			slog.String("name", connector.Name))
 		err = fmt.Errorf("No pods match selector")
 	} else {
-
+		err = s.bindings.CheckConnector(connector)
 	}
 	if connector.SetConfigured(err) || connector.SetSelectedPods(selected) {
 		return s.updateConnectorStatus(connector)
@@ -897,6 +912,7 @@ func (s *Site) CheckConnector(name string, connector *skupperv2alpha1.Connector)
 		}
 		return s.updateConnectorConfiguredStatus(connector, stderrors.New("No active site in namespace"))
 	}
+	s.monitorConnectorHealth(name, connector)
 	if update == nil {
 		return nil
 	}
@@ -908,6 +924,9 @@ func (s *Site) CheckConnector(name string, connector *skupperv2alpha1.Connector)
 }
 
 func (s *Site) updateListenerStatus(listener *skupperv2alpha1.Listener, err error) error {
+	if err == nil {
+		err = s.bindings.CheckListener(listener)
+	}
 	if listener.SetConfigured(err) {
 		_, err := s.clients.GetSkupperClient().SkupperV2alpha1().Listeners(listener.ObjectMeta.Namespace).UpdateStatus(context.TODO(), listener, metav1.UpdateOptions{})
 		if err != nil {
@@ -1026,6 +1045,9 @@ func (s *Site) CheckMultiKeyListener(name string, mkl *skupperv2alpha1.MultiKeyL
 }
 
 func (s *Site) updateMultiKeyListenerStatus(mkl *skupperv2alpha1.MultiKeyListener, err error) error {
+	if err == nil {
+		err = s.bindings.CheckMultiKeyListener(mkl)
+	}
 	if mkl.SetConfigured(err) {
 		updated, updateErr := s.clients.GetSkupperClient().SkupperV2alpha1().MultiKeyListeners(mkl.ObjectMeta.Namespace).UpdateStatus(context.TODO(), mkl, metav1.UpdateOptions{})
 		if updateErr != nil {
@@ -1038,7 +1060,7 @@ func (s *Site) updateMultiKeyListenerStatus(mkl *skupperv2alpha1.MultiKeyListene
 
 func (s *Site) setBindingsConfiguredStatus(err error) {
 	lf := func(listener *skupperv2alpha1.Listener) *skupperv2alpha1.Listener {
-		if listener.SetConfigured(nil) {
+		if listener.SetConfigured(s.bindings.CheckListener(listener)) {
 			updated, err := s.clients.GetSkupperClient().SkupperV2alpha1().Listeners(listener.ObjectMeta.Namespace).UpdateStatus(context.TODO(), listener, metav1.UpdateOptions{})
 			if err == nil {
 				return updated
@@ -1052,7 +1074,7 @@ func (s *Site) setBindingsConfiguredStatus(err error) {
 		return nil
 	}
 	cf := func(connector *skupperv2alpha1.Connector) *skupperv2alpha1.Connector {
-		if connector.SetConfigured(nil) {
+		if connector.SetConfigured(s.bindings.CheckConnector(connector)) {
 			updated, err := s.clients.GetSkupperClient().SkupperV2alpha1().Connectors(connector.ObjectMeta.Namespace).UpdateStatus(context.TODO(), connector, metav1.UpdateOptions{})
 			if err == nil {
 				return updated
@@ -1068,6 +1090,71 @@ func (s *Site) setBindingsConfiguredStatus(err error) {
 	s.bindings.Map(cf, lf)
 }
 
+func (s *Site) CheckTrafficPolicy(name string, policy *skupperv2alpha1.TrafficPolicy) error {
+	update := s.bindings.UpdateTrafficPolicy(name, policy)
+	if s.site == nil {
+		if policy == nil {
+			return nil
+		}
+		return s.updateTrafficPolicyStatus(policy, stderrors.New("No active site in namespace"))
+	}
+	if update == nil {
+		return nil
+	}
+	err := s.updateRouterConfig(update)
+	// bindings newly permitted or denied by the policy report it in their
+	// configured condition
+	s.setBindingsConfiguredStatus(nil)
+	s.setMultiKeyListenersConfiguredStatus()
+	if policy == nil {
+		return err
+	}
+	return s.updateTrafficPolicyStatus(policy, err)
+}
+
+func (s *Site) updateTrafficPolicyStatus(policy *skupperv2alpha1.TrafficPolicy, err error) error {
+	if policy.SetConfigured(err) {
+		updated, err := s.clients.GetSkupperClient().SkupperV2alpha1().TrafficPolicies(policy.ObjectMeta.Namespace).UpdateStatus(context.TODO(), policy, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+		s.bindings.UpdateTrafficPolicy(updated.Name, updated)
+	}
+	return nil
+}
+
+func (s *Site) setMultiKeyListenersConfiguredStatus() {
+	s.bindings.MapOverMultiKeyListeners(func(mkl *skupperv2alpha1.MultiKeyListener) *skupperv2alpha1.MultiKeyListener {
+		if mkl.SetConfigured(s.bindings.CheckMultiKeyListener(mkl)) {
+			updated, err := s.clients.GetSkupperClient().SkupperV2alpha1().MultiKeyListeners(mkl.ObjectMeta.Namespace).UpdateStatus(context.TODO(), mkl, metav1.UpdateOptions{})
+			if err == nil {
+				return updated
+			}
+			s.logger.Error("Could not update multikeylistener status",
+				slog.String("namespace", mkl.ObjectMeta.Namespace),
+				slog.String("multikeylistener", mkl.ObjectMeta.Name),
+				slog.Any("error", err))
+		}
+		return nil
+	})
+}
+
+func (s *Site) setTrafficPoliciesConfiguredStatus(err error) {
+	s.bindings.MapOverTrafficPolicies(func(policy *skupperv2alpha1.TrafficPolicy) *skupperv2alpha1.TrafficPolicy {
+		if policy.SetConfigured(err) {
+			updated, err := s.clients.GetSkupperClient().SkupperV2alpha1().TrafficPolicies(policy.ObjectMeta.Namespace).UpdateStatus(context.TODO(), policy, metav1.UpdateOptions{})
+			if err == nil {
+				return updated
+			}
+			s.logger.Error("Could not update traffic policy status",
+				slog.String("namespace", policy.ObjectMeta.Namespace),
+				slog.String("policy", policy.ObjectMeta.Name),
+				slog.Any("error", err))
+		}
+		return nil
+	})
+}
+
 func (s *Site) newLink(linkconfig *skupperv2alpha1.Link) *site.Link {
 	config := site.NewLink(linkconfig.ObjectMeta.Name, SSL_PROFILE_PATH)
 	config.Update(linkconfig)
@@ -1154,6 +1241,7 @@ func (s *Site) Deleted() {
 	s.updateAccessTokensForDeletedSite(s.namespace)
 	s.setBindingsConfiguredStatus(stderrors.New("No active site"))
 	s.profiles.Stop()
+	s.health.Stop()
 }
 
 func (s *Site) setDefaultIssuerInStatus() bool {
@@ -1281,13 +1369,19 @@ func (s *Site) NetworkStatusUpdated(network []skupperv2alpha1.SiteRecord) error
 			}
 		}
 	}
-	if config := s.bindings.networkUpdated(network); config != nil {
-		if err := s.updateRouterConfig(config); err != nil {
+	// traffic policies withhold connectors from sites whose listeners
+	// they deny
+	policiesAffected := s.bindings.updateNetworkListeners(network)
+	if config := s.bindings.networkUpdated(network); config != nil || policiesAffected {
+		if err := s.updateRouterConfig(s.bindings); err != nil {
 			return err
 		}
 	}
+	if policiesAffected {
+		s.setBindingsConfiguredStatus(nil)
+	}
 
-	bindingStatus := newBindingStatus(s.clients, network)
+	bindingStatus := newBindingStatus(s.clients, network, s.bindings.RoutingKeyScope())
 	s.bindings.Map(bindingStatus.updateMatchingListenerCount, bindingStatus.updateMatchingConnectorCount)
 	s.bindings.MapOverMultiKeyListeners(bindingStatus.updateMultiKeyListenerDestination)
 	s.logger.Debug("Updating matching listeners for attached connectors")
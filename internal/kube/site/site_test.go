@@ -1433,6 +1433,7 @@ func newSiteMocks(namespace string, k8sObjects []runtime.Object, skupperObjects
 			slog.String("component", "kube.site.site"),
 		),
 		leadListeners: make(map[string]string),
+		health:        site1.NewHealthMonitor(nil),
 	}
 	newSite.bindings.init(NewMockBindingContext(map[string]TargetSelection{}), &qdr.RouterConfig{})
 
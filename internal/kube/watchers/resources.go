@@ -66,6 +66,8 @@ type (
 	SecuredAccessWatcher            = ResourceWatcher[*v2alpha1.SecuredAccess]
 	SiteHandler                     = Handler[*v2alpha1.Site]
 	SiteWatcher                     = ResourceWatcher[*v2alpha1.Site]
+	TrafficPolicyHandler            = Handler[*v2alpha1.TrafficPolicy]
+	TrafficPolicyWatcher            = ResourceWatcher[*v2alpha1.TrafficPolicy]
 )
 
 // Handler function the EventProcessor will use as a callback for a work item
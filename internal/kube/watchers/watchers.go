@@ -158,6 +158,10 @@ func (c *EventProcessor) HasMultiKeyListener() bool {
 	return resource.IsResourceAvailable(c.discoveryClient, resource.MultiKeyListenerResource())
 }
 
+func (c *EventProcessor) HasTrafficPolicy() bool {
+	return resource.IsResourceAvailable(c.discoveryClient, resource.TrafficPolicyResource())
+}
+
 func (c *EventProcessor) GetRouteInterface() openshiftroute.Interface {
 	return c.routeClient
 }
@@ -286,6 +290,18 @@ func (c *EventProcessor) newEventHandler(handler ResourceChangeHandler) *cache.R
 	}
 }
 
+// Adds an event with the supplied key and handler to the work queue. This
+// allows events that do not originate from an informer to be handled on
+// the same go routine as those that do.
+func (c *EventProcessor) Enqueue(key string, handler ResourceChangeHandler) {
+	evt := ResourceChange{
+		Handler: handler,
+		Key:     key,
+	}
+	c.metrics.add(evt)
+	c.queue.Add(evt)
+}
+
 func (c *EventProcessor) addWatcher(watcher Watcher) {
 	c.watchers = append(c.watchers, watcher)
 }
@@ -480,6 +496,19 @@ func (c *EventProcessor) WatchMultiKeyListeners(namespace string, handler MultiK
 	return addEventProcessorWatcher(c, handler, v2alpha1.SchemeGroupVersion, informer)
 }
 
+func (c *EventProcessor) WatchTrafficPolicies(namespace string, handler TrafficPolicyHandler) *TrafficPolicyWatcher {
+	if !c.HasTrafficPolicy() {
+		c.logger.Warn("Cannot watch TrafficPolicies; resource not installed")
+		return nil
+	}
+	informer := skupperv2alpha1informer.NewTrafficPolicyInformer(
+		c.skupperClient,
+		namespace,
+		c.resyncShort,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	return addEventProcessorWatcher(c, handler, v2alpha1.SchemeGroupVersion, informer)
+}
+
 func (c *EventProcessor) WatchConnectors(namespace string, handler ConnectorHandler) *ConnectorWatcher {
 	informer := skupperv2alpha1informer.NewConnectorInformer(
 		c.skupperClient,
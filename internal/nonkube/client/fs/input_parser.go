@@ -26,6 +26,7 @@ type InputFileResource struct {
 	Certificate      []v2alpha1.Certificate
 	SecuredAccess    []v2alpha1.SecuredAccess
 	MultiKeyListener []v2alpha1.MultiKeyListener
+	TrafficPolicy    []v2alpha1.TrafficPolicy
 	Secret           []corev1.Secret
 }
 
@@ -104,6 +105,11 @@ func ParseInput(namespace string, reader *bufio.Reader, result *InputFileResourc
 				convertTo(obj, &multiKeyListener)
 				multiKeyListener.Namespace = namespace
 				result.MultiKeyListener = append(result.MultiKeyListener, multiKeyListener)
+			case "TrafficPolicy":
+				var trafficPolicy v2alpha1.TrafficPolicy
+				convertTo(obj, &trafficPolicy)
+				trafficPolicy.Namespace = namespace
+				result.TrafficPolicy = append(result.TrafficPolicy, trafficPolicy)
 			default:
 				logInvalidResource(gvk)
 			}
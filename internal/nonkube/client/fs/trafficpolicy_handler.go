@@ -0,0 +1,112 @@
+package fs
+
+import (
+	"os"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+type TrafficPolicyHandler struct {
+	BaseCustomResourceHandler
+	pathProvider PathProvider
+}
+
+func NewTrafficPolicyHandler(namespace string) *TrafficPolicyHandler {
+	return &TrafficPolicyHandler{
+		pathProvider: PathProvider{
+			Namespace: namespace,
+		},
+	}
+}
+
+func (s *TrafficPolicyHandler) Add(resource v2alpha1.TrafficPolicy) error {
+
+	fileName := resource.Name + ".yaml"
+	content, err := s.EncodeToYaml(resource)
+	if err != nil {
+		return err
+	}
+
+	err = s.WriteFile(s.pathProvider.GetNamespace(), fileName, content, common.TrafficPolicies)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *TrafficPolicyHandler) Get(name string, opts GetOptions) (*v2alpha1.TrafficPolicy, error) {
+	var context v2alpha1.TrafficPolicy
+	fileName := name + ".yaml"
+
+	if opts.RuntimeFirst == true {
+		// First read from runtime directory, where output is found after bootstrap
+		// has run.  If no runtime traffic policies try and display configured ones
+		err, file := s.ReadFile(s.pathProvider.GetRuntimeNamespace(), fileName, common.TrafficPolicies)
+		if err != nil {
+			if opts.LogWarning {
+				os.Stderr.WriteString("Site not initialized yet\n")
+			}
+			err, file = s.ReadFile(s.pathProvider.GetNamespace(), fileName, common.TrafficPolicies)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if err = s.DecodeYaml(file, &context); err != nil {
+			return nil, err
+		}
+	} else {
+		// read from input directory to get latest config
+		err, file := s.ReadFile(s.pathProvider.GetNamespace(), fileName, common.TrafficPolicies)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.DecodeYaml(file, &context); err != nil {
+			return nil, err
+		}
+	}
+
+	return &context, nil
+}
+
+func (s *TrafficPolicyHandler) Delete(name string) error {
+	fileName := name + ".yaml"
+
+	if err := s.DeleteFile(s.pathProvider.GetNamespace(), fileName, common.TrafficPolicies); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *TrafficPolicyHandler) List() ([]*v2alpha1.TrafficPolicy, error) {
+	var trafficPolicies []*v2alpha1.TrafficPolicy
+
+	// First read from runtime directory, where output is found after bootstrap
+	// has run.  If no runtime traffic policies try and display configured ones
+	path := s.pathProvider.GetRuntimeNamespace()
+	err, files := s.ReadDir(path, common.TrafficPolicies)
+	if err != nil {
+		os.Stderr.WriteString("Site not initialized yet\n")
+		path = s.pathProvider.GetNamespace()
+		err, files = s.ReadDir(path, common.TrafficPolicies)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, file := range files {
+		err, policy := s.ReadFile(path, file.Name(), common.TrafficPolicies)
+		if err != nil {
+			return nil, err
+		}
+		var context v2alpha1.TrafficPolicy
+		if err = s.DecodeYaml(policy, &context); err != nil {
+			return nil, err
+		}
+		trafficPolicies = append(trafficPolicies, &context)
+	}
+	return trafficPolicies, nil
+}
@@ -15,6 +15,24 @@ func GetLocalRouterAddress(namespace string) (string, error) {
 	return address, nil
 }
 
+// GetLocalRouterAddresses returns the address of every role of the local
+// router access, starting with the one returned by GetLocalRouterAddress.
+func GetLocalRouterAddresses(namespace string) ([]string, error) {
+	client := fs.NewRouterAccessHandler(namespace)
+	ra, err := client.Get("skupper-local")
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine router port: %w", err)
+	}
+	if len(ra.Spec.Roles) == 0 {
+		return nil, fmt.Errorf("no roles defined on RouterAccess: %s", ra.Name)
+	}
+	var addresses []string
+	for _, role := range ra.Spec.Roles {
+		addresses = append(addresses, fmt.Sprintf("amqps://127.0.0.1:%d", role.Port))
+	}
+	return addresses, nil
+}
+
 func GetLocalRouterPort(namespace string) (int, error) {
 	client := fs.NewRouterAccessHandler(namespace)
 	ra, err := client.Get("skupper-local")
@@ -333,6 +333,7 @@ func fakeSiteState() *api.SiteState {
 				},
 			},
 		},
-		ConfigMaps: make(map[string]*corev1.ConfigMap),
+		TrafficPolicies: make(map[string]*v2alpha1.TrafficPolicy),
+		ConfigMaps:      make(map[string]*corev1.ConfigMap),
 	}
 }
@@ -86,6 +86,7 @@ func GetNamespacesFound(s *api.SiteState) []string {
 	addNamespacesFromMap(s.Certificates, nsMap)
 	addNamespacesFromMap(s.SecuredAccesses, nsMap)
 	addNamespacesFromMap(s.MultiKeyListeners, nsMap)
+	addNamespacesFromMap(s.TrafficPolicies, nsMap)
 	addNamespacesFromMap(s.ConfigMaps, nsMap)
 	for ns := range nsMap {
 		namespaces = append(namespaces, ns)
@@ -159,6 +160,10 @@ func LoadIntoSiteState(reader *bufio.Reader, siteState *api.SiteState) error {
 				var mkl v2alpha1.MultiKeyListener
 				runtime.DefaultUnstructuredConverter.FromUnstructured(obj.(runtime.Unstructured).UnstructuredContent(), &mkl)
 				siteState.MultiKeyListeners[mkl.Name] = &mkl
+			case "TrafficPolicy":
+				var policy v2alpha1.TrafficPolicy
+				runtime.DefaultUnstructuredConverter.FromUnstructured(obj.(runtime.Unstructured).UnstructuredContent(), &policy)
+				siteState.TrafficPolicies[policy.Name] = &policy
 			default:
 				logInvalidResource(gvk)
 			}
@@ -195,3 +200,16 @@ func LoadRouterConfig(namespace string) (*qdr.RouterConfig, error) {
 	}
 	return &routerConfig, nil
 }
+
+func SaveRouterConfig(namespace string, routerConfig *qdr.RouterConfig) error {
+	routerConfigPath := api.GetInternalOutputPath(namespace, api.RouterConfigPath)
+	routerConfigFile := path.Join(routerConfigPath, "skrouterd.json")
+	routerConfigJson, err := qdr.MarshalRouterConfig(*routerConfig)
+	if err != nil {
+		return fmt.Errorf("unable to marshal router configuration: %w", err)
+	}
+	if err := os.WriteFile(routerConfigFile, []byte(routerConfigJson), 0644); err != nil {
+		return fmt.Errorf("unable to write router configuration: %w", err)
+	}
+	return nil
+}
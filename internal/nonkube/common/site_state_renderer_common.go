@@ -23,6 +23,7 @@ func CopySiteState(siteState *api.SiteState) *api.SiteState {
 	activeSiteState.Grants = copySiteStateMap(siteState.Grants)
 	activeSiteState.SecuredAccesses = copySiteStateMap(siteState.SecuredAccesses)
 	activeSiteState.MultiKeyListeners = copySiteStateMap(siteState.MultiKeyListeners)
+	activeSiteState.TrafficPolicies = copySiteStateMap(siteState.TrafficPolicies)
 	activeSiteState.Certificates = copySiteStateMap(siteState.Certificates)
 	activeSiteState.Secrets = copySiteStateMap(siteState.Secrets)
 	activeSiteState.ConfigMaps = copySiteStateMap(siteState.ConfigMaps)
@@ -55,6 +56,8 @@ func copySiteStateMap[T any](m map[string]T) map[string]T {
 			c = vv.DeepCopy()
 		case *v2alpha1.MultiKeyListener:
 			c = vv.DeepCopy()
+		case *v2alpha1.TrafficPolicy:
+			c = vv.DeepCopy()
 		case *corev1.Secret:
 			c = vv.DeepCopy()
 		}
@@ -3,9 +3,12 @@ package common
 import (
 	"fmt"
 	"net"
+	"path"
 	"regexp"
 	"slices"
+	"strings"
 
+	"github.com/skupperproject/skupper/internal/utils/validator"
 	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 	"github.com/skupperproject/skupper/pkg/nonkube/api"
 	corev1 "k8s.io/api/core/v1"
@@ -45,13 +48,24 @@ func (s *SiteStateValidator) Validate(siteState *api.SiteState) error {
 	if err = s.validateGrants(siteState.Grants); err != nil {
 		return err
 	}
-	if err = s.validateListeners(siteState.Listeners); err != nil {
+	// the separators of routing key scope addresses are only reserved in a
+	// site with a routing key scope; connectors never import, so "/" is
+	// reserved in their routing keys
+	var listenerKeys, connectorKeys validator.Validator
+	if siteState.Site != nil && siteState.Site.Spec.RoutingKeyScope != nil && siteState.Site.Spec.RoutingKeyScope.Name != "" {
+		listenerKeys = validator.NewRoutingKeyValidator(true)
+		connectorKeys = validator.NewRoutingKeyValidator(false)
+	}
+	if err = s.validateListeners(siteState.Listeners, listenerKeys); err != nil {
+		return err
+	}
+	if err = s.validateConnectors(siteState.Connectors, connectorKeys); err != nil {
 		return err
 	}
-	if err = s.validateConnectors(siteState.Connectors); err != nil {
+	if err = s.validateMultiKeyListeners(siteState.MultiKeyListeners, siteState.Listeners, listenerKeys); err != nil {
 		return err
 	}
-	if err = s.validateMultiKeyListeners(siteState.MultiKeyListeners, siteState.Listeners); err != nil {
+	if err = s.validateTrafficPolicies(siteState.TrafficPolicies); err != nil {
 		return err
 	}
 
@@ -68,6 +82,21 @@ func (s *SiteStateValidator) validateSite(site *v2alpha1.Site) error {
 	if err := ValidateName(site.Name); err != nil {
 		return fmt.Errorf("invalid site name: %w", err)
 	}
+	if scope := site.Spec.RoutingKeyScope; scope != nil {
+		if err := ValidateName(scope.Name); err != nil {
+			return fmt.Errorf("invalid routing key scope name: %w", err)
+		}
+		for _, pattern := range scope.Exports {
+			if _, err := path.Match(pattern, ""); pattern == "" || err != nil {
+				return fmt.Errorf("invalid routing key scope: %s - invalid export %q", scope.Name, pattern)
+			}
+		}
+		for _, key := range scope.Imports {
+			if exporter, routingKey, ok := strings.Cut(key, "/"); !ok || ValidateName(exporter) != nil || routingKey == "" {
+				return fmt.Errorf("invalid routing key scope: %s - import %q must be of the form <scope>/<routing key>", scope.Name, key)
+			}
+		}
+	}
 	return nil
 }
 
@@ -131,7 +160,7 @@ func (s *SiteStateValidator) validateGrants(grants map[string]*v2alpha1.AccessGr
 	return nil
 }
 
-func (s *SiteStateValidator) validateListeners(listeners map[string]*v2alpha1.Listener) error {
+func (s *SiteStateValidator) validateListeners(listeners map[string]*v2alpha1.Listener, keys validator.Validator) error {
 	hostPorts := map[string][]int{}
 	for name, listener := range listeners {
 		if err := ValidateName(listener.Name); err != nil {
@@ -151,12 +180,15 @@ func (s *SiteStateValidator) validateListeners(listeners map[string]*v2alpha1.Li
 		if listener.Spec.RoutingKey == "" {
 			return fmt.Errorf("routingKey is missing for listener: %s", listener.Name)
 		}
+		if err := checkRoutingKey(keys, listener.Spec.RoutingKey); err != nil {
+			return fmt.Errorf("invalid listener routingKey: %w (listener: %q)", err, name)
+		}
 		hostPorts[listener.Spec.Host] = append(hostPorts[listener.Spec.Host], listener.Spec.Port)
 	}
 	return nil
 }
 
-func (s *SiteStateValidator) validateConnectors(connectors map[string]*v2alpha1.Connector) error {
+func (s *SiteStateValidator) validateConnectors(connectors map[string]*v2alpha1.Connector, keys validator.Validator) error {
 	for _, connector := range connectors {
 		if err := ValidateName(connector.Name); err != nil {
 			return fmt.Errorf("invalid connector name: %w", err)
@@ -172,11 +204,25 @@ func (s *SiteStateValidator) validateConnectors(connectors map[string]*v2alpha1.
 		if connector.Spec.RoutingKey == "" {
 			return fmt.Errorf("routingKey is missing for connector: %s", connector.Name)
 		}
+		if err := checkRoutingKey(keys, connector.Spec.RoutingKey); err != nil {
+			return fmt.Errorf("invalid connector routingKey: %w (connector: %q)", err, connector.Name)
+		}
+		if check := connector.Spec.HealthCheck; check != nil {
+			if check.Type != "" && check.Type != v2alpha1.HealthCheckTypeTcp && check.Type != v2alpha1.HealthCheckTypeHttp {
+				return fmt.Errorf("invalid connector health check type: %s - tcp or http is expected (connector: %q)", check.Type, connector.Name)
+			}
+			if check.Type == v2alpha1.HealthCheckTypeHttp && connector.Spec.TlsCredentials != "" {
+				return fmt.Errorf("invalid connector health check type: %s - tcp is expected for connectors with tlsCredentials (connector: %q)", check.Type, connector.Name)
+			}
+			if check.Port < 0 || check.Port > 65535 || check.PeriodSeconds < 0 || check.TimeoutSeconds < 0 || check.FailureThreshold < 0 || check.SuccessThreshold < 0 {
+				return fmt.Errorf("invalid connector health check: port, period, timeout and thresholds cannot be negative or out of range (connector: %q)", connector.Name)
+			}
+		}
 	}
 	return nil
 }
 
-func (s *SiteStateValidator) validateMultiKeyListeners(multiKeyListeners map[string]*v2alpha1.MultiKeyListener, listeners map[string]*v2alpha1.Listener) error {
+func (s *SiteStateValidator) validateMultiKeyListeners(multiKeyListeners map[string]*v2alpha1.MultiKeyListener, listeners map[string]*v2alpha1.Listener, keys validator.Validator) error {
 	// collect host:port pairs already used by listeners
 	hostPorts := map[string][]int{}
 	for _, listener := range listeners {
@@ -211,6 +257,9 @@ func (s *SiteStateValidator) validateMultiKeyListeners(multiKeyListeners map[str
 				if key == "" {
 					return fmt.Errorf("invalid multikeylistener: %s - routingKey must not be empty", mkl.Name)
 				}
+				if err := checkRoutingKey(keys, key); err != nil {
+					return fmt.Errorf("invalid multikeylistener: %s - %w", mkl.Name, err)
+				}
 			}
 		}
 		if mkl.Spec.Strategy.Weighted != nil {
@@ -221,6 +270,9 @@ func (s *SiteStateValidator) validateMultiKeyListeners(multiKeyListeners map[str
 				if key == "" {
 					return fmt.Errorf("invalid multikeylistener: %s - routingKey must not be empty", mkl.Name)
 				}
+				if err := checkRoutingKey(keys, key); err != nil {
+					return fmt.Errorf("invalid multikeylistener: %s - %w", mkl.Name, err)
+				}
 				if weight <= 0 {
 					return fmt.Errorf("invalid multikeylistener: %s - weight value must be positive", mkl.Name)
 				}
@@ -230,9 +282,48 @@ func (s *SiteStateValidator) validateMultiKeyListeners(multiKeyListeners map[str
 	return nil
 }
 
+func (s *SiteStateValidator) validateTrafficPolicies(policies map[string]*v2alpha1.TrafficPolicy) error {
+	for _, policy := range policies {
+		if err := ValidateName(policy.Name); err != nil {
+			return fmt.Errorf("invalid traffic policy name: %w", err)
+		}
+		if len(policy.Spec.RoutingKeys) == 0 {
+			return fmt.Errorf("invalid traffic policy: %s - routingKeys must not be empty", policy.Name)
+		}
+		patterns := slices.Clone(policy.Spec.RoutingKeys)
+		for _, rule := range []*v2alpha1.TrafficPolicyRule{policy.Spec.Listeners, policy.Spec.Connectors} {
+			if rule != nil {
+				patterns = append(patterns, rule.AllowedSites...)
+				patterns = append(patterns, rule.DeniedSites...)
+			}
+		}
+		for _, pattern := range patterns {
+			if pattern == "" {
+				return fmt.Errorf("invalid traffic policy: %s - patterns must not be empty", policy.Name)
+			}
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid traffic policy: %s - invalid pattern %q", policy.Name, pattern)
+			}
+		}
+	}
+	return nil
+}
+
 func ValidateName(name string) error {
 	if !rfc1123Regex.MatchString(name) {
 		return fmt.Errorf("invalid name %q: %s", name, rfc1123Error)
 	}
 	return nil
 }
+
+// checkRoutingKey evaluates the routing key with keys, which is nil in a
+// site without a routing key scope, where no characters are reserved.
+func checkRoutingKey(keys validator.Validator, routingKey string) error {
+	if keys == nil {
+		return nil
+	}
+	if ok, err := keys.Evaluate(routingKey); !ok {
+		return err
+	}
+	return nil
+}
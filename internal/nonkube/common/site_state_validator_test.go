@@ -201,6 +201,44 @@ func TestSiteStateValidator_Validate(t *testing.T) {
 			valid:         false,
 			errorContains: "invalid connector host: ",
 		},
+		{
+			info: "valid-connector-health-check",
+			siteState: customize(func(siteState *api.SiteState) {
+				for _, connector := range siteState.Connectors {
+					connector.Spec.TlsCredentials = ""
+					connector.Spec.HealthCheck = &v2alpha1.HealthCheck{
+						Type: "http",
+						Path: "/healthz",
+					}
+				}
+			}),
+			valid: true,
+		},
+		{
+			info: "invalid-connector-health-check-type",
+			siteState: customize(func(siteState *api.SiteState) {
+				for _, connector := range siteState.Connectors {
+					connector.Spec.HealthCheck = &v2alpha1.HealthCheck{
+						Type: "grpc",
+					}
+				}
+			}),
+			valid:         false,
+			errorContains: "invalid connector health check type: ",
+		},
+		{
+			info: "invalid-connector-health-check-http-tls",
+			siteState: customize(func(siteState *api.SiteState) {
+				for _, connector := range siteState.Connectors {
+					connector.Spec.TlsCredentials = "backend-tls"
+					connector.Spec.HealthCheck = &v2alpha1.HealthCheck{
+						Type: "http",
+					}
+				}
+			}),
+			valid:         false,
+			errorContains: "tcp is expected for connectors with tlsCredentials",
+		},
 		{
 			info: "invalid-claim-name",
 			siteState: customize(func(siteState *api.SiteState) {
@@ -325,6 +363,158 @@ func TestSiteStateValidator_Validate(t *testing.T) {
 			valid:         false,
 			errorContains: "is already mapped for host",
 		},
+		{
+			info: "invalid-routing-key-scope-name",
+			siteState: customize(func(siteState *api.SiteState) {
+				siteState.Site.Spec.RoutingKeyScope = &v2alpha1.RoutingKeyScope{
+					Name: "team/a",
+				}
+			}),
+			valid:         false,
+			errorContains: "invalid routing key scope name:",
+		},
+		{
+			info: "invalid-routing-key-scope-export",
+			siteState: customize(func(siteState *api.SiteState) {
+				siteState.Site.Spec.RoutingKeyScope = &v2alpha1.RoutingKeyScope{
+					Name:    "team-a",
+					Exports: []string{"api-["},
+				}
+			}),
+			valid:         false,
+			errorContains: `invalid export "api-["`,
+		},
+		{
+			info: "invalid-routing-key-scope-import",
+			siteState: customize(func(siteState *api.SiteState) {
+				siteState.Site.Spec.RoutingKeyScope = &v2alpha1.RoutingKeyScope{
+					Name:    "team-a",
+					Imports: []string{"db"},
+				}
+			}),
+			valid:         false,
+			errorContains: "must be of the form <scope>/<routing key>",
+		},
+		{
+			info: "valid-routing-key-scope",
+			siteState: customize(func(siteState *api.SiteState) {
+				siteState.Site.Spec.RoutingKeyScope = &v2alpha1.RoutingKeyScope{
+					Name:    "team-a",
+					Exports: []string{"api-*"},
+					Imports: []string{"team-b/db"},
+				}
+			}),
+			valid: true,
+		},
+		{
+			info: "valid-routing-key-separators-without-scope",
+			siteState: customize(func(siteState *api.SiteState) {
+				for _, listener := range siteState.Listeners {
+					listener.Spec.RoutingKey = "team-b/db"
+				}
+				for _, connector := range siteState.Connectors {
+					connector.Spec.RoutingKey = "echo:9090"
+				}
+			}),
+			valid: true,
+		},
+		{
+			info: "invalid-listener-routing-key-scope-address",
+			siteState: customize(func(siteState *api.SiteState) {
+				siteState.Site.Spec.RoutingKeyScope = &v2alpha1.RoutingKeyScope{Name: "team-a"}
+				for _, listener := range siteState.Listeners {
+					listener.Spec.RoutingKey = "team-b:db"
+				}
+			}),
+			valid:         false,
+			errorContains: "invalid listener routingKey: ",
+		},
+		{
+			info: "invalid-connector-routing-key-scope-address",
+			siteState: customize(func(siteState *api.SiteState) {
+				siteState.Site.Spec.RoutingKeyScope = &v2alpha1.RoutingKeyScope{Name: "team-a"}
+				for _, connector := range siteState.Connectors {
+					connector.Spec.RoutingKey = "team-b:db"
+				}
+			}),
+			valid:         false,
+			errorContains: "invalid connector routingKey: ",
+		},
+		{
+			info: "valid-listener-routing-key-import",
+			siteState: customize(func(siteState *api.SiteState) {
+				siteState.Site.Spec.RoutingKeyScope = &v2alpha1.RoutingKeyScope{
+					Name:    "team-a",
+					Imports: []string{"team-b/db"},
+				}
+				for _, listener := range siteState.Listeners {
+					listener.Spec.RoutingKey = "team-b/db"
+				}
+			}),
+			valid: true,
+		},
+		{
+			info: "invalid-traffic-policy-name",
+			siteState: customize(func(siteState *api.SiteState) {
+				siteState.TrafficPolicies["bad_name"] = &v2alpha1.TrafficPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "bad_name",
+					},
+					Spec: v2alpha1.TrafficPolicySpec{
+						RoutingKeys: []string{"db"},
+					},
+				}
+			}),
+			valid:         false,
+			errorContains: "invalid traffic policy name:",
+		},
+		{
+			info: "invalid-traffic-policy-empty-routing-keys",
+			siteState: customize(func(siteState *api.SiteState) {
+				siteState.TrafficPolicies["policy"] = &v2alpha1.TrafficPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "policy",
+					},
+				}
+			}),
+			valid:         false,
+			errorContains: "routingKeys must not be empty",
+		},
+		{
+			info: "invalid-traffic-policy-pattern",
+			siteState: customize(func(siteState *api.SiteState) {
+				siteState.TrafficPolicies["policy"] = &v2alpha1.TrafficPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "policy",
+					},
+					Spec: v2alpha1.TrafficPolicySpec{
+						RoutingKeys: []string{"db"},
+						Connectors: &v2alpha1.TrafficPolicyRule{
+							AllowedSites: []string{"site-["},
+						},
+					},
+				}
+			}),
+			valid:         false,
+			errorContains: `invalid pattern "site-["`,
+		},
+		{
+			info: "valid-traffic-policy",
+			siteState: customize(func(siteState *api.SiteState) {
+				siteState.TrafficPolicies["policy"] = &v2alpha1.TrafficPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "policy",
+					},
+					Spec: v2alpha1.TrafficPolicySpec{
+						RoutingKeys: []string{"db-*"},
+						Connectors: &v2alpha1.TrafficPolicyRule{
+							AllowedSites: []string{"site-*"},
+						},
+					},
+				}
+			}),
+			valid: true,
+		},
 		{
 			info:      "valid-site-state",
 			siteState: fakeSiteState(),
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"log/slog"
+	"sync"
+
+	"github.com/skupperproject/skupper/internal/nonkube/client/runtime"
+	"github.com/skupperproject/skupper/internal/nonkube/common"
+	"github.com/skupperproject/skupper/internal/qdr"
+	"github.com/skupperproject/skupper/internal/site"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+)
+
+// ConnectorHealthHandler runs the health checks configured for the
+// connectors in the runtime site state while the router is running. When
+// the health of a target changes, it is recorded in the connector status
+// and the router configuration is updated so that only healthy targets
+// receive traffic.
+type ConnectorHealthHandler struct {
+	running   bool
+	namespace string
+	logger    *slog.Logger
+	lock      sync.Mutex
+	monitor   *site.HealthMonitor
+	agentPool *qdr.AgentPool
+	sync      func(bridges *qdr.BridgeConfig) error
+}
+
+func NewConnectorHealthHandler(namespace string) *ConnectorHealthHandler {
+	handler := &ConnectorHealthHandler{
+		namespace: namespace,
+	}
+	handler.sync = handler.syncWithRouter
+	handler.logger = slog.Default().With("component", handler.Id(), "namespace", namespace)
+	return handler
+}
+
+func (h *ConnectorHealthHandler) Start(stopCh <-chan struct{}) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.running {
+		return
+	}
+	siteState, err := h.siteStateLoader().Load()
+	if err != nil {
+		h.logger.Error("Error loading runtime site state", slog.Any("error", err))
+		return
+	}
+	h.logger.Info("Starting")
+	h.running = true
+	h.monitor = site.NewHealthMonitor(h.targetHealthChanged)
+	for name, connector := range siteState.Connectors {
+		h.monitor.Update(name, connector.Spec.HealthCheck, connector.Spec.Port, []string{connector.Spec.Host})
+	}
+}
+
+func (h *ConnectorHealthHandler) Stop() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.running {
+		h.logger.Info("Stopping")
+		h.monitor.Stop()
+		h.running = false
+	}
+}
+
+func (h *ConnectorHealthHandler) Id() string {
+	return "connector.health.handler"
+}
+
+func (h *ConnectorHealthHandler) siteStateLoader() *common.FileSystemSiteStateLoader {
+	return &common.FileSystemSiteStateLoader{
+		Path: api.GetInternalOutputPath(h.namespace, api.RuntimeSiteStatePath),
+	}
+}
+
+func (h *ConnectorHealthHandler) targetHealthChanged(name string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if !h.running {
+		return
+	}
+	runtimeSiteStateLock.Lock()
+	defer runtimeSiteStateLock.Unlock()
+	siteState, err := h.siteStateLoader().Load()
+	if err != nil {
+		h.logger.Error("Error loading runtime site state", slog.Any("error", err))
+		return
+	}
+	connector, ok := siteState.Connectors[name]
+	if !ok || !connector.SetTargetHealth(h.monitor.Targets(name)) {
+		return
+	}
+	h.logger.Info("Health of connector targets changed",
+		slog.String("connector", name),
+		slog.Any("targets", connector.Status.Targets))
+	if err := api.MarshalSiteState(*siteState, api.GetInternalOutputPath(h.namespace, api.RuntimeSiteStatePath)); err != nil {
+		h.logger.Error("Error marshaling runtime site state", slog.Any("error", err))
+		return
+	}
+	routerConfig, err := common.LoadRouterConfig(h.namespace)
+	if err != nil {
+		h.logger.Error("Error loading router config", slog.Any("error", err))
+		return
+	}
+	if !routerConfig.UpdateBridgeConfig(siteState.ToBridgeConfig()) {
+		return
+	}
+	if err := common.SaveRouterConfig(h.namespace, routerConfig); err != nil {
+		h.logger.Error("Error saving router config", slog.Any("error", err))
+		return
+	}
+	if err := h.sync(&routerConfig.Bridges); err != nil {
+		h.logger.Error("Error updating router bridge configuration", slog.Any("error", err))
+	}
+}
+
+func (h *ConnectorHealthHandler) syncWithRouter(bridges *qdr.BridgeConfig) error {
+	if h.agentPool == nil {
+		address, err := runtime.GetLocalRouterAddress(h.namespace)
+		if err != nil {
+			return err
+		}
+		h.agentPool = qdr.NewAgentPool(address, runtime.GetRuntimeTlsCert(h.namespace, "skupper-local-client"))
+	}
+	return qdr.SyncBridgeConfig(h.agentPool, bridges)
+}
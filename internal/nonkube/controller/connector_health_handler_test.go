@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"net"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/nonkube/common"
+	"github.com/skupperproject/skupper/internal/qdr"
+	"github.com/skupperproject/skupper/internal/utils"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"gotest.tools/v3/assert"
+)
+
+func TestConnectorHealthHandler(t *testing.T) {
+	tempDir := t.TempDir()
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = tempDir
+	} else {
+		t.Setenv("XDG_DATA_HOME", tempDir)
+	}
+	namespace := "test-connector-health-handler"
+	runtimePath := api.GetInternalOutputPath(namespace, api.RuntimeSiteStatePath)
+	assert.Assert(t, os.MkdirAll(runtimePath, 0755))
+	assert.Assert(t, os.MkdirAll(api.GetInternalOutputPath(namespace, api.RouterConfigPath), 0755))
+
+	// nothing listens on the connector's port, so the check fails
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Assert(t, err)
+	port := closed.Addr().(*net.TCPAddr).Port
+	closed.Close()
+
+	siteState := fakeSiteState()
+	connector := siteState.Connectors["connector-one"]
+	connector.Spec.Host = "127.0.0.1"
+	connector.Spec.Port = port
+	connector.Spec.HealthCheck = &v2alpha1.HealthCheck{
+		PeriodSeconds:    1,
+		FailureThreshold: 1,
+	}
+	routerConfig := siteState.ToRouterConfig("", "linux")
+	_, ok := routerConfig.Bridges.TcpConnectors["connector/connector-one@127.0.0.1"]
+	assert.Assert(t, ok)
+	assert.Assert(t, api.MarshalSiteState(*siteState, runtimePath))
+	assert.Assert(t, common.SaveRouterConfig(namespace, &routerConfig))
+
+	var lock sync.Mutex
+	var synced []qdr.BridgeConfig
+	handler := NewConnectorHealthHandler(namespace)
+	handler.sync = func(bridges *qdr.BridgeConfig) error {
+		lock.Lock()
+		defer lock.Unlock()
+		synced = append(synced, *bridges)
+		return nil
+	}
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	handler.Start(stopCh)
+	defer handler.Stop()
+
+	assert.Assert(t, verifyJsonPathExpected(namespace, "Connector", "connector-one", "{.status.targets[0].host}", "127.0.0.1"))
+	assert.Assert(t, verifyJsonPathExpected(namespace, "Connector", "connector-one", "{.status.targets[0].healthy}", "false"))
+	err = utils.Retry(time.Second*delaySecs, attempts, func() (bool, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(synced) > 0, nil
+	})
+	assert.Assert(t, err)
+	lock.Lock()
+	_, ok = synced[0].TcpConnectors["connector/connector-one@127.0.0.1"]
+	lock.Unlock()
+	assert.Assert(t, !ok)
+
+	updated, err := common.LoadRouterConfig(namespace)
+	assert.Assert(t, err)
+	_, ok = updated.Bridges.TcpConnectors["connector/connector-one@127.0.0.1"]
+	assert.Assert(t, !ok)
+	assert.Equal(t, len(updated.Bridges.TcpListeners), len(routerConfig.Bridges.TcpListeners))
+}
@@ -45,6 +45,7 @@ func (w *NamespaceController) Start() {
 		routerConfigHandler.AddCallback(routerStateHandler)
 		collectorLifecycleHandler := NewCollectorLifecycleHandler(w.ns)
 		routerStateHandler.AddCallback(collectorLifecycleHandler)
+		routerStateHandler.AddCallback(NewConnectorHealthHandler(w.ns))
 		inputResourceHandler := NewInputResourceHandler(w.ns, w.pathProvider.GetNamespace(), bootstrap.Bootstrap, bootstrap.PostBootstrap, bootstrap.Teardown)
 		systemAdaptorHandler := NewSystemAdaptorHandler(w.ns)
 		if systemAdaptorHandler != nil {
@@ -16,6 +16,10 @@ import (
 	"k8s.io/apimachinery/pkg/util/yaml"
 )
 
+// runtimeSiteStateLock serialises the handlers that update the runtime
+// site state.
+var runtimeSiteStateLock sync.Mutex
+
 type NetworkStatusHandler struct {
 	Namespace string
 	basePath  string
@@ -68,6 +72,8 @@ func (n *NetworkStatusHandler) processEvents(done chan struct{}) {
 }
 
 func (n *NetworkStatusHandler) updateRuntimeSiteState(networkStatusInfo network.NetworkStatusInfo, ready bool) {
+	runtimeSiteStateLock.Lock()
+	defer runtimeSiteStateLock.Unlock()
 	runtimeSiteStatePath := api.GetInternalOutputPath(n.Namespace, api.RuntimeSiteStatePath)
 	siteStateLoader := &common.FileSystemSiteStateLoader{
 		Path: runtimeSiteStatePath,
@@ -66,7 +66,7 @@ func siteCollector(ctx context.Context, namespace string) error {
 		return err
 	}
 
-	address, err := runtime.GetLocalRouterAddress(namespace)
+	addresses, err := runtime.GetLocalRouterAddresses(namespace)
 	if err != nil {
 		return err
 	}
@@ -74,10 +74,13 @@ func siteCollector(ctx context.Context, namespace string) error {
 	if err != nil {
 		return err
 	}
-	factory := session.NewContainerFactory(address, session.ContainerConfig{
-		ContainerID: "nonkube-flow-collector",
-		TLSConfig:   tlsConfig,
-		SASLType:    session.SASLTypeExternal,
+	// a system site runs a single router: fail over between the ports it
+	// exposes for local access rather than to another router
+	factory := session.NewContainerFactory(addresses[0], session.ContainerConfig{
+		ContainerID:       "nonkube-flow-collector",
+		TLSConfig:         tlsConfig,
+		SASLType:          session.SASLTypeExternal,
+		FailoverAddresses: addresses[1:],
 	})
 	statusSyncClient := &StatusSyncClient{
 		client:    fs.NewConfigMapHandler(namespace),
@@ -1,9 +1,13 @@
 package site
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
+	"slices"
 
 	"github.com/skupperproject/skupper/internal/qdr"
+	"github.com/skupperproject/skupper/internal/utils/validator"
 	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 )
 
@@ -21,15 +25,25 @@ type BindingEventHandler interface {
 type ConnectorFunction func(*skupperv2alpha1.Connector) *skupperv2alpha1.Connector
 type ListenerFunction func(*skupperv2alpha1.Listener) *skupperv2alpha1.Listener
 type MultiKeyListenerFunction func(*skupperv2alpha1.MultiKeyListener) *skupperv2alpha1.MultiKeyListener
+type TrafficPolicyFunction func(*skupperv2alpha1.TrafficPolicy) *skupperv2alpha1.TrafficPolicy
 
 type Bindings struct {
 	SiteId            string
+	SiteName          string
 	ProfilePath       string
 	connectors        map[string]*skupperv2alpha1.Connector
 	listeners         map[string]*skupperv2alpha1.Listener
 	multiKeyListeners map[string]*skupperv2alpha1.MultiKeyListener
-	handler           BindingEventHandler
-	configure         struct {
+	// policies are those defined for this site. They are enforced only
+	// here: on the bindings of this site and, through listenerSites, on
+	// the listeners other sites bind to the keys of its connectors.
+	policies TrafficPolicies
+	// listenerSites are the names of the sites with listeners for each
+	// router address, as last reported by the network status.
+	listenerSites map[string][]string
+	scope         *skupperv2alpha1.RoutingKeyScope
+	handler       BindingEventHandler
+	configure     struct {
 		listener         ListenerConfiguration
 		connector        ConnectorConfiguration
 		multiKeyListener MultiKeyListenerConfiguration
@@ -42,6 +56,7 @@ func NewBindings(profilePath string) *Bindings {
 		connectors:        map[string]*skupperv2alpha1.Connector{},
 		listeners:         map[string]*skupperv2alpha1.Listener{},
 		multiKeyListeners: map[string]*skupperv2alpha1.MultiKeyListener{},
+		policies:          TrafficPolicies{},
 	}
 	bindings.configure.listener = UpdateBridgeConfigForListener
 	bindings.configure.connector = UpdateBridgeConfigForConnector
@@ -53,6 +68,34 @@ func (b *Bindings) SetSiteId(siteId string) {
 	b.SiteId = siteId
 }
 
+func (b *Bindings) SetSiteName(siteName string) {
+	b.SiteName = siteName
+}
+
+// SetRoutingKeyScope sets the scope used to qualify the routing keys of
+// the bindings, returning true if it changed.
+func (b *Bindings) SetRoutingKeyScope(scope *skupperv2alpha1.RoutingKeyScope) bool {
+	if reflect.DeepEqual(b.scope, scope) {
+		return false
+	}
+	b.scope = scope
+	return true
+}
+
+func (b *Bindings) RoutingKeyScope() *skupperv2alpha1.RoutingKeyScope {
+	return b.scope
+}
+
+// ListenerAddress returns the router address for a listener routing key.
+func (b *Bindings) ListenerAddress(routingKey string) string {
+	return ListenerAddress(b.scope, routingKey)
+}
+
+// ConnectorAddress returns the router address for a connector routing key.
+func (b *Bindings) ConnectorAddress(routingKey string) string {
+	return ConnectorAddress(b.scope, routingKey)
+}
+
 func (b *Bindings) SetListenerConfiguration(configuration ListenerConfiguration) {
 	b.configure.listener = configuration
 }
@@ -98,6 +141,16 @@ func (b *Bindings) MapOverMultiKeyListeners(mkf MultiKeyListenerFunction) {
 	}
 }
 
+func (b *Bindings) MapOverTrafficPolicies(pf TrafficPolicyFunction) {
+	if pf != nil {
+		for key, policy := range b.policies {
+			if updated := pf(policy); updated != nil {
+				b.policies[key] = updated
+			}
+		}
+	}
+}
+
 func (b *Bindings) GetConnector(name string) *skupperv2alpha1.Connector {
 	if existing, ok := b.connectors[name]; ok {
 		return existing
@@ -220,6 +273,107 @@ func (b *Bindings) deleteMultiKeyListener(name string) qdr.ConfigUpdate {
 	return b
 }
 
+func (b *Bindings) UpdateTrafficPolicy(name string, policy *skupperv2alpha1.TrafficPolicy) qdr.ConfigUpdate {
+	existing, ok := b.policies[name]
+	if policy == nil {
+		if !ok {
+			return nil
+		}
+		delete(b.policies, name)
+		return b
+	}
+	b.policies[name] = policy
+	if ok && reflect.DeepEqual(existing.Spec, policy.Spec) {
+		return nil
+	}
+	return b
+}
+
+// CheckListener returns an error if a traffic policy does not permit this
+// site to bind the listener.
+func (b *Bindings) CheckListener(listener *skupperv2alpha1.Listener) error {
+	if err := checkRoutingKey(b.scope, listener.Spec.RoutingKey, true); err != nil {
+		return err
+	}
+	return b.policies.CheckListener(b.SiteName, listener)
+}
+
+// CheckConnector returns an error if a traffic policy does not permit this
+// site to bind the connector, or withholds connectors while it denies a
+// site in the network that has a listener for it.
+func (b *Bindings) CheckConnector(connector *skupperv2alpha1.Connector) error {
+	// connectors never import, so "/" is reserved in their routing keys
+	if err := checkRoutingKey(b.scope, connector.Spec.RoutingKey, false); err != nil {
+		return err
+	}
+	if err := ValidateHealthCheck(connector); err != nil {
+		return err
+	}
+	if err := b.policies.CheckConnector(b.SiteName, connector); err != nil {
+		return err
+	}
+	address := ConnectorAddress(b.scope, connector.Spec.RoutingKey)
+	if err := b.policies.CheckListenerSites(connector.Spec.RoutingKey, b.listenerSites[address]); err != nil {
+		return fmt.Errorf("Connector withheld from all sites: %w", err)
+	}
+	return nil
+}
+
+// CheckMultiKeyListener returns an error if any of the strategy routing
+// keys of the multi-key listener is reserved or a traffic policy does not
+// permit this site to bind it. Those keys are left out of its
+// configuration.
+func (b *Bindings) CheckMultiKeyListener(mkl *skupperv2alpha1.MultiKeyListener) error {
+	var errs []error
+	for _, routingKey := range multiKeyListenerRoutingKeys(mkl) {
+		errs = append(errs, checkRoutingKey(b.scope, routingKey, true))
+	}
+	errs = append(errs, b.policies.CheckMultiKeyListener(b.SiteName, mkl))
+	return errors.Join(errs...)
+}
+
+func (b *Bindings) permittedMultiKeyListener(mkl *skupperv2alpha1.MultiKeyListener) *skupperv2alpha1.MultiKeyListener {
+	return filterMultiKeyListener(mkl, func(routingKey string) bool {
+		return checkRoutingKey(b.scope, routingKey, true) == nil && b.policies.check("listener", b.SiteName, routingKey, listenerRule) == nil
+	})
+}
+
+// checkRoutingKey rejects routing keys using the characters reserved for
+// the addresses of routing key scopes in a site with a scope, other than
+// "/" in the keys a listener imports. Without a scope, routing keys are
+// used as addresses as is and nothing is reserved.
+func checkRoutingKey(scope *skupperv2alpha1.RoutingKeyScope, routingKey string, imports bool) error {
+	if scope == nil || scope.Name == "" {
+		return nil
+	}
+	if ok, err := validator.NewRoutingKeyValidator(imports).Evaluate(routingKey); !ok {
+		return fmt.Errorf("Invalid routing key: %w", err)
+	}
+	return nil
+}
+
+// UpdateNetwork records the sites with listeners for each router address
+// in the network. It returns true if the change can affect which
+// connectors the traffic policies withhold.
+func (b *Bindings) UpdateNetwork(network []skupperv2alpha1.SiteRecord) bool {
+	listenerSites := map[string][]string{}
+	for _, site := range network {
+		for _, svc := range site.Services {
+			if len(svc.Listeners) > 0 {
+				listenerSites[svc.RoutingKey] = append(listenerSites[svc.RoutingKey], site.Name)
+			}
+		}
+	}
+	for _, sites := range listenerSites {
+		slices.Sort(sites)
+	}
+	if reflect.DeepEqual(listenerSites, b.listenerSites) {
+		return false
+	}
+	b.listenerSites = listenerSites
+	return len(b.policies.withholdingConnectors()) > 0
+}
+
 func (b *Bindings) SetMultiKeyListenerConfiguration(configuration MultiKeyListenerConfiguration) {
 	b.configure.multiKeyListener = configuration
 }
@@ -231,13 +385,23 @@ func (b *Bindings) ToBridgeConfig() qdr.BridgeConfig {
 		ListenerAddresses: qdr.ListenerAddressMap{},
 	}
 	for _, c := range b.connectors {
-		b.configure.connector(b.SiteId, c, &config)
+		if b.CheckConnector(c) != nil {
+			continue
+		}
+		b.configure.connector(b.SiteId, scopedConnector(b.scope, c), &config)
 	}
 	for _, l := range b.listeners {
-		b.configure.listener(b.SiteId, l, &config)
+		if b.CheckListener(l) != nil {
+			continue
+		}
+		b.configure.listener(b.SiteId, scopedListener(b.scope, l), &config)
 	}
 	for _, mkl := range b.multiKeyListeners {
-		b.configure.multiKeyListener(b.SiteId, mkl, &config)
+		permitted := b.permittedMultiKeyListener(mkl)
+		if len(multiKeyListenerRoutingKeys(permitted)) == 0 {
+			continue
+		}
+		b.configure.multiKeyListener(b.SiteId, scopedMultiKeyListener(b.scope, permitted), &config)
 	}
 
 	return config
@@ -8,13 +8,16 @@ import (
 )
 
 func UpdateBridgeConfigForConnector(siteId string, connector *skupperv2alpha1.Connector, config *qdr.BridgeConfig) {
-	if connector.Spec.Host != "" {
+	if connector.Spec.Host != "" && connector.IsTargetHealthy(connector.Spec.Host) {
 		updateBridgeConfigForConnector(qdr.TcpConnectorNamePrefix+connector.Name+"@"+connector.Spec.Host, siteId, connector, connector.Spec.Host, "", connector.Spec.RoutingKey, config)
 	}
 }
 
 func UpdateBridgeConfigForConnectorToPod(siteId string, connector *skupperv2alpha1.Connector, pod skupperv2alpha1.PodDetails, addQualifiedAddress bool, config *qdr.BridgeConfig) bool {
 	updated := false
+	if !connector.IsTargetHealthy(pod.IP) {
+		return updated
+	}
 	if updateBridgeConfigForConnector(qdr.TcpConnectorNamePrefix+connector.Name+"@"+pod.IP, siteId, connector, pod.IP, pod.UID, connector.Spec.RoutingKey, config) {
 		updated = true
 	}
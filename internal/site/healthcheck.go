@@ -0,0 +1,243 @@
+package site
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+type HealthCheckFunction func(check *skupperv2alpha1.HealthCheck, host string, port int) error
+
+// ValidateHealthCheck returns an error if the connector's health check
+// cannot be performed. Http checks are made in plain text, so they are
+// rejected for connectors that use TLS to reach their targets.
+func ValidateHealthCheck(connector *skupperv2alpha1.Connector) error {
+	check := connector.Spec.HealthCheck
+	if check != nil && check.GetType() == skupperv2alpha1.HealthCheckTypeHttp && connector.Spec.TlsCredentials != "" {
+		return fmt.Errorf("Health check of type http is not supported for connectors with tlsCredentials, use tcp")
+	}
+	return nil
+}
+
+// CheckTargetHealth performs a single health check against the target.
+// Http checks use plain http, see ValidateHealthCheck, and pass on a 2xx
+// or 3xx status. Redirects are not followed.
+func CheckTargetHealth(check *skupperv2alpha1.HealthCheck, host string, port int) error {
+	address := net.JoinHostPort(host, strconv.Itoa(port))
+	switch check.GetType() {
+	case skupperv2alpha1.HealthCheckTypeHttp:
+		client := &http.Client{
+			Timeout: check.GetTimeout(),
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+		resp, err := client.Get("http://" + address + check.GetPath())
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+			return fmt.Errorf("GET %s returned %s", check.GetPath(), resp.Status)
+		}
+		return nil
+	default:
+		conn, err := net.DialTimeout("tcp", address, check.GetTimeout())
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
+
+// HealthMonitor runs the health checks configured for connectors and
+// invokes a callback, with the name of the connector, whenever the health
+// of one of its targets changes. Targets are considered healthy until the
+// failure threshold is reached.
+type HealthMonitor struct {
+	mutex      sync.Mutex
+	connectors map[string]*connectorHealth
+	check      HealthCheckFunction
+	changed    func(name string)
+	interval   time.Duration
+}
+
+type connectorHealth struct {
+	spec    skupperv2alpha1.HealthCheck
+	port    int
+	targets map[string]*targetHealth
+}
+
+type targetHealth struct {
+	host      string
+	checked   bool
+	healthy   bool
+	message   string
+	successes int
+	failures  int
+	stopCh    chan struct{}
+}
+
+func NewHealthMonitor(changed func(name string)) *HealthMonitor {
+	return &HealthMonitor{
+		connectors: map[string]*connectorHealth{},
+		check:      CheckTargetHealth,
+		changed:    changed,
+	}
+}
+
+// Update starts and stops health checks so that they match the health
+// check configuration and the current targets of the named connector.
+func (m *HealthMonitor) Update(name string, check *skupperv2alpha1.HealthCheck, port int, hosts []string) {
+	if check == nil || len(hosts) == 0 {
+		m.Remove(name)
+		return
+	}
+	if check.Port != 0 {
+		port = check.Port
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	current, ok := m.connectors[name]
+	if ok && (current.port != port || !reflect.DeepEqual(current.spec, *check)) {
+		current.stop()
+		ok = false
+	}
+	if !ok {
+		current = &connectorHealth{
+			spec:    *check,
+			port:    port,
+			targets: map[string]*targetHealth{},
+		}
+		m.connectors[name] = current
+	}
+	for host, target := range current.targets {
+		if !slices.Contains(hosts, host) {
+			close(target.stopCh)
+			delete(current.targets, host)
+		}
+	}
+	for _, host := range hosts {
+		if _, ok := current.targets[host]; ok || host == "" {
+			continue
+		}
+		target := &targetHealth{
+			host:    host,
+			healthy: true,
+			stopCh:  make(chan struct{}),
+		}
+		current.targets[host] = target
+		go m.run(name, current, target)
+	}
+}
+
+// Remove stops the health checks for the named connector.
+func (m *HealthMonitor) Remove(name string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if current, ok := m.connectors[name]; ok {
+		current.stop()
+		delete(m.connectors, name)
+	}
+}
+
+// Stop stops all health checks.
+func (m *HealthMonitor) Stop() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for name, current := range m.connectors {
+		current.stop()
+		delete(m.connectors, name)
+	}
+}
+
+// Targets returns the health of the checked targets of the named
+// connector, ordered by host.
+func (m *HealthMonitor) Targets(name string) []skupperv2alpha1.TargetHealth {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	current, ok := m.connectors[name]
+	if !ok {
+		return nil
+	}
+	var targets []skupperv2alpha1.TargetHealth
+	for _, target := range current.targets {
+		if target.checked {
+			targets = append(targets, skupperv2alpha1.TargetHealth{
+				Host:    target.host,
+				Healthy: target.healthy,
+				Message: target.message,
+			})
+		}
+	}
+	slices.SortFunc(targets, func(a, b skupperv2alpha1.TargetHealth) int {
+		return strings.Compare(a.Host, b.Host)
+	})
+	return targets
+}
+
+func (m *HealthMonitor) run(name string, current *connectorHealth, target *targetHealth) {
+	interval := m.interval
+	if interval == 0 {
+		interval = current.spec.GetPeriod()
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		err := m.check(&current.spec, target.host, current.port)
+		if m.record(name, current, target, err) && m.changed != nil {
+			m.changed(name)
+		}
+		select {
+		case <-target.stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// record updates the health of the target with the result of a check,
+// returning true if the health as reported by Targets changed.
+func (m *HealthMonitor) record(name string, current *connectorHealth, target *targetHealth, err error) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.connectors[name] != current || current.targets[target.host] != target {
+		// checks for the target have since been stopped
+		return false
+	}
+	changed := !target.checked
+	target.checked = true
+	if err == nil {
+		target.failures = 0
+		target.successes++
+		if !target.healthy && target.successes >= current.spec.GetSuccessThreshold() {
+			target.healthy = true
+			target.message = ""
+			changed = true
+		}
+	} else {
+		target.successes = 0
+		target.failures++
+		if target.healthy && target.failures >= current.spec.GetFailureThreshold() {
+			target.healthy = false
+			target.message = err.Error()
+			changed = true
+		}
+	}
+	return changed
+}
+
+func (c *connectorHealth) stop() {
+	for host, target := range c.targets {
+		close(target.stopCh)
+		delete(c.targets, host)
+	}
+}
@@ -0,0 +1,244 @@
+package site
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckTargetHealth(t *testing.T) {
+	tcp, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	defer tcp.Close()
+	tcpPort := tcp.Addr().(*net.TCPAddr).Port
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/healthz" {
+			w.WriteHeader(http.StatusOK)
+		} else if code, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/status/")); err == nil {
+			w.WriteHeader(code)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	assert.NilError(t, err)
+	httpPort, err := strconv.Atoi(port)
+	assert.NilError(t, err)
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	closedPort := closed.Addr().(*net.TCPAddr).Port
+	closed.Close()
+
+	tests := []struct {
+		name      string
+		check     skupperv2alpha1.HealthCheck
+		port      int
+		errorText string
+	}{
+		{
+			name:  "tcp listening",
+			check: skupperv2alpha1.HealthCheck{},
+			port:  tcpPort,
+		},
+		{
+			name:      "tcp not listening",
+			check:     skupperv2alpha1.HealthCheck{Type: "tcp"},
+			port:      closedPort,
+			errorText: "connection refused",
+		},
+		{
+			name:  "http ok",
+			check: skupperv2alpha1.HealthCheck{Type: "http", Path: "/healthz"},
+			port:  httpPort,
+		},
+		{
+			name:      "http error status",
+			check:     skupperv2alpha1.HealthCheck{Type: "http", Path: "/other"},
+			port:      httpPort,
+			errorText: "GET /other returned 503 Service Unavailable",
+		},
+		{
+			name:      "http 1xx status",
+			check:     skupperv2alpha1.HealthCheck{Type: "http", Path: "/status/101"},
+			port:      httpPort,
+			errorText: "GET /status/101 returned 101 Switching Protocols",
+		},
+		{
+			name:  "http 2xx status",
+			check: skupperv2alpha1.HealthCheck{Type: "http", Path: "/status/204"},
+			port:  httpPort,
+		},
+		{
+			name:  "http 3xx status",
+			check: skupperv2alpha1.HealthCheck{Type: "http", Path: "/status/302"},
+			port:  httpPort,
+		},
+		{
+			name:      "http 4xx status",
+			check:     skupperv2alpha1.HealthCheck{Type: "http", Path: "/status/404"},
+			port:      httpPort,
+			errorText: "GET /status/404 returned 404 Not Found",
+		},
+		{
+			name:      "http 5xx status",
+			check:     skupperv2alpha1.HealthCheck{Type: "http", Path: "/status/500"},
+			port:      httpPort,
+			errorText: "GET /status/500 returned 500 Internal Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckTargetHealth(&tt.check, host, tt.port)
+			if tt.errorText == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.errorText)
+			}
+		})
+	}
+}
+
+type fakeTargets struct {
+	mutex  sync.Mutex
+	failed map[string]bool
+}
+
+func (f *fakeTargets) set(host string, failed bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.failed[host] = failed
+}
+
+func (f *fakeTargets) check(check *skupperv2alpha1.HealthCheck, host string, port int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.failed[host] {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func TestHealthMonitor(t *testing.T) {
+	targets := &fakeTargets{failed: map[string]bool{}}
+	changes := make(chan string, 100)
+	m := NewHealthMonitor(func(name string) {
+		changes <- name
+	})
+	m.check = targets.check
+	m.interval = 10 * time.Millisecond
+	defer m.Stop()
+
+	healthOf := func(expected ...skupperv2alpha1.TargetHealth) poll.Check {
+		return func(t poll.LogT) poll.Result {
+			actual := m.Targets("db")
+			if len(actual) != len(expected) {
+				return poll.Continue("got %v", actual)
+			}
+			for i := range expected {
+				if actual[i] != expected[i] {
+					return poll.Continue("got %v", actual)
+				}
+			}
+			return poll.Success()
+		}
+	}
+
+	check := &skupperv2alpha1.HealthCheck{FailureThreshold: 2, SuccessThreshold: 2}
+	m.Update("db", check, 5432, []string{"10.0.0.2", "10.0.0.1"})
+	poll.WaitOn(t, healthOf(
+		skupperv2alpha1.TargetHealth{Host: "10.0.0.1", Healthy: true},
+		skupperv2alpha1.TargetHealth{Host: "10.0.0.2", Healthy: true},
+	))
+	assert.Equal(t, <-changes, "db")
+
+	targets.set("10.0.0.2", true)
+	poll.WaitOn(t, healthOf(
+		skupperv2alpha1.TargetHealth{Host: "10.0.0.1", Healthy: true},
+		skupperv2alpha1.TargetHealth{Host: "10.0.0.2", Healthy: false, Message: "connection refused"},
+	))
+
+	targets.set("10.0.0.2", false)
+	poll.WaitOn(t, healthOf(
+		skupperv2alpha1.TargetHealth{Host: "10.0.0.1", Healthy: true},
+		skupperv2alpha1.TargetHealth{Host: "10.0.0.2", Healthy: true},
+	))
+
+	m.Update("db", check, 5432, []string{"10.0.0.1"})
+	poll.WaitOn(t, healthOf(
+		skupperv2alpha1.TargetHealth{Host: "10.0.0.1", Healthy: true},
+	))
+
+	m.Update("db", nil, 5432, []string{"10.0.0.1"})
+	assert.Assert(t, m.Targets("db") == nil)
+}
+
+func TestBindings_UnhealthyTargets(t *testing.T) {
+	b := NewBindings("")
+	connector := &skupperv2alpha1.Connector{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "db",
+			Namespace: "test",
+		},
+		Spec: skupperv2alpha1.ConnectorSpec{
+			RoutingKey:  "db",
+			Host:        "10.10.10.1",
+			Port:        5432,
+			HealthCheck: &skupperv2alpha1.HealthCheck{},
+		},
+	}
+	b.UpdateConnector(connector.Name, connector)
+	assert.Equal(t, len(b.ToBridgeConfig().TcpConnectors), 1)
+
+	assert.Assert(t, connector.SetTargetHealth([]skupperv2alpha1.TargetHealth{
+		{Host: "10.10.10.1", Healthy: false, Message: "connection refused"},
+	}))
+	assert.Equal(t, len(b.ToBridgeConfig().TcpConnectors), 0)
+
+	pod := skupperv2alpha1.PodDetails{UID: "abc", Name: "db-0", IP: "10.10.10.2"}
+	config := b.ToBridgeConfig()
+	assert.Assert(t, UpdateBridgeConfigForConnectorToPod("", connector, pod, false, &config))
+	connector.Status.Targets = append(connector.Status.Targets, skupperv2alpha1.TargetHealth{Host: pod.IP})
+	config = b.ToBridgeConfig()
+	assert.Assert(t, !UpdateBridgeConfigForConnectorToPod("", connector, pod, false, &config))
+
+	connector.Spec.HealthCheck = nil
+	assert.Equal(t, len(b.ToBridgeConfig().TcpConnectors), 1)
+}
+
+func TestBindings_HttpHealthCheckWithTls(t *testing.T) {
+	b := NewBindings("")
+	connector := &skupperv2alpha1.Connector{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "api",
+			Namespace: "test",
+		},
+		Spec: skupperv2alpha1.ConnectorSpec{
+			RoutingKey:     "api",
+			Host:           "10.10.10.1",
+			Port:           8443,
+			TlsCredentials: "api-tls",
+			HealthCheck:    &skupperv2alpha1.HealthCheck{Type: "http"},
+		},
+	}
+	b.UpdateConnector(connector.Name, connector)
+	assert.ErrorContains(t, b.CheckConnector(connector), "not supported for connectors with tlsCredentials")
+	assert.Equal(t, len(b.ToBridgeConfig().TcpConnectors), 0)
+
+	connector.Spec.HealthCheck.Type = "tcp"
+	assert.NilError(t, b.CheckConnector(connector))
+	assert.Equal(t, len(b.ToBridgeConfig().TcpConnectors), 1)
+}
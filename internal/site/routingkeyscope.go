@@ -0,0 +1,90 @@
+package site
+
+import (
+	"strings"
+
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+// Router addresses for keys private to a scope are of the form
+// "<scope>/<key>", those for exported keys are of the form
+// "<scope>:<key>". As scope names are DNS labels and both separators are
+// reserved in the routing keys of a site with a scope, other than "/" in
+// the keys a listener imports, such a site can only generate an address
+// that belongs to another scope by importing a key that scope exports, or
+// by declaring that scope's name. Sites without a scope use routing keys
+// as addresses as is, so existing keys keep working, and can reach any
+// scope's addresses just as a site declaring its name could. Scope names
+// are not authenticated, so the isolation only holds between sites that
+// declare their scope honestly.
+const (
+	privateAddressSeparator  = "/"
+	exportedAddressSeparator = ":"
+)
+
+// ListenerAddress returns the router address a listener with the given
+// routing key binds to in a site using the scope. Without a scope, the
+// routing key is used as is.
+func ListenerAddress(scope *skupperv2alpha1.RoutingKeyScope, routingKey string) string {
+	if scope == nil || scope.Name == "" {
+		return routingKey
+	}
+	if scope.IsImported(routingKey) {
+		exporter, key, _ := strings.Cut(routingKey, privateAddressSeparator)
+		return exporter + exportedAddressSeparator + key
+	}
+	return ConnectorAddress(scope, routingKey)
+}
+
+// ConnectorAddress returns the router address a connector with the given
+// routing key binds to in a site using the scope. Imports only apply to
+// listeners, so a connector can never bind to another scope's address.
+func ConnectorAddress(scope *skupperv2alpha1.RoutingKeyScope, routingKey string) string {
+	if scope == nil || scope.Name == "" {
+		return routingKey
+	}
+	if scope.IsExported(routingKey) {
+		return scope.Name + exportedAddressSeparator + routingKey
+	}
+	return scope.Name + privateAddressSeparator + routingKey
+}
+
+func scopedListener(scope *skupperv2alpha1.RoutingKeyScope, listener *skupperv2alpha1.Listener) *skupperv2alpha1.Listener {
+	if scope == nil {
+		return listener
+	}
+	scoped := *listener
+	scoped.Spec.RoutingKey = ListenerAddress(scope, listener.Spec.RoutingKey)
+	return &scoped
+}
+
+func scopedConnector(scope *skupperv2alpha1.RoutingKeyScope, connector *skupperv2alpha1.Connector) *skupperv2alpha1.Connector {
+	if scope == nil {
+		return connector
+	}
+	scoped := *connector
+	scoped.Spec.RoutingKey = ConnectorAddress(scope, connector.Spec.RoutingKey)
+	return &scoped
+}
+
+func scopedMultiKeyListener(scope *skupperv2alpha1.RoutingKeyScope, mkl *skupperv2alpha1.MultiKeyListener) *skupperv2alpha1.MultiKeyListener {
+	if scope == nil {
+		return mkl
+	}
+	scoped := *mkl
+	if priority := mkl.Spec.Strategy.Priority; priority != nil {
+		scoped.Spec.Strategy.Priority = &skupperv2alpha1.PriorityStrategySpec{}
+		for _, key := range priority.RoutingKeys {
+			scoped.Spec.Strategy.Priority.RoutingKeys = append(scoped.Spec.Strategy.Priority.RoutingKeys, ListenerAddress(scope, key))
+		}
+	}
+	if weighted := mkl.Spec.Strategy.Weighted; weighted != nil {
+		scoped.Spec.Strategy.Weighted = &skupperv2alpha1.WeightedStrategySpec{
+			RoutingKeys: map[string]uint{},
+		}
+		for key, weight := range weighted.RoutingKeys {
+			scoped.Spec.Strategy.Weighted.RoutingKeys[ListenerAddress(scope, key)] = weight
+		}
+	}
+	return &scoped
+}
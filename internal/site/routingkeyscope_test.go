@@ -0,0 +1,222 @@
+package site
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/qdr"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRoutingKeyScopeAddresses(t *testing.T) {
+	scope := &skupperv2alpha1.RoutingKeyScope{
+		Name:    "team-a",
+		Exports: []string{"api", "public-*"},
+		Imports: []string{"team-b/db"},
+	}
+	tests := []struct {
+		name             string
+		scope            *skupperv2alpha1.RoutingKeyScope
+		routingKey       string
+		listenerAddress  string
+		connectorAddress string
+	}{
+		{
+			name:             "no scope",
+			routingKey:       "db",
+			listenerAddress:  "db",
+			connectorAddress: "db",
+		},
+		{
+			name:             "private key",
+			scope:            scope,
+			routingKey:       "db",
+			listenerAddress:  "team-a/db",
+			connectorAddress: "team-a/db",
+		},
+		{
+			name:             "exported key",
+			scope:            scope,
+			routingKey:       "api",
+			listenerAddress:  "team-a:api",
+			connectorAddress: "team-a:api",
+		},
+		{
+			name:             "exported pattern",
+			scope:            scope,
+			routingKey:       "public-web",
+			listenerAddress:  "team-a:public-web",
+			connectorAddress: "team-a:public-web",
+		},
+		{
+			name:             "imported key",
+			scope:            scope,
+			routingKey:       "team-b/db",
+			listenerAddress:  "team-b:db",
+			connectorAddress: "team-a/team-b/db",
+		},
+		{
+			name:             "key not imported",
+			scope:            scope,
+			routingKey:       "team-c/db",
+			listenerAddress:  "team-a/team-c/db",
+			connectorAddress: "team-a/team-c/db",
+		},
+		{
+			name:             "key in exported form",
+			scope:            scope,
+			routingKey:       "team-b:db",
+			listenerAddress:  "team-a/team-b:db",
+			connectorAddress: "team-a/team-b:db",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, ListenerAddress(tt.scope, tt.routingKey), tt.listenerAddress)
+			assert.Equal(t, ConnectorAddress(tt.scope, tt.routingKey), tt.connectorAddress)
+		})
+	}
+}
+
+func TestBindings_RoutingKeyScope(t *testing.T) {
+	b := NewBindings("")
+	listener := &skupperv2alpha1.Listener{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "db",
+			Namespace: "test",
+		},
+		Spec: skupperv2alpha1.ListenerSpec{
+			RoutingKey: "team-b/db",
+			Host:       "db",
+			Port:       5432,
+		},
+	}
+	connector := &skupperv2alpha1.Connector{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "api",
+			Namespace: "test",
+		},
+		Spec: skupperv2alpha1.ConnectorSpec{
+			RoutingKey: "api",
+			Host:       "10.10.10.1",
+			Port:       8080,
+		},
+	}
+	mkl := &skupperv2alpha1.MultiKeyListener{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "failover",
+			Namespace: "test",
+		},
+		Spec: skupperv2alpha1.MultiKeyListenerSpec{
+			Host: "failover",
+			Port: 9090,
+			Strategy: skupperv2alpha1.MultiKeyListenerStrategy{
+				Priority: &skupperv2alpha1.PriorityStrategySpec{
+					RoutingKeys: []string{"primary", "team-b/db"},
+				},
+			},
+		},
+	}
+	b.UpdateListener(listener.Name, listener)
+	b.UpdateConnector(connector.Name, connector)
+	b.UpdateMultiKeyListener(mkl.Name, mkl)
+
+	// without a routing key scope, routing keys are used as is
+	assert.NilError(t, b.CheckListener(listener))
+	assert.NilError(t, b.CheckMultiKeyListener(mkl))
+	config := b.ToBridgeConfig()
+	assert.Equal(t, config.TcpListeners["listener/db"].Address, "team-b/db")
+	assert.Equal(t, config.TcpConnectors["connector/api@10.10.10.1"].Address, "api")
+	assert.DeepEqual(t, config.ListenerAddresses, qdr.ListenerAddressMap{
+		"failover/primary": {
+			Name:     "failover/primary",
+			Address:  "primary",
+			Value:    1,
+			Listener: "multiAddress/failover",
+		},
+		"failover/team-b/db": {
+			Name:     "failover/team-b/db",
+			Address:  "team-b/db",
+			Value:    0,
+			Listener: "multiAddress/failover",
+		},
+	})
+
+	scope := &skupperv2alpha1.RoutingKeyScope{
+		Name:    "team-a",
+		Exports: []string{"api"},
+		Imports: []string{"team-b/db"},
+	}
+	assert.Assert(t, b.SetRoutingKeyScope(scope))
+	assert.Assert(t, !b.SetRoutingKeyScope(scope))
+	assert.Equal(t, b.ListenerAddress("db"), "team-a/db")
+	assert.Equal(t, b.ConnectorAddress("api"), "team-a:api")
+
+	assert.NilError(t, b.CheckListener(listener))
+	assert.NilError(t, b.CheckMultiKeyListener(mkl))
+	config = b.ToBridgeConfig()
+	assert.Equal(t, config.TcpListeners["listener/db"].Address, "team-b:db")
+	assert.Equal(t, config.TcpConnectors["connector/api@10.10.10.1"].Address, "team-a:api")
+	assert.DeepEqual(t, config.ListenerAddresses, qdr.ListenerAddressMap{
+		"failover/team-a/primary": {
+			Name:     "failover/team-a/primary",
+			Address:  "team-a/primary",
+			Value:    1,
+			Listener: "multiAddress/failover",
+		},
+		"failover/team-b:db": {
+			Name:     "failover/team-b:db",
+			Address:  "team-b:db",
+			Value:    0,
+			Listener: "multiAddress/failover",
+		},
+	})
+	// the definitions themselves are left unchanged
+	assert.Equal(t, listener.Spec.RoutingKey, "team-b/db")
+	assert.Equal(t, connector.Spec.RoutingKey, "api")
+	assert.DeepEqual(t, mkl.Spec.Strategy.Priority.RoutingKeys, []string{"primary", "team-b/db"})
+}
+
+func TestBindings_RoutingKeySeparators(t *testing.T) {
+	b := NewBindings("")
+	b.SetSiteId("site-1")
+	listener := &skupperv2alpha1.Listener{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "ab",
+			Namespace: "test",
+		},
+		Spec: skupperv2alpha1.ListenerSpec{
+			RoutingKey: "a:b",
+			Host:       "ab",
+			Port:       8080,
+		},
+	}
+	connector := &skupperv2alpha1.Connector{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "ab",
+			Namespace: "test",
+		},
+		Spec: skupperv2alpha1.ConnectorSpec{
+			RoutingKey: "a:b",
+			Host:       "10.10.10.1",
+			Port:       8080,
+		},
+	}
+	b.UpdateListener(listener.Name, listener)
+	b.UpdateConnector(connector.Name, connector)
+
+	// the separators are only reserved in a site with a routing key scope
+	assert.NilError(t, b.CheckListener(listener))
+	assert.NilError(t, b.CheckConnector(connector))
+	config := b.ToBridgeConfig()
+	assert.Equal(t, config.TcpListeners["listener/ab"].Address, "a:b")
+	assert.Equal(t, config.TcpConnectors["connector/ab@10.10.10.1"].Address, "a:b")
+
+	b.SetRoutingKeyScope(&skupperv2alpha1.RoutingKeyScope{Name: "team-a"})
+	assert.ErrorContains(t, b.CheckListener(listener), `must not contain ":"`)
+	assert.ErrorContains(t, b.CheckConnector(connector), `must not contain ":"`)
+	config = b.ToBridgeConfig()
+	assert.Equal(t, len(config.TcpListeners), 0)
+	assert.Equal(t, len(config.TcpConnectors), 0)
+}
@@ -0,0 +1,131 @@
+package site
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+// TrafficPolicies are the traffic policies that apply to the bindings of a
+// site, keyed by name.
+type TrafficPolicies map[string]*skupperv2alpha1.TrafficPolicy
+
+type trafficPolicyRuleFunction func(spec *skupperv2alpha1.TrafficPolicySpec) *skupperv2alpha1.TrafficPolicyRule
+
+func listenerRule(spec *skupperv2alpha1.TrafficPolicySpec) *skupperv2alpha1.TrafficPolicyRule {
+	return spec.Listeners
+}
+
+func connectorRule(spec *skupperv2alpha1.TrafficPolicySpec) *skupperv2alpha1.TrafficPolicyRule {
+	return spec.Connectors
+}
+
+// CheckListener returns an error naming the policy that denies the site
+// binding the listener, if any.
+func (p TrafficPolicies) CheckListener(siteName string, listener *skupperv2alpha1.Listener) error {
+	return p.check("listener", siteName, listener.Spec.RoutingKey, listenerRule)
+}
+
+// CheckConnector returns an error naming the policy that denies the site
+// binding the connector, if any.
+func (p TrafficPolicies) CheckConnector(siteName string, connector *skupperv2alpha1.Connector) error {
+	return p.check("connector", siteName, connector.Spec.RoutingKey, connectorRule)
+}
+
+// CheckMultiKeyListener returns an error naming each strategy routing key
+// of the multi-key listener that a policy denies the site binding, if any.
+func (p TrafficPolicies) CheckMultiKeyListener(siteName string, mkl *skupperv2alpha1.MultiKeyListener) error {
+	var errs []error
+	for _, routingKey := range multiKeyListenerRoutingKeys(mkl) {
+		errs = append(errs, p.check("listener", siteName, routingKey, listenerRule))
+	}
+	return errors.Join(errs...)
+}
+
+// CheckListenerSites returns an error naming the policy and site if a
+// policy that withholds connectors denies any of the named sites binding a
+// listener to the routing key. A site hosting connectors uses it to
+// withhold them from listeners on other sites, which it cannot otherwise
+// restrict.
+func (p TrafficPolicies) CheckListenerSites(routingKey string, siteNames []string) error {
+	withholding := p.withholdingConnectors()
+	for _, siteName := range siteNames {
+		if err := withholding.check("listener", siteName, routingKey, listenerRule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withholdingConnectors returns the policies that opt in to withholding
+// connectors from listeners on denied sites.
+func (p TrafficPolicies) withholdingConnectors() TrafficPolicies {
+	withholding := TrafficPolicies{}
+	for name, policy := range p {
+		if policy.Spec.WithholdConnectors {
+			withholding[name] = policy
+		}
+	}
+	return withholding
+}
+
+// filterMultiKeyListener returns a copy of the multi-key listener with
+// only the strategy routing keys for which permitted returns true.
+func filterMultiKeyListener(mkl *skupperv2alpha1.MultiKeyListener, permitted func(routingKey string) bool) *skupperv2alpha1.MultiKeyListener {
+	filtered := *mkl
+	if priority := mkl.Spec.Strategy.Priority; priority != nil {
+		filtered.Spec.Strategy.Priority = &skupperv2alpha1.PriorityStrategySpec{}
+		for _, routingKey := range priority.RoutingKeys {
+			if permitted(routingKey) {
+				filtered.Spec.Strategy.Priority.RoutingKeys = append(filtered.Spec.Strategy.Priority.RoutingKeys, routingKey)
+			}
+		}
+	}
+	if weighted := mkl.Spec.Strategy.Weighted; weighted != nil {
+		filtered.Spec.Strategy.Weighted = &skupperv2alpha1.WeightedStrategySpec{
+			RoutingKeys: map[string]uint{},
+		}
+		for routingKey, weight := range weighted.RoutingKeys {
+			if permitted(routingKey) {
+				filtered.Spec.Strategy.Weighted.RoutingKeys[routingKey] = weight
+			}
+		}
+	}
+	return &filtered
+}
+
+// multiKeyListenerRoutingKeys returns the strategy routing keys of the
+// multi-key listener, prioritised keys first, then weighted keys sorted.
+func multiKeyListenerRoutingKeys(mkl *skupperv2alpha1.MultiKeyListener) []string {
+	var routingKeys []string
+	if priority := mkl.Spec.Strategy.Priority; priority != nil {
+		routingKeys = append(routingKeys, priority.RoutingKeys...)
+	}
+	if weighted := mkl.Spec.Strategy.Weighted; weighted != nil {
+		routingKeys = append(routingKeys, slices.Sorted(maps.Keys(weighted.RoutingKeys))...)
+	}
+	return routingKeys
+}
+
+func (p TrafficPolicies) check(kind string, siteName string, routingKey string, ruleFor trafficPolicyRuleFunction) error {
+	var names []string
+	for name := range p {
+		names = append(names, name)
+	}
+	// check in a stable order so that the reported policy does not change
+	slices.Sort(names)
+	for _, name := range names {
+		policy := p[name]
+		rule := ruleFor(&policy.Spec)
+		if rule == nil || !policy.SelectsRoutingKey(routingKey) {
+			continue
+		}
+		if rule.Denies(siteName) {
+			return fmt.Errorf("Traffic policy %s does not permit site %q to bind a %s to routing key %q", name, siteName, kind, routingKey)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package site
+
+import (
+	"testing"
+
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func trafficPolicy(name string, routingKeys []string, listeners *skupperv2alpha1.TrafficPolicyRule, connectors *skupperv2alpha1.TrafficPolicyRule) *skupperv2alpha1.TrafficPolicy {
+	return &skupperv2alpha1.TrafficPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+		},
+		Spec: skupperv2alpha1.TrafficPolicySpec{
+			RoutingKeys: routingKeys,
+			Listeners:   listeners,
+			Connectors:  connectors,
+		},
+	}
+}
+
+func TestTrafficPolicies_Check(t *testing.T) {
+	policies := TrafficPolicies{
+		"db-connectors": trafficPolicy("db-connectors", []string{"db-*"}, nil, &skupperv2alpha1.TrafficPolicyRule{
+			AllowedSites: []string{"datacenter-*"},
+		}),
+		"no-edge": trafficPolicy("no-edge", []string{"*"}, &skupperv2alpha1.TrafficPolicyRule{
+			DeniedSites: []string{"edge"},
+		}, nil),
+	}
+	tests := []struct {
+		name      string
+		siteName  string
+		kind      string
+		key       string
+		errorText string
+	}{
+		{
+			name:     "connector allowed",
+			siteName: "datacenter-east",
+			kind:     "connector",
+			key:      "db-main",
+		},
+		{
+			name:      "connector not in allowed sites",
+			siteName:  "cloud",
+			kind:      "connector",
+			key:       "db-main",
+			errorText: `Traffic policy db-connectors does not permit site "cloud" to bind a connector to routing key "db-main"`,
+		},
+		{
+			name:     "connector for unselected routing key",
+			siteName: "cloud",
+			kind:     "connector",
+			key:      "web",
+		},
+		{
+			name:     "connector at denied listener site",
+			siteName: "edge",
+			kind:     "connector",
+			key:      "web",
+		},
+		{
+			name:      "listener at denied site",
+			siteName:  "edge",
+			kind:      "listener",
+			key:       "web",
+			errorText: `Traffic policy no-edge does not permit site "edge" to bind a listener to routing key "web"`,
+		},
+		{
+			name:     "listener at other site",
+			siteName: "cloud",
+			kind:     "listener",
+			key:      "db-main",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.kind == "listener" {
+				err = policies.CheckListener(tt.siteName, &skupperv2alpha1.Listener{
+					Spec: skupperv2alpha1.ListenerSpec{RoutingKey: tt.key},
+				})
+			} else {
+				err = policies.CheckConnector(tt.siteName, &skupperv2alpha1.Connector{
+					Spec: skupperv2alpha1.ConnectorSpec{RoutingKey: tt.key},
+				})
+			}
+			if tt.errorText == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.errorText)
+			}
+		})
+	}
+}
+
+func TestBindings_TrafficPolicy(t *testing.T) {
+	b := NewBindings("")
+	b.SetSiteName("edge")
+	b.UpdateListener("web", &skupperv2alpha1.Listener{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "web",
+			Namespace: "test",
+		},
+		Spec: skupperv2alpha1.ListenerSpec{
+			RoutingKey: "web",
+			Host:       "web",
+			Port:       8080,
+		},
+	})
+	b.UpdateConnector("db", &skupperv2alpha1.Connector{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "db",
+			Namespace: "test",
+		},
+		Spec: skupperv2alpha1.ConnectorSpec{
+			RoutingKey: "db",
+			Host:       "10.10.10.1",
+			Port:       5432,
+		},
+	})
+	config := b.ToBridgeConfig()
+	assert.Equal(t, len(config.TcpListeners), 1)
+	assert.Equal(t, len(config.TcpConnectors), 1)
+
+	policy := trafficPolicy("restricted", []string{"web", "db"}, &skupperv2alpha1.TrafficPolicyRule{
+		DeniedSites: []string{"edge"},
+	}, &skupperv2alpha1.TrafficPolicyRule{
+		AllowedSites: []string{"datacenter"},
+	})
+	assert.Assert(t, b.UpdateTrafficPolicy("restricted", policy) != nil)
+	assert.ErrorContains(t, b.CheckListener(b.listeners["web"]), "does not permit site")
+	assert.ErrorContains(t, b.CheckConnector(b.connectors["db"]), "does not permit site")
+	config = b.ToBridgeConfig()
+	assert.Equal(t, len(config.TcpListeners), 0)
+	assert.Equal(t, len(config.TcpConnectors), 0)
+
+	var names []string
+	b.MapOverTrafficPolicies(func(p *skupperv2alpha1.TrafficPolicy) *skupperv2alpha1.TrafficPolicy {
+		names = append(names, p.Name)
+		return nil
+	})
+	assert.DeepEqual(t, names, []string{"restricted"})
+
+	assert.Assert(t, b.UpdateTrafficPolicy("restricted", nil) != nil)
+	assert.NilError(t, b.CheckListener(b.listeners["web"]))
+	config = b.ToBridgeConfig()
+	assert.Equal(t, len(config.TcpListeners), 1)
+	assert.Equal(t, len(config.TcpConnectors), 1)
+}
+
+func TestBindings_TrafficPolicyMultiKeyListener(t *testing.T) {
+	b := NewBindings("")
+	b.SetSiteName("edge")
+	b.UpdateMultiKeyListener("db", &skupperv2alpha1.MultiKeyListener{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "db",
+			Namespace: "test",
+		},
+		Spec: skupperv2alpha1.MultiKeyListenerSpec{
+			Host: "db",
+			Port: 5432,
+			Strategy: skupperv2alpha1.MultiKeyListenerStrategy{
+				Priority: &skupperv2alpha1.PriorityStrategySpec{
+					RoutingKeys: []string{"db-primary", "db-replica"},
+				},
+			},
+		},
+	})
+	config := b.ToBridgeConfig()
+	assert.Equal(t, len(config.ListenerAddresses), 2)
+
+	b.UpdateTrafficPolicy("no-primary", trafficPolicy("no-primary", []string{"db-primary"}, &skupperv2alpha1.TrafficPolicyRule{
+		DeniedSites: []string{"edge"},
+	}, nil))
+	assert.Error(t, b.CheckMultiKeyListener(b.multiKeyListeners["db"]), `Traffic policy no-primary does not permit site "edge" to bind a listener to routing key "db-primary"`)
+	config = b.ToBridgeConfig()
+	assert.Equal(t, len(config.TcpListeners), 1)
+	assert.Equal(t, len(config.ListenerAddresses), 1)
+	for _, la := range config.ListenerAddresses {
+		assert.Equal(t, la.Address, "db-replica")
+	}
+
+	b.UpdateTrafficPolicy("no-replica", trafficPolicy("no-replica", []string{"db-replica"}, &skupperv2alpha1.TrafficPolicyRule{
+		DeniedSites: []string{"edge"},
+	}, nil))
+	config = b.ToBridgeConfig()
+	assert.Equal(t, len(config.TcpListeners), 0)
+	assert.Equal(t, len(config.ListenerAddresses), 0)
+}
+
+func TestBindings_TrafficPolicyListenerSites(t *testing.T) {
+	b := NewBindings("")
+	b.SetSiteName("datacenter")
+	b.UpdateConnector("db", &skupperv2alpha1.Connector{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "db",
+			Namespace: "test",
+		},
+		Spec: skupperv2alpha1.ConnectorSpec{
+			RoutingKey: "db",
+			Host:       "10.10.10.1",
+			Port:       5432,
+		},
+	})
+	network := []skupperv2alpha1.SiteRecord{
+		{
+			Name:     "datacenter",
+			Services: []skupperv2alpha1.ServiceRecord{{RoutingKey: "db", Connectors: []string{"db"}}},
+		},
+		{
+			Name:     "edge",
+			Services: []skupperv2alpha1.ServiceRecord{{RoutingKey: "db", Listeners: []string{"db"}}},
+		},
+	}
+	assert.Assert(t, !b.UpdateNetwork(network), "no policies to apply")
+	assert.NilError(t, b.CheckConnector(b.connectors["db"]))
+
+	policy := trafficPolicy("no-edge", []string{"db"}, &skupperv2alpha1.TrafficPolicyRule{
+		DeniedSites: []string{"edge"},
+	}, nil)
+	b.UpdateTrafficPolicy("no-edge", policy)
+	assert.NilError(t, b.CheckConnector(b.connectors["db"]), "connectors are only withheld on opt in")
+	assert.Equal(t, len(b.ToBridgeConfig().TcpConnectors), 1)
+
+	withholding := policy.DeepCopy()
+	withholding.Spec.WithholdConnectors = true
+	b.UpdateTrafficPolicy("no-edge", withholding)
+	assert.Error(t, b.CheckConnector(b.connectors["db"]), `Connector withheld from all sites: Traffic policy no-edge does not permit site "edge" to bind a listener to routing key "db"`)
+	assert.Equal(t, len(b.ToBridgeConfig().TcpConnectors), 0)
+	assert.Assert(t, !b.UpdateNetwork(network), "network unchanged")
+
+	network[1].Services = nil
+	assert.Assert(t, b.UpdateNetwork(network))
+	assert.NilError(t, b.CheckConnector(b.connectors["db"]))
+	assert.Equal(t, len(b.ToBridgeConfig().TcpConnectors), 1)
+}
@@ -144,6 +144,37 @@ func (i OptionValidator) Evaluate(value interface{}) (bool, error) {
 	return true, nil
 }
 
+// RoutingKeyValidator rejects the routing keys of a site with a routing key
+// scope that contain the characters qualifying router addresses with the
+// name of a scope: ":" always, and "/" unless the key is one a listener
+// imports from another scope. Sites without a scope do not reserve them.
+type RoutingKeyValidator struct {
+	Imports bool
+}
+
+func NewRoutingKeyValidator(imports bool) *RoutingKeyValidator {
+	return &RoutingKeyValidator{
+		Imports: imports,
+	}
+}
+
+func (r RoutingKeyValidator) Evaluate(value interface{}) (bool, error) {
+	v, ok := value.(string)
+	if !ok {
+		return false, fmt.Errorf("value is not a string")
+	}
+	if v == "" {
+		return false, fmt.Errorf("value must not be empty")
+	}
+	if strings.Contains(v, ":") {
+		return false, fmt.Errorf("routing key %q must not contain \":\", which is reserved for routing key scopes", v)
+	}
+	if !r.Imports && strings.Contains(v, "/") {
+		return false, fmt.Errorf("routing key %q must not contain \"/\", which is reserved for routing key scopes", v)
+	}
+	return true, nil
+}
+
 type DurationValidator struct {
 	MinDuration time.Duration
 }
@@ -145,6 +145,33 @@ func TestOptionValidator_Evaluate(t *testing.T) {
 	}
 }
 
+func TestRoutingKeyValidator_Evaluate(t *testing.T) {
+	type test struct {
+		name    string
+		imports bool
+		value   interface{}
+		result  bool
+	}
+
+	testTable := []test{
+		{name: "plain key", value: "backend", result: true},
+		{name: "plain key import", imports: true, value: "backend", result: true},
+		{name: "colon", value: "backend:8080", result: false},
+		{name: "colon in import", imports: true, value: "team-a:backend", result: false},
+		{name: "slash", value: "team-a/backend", result: false},
+		{name: "import", imports: true, value: "team-a/backend", result: true},
+		{name: "empty string", value: "", result: false},
+		{name: "not a string", value: 8080, result: false},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			actualResult, _ := NewRoutingKeyValidator(test.imports).Evaluate(test.value)
+			assert.Equal(t, actualResult, test.result)
+		})
+	}
+}
+
 func TestNewResourceStringValidator(t *testing.T) {
 
 	t.Run("Test New Resource String Validator constructor", func(t *testing.T) {
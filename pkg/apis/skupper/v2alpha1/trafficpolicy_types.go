@@ -0,0 +1,112 @@
+package v2alpha1
+
+import (
+	"path"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+//
+
+// TrafficPolicies restrict which sites may bind Listeners or Connectors to a
+// set of routing keys. A binding is permitted only if every policy selecting
+// its routing key permits it.
+//
+// Policies are not distributed: only the site whose namespace defines a
+// policy enforces it. That site leaves the Listeners, Connectors and
+// MultiKeyListener routing keys a policy denies it out of its router
+// configuration. It cannot stop other sites binding Listeners; a policy may
+// opt in to withholding the site's Connectors instead with
+// withholdConnectors. Sites without network status, such as those not
+// running on Kubernetes, only enforce policies on their own bindings.
+type TrafficPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata"`
+	// +required
+	Spec TrafficPolicySpec `json:"spec"`
+	// +optional
+	Status TrafficPolicyStatus `json:"status"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TrafficPolicyList contains a list of TrafficPolicy
+type TrafficPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TrafficPolicy `json:"items"`
+}
+
+type TrafficPolicySpec struct {
+	// routingKeys selects the routing keys the policy applies to. Entries
+	// are matched as shell patterns, so `db-*` selects every routing key
+	// starting with `db-`.
+	//
+	// +kubebuilder:validation:MinItems=1
+	RoutingKeys []string `json:"routingKeys"`
+	// listeners restricts which sites may bind listeners to the selected
+	// routing keys.
+	Listeners *TrafficPolicyRule `json:"listeners,omitempty"`
+	// connectors restricts which sites may bind connectors to the selected
+	// routing keys.
+	Connectors *TrafficPolicyRule `json:"connectors,omitempty"`
+	// withholdConnectors makes the site defining the policy withhold its
+	// connectors for a selected routing key while the network status shows
+	// a listener for that key on a site the listener rule denies. The
+	// connectors are then unavailable to every site, permitted ones
+	// included, which is reported in their status.
+	WithholdConnectors bool `json:"withholdConnectors,omitempty"`
+}
+
+// TrafficPolicyRule lists sites by name. Entries are matched as shell
+// patterns. A site matched by deniedSites is always denied. When
+// allowedSites is set, any site it does not match is denied.
+type TrafficPolicyRule struct {
+	AllowedSites []string `json:"allowedSites,omitempty"`
+	DeniedSites  []string `json:"deniedSites,omitempty"`
+}
+
+type TrafficPolicyStatus struct {
+	Status `json:",inline"`
+}
+
+func (p *TrafficPolicy) SetConfigured(err error) bool {
+	if p.Status.SetCondition(CONDITION_TYPE_CONFIGURED, ErrorOrReadyCondition(err), p.ObjectMeta.Generation) {
+		p.Status.setReady([]string{CONDITION_TYPE_CONFIGURED}, p.ObjectMeta.Generation)
+		return true
+	}
+	return false
+}
+
+func (p *TrafficPolicy) IsConfigured() bool {
+	return meta.IsStatusConditionTrue(p.Status.Conditions, CONDITION_TYPE_CONFIGURED)
+}
+
+// SelectsRoutingKey returns true if the routing key is matched by one of
+// the patterns in the policy.
+func (p *TrafficPolicy) SelectsRoutingKey(routingKey string) bool {
+	return matchesAny(p.Spec.RoutingKeys, routingKey)
+}
+
+// Denies returns true if the rule denies the named site.
+func (r *TrafficPolicyRule) Denies(siteName string) bool {
+	if matchesAny(r.DeniedSites, siteName) {
+		return true
+	}
+	return len(r.AllowedSites) > 0 && !matchesAny(r.AllowedSites, siteName)
+}
+
+func matchesAny(patterns []string, value string) bool {
+	return slices.ContainsFunc(patterns, func(pattern string) bool {
+		matched, err := path.Match(pattern, value)
+		return matched || (err != nil && pattern == value)
+	})
+}
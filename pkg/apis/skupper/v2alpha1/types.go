@@ -3,6 +3,8 @@ package v2alpha1
 import (
 	"fmt"
 	"reflect"
+	"slices"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -200,6 +202,43 @@ type SiteSpec struct {
 	Edge           bool              `json:"edge,omitempty"`
 	HA             bool              `json:"ha,omitempty"`
 	Settings       map[string]string `json:"settings,omitempty"`
+	// routingKeyScope isolates the routing keys of the site's listeners
+	// and connectors from those of sites in other scopes.
+	RoutingKeyScope *RoutingKeyScope `json:"routingKeyScope,omitempty"`
+}
+
+// RoutingKeyScope qualifies the routing keys used by the bindings of a
+// site, so that sites in different scopes cannot collide on or bind to
+// each other's keys unless a key is explicitly exported by one scope and
+// imported by the other. Sites sharing a scope should declare the same
+// exports.
+//
+// Scope names are trust-on-declaration: nothing prevents a site from
+// declaring another site's scope name, so scopes only separate sites that
+// declare their scope honestly. Isolation from untrusted sites relies on
+// not linking to them or on traffic policies.
+type RoutingKeyScope struct {
+	// name qualifies every routing key used by the site. It must be a
+	// valid DNS label.
+	Name string `json:"name"`
+	// exports lists the routing keys, matched as shell patterns, that
+	// listeners in other scopes may import.
+	Exports []string `json:"exports,omitempty"`
+	// imports lists the routing keys exported by other scopes that
+	// listeners in this site may bind to, each in the form
+	// `<scope>/<routing key>`.
+	Imports []string `json:"imports,omitempty"`
+}
+
+// IsExported returns true if the routing key is exported by the scope.
+func (s *RoutingKeyScope) IsExported(routingKey string) bool {
+	return matchesAny(s.Exports, routingKey)
+}
+
+// IsImported returns true if the routing key refers to a key exported by
+// another scope and is imported by this scope.
+func (s *RoutingKeyScope) IsImported(routingKey string) bool {
+	return strings.Contains(routingKey, "/") && slices.Contains(s.Imports, routingKey)
 }
 
 func (s *SiteSpec) GetServiceAccount() string {
@@ -476,6 +515,28 @@ func (c *Connector) SetSelectedPods(pods []PodDetails) bool {
 	return false
 }
 
+func (c *Connector) SetTargetHealth(targets []TargetHealth) bool {
+	if !reflect.DeepEqual(targets, c.Status.Targets) {
+		c.Status.Targets = targets
+		return true
+	}
+	return false
+}
+
+// IsTargetHealthy returns false only if health checking is enabled and
+// the target has been found to be unhealthy.
+func (c *Connector) IsTargetHealthy(host string) bool {
+	if c.Spec.HealthCheck == nil {
+		return true
+	}
+	for _, target := range c.Status.Targets {
+		if target.Host == host {
+			return target.Healthy
+		}
+	}
+	return true
+}
+
 func (s *Connector) IsConfigured() bool {
 	return meta.IsStatusConditionTrue(s.Status.Conditions, CONDITION_TYPE_CONFIGURED)
 }
@@ -501,6 +562,80 @@ type ConnectorSpec struct {
 	ExposePodsByName    bool              `json:"exposePodsByName,omitempty"`
 	IncludeNotReadyPods bool              `json:"includeNotReadyPods,omitempty"`
 	Settings            map[string]string `json:"settings,omitempty"`
+	HealthCheck         *HealthCheck      `json:"healthCheck,omitempty"`
+}
+
+// HealthCheck configures active health checking of the targets of a
+// connector. Targets that fail the check are removed from the router
+// configuration until they pass it again.
+type HealthCheck struct {
+	// type of check, either tcp (the default), which only checks that a
+	// connection can be established, or http, which issues a GET request
+	// and expects a 2xx or 3xx response; http checks are made in plain
+	// text and so cannot be used by connectors with tlsCredentials
+	Type string `json:"type,omitempty"`
+	// path requested by http checks, defaults to /
+	Path string `json:"path,omitempty"`
+	// port to check, defaults to the port of the connector
+	Port int `json:"port,omitempty"`
+	// periodSeconds between checks, defaults to 10
+	PeriodSeconds int `json:"periodSeconds,omitempty"`
+	// timeoutSeconds after which a check fails, defaults to 1
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+	// failureThreshold is the number of consecutive failures after which
+	// a target is considered unhealthy, defaults to 3
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+	// successThreshold is the number of consecutive successes after
+	// which an unhealthy target is considered healthy again, defaults
+	// to 1
+	SuccessThreshold int `json:"successThreshold,omitempty"`
+}
+
+const (
+	HealthCheckTypeTcp  string = "tcp"
+	HealthCheckTypeHttp string = "http"
+)
+
+func (h *HealthCheck) GetType() string {
+	if h.Type == "" {
+		return HealthCheckTypeTcp
+	}
+	return h.Type
+}
+
+func (h *HealthCheck) GetPath() string {
+	if h.Path == "" {
+		return "/"
+	}
+	return h.Path
+}
+
+func (h *HealthCheck) GetPeriod() time.Duration {
+	if h.PeriodSeconds <= 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(h.PeriodSeconds) * time.Second
+}
+
+func (h *HealthCheck) GetTimeout() time.Duration {
+	if h.TimeoutSeconds <= 0 {
+		return time.Second
+	}
+	return time.Duration(h.TimeoutSeconds) * time.Second
+}
+
+func (h *HealthCheck) GetFailureThreshold() int {
+	if h.FailureThreshold <= 0 {
+		return 3
+	}
+	return h.FailureThreshold
+}
+
+func (h *HealthCheck) GetSuccessThreshold() int {
+	if h.SuccessThreshold <= 0 {
+		return 1
+	}
+	return h.SuccessThreshold
 }
 
 type PodDetails struct {
@@ -511,8 +646,16 @@ type PodDetails struct {
 
 type ConnectorStatus struct {
 	Status              `json:",inline"`
-	SelectedPods        []PodDetails `json:"selectedPods,omitempty"`
-	HasMatchingListener bool         `json:"hasMatchingListener,omitempty"`
+	SelectedPods        []PodDetails   `json:"selectedPods,omitempty"`
+	HasMatchingListener bool           `json:"hasMatchingListener,omitempty"`
+	Targets             []TargetHealth `json:"targets,omitempty"`
+}
+
+// TargetHealth records the result of health checking a connector target.
+type TargetHealth struct {
+	Host    string `json:"host"`
+	Healthy bool   `json:"healthy"`
+	Message string `json:"message,omitempty"`
 }
 
 // +genclient
@@ -628,6 +628,11 @@ func (in *ConnectorSpec) DeepCopyInto(out *ConnectorSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(HealthCheck)
+		**out = **in
+	}
 	return
 }
 
@@ -650,6 +655,11 @@ func (in *ConnectorStatus) DeepCopyInto(out *ConnectorStatus) {
 		*out = make([]PodDetails, len(*in))
 		copy(*out, *in)
 	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]TargetHealth, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -695,6 +705,22 @@ func (in *Endpoint) DeepCopy() *Endpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheck.
+func (in *HealthCheck) DeepCopy() *HealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Link) DeepCopyInto(out *Link) {
 	*out = *in
@@ -1247,6 +1273,32 @@ func (in *RouterAccessStatus) DeepCopy() *RouterAccessStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutingKeyScope) DeepCopyInto(out *RoutingKeyScope) {
+	*out = *in
+	if in.Exports != nil {
+		in, out := &in.Exports, &out.Exports
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Imports != nil {
+		in, out := &in.Imports, &out.Imports
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingKeyScope.
+func (in *RoutingKeyScope) DeepCopy() *RoutingKeyScope {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutingKeyScope)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecuredAccess) DeepCopyInto(out *SecuredAccess) {
 	*out = *in
@@ -1522,6 +1574,11 @@ func (in *SiteSpec) DeepCopyInto(out *SiteSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.RoutingKeyScope != nil {
+		in, out := &in.RoutingKeyScope, &out.RoutingKeyScope
+		*out = new(RoutingKeyScope)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1618,6 +1675,157 @@ func (in *StrategyStatus) DeepCopy() *StrategyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetHealth) DeepCopyInto(out *TargetHealth) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetHealth.
+func (in *TargetHealth) DeepCopy() *TargetHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficPolicy) DeepCopyInto(out *TrafficPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficPolicy.
+func (in *TrafficPolicy) DeepCopy() *TrafficPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TrafficPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficPolicyList) DeepCopyInto(out *TrafficPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TrafficPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficPolicyList.
+func (in *TrafficPolicyList) DeepCopy() *TrafficPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TrafficPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficPolicyRule) DeepCopyInto(out *TrafficPolicyRule) {
+	*out = *in
+	if in.AllowedSites != nil {
+		in, out := &in.AllowedSites, &out.AllowedSites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedSites != nil {
+		in, out := &in.DeniedSites, &out.DeniedSites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficPolicyRule.
+func (in *TrafficPolicyRule) DeepCopy() *TrafficPolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficPolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficPolicySpec) DeepCopyInto(out *TrafficPolicySpec) {
+	*out = *in
+	if in.RoutingKeys != nil {
+		in, out := &in.RoutingKeys, &out.RoutingKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Listeners != nil {
+		in, out := &in.Listeners, &out.Listeners
+		*out = new(TrafficPolicyRule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Connectors != nil {
+		in, out := &in.Connectors, &out.Connectors
+		*out = new(TrafficPolicyRule)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficPolicySpec.
+func (in *TrafficPolicySpec) DeepCopy() *TrafficPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficPolicyStatus) DeepCopyInto(out *TrafficPolicyStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficPolicyStatus.
+func (in *TrafficPolicyStatus) DeepCopy() *TrafficPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WeightedStrategySpec) DeepCopyInto(out *WeightedStrategySpec) {
 	*out = *in
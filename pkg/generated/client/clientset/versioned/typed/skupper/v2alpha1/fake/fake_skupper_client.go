@@ -76,6 +76,10 @@ func (c *FakeSkupperV2alpha1) Sites(namespace string) v2alpha1.SiteInterface {
 	return newFakeSites(c, namespace)
 }
 
+func (c *FakeSkupperV2alpha1) TrafficPolicies(namespace string) v2alpha1.TrafficPolicyInterface {
+	return newFakeTrafficPolicies(c, namespace)
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeSkupperV2alpha1) RESTClient() rest.Interface {
@@ -0,0 +1,52 @@
+/*
+Copyright 2021 The Skupper Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeTrafficPolicies implements TrafficPolicyInterface
+type fakeTrafficPolicies struct {
+	*gentype.FakeClientWithList[*v2alpha1.TrafficPolicy, *v2alpha1.TrafficPolicyList]
+	Fake *FakeSkupperV2alpha1
+}
+
+func newFakeTrafficPolicies(fake *FakeSkupperV2alpha1, namespace string) skupperv2alpha1.TrafficPolicyInterface {
+	return &fakeTrafficPolicies{
+		gentype.NewFakeClientWithList[*v2alpha1.TrafficPolicy, *v2alpha1.TrafficPolicyList](
+			fake.Fake,
+			namespace,
+			v2alpha1.SchemeGroupVersion.WithResource("trafficpolicies"),
+			v2alpha1.SchemeGroupVersion.WithKind("TrafficPolicy"),
+			func() *v2alpha1.TrafficPolicy { return &v2alpha1.TrafficPolicy{} },
+			func() *v2alpha1.TrafficPolicyList { return &v2alpha1.TrafficPolicyList{} },
+			func(dst, src *v2alpha1.TrafficPolicyList) { dst.ListMeta = src.ListMeta },
+			func(list *v2alpha1.TrafficPolicyList) []*v2alpha1.TrafficPolicy {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v2alpha1.TrafficPolicyList, items []*v2alpha1.TrafficPolicy) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
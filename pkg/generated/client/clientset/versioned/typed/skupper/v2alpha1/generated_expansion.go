@@ -41,3 +41,5 @@ type RouterAccessExpansion interface{}
 type SecuredAccessExpansion interface{}
 
 type SiteExpansion interface{}
+
+type TrafficPolicyExpansion interface{}
@@ -40,6 +40,7 @@ type SkupperV2alpha1Interface interface {
 	RouterAccessesGetter
 	SecuredAccessesGetter
 	SitesGetter
+	TrafficPoliciesGetter
 }
 
 // SkupperV2alpha1Client is used to interact with features provided by the skupper.io group.
@@ -95,6 +96,10 @@ func (c *SkupperV2alpha1Client) Sites(namespace string) SiteInterface {
 	return newSites(c, namespace)
 }
 
+func (c *SkupperV2alpha1Client) TrafficPolicies(namespace string) TrafficPolicyInterface {
+	return newTrafficPolicies(c, namespace)
+}
+
 // NewForConfig creates a new SkupperV2alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -48,6 +48,8 @@ type Interface interface {
 	SecuredAccesses() SecuredAccessInformer
 	// Sites returns a SiteInformer.
 	Sites() SiteInformer
+	// TrafficPolicies returns a TrafficPolicyInformer.
+	TrafficPolicies() TrafficPolicyInformer
 }
 
 type version struct {
@@ -120,3 +122,8 @@ func (v *version) SecuredAccesses() SecuredAccessInformer {
 func (v *version) Sites() SiteInformer {
 	return &siteInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// TrafficPolicies returns a TrafficPolicyInformer.
+func (v *version) TrafficPolicies() TrafficPolicyInformer {
+	return &trafficPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -113,3 +113,11 @@ type SiteListerExpansion interface{}
 // SiteNamespaceListerExpansion allows custom methods to be added to
 // SiteNamespaceLister.
 type SiteNamespaceListerExpansion interface{}
+
+// TrafficPolicyListerExpansion allows custom methods to be added to
+// TrafficPolicyLister.
+type TrafficPolicyListerExpansion interface{}
+
+// TrafficPolicyNamespaceListerExpansion allows custom methods to be added to
+// TrafficPolicyNamespaceLister.
+type TrafficPolicyNamespaceListerExpansion interface{}
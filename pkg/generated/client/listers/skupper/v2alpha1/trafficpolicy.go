@@ -0,0 +1,70 @@
+/*
+Copyright 2021 The Skupper Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v2alpha1
+
+import (
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// TrafficPolicyLister helps list TrafficPolicies.
+// All objects returned here must be treated as read-only.
+type TrafficPolicyLister interface {
+	// List lists all TrafficPolicies in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*skupperv2alpha1.TrafficPolicy, err error)
+	// TrafficPolicies returns an object that can list and get TrafficPolicies.
+	TrafficPolicies(namespace string) TrafficPolicyNamespaceLister
+	TrafficPolicyListerExpansion
+}
+
+// trafficPolicyLister implements the TrafficPolicyLister interface.
+type trafficPolicyLister struct {
+	listers.ResourceIndexer[*skupperv2alpha1.TrafficPolicy]
+}
+
+// NewTrafficPolicyLister returns a new TrafficPolicyLister.
+func NewTrafficPolicyLister(indexer cache.Indexer) TrafficPolicyLister {
+	return &trafficPolicyLister{listers.New[*skupperv2alpha1.TrafficPolicy](indexer, skupperv2alpha1.Resource("trafficpolicy"))}
+}
+
+// TrafficPolicies returns an object that can list and get TrafficPolicies.
+func (s *trafficPolicyLister) TrafficPolicies(namespace string) TrafficPolicyNamespaceLister {
+	return trafficPolicyNamespaceLister{listers.NewNamespaced[*skupperv2alpha1.TrafficPolicy](s.ResourceIndexer, namespace)}
+}
+
+// TrafficPolicyNamespaceLister helps list and get TrafficPolicies.
+// All objects returned here must be treated as read-only.
+type TrafficPolicyNamespaceLister interface {
+	// List lists all TrafficPolicies in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*skupperv2alpha1.TrafficPolicy, err error)
+	// Get retrieves the TrafficPolicy from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*skupperv2alpha1.TrafficPolicy, error)
+	TrafficPolicyNamespaceListerExpansion
+}
+
+// trafficPolicyNamespaceLister implements the TrafficPolicyNamespaceLister
+// interface.
+type trafficPolicyNamespaceLister struct {
+	listers.ResourceIndexer[*skupperv2alpha1.TrafficPolicy]
+}
@@ -39,6 +39,7 @@ type SiteState struct {
 	Certificates      map[string]*v2alpha1.Certificate
 	SecuredAccesses   map[string]*v2alpha1.SecuredAccess
 	MultiKeyListeners map[string]*v2alpha1.MultiKeyListener
+	TrafficPolicies   map[string]*v2alpha1.TrafficPolicy
 	Secrets           map[string]*corev1.Secret
 	ConfigMaps        map[string]*corev1.ConfigMap
 	bundle            bool
@@ -56,6 +57,7 @@ func NewSiteState(bundle bool) *SiteState {
 		Certificates:      make(map[string]*v2alpha1.Certificate),
 		SecuredAccesses:   make(map[string]*v2alpha1.SecuredAccess),
 		MultiKeyListeners: make(map[string]*v2alpha1.MultiKeyListener),
+		TrafficPolicies:   make(map[string]*v2alpha1.TrafficPolicy),
 		Secrets:           make(map[string]*corev1.Secret),
 		ConfigMaps:        make(map[string]*corev1.ConfigMap),
 		bundle:            bundle,
@@ -277,21 +279,33 @@ func (s *SiteState) linkMap(sslProfileBasePath string) site.LinkMap {
 
 func (s *SiteState) bindings(sslProfileBasePath string) *site.Bindings {
 	b := site.NewBindings(path.Join(sslProfileBasePath, string(CertificatesPath)))
+	b.SetSiteName(s.Site.Name)
+	b.SetRoutingKeyScope(s.Site.Spec.RoutingKeyScope)
+	for name, policy := range s.TrafficPolicies {
+		policy.SetConfigured(nil)
+		_ = b.UpdateTrafficPolicy(name, policy)
+	}
 	for name, connector := range s.Connectors {
-		connector.SetConfigured(nil)
+		connector.SetConfigured(b.CheckConnector(connector))
 		_ = b.UpdateConnector(name, connector)
 	}
 	for name, listener := range s.Listeners {
-		listener.SetConfigured(nil)
+		listener.SetConfigured(b.CheckListener(listener))
 		_ = b.UpdateListener(name, listener)
 	}
 	for name, mkl := range s.MultiKeyListeners {
-		mkl.SetConfigured(nil)
+		mkl.SetConfigured(b.CheckMultiKeyListener(mkl))
 		b.UpdateMultiKeyListener(name, mkl)
 	}
 	return b
 }
 
+// ToBridgeConfig returns the router bridge configuration for the
+// listeners and connectors in the site state.
+func (s *SiteState) ToBridgeConfig() qdr.BridgeConfig {
+	return s.bindings("").ToBridgeConfig()
+}
+
 func (s *SiteState) ToRouterConfig(sslProfileBasePath string, platform string) qdr.RouterConfig {
 	if s.SiteId == "" {
 		s.SiteId = uuid.New().String()
@@ -365,6 +379,7 @@ func (s *SiteState) SetNamespace(namespace string) {
 	setNamespaceOnMap(s.Certificates, namespace)
 	setNamespaceOnMap(s.SecuredAccesses, namespace)
 	setNamespaceOnMap(s.MultiKeyListeners, namespace)
+	setNamespaceOnMap(s.TrafficPolicies, namespace)
 	setNamespaceOnMap(s.ConfigMaps, namespace)
 }
 
@@ -393,17 +408,17 @@ func (s *SiteState) UpdateStatus(networkStatus network.NetworkStatusInfo) {
 
 	// updating listeners and connectors
 	for _, listener := range s.Listeners {
-		listener.SetHasMatchingConnector(network.HasMatchingPair(networkStatus, listener.Spec.RoutingKey))
+		listener.SetHasMatchingConnector(network.HasMatchingPair(networkStatus, site.ListenerAddress(s.Site.Spec.RoutingKeyScope, listener.Spec.RoutingKey)))
 	}
 	for _, connector := range s.Connectors {
-		connector.SetHasMatchingListener(network.HasMatchingPair(networkStatus, connector.Spec.RoutingKey))
+		connector.SetHasMatchingListener(network.HasMatchingPair(networkStatus, site.ConnectorAddress(s.Site.Spec.RoutingKeyScope, connector.Spec.RoutingKey)))
 	}
 	for _, mkl := range s.MultiKeyListeners {
 		hasDestination := false
 		var reachableKeys []string
 		for _, routingKey := range mkl.GetRoutingKeys() {
 			for _, addr := range networkStatus.Addresses {
-				if addr.Name == routingKey && addr.ConnectorCount > 0 {
+				if addr.Name == site.ListenerAddress(s.Site.Spec.RoutingKeyScope, routingKey) && addr.ConnectorCount > 0 {
 					hasDestination = true
 					reachableKeys = append(reachableKeys, routingKey)
 					break
@@ -480,6 +495,9 @@ func MarshalSiteState(siteState SiteState, outputDirectory string) error {
 	if err = marshalMap(outputDirectory, "MultiKeyListener", siteState.MultiKeyListeners); err != nil {
 		return err
 	}
+	if err = marshalMap(outputDirectory, "TrafficPolicy", siteState.TrafficPolicies); err != nil {
+		return err
+	}
 	if err = marshalMap(outputDirectory, "Secret", siteState.Secrets); err != nil {
 		return err
 	}
@@ -0,0 +1,133 @@
+package capture
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	amqp "github.com/Azure/go-amqp"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+)
+
+const (
+	formatName    = "vanflow-capture"
+	formatVersion = 1
+)
+
+// Entry is a single captured vanflow message
+type Entry struct {
+	// Time the message was received
+	Time time.Time
+	// Address the message was received on
+	Address string
+	// Message as received
+	Message *amqp.Message
+}
+
+// Subject of the captured message
+func (e Entry) Subject() string {
+	if e.Message == nil || e.Message.Properties == nil || e.Message.Properties.Subject == nil {
+		return ""
+	}
+	return *e.Message.Properties.Subject
+}
+
+// Decode the captured message into one of the vanflow message types. See
+// vanflow.Decode.
+func (e Entry) Decode() (interface{}, error) {
+	return vanflow.Decode(e.Message)
+}
+
+type header struct {
+	Format  string `json:"format"`
+	Version int    `json:"version"`
+}
+
+type entry struct {
+	Time    time.Time `json:"time"`
+	Address string    `json:"address"`
+	Subject string    `json:"subject,omitempty"`
+	Message []byte    `json:"message"`
+}
+
+// Writer writes entries to a capture. It is safe for concurrent use.
+type Writer struct {
+	mu            sync.Mutex
+	enc           *json.Encoder
+	headerWritten bool
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{enc: json.NewEncoder(w)}
+}
+
+// Write an entry to the capture
+func (w *Writer) Write(e Entry) error {
+	if e.Message == nil {
+		return errors.New("cannot capture nil message")
+	}
+	data, err := e.Message.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("error encoding message: %w", err)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.headerWritten {
+		if err := w.enc.Encode(header{Format: formatName, Version: formatVersion}); err != nil {
+			return fmt.Errorf("error writing capture header: %w", err)
+		}
+		w.headerWritten = true
+	}
+	return w.enc.Encode(entry{
+		Time:    e.Time,
+		Address: e.Address,
+		Subject: e.Subject(),
+		Message: data,
+	})
+}
+
+// Reader reads entries from a capture
+type Reader struct {
+	dec        *json.Decoder
+	headerRead bool
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{dec: json.NewDecoder(r)}
+}
+
+// Next returns the next entry in the capture. Returns io.EOF when there are
+// no more entries.
+func (r *Reader) Next() (Entry, error) {
+	if !r.headerRead {
+		var h header
+		if err := r.dec.Decode(&h); err != nil {
+			if errors.Is(err, io.EOF) {
+				return Entry{}, io.EOF
+			}
+			return Entry{}, fmt.Errorf("error reading capture header: %w", err)
+		}
+		if h.Format != formatName {
+			return Entry{}, fmt.Errorf("not a vanflow capture: unexpected format %q", h.Format)
+		}
+		if h.Version != formatVersion {
+			return Entry{}, fmt.Errorf("unsupported vanflow capture version %d", h.Version)
+		}
+		r.headerRead = true
+	}
+	var e entry
+	if err := r.dec.Decode(&e); err != nil {
+		if errors.Is(err, io.EOF) {
+			return Entry{}, io.EOF
+		}
+		return Entry{}, fmt.Errorf("error reading capture entry: %w", err)
+	}
+	msg := new(amqp.Message)
+	if err := msg.UnmarshalBinary(e.Message); err != nil {
+		return Entry{}, fmt.Errorf("error decoding captured message: %w", err)
+	}
+	return Entry{Time: e.Time, Address: e.Address, Message: msg}, nil
+}
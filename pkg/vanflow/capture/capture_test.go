@@ -0,0 +1,181 @@
+package capture
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/eventsource"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+	"gotest.tools/v3/assert"
+)
+
+func ptrTo[T any](c T) *T {
+	return &c
+}
+
+func TestWriterReader(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	beacon := vanflow.BeaconMessage{Version: 1, SourceType: "ROUTER", Address: "mc/sfe.r1", Direct: "sfe.r1", Identity: "r1"}
+	record := vanflow.RecordMessage{
+		MessageProps: vanflow.MessageProps{To: "mc/sfe.r1", Subject: "RECORD"},
+		Records: []vanflow.Record{
+			vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1", start), Name: ptrTo("east")},
+		},
+	}
+	recordMsg, err := record.Encode()
+	assert.Assert(t, err)
+	assert.Assert(t, w.Write(Entry{Time: start, Address: "mc/sfe.all", Message: beacon.Encode()}))
+	assert.Assert(t, w.Write(Entry{Time: start.Add(time.Second), Address: "mc/sfe.r1", Message: recordMsg}))
+	assert.Assert(t, w.Write(Entry{Time: start}) != nil)
+
+	r := NewReader(&buf)
+	first, err := r.Next()
+	assert.Assert(t, err)
+	assert.Assert(t, first.Time.Equal(start))
+	assert.Equal(t, first.Address, "mc/sfe.all")
+	decoded, err := first.Decode()
+	assert.Assert(t, err)
+	beacon.To, beacon.Subject = "mc/sfe.all", "BEACON"
+	assert.DeepEqual(t, decoded, beacon)
+
+	second, err := r.Next()
+	assert.Assert(t, err)
+	assert.Equal(t, second.Subject(), "RECORD")
+	decoded, err = second.Decode()
+	assert.Assert(t, err)
+	assert.DeepEqual(t, decoded, record)
+
+	_, err = r.Next()
+	assert.Equal(t, err, io.EOF)
+
+	_, err = NewReader(strings.NewReader(`{"format":"pcap","version":1}`)).Next()
+	assert.ErrorContains(t, err, "not a vanflow capture")
+	_, err = NewReader(strings.NewReader(`{"format":"vanflow-capture","version":2}`)).Next()
+	assert.ErrorContains(t, err, "unsupported")
+	_, err = NewReader(strings.NewReader("")).Next()
+	assert.Equal(t, err, io.EOF)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// capture from a fake router event source
+	live := session.NewMockRouter()
+	source := session.NewMockContainer(live)
+	var buf bytes.Buffer
+	entries := make(chan Entry, 16)
+	recorder := NewRecorder(session.NewMockContainer(live), NewWriter(&buf), RecorderOptions{
+		Flush:   true,
+		OnEntry: func(e Entry) { entries <- e },
+	})
+	flushes := source.NewReceiver("sfe.r1", session.ReceiverOptions{})
+	recordCtx, recordCancel := context.WithCancel(ctx)
+	recorderDone := make(chan error)
+	go func() { recorderDone <- recorder.Run(recordCtx) }()
+
+	beacon := vanflow.BeaconMessage{Version: 1, SourceType: "ROUTER", Address: "mc/sfe.r1", Direct: "sfe.r1", Identity: "r1"}
+	assert.Assert(t, source.NewSender("mc/sfe.all", session.SenderOptions{}).Send(ctx, beacon.Encode()))
+	<-entries
+
+	sender := source.NewSender("mc/sfe.r1", session.SenderOptions{})
+	heartbeat := vanflow.HeartbeatMessage{Identity: "r1", Version: 1, Now: 1}
+	assert.Assert(t, sender.Send(ctx, heartbeat.Encode()))
+	<-entries
+
+	// recorder asks the source to flush after the first message
+	_, err := flushes.Next(ctx)
+	assert.Assert(t, err)
+	records := []vanflow.Record{
+		vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1"), Name: ptrTo("east")},
+		vanflow.RouterRecord{BaseRecord: vanflow.NewBase("router-1"), Parent: ptrTo("site-1")},
+	}
+	for _, record := range records {
+		msg, err := vanflow.RecordMessage{
+			MessageProps: vanflow.MessageProps{To: "mc/sfe.r1", Subject: "RECORD"},
+			Records:      []vanflow.Record{record},
+		}.Encode()
+		assert.Assert(t, err)
+		assert.Assert(t, sender.Send(ctx, msg))
+		<-entries
+	}
+	recordCancel()
+	assert.Assert(t, <-recorderDone)
+
+	// replay to a client discovered on a separate network
+	replayed := session.NewMockRouter()
+	discovery := eventsource.NewDiscovery(session.NewMockContainer(replayed), eventsource.DiscoveryOptions{})
+	received := make(chan vanflow.RecordMessage, 8)
+	clients := make(chan *eventsource.Client, 1)
+	go discovery.Run(ctx, eventsource.DiscoveryHandlers{
+		Discovered: func(info eventsource.Info) {
+			client := eventsource.NewClient(session.NewMockContainer(replayed), eventsource.ClientOptions{Source: info})
+			client.OnRecord(func(msg vanflow.RecordMessage) { received <- msg })
+			client.Listen(ctx, eventsource.FromSourceAddress())
+			clients <- client
+		},
+	})
+
+	replayCtx, replayCancel := context.WithCancel(ctx)
+	replayDone := make(chan ReplayStats)
+	replayer := NewReplayer(session.NewMockContainer(replayed), NewReader(&buf), ReplayOptions{Speed: 100, Hold: true})
+	go func() {
+		stats, err := replayer.Run(replayCtx)
+		assert.Check(t, err)
+		replayDone <- stats
+	}()
+	for _, expected := range records {
+		msg := <-received
+		assert.DeepEqual(t, msg.Records, []vanflow.Record{expected})
+	}
+	// flush requests to replayed sources are absorbed by the replayer
+	assert.Assert(t, (<-clients).SendFlush(ctx))
+	replayCancel()
+	assert.DeepEqual(t, <-replayDone, ReplayStats{Sent: 4})
+}
+
+func TestReplaySpeed(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		heartbeat := vanflow.HeartbeatMessage{Identity: "c1", Version: 1, Now: uint64(i)}
+		assert.Assert(t, w.Write(Entry{
+			Time:    start.Add(time.Duration(i) * time.Second),
+			Address: "mc/sfe.c1",
+			Message: heartbeat.Encode(),
+		}))
+	}
+
+	router := session.NewMockRouter()
+	receiver := session.NewMockContainer(router).NewReceiver("mc/sfe.c1", session.ReceiverOptions{})
+	replayer := NewReplayer(session.NewMockContainer(router), NewReader(bytes.NewReader(buf.Bytes())), ReplayOptions{Speed: 20})
+	began := time.Now()
+	stats, err := replayer.Run(ctx)
+	assert.Assert(t, err)
+	assert.Equal(t, stats.Sent, 3)
+	elapsed := time.Since(began)
+	assert.Assert(t, elapsed >= 100*time.Millisecond, "replayed too fast: %s", elapsed)
+	for i := 0; i < 3; i++ {
+		msg, err := receiver.Next(ctx)
+		assert.Assert(t, err)
+		assert.Equal(t, vanflow.DecodeHeartbeat(msg).Now, uint64(i))
+	}
+
+	// messages without a receiver are skipped after the send timeout
+	replayer = NewReplayer(session.NewMockContainer(session.NewMockRouter()), NewReader(bytes.NewReader(buf.Bytes())), ReplayOptions{
+		SendTimeout: 10 * time.Millisecond,
+	})
+	stats, err = replayer.Run(ctx)
+	assert.Assert(t, err)
+	assert.DeepEqual(t, stats, ReplayStats{Skipped: 3})
+}
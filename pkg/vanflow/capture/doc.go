@@ -0,0 +1,12 @@
+/*
+Package capture records raw vanflow traffic to a file and replays it later.
+
+A capture is a stream of newline delimited JSON objects. The first line is a
+header identifying the format version. Every line after it is an Entry: a
+vanflow message in its AMQP encoding along with the address it was received
+on and the time it was received. Keeping messages in their wire encoding lets
+captures be replayed to any consumer of vanflow events, such as an
+eventsource.Client or the network observer collector, exactly as they were
+originally sent.
+*/
+package capture
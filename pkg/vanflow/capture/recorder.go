@@ -0,0 +1,191 @@
+package capture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	amqp "github.com/Azure/go-amqp"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/eventsource"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+)
+
+const beaconAddress = "mc/sfe.all"
+
+// sourceAddresses are the addresses an event source publishes messages to
+var sourceAddresses = []eventsource.ListenerConfigProvider{
+	eventsource.FromSourceAddress(),
+	eventsource.FromSourceAddressFlows(),
+	eventsource.FromSourceAddressHeartbeats(),
+	eventsource.FromSourceAddressLogs(),
+}
+
+type RecorderOptions struct {
+	// Sources limits the capture to the event sources with these IDs.
+	// Captures every discovered source when empty.
+	Sources []string
+	// Flush requests that each source send all of its records once it has
+	// been discovered so that the capture starts with a complete picture of
+	// the network instead of only the changes made while capturing.
+	Flush bool
+	// OnEntry is called with each entry after it has been written
+	OnEntry func(Entry)
+}
+
+// Recorder captures the vanflow messages sent by event sources
+type Recorder struct {
+	container session.Container
+	writer    *Writer
+	opts      RecorderOptions
+	logger    *slog.Logger
+
+	mu      sync.Mutex
+	sources map[string]bool
+}
+
+func NewRecorder(container session.Container, writer *Writer, opts RecorderOptions) *Recorder {
+	sources := make(map[string]bool)
+	for _, id := range opts.Sources {
+		sources[id] = false
+	}
+	return &Recorder{
+		container: container,
+		writer:    writer,
+		opts:      opts,
+		sources:   sources,
+		logger: slog.New(slog.Default().Handler()).With(
+			slog.String("component", "vanflow.capture.recorder"),
+		),
+	}
+}
+
+// Run captures messages until the context is cancelled. Returns nil when
+// stopped by the context, otherwise the error that stopped the capture.
+func (r *Recorder) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	receiver := r.container.NewReceiver(beaconAddress, session.ReceiverOptions{})
+	defer receiver.Close(context.Background())
+	for {
+		msg, err := receiver.Next(ctx)
+		if err != nil {
+			if errors.Is(err, ctx.Err()) {
+				return ignoreCanceled(context.Cause(ctx))
+			}
+			return fmt.Errorf("error receiving beacon messages: %w", err)
+		}
+		if err := receiver.Accept(ctx, msg); err != nil {
+			r.logger.Error("error accepting message", slog.Any("error", err))
+		}
+		if err := r.record(beaconAddress, msg); err != nil {
+			return err
+		}
+		if msg.Properties == nil || msg.Properties.Subject == nil || *msg.Properties.Subject != "BEACON" {
+			continue
+		}
+		beacon := vanflow.DecodeBeacon(msg)
+		if !r.discover(beacon.Identity) {
+			continue
+		}
+		r.logger.Info("capturing event source",
+			slog.String("id", beacon.Identity),
+			slog.String("type", beacon.SourceType))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.capture(ctx, cancel, beacon)
+		}()
+	}
+}
+
+// discover returns true the first time a source that should be captured is
+// seen.
+func (r *Recorder) discover(id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	seen, ok := r.sources[id]
+	if seen || (!ok && len(r.opts.Sources) > 0) {
+		return false
+	}
+	r.sources[id] = true
+	return true
+}
+
+func (r *Recorder) capture(ctx context.Context, stop context.CancelCauseFunc, beacon vanflow.BeaconMessage) {
+	info := eventsource.Info{ID: beacon.Identity, Address: beacon.Address, Direct: beacon.Direct}
+	first := make(chan struct{})
+	var once sync.Once
+	var wg sync.WaitGroup
+	for _, provider := range sourceAddresses {
+		address := provider.Get(info).Address
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			receiver := r.container.NewReceiver(address, session.ReceiverOptions{Credit: 256})
+			defer receiver.Close(context.Background())
+			for {
+				msg, err := receiver.Next(ctx)
+				if err != nil {
+					if errors.Is(err, ctx.Err()) {
+						return
+					}
+					r.logger.Error("error receiving message", slog.Any("error", err), slog.String("address", address))
+					continue
+				}
+				if err := receiver.Accept(ctx, msg); err != nil {
+					r.logger.Error("error accepting message", slog.Any("error", err), slog.String("address", address))
+				}
+				if err := r.record(address, msg); err != nil {
+					stop(err)
+					return
+				}
+				once.Do(func() { close(first) })
+			}
+		}()
+	}
+	if r.opts.Flush && beacon.Direct != "" {
+		select {
+		case <-ctx.Done():
+		case <-first:
+			if err := r.flush(ctx, beacon.Direct); err != nil {
+				r.logger.Error("error sending flush", slog.Any("error", err), slog.String("id", beacon.Identity))
+			}
+		}
+	}
+	wg.Wait()
+}
+
+func (r *Recorder) flush(ctx context.Context, direct string) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+	var flush vanflow.FlushMessage
+	flush.To = direct
+	sender := r.container.NewSender(direct, session.SenderOptions{})
+	defer sender.Close(ctx)
+	return sender.Send(ctx, flush.Encode())
+}
+
+func (r *Recorder) record(address string, msg *amqp.Message) error {
+	e := Entry{Time: time.Now(), Address: address, Message: msg}
+	if err := r.writer.Write(e); err != nil {
+		return fmt.Errorf("error writing capture: %w", err)
+	}
+	if r.opts.OnEntry != nil {
+		r.opts.OnEntry(e)
+	}
+	return nil
+}
+
+func ignoreCanceled(err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return nil
+	}
+	return err
+}
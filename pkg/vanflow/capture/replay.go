@@ -0,0 +1,214 @@
+package capture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	amqp "github.com/Azure/go-amqp"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+)
+
+type ReplayOptions struct {
+	// Speed scales the delay between messages relative to when they were
+	// captured. 1 replays at the original speed and 10 replays ten times
+	// faster. Zero or less replays messages as fast as they can be sent.
+	Speed float64
+	// SendTimeout is the time to wait for each message to be sent before
+	// skipping it. Waits indefinitely when zero.
+	SendTimeout time.Duration
+	// Hold keeps the replayed event sources alive once the capture has been
+	// exhausted by sending beacons and heartbeats on their behalf until the
+	// context is cancelled. Without it, consumers will forget the sources
+	// once they stop hearing from them.
+	Hold bool
+	// HoldInterval is the period at which beacons and heartbeats are sent
+	// while holding. Defaults to two seconds.
+	HoldInterval time.Duration
+}
+
+// ReplayStats summarizes a replay
+type ReplayStats struct {
+	Sent    int
+	Skipped int
+}
+
+// Replayer sends captured messages to a container
+type Replayer struct {
+	container session.Container
+	reader    *Reader
+	opts      ReplayOptions
+	logger    *slog.Logger
+
+	senders map[string]session.Sender
+	sources map[string]vanflow.BeaconMessage
+}
+
+func NewReplayer(container session.Container, reader *Reader, opts ReplayOptions) *Replayer {
+	if opts.HoldInterval <= 0 {
+		opts.HoldInterval = 2 * time.Second
+	}
+	return &Replayer{
+		container: container,
+		reader:    reader,
+		opts:      opts,
+		senders:   make(map[string]session.Sender),
+		sources:   make(map[string]vanflow.BeaconMessage),
+		logger: slog.New(slog.Default().Handler()).With(
+			slog.String("component", "vanflow.capture.replayer"),
+		),
+	}
+}
+
+// Run sends each captured message to the address it was captured on, in
+// order, until the capture is exhausted or the context is cancelled. Flush
+// requests sent to the replayed sources are discarded since the capture
+// already holds whatever the sources sent in response.
+func (r *Replayer) Run(ctx context.Context) (ReplayStats, error) {
+	var stats ReplayStats
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer func() {
+		for _, sender := range r.senders {
+			sender.Close(context.Background())
+		}
+	}()
+
+	var (
+		captureStart time.Time
+		replayStart  = time.Now()
+	)
+	for {
+		e, err := r.reader.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return stats, err
+		}
+		if captureStart.IsZero() {
+			captureStart = e.Time
+		}
+		if r.opts.Speed > 0 {
+			offset := time.Duration(float64(e.Time.Sub(captureStart)) / r.opts.Speed)
+			if err := sleepUntil(ctx, replayStart.Add(offset)); err != nil {
+				return stats, err
+			}
+		}
+		if e.Subject() == "BEACON" {
+			beacon := vanflow.DecodeBeacon(e.Message)
+			if _, ok := r.sources[beacon.Identity]; !ok && beacon.Direct != "" {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					r.discardFlushes(ctx, beacon.Direct)
+				}()
+			}
+			r.sources[beacon.Identity] = beacon
+		}
+		if err := r.send(ctx, e.Address, e.Message); err != nil {
+			if ctx.Err() != nil {
+				return stats, ctx.Err()
+			}
+			if !errors.Is(err, context.DeadlineExceeded) {
+				return stats, fmt.Errorf("error replaying message to %s: %w", e.Address, err)
+			}
+			r.logger.Debug("skipping message that could not be sent in time",
+				slog.String("address", e.Address),
+				slog.String("subject", e.Subject()))
+			stats.Skipped++
+			continue
+		}
+		stats.Sent++
+	}
+	if r.opts.Hold {
+		r.hold(ctx)
+	}
+	return stats, nil
+}
+
+func (r *Replayer) send(ctx context.Context, address string, msg *amqp.Message) error {
+	sender, ok := r.senders[address]
+	if !ok {
+		sender = r.container.NewSender(address, session.SenderOptions{})
+		r.senders[address] = sender
+	}
+	if r.opts.SendTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.opts.SendTimeout)
+		defer cancel()
+	}
+	return sender.Send(ctx, msg)
+}
+
+// hold sends beacons and heartbeats for each replayed source until the
+// context is cancelled.
+func (r *Replayer) hold(ctx context.Context) {
+	r.logger.Info("capture replayed. holding event sources", slog.Int("sources", len(r.sources)))
+	ticker := time.NewTicker(r.opts.HoldInterval)
+	defer ticker.Stop()
+	for {
+		for _, beacon := range r.sources {
+			heartbeat := vanflow.HeartbeatMessage{
+				Identity: beacon.Identity,
+				Version:  beacon.Version,
+				Now:      uint64(time.Now().UnixMicro()),
+			}
+			for address, msg := range map[string]*amqp.Message{
+				beaconAddress:  beacon.Encode(),
+				beacon.Address: heartbeat.Encode(),
+			} {
+				sendCtx, cancel := context.WithTimeout(ctx, r.opts.HoldInterval)
+				err := r.send(sendCtx, address, msg)
+				cancel()
+				if err != nil && ctx.Err() == nil {
+					r.logger.Debug("error sending keepalive", slog.Any("error", err), slog.String("address", address))
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Replayer) discardFlushes(ctx context.Context, address string) {
+	receiver := r.container.NewReceiver(address, session.ReceiverOptions{})
+	defer receiver.Close(context.Background())
+	for {
+		msg, err := receiver.Next(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			r.logger.Error("error receiving message", slog.Any("error", err), slog.String("address", address))
+			continue
+		}
+		receiver.Accept(ctx, msg)
+		r.logger.Debug("discarding message sent to replayed source", slog.String("address", address))
+	}
+}
+
+func sleepUntil(ctx context.Context, t time.Time) error {
+	d := time.Until(t)
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
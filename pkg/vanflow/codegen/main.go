@@ -0,0 +1,300 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+
+	"sigs.k8s.io/yaml"
+)
+
+const recordsTmpl = `// Code generated by vanflow codegen. DO NOT EDIT.
+
+package vanflow
+
+import "github.com/skupperproject/skupper/pkg/vanflow/encoding"
+
+const apiVersion = "{{ .APIVersion }}"
+
+// Record attribute codepoints
+const (
+{{- range .Attributes }}
+	Attribute{{ .Name }} uint32 = {{ .Codepoint }}
+{{- end }}
+)
+
+func init() {
+{{- range .Records }}
+	encoding.MustRegisterRecord({{ .Codepoint }}, {{ .Name }}{})
+{{- end }}
+}
+{{ range .Records }}
+// {{ .Name }} is {{ lowerFirst .Description }}
+type {{ .Name }} struct {
+	BaseRecord
+{{- range .Fields }}
+	{{ .Name }} *{{ .GoType }} ` + "`" + `vflow:"{{ .Codepoint }}{{ if .Required }},required{{ end }}"` + "`" + `{{ if .Unspecified }} // unspecified{{ end }}
+{{- end }}
+}
+
+func (r {{ .Name }}) GetTypeMeta() TypeMeta {
+	return TypeMeta{
+		APIVersion: apiVersion,
+		Type:       "{{ .Name }}",
+	}
+}
+{{ end }}`
+
+const docsTmpl = `<!-- Code generated by vanflow codegen. DO NOT EDIT. -->
+# Vanflow Records
+
+The vanflow record types of API version ` + "`{{ .APIVersion }}`" + ` and their
+attributes, generated from [schema.yaml](schema.yaml). Attributes marked
+unspecified are sent by skupper components but are not part of the vanflow
+specification.
+
+Every record type has the base attributes:
+
+| Codepoint | Field | Type | Description |
+|-----------|-------|------|-------------|
+{{- range .Attributes }}{{ if .Base }}
+| {{ .Codepoint }} | {{ .Name }} | {{ .Type }} | {{ .Description }}{{ if .Required }} (required){{ end }} |
+{{- end }}{{ end }}
+
+## Record Types
+
+| Codepoint | Record | Description |
+|-----------|--------|-------------|
+{{- range .Records }}
+| {{ .Codepoint }} | [{{ .Name }}](#{{ lower .Name }}) | {{ .Description }} |
+{{- end }}
+{{ range .Records }}
+### {{ .Name }}
+
+{{ .Description }}.
+
+| Codepoint | Field | Type | Description |
+|-----------|-------|------|-------------|
+{{- range .Fields }}
+| {{ .Codepoint }} | {{ .Name }} | {{ .Type }} | {{ .Description }}{{ if .Required }} (required){{ end }}{{ if .Unspecified }} (unspecified){{ end }} |
+{{- end }}
+{{ end }}`
+
+// Schema describes the vanflow record types
+type Schema struct {
+	APIVersion string      `json:"apiVersion"`
+	Attributes []Attribute `json:"attributes"`
+	Records    []Record    `json:"records"`
+}
+
+type Attribute struct {
+	Codepoint   uint32 `json:"codepoint"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Base        bool   `json:"base"`
+	Required    bool   `json:"required"`
+	Unspecified bool   `json:"unspecified"`
+}
+
+type Record struct {
+	Codepoint   uint32            `json:"codepoint"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Attributes  []RecordAttribute `json:"attributes"`
+
+	// Fields are the resolved record attributes
+	Fields []Field `json:"-"`
+}
+
+// RecordAttribute references an attribute carried by a record type
+type RecordAttribute struct {
+	Name string `json:"name"`
+	// Field overrides the struct field name of the attribute
+	Field       string `json:"field"`
+	Required    bool   `json:"required"`
+	Unspecified bool   `json:"unspecified"`
+}
+
+// Field is a struct field of a generated record type
+type Field struct {
+	Attribute
+	GoType string
+}
+
+var goTypes = map[string]string{
+	"string": "string",
+	"uint64": "uint64",
+	"time":   "Time",
+}
+
+func main() {
+	var output, docs string
+	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	flags.StringVar(&output, "o", "", "file to write the generated record types to. Defaults to stdout")
+	flags.StringVar(&docs, "doc", "", "file to write the markdown documentation of the record types to")
+	flags.Usage = func() {
+		fmt.Println("codegen [options...] <schema file>")
+		fmt.Println(`generates the vanflow record types from the vanflow schema.`)
+		flags.PrintDefaults()
+	}
+	flags.Parse(os.Args[1:])
+
+	input := flags.Args()
+	if len(input) != 1 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	schema, err := loadSchema(input[0])
+	if err != nil {
+		slog.Error("error loading schema", slog.Any("error", err))
+		os.Exit(1)
+	}
+	records, err := generateRecords(schema)
+	if err != nil {
+		slog.Error("error generating record types", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if err := writeOutput(output, records); err != nil {
+		slog.Error("error writing record types", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if docs != "" {
+		out, err := generateDocs(schema)
+		if err != nil {
+			slog.Error("error generating documentation", slog.Any("error", err))
+			os.Exit(1)
+		}
+		if err := writeOutput(docs, out); err != nil {
+			slog.Error("error writing documentation", slog.Any("error", err))
+			os.Exit(1)
+		}
+	}
+}
+
+func writeOutput(path string, data []byte) error {
+	if path == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func loadSchema(path string) (Schema, error) {
+	var schema Schema
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return schema, err
+	}
+	if err := yaml.UnmarshalStrict(data, &schema); err != nil {
+		return schema, fmt.Errorf("error parsing %s: %w", path, err)
+	}
+	return schema, schema.resolve()
+}
+
+// resolve validates the schema and resolves the fields of each record type
+func (s *Schema) resolve() error {
+	if s.APIVersion == "" {
+		return fmt.Errorf("apiVersion is required")
+	}
+	byName := make(map[string]Attribute, len(s.Attributes))
+	byCodepoint := make(map[uint32]Attribute, len(s.Attributes))
+	for _, attr := range s.Attributes {
+		if attr.Name == "" {
+			return fmt.Errorf("attribute %d has no name", attr.Codepoint)
+		}
+		if attr.Codepoint == 0 {
+			return fmt.Errorf("attribute %s: codepoint 0 is reserved for the record type", attr.Name)
+		}
+		if _, ok := goTypes[attr.Type]; !ok {
+			return fmt.Errorf("attribute %s: unsupported type %q", attr.Name, attr.Type)
+		}
+		if existing, ok := byCodepoint[attr.Codepoint]; ok {
+			return fmt.Errorf("attribute %s repeats codepoint %d used by %s", attr.Name, attr.Codepoint, existing.Name)
+		}
+		if _, ok := byName[attr.Name]; ok {
+			return fmt.Errorf("attribute name %s is not unique", attr.Name)
+		}
+		byName[attr.Name] = attr
+		byCodepoint[attr.Codepoint] = attr
+	}
+	slices.SortFunc(s.Attributes, func(a, b Attribute) int {
+		return int(a.Codepoint) - int(b.Codepoint)
+	})
+
+	records := make(map[uint32]string, len(s.Records))
+	for i := range s.Records {
+		record := &s.Records[i]
+		if existing, ok := records[record.Codepoint]; ok {
+			return fmt.Errorf("record %s repeats codepoint %d used by %s", record.Name, record.Codepoint, existing)
+		}
+		records[record.Codepoint] = record.Name
+		if record.Description == "" {
+			return fmt.Errorf("record %s has no description", record.Name)
+		}
+		fields := make(map[string]bool)
+		codepoints := make(map[uint32]bool)
+		record.Fields = nil
+		for _, ref := range record.Attributes {
+			attr, ok := byName[ref.Name]
+			if !ok {
+				return fmt.Errorf("record %s: unknown attribute %q", record.Name, ref.Name)
+			}
+			if attr.Base {
+				return fmt.Errorf("record %s: base attribute %s is part of every record", record.Name, ref.Name)
+			}
+			if codepoints[attr.Codepoint] {
+				return fmt.Errorf("record %s repeats attribute %s", record.Name, ref.Name)
+			}
+			codepoints[attr.Codepoint] = true
+			if ref.Field != "" {
+				attr.Name = ref.Field
+			}
+			if fields[attr.Name] {
+				return fmt.Errorf("record %s repeats field name %s", record.Name, attr.Name)
+			}
+			fields[attr.Name] = true
+			attr.Required = attr.Required || ref.Required
+			attr.Unspecified = attr.Unspecified || ref.Unspecified
+			record.Fields = append(record.Fields, Field{Attribute: attr, GoType: goTypes[attr.Type]})
+		}
+	}
+	slices.SortFunc(s.Records, func(a, b Record) int {
+		return int(a.Codepoint) - int(b.Codepoint)
+	})
+	return nil
+}
+
+var funcs = template.FuncMap{
+	"lower": strings.ToLower,
+	"lowerFirst": func(s string) string {
+		if s == "" {
+			return s
+		}
+		return strings.ToLower(s[:1]) + s[1:]
+	},
+}
+
+func generateRecords(schema Schema) ([]byte, error) {
+	t := template.Must(template.New("records").Funcs(funcs).Parse(recordsTmpl))
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, schema); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+func generateDocs(schema Schema) ([]byte, error) {
+	t := template.Must(template.New("docs").Funcs(funcs).Parse(docsTmpl))
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, schema); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
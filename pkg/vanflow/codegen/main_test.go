@@ -0,0 +1,132 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+// TestGeneratedUpToDate fails when the schema has been changed without
+// running go generate
+func TestGeneratedUpToDate(t *testing.T) {
+	schema, err := loadSchema("../schema.yaml")
+	assert.Assert(t, err)
+
+	records, err := generateRecords(schema)
+	assert.Assert(t, err)
+	expected, err := os.ReadFile("../records_gen.go")
+	assert.Assert(t, err)
+	assert.Equal(t, string(records), string(expected), "records_gen.go is out of date: run go generate ./pkg/vanflow")
+
+	docs, err := generateDocs(schema)
+	assert.Assert(t, err)
+	expected, err = os.ReadFile("../RECORDS.md")
+	assert.Assert(t, err)
+	assert.Equal(t, string(docs), string(expected), "RECORDS.md is out of date: run go generate ./pkg/vanflow")
+}
+
+func TestResolve(t *testing.T) {
+	attributes := func() []Attribute {
+		return []Attribute{
+			{Codepoint: 1, Name: "ID", Type: "string", Base: true, Required: true},
+			{Codepoint: 2, Name: "Parent", Type: "string"},
+			{Codepoint: 32, Name: "BuildVersion", Type: "string"},
+		}
+	}
+	testCases := []struct {
+		Name          string
+		Schema        Schema
+		ExpectedError string
+	}{
+		{
+			Name: "valid",
+			Schema: Schema{APIVersion: "v1", Attributes: attributes(), Records: []Record{
+				{Codepoint: 1, Name: "B", Description: "b", Attributes: []RecordAttribute{{Name: "Parent"}}},
+				{Codepoint: 0, Name: "A", Description: "a", Attributes: []RecordAttribute{{Name: "BuildVersion", Field: "Version"}}},
+			}},
+		}, {
+			Name:          "missing api version",
+			Schema:        Schema{Attributes: attributes()},
+			ExpectedError: "apiVersion is required",
+		}, {
+			Name: "repeated attribute codepoint",
+			Schema: Schema{APIVersion: "v1", Attributes: append(attributes(),
+				Attribute{Codepoint: 2, Name: "Other", Type: "string"},
+			)},
+			ExpectedError: "attribute Other repeats codepoint 2 used by Parent",
+		}, {
+			Name: "unsupported type",
+			Schema: Schema{APIVersion: "v1", Attributes: append(attributes(),
+				Attribute{Codepoint: 3, Name: "Other", Type: "float"},
+			)},
+			ExpectedError: `unsupported type "float"`,
+		}, {
+			Name: "repeated record codepoint",
+			Schema: Schema{APIVersion: "v1", Attributes: attributes(), Records: []Record{
+				{Codepoint: 0, Name: "A", Description: "a"},
+				{Codepoint: 0, Name: "B", Description: "b"},
+			}},
+			ExpectedError: "record B repeats codepoint 0 used by A",
+		}, {
+			Name: "unknown attribute",
+			Schema: Schema{APIVersion: "v1", Attributes: attributes(), Records: []Record{
+				{Codepoint: 0, Name: "A", Description: "a", Attributes: []RecordAttribute{{Name: "Missing"}}},
+			}},
+			ExpectedError: `record A: unknown attribute "Missing"`,
+		}, {
+			Name: "base attribute",
+			Schema: Schema{APIVersion: "v1", Attributes: attributes(), Records: []Record{
+				{Codepoint: 0, Name: "A", Description: "a", Attributes: []RecordAttribute{{Name: "ID"}}},
+			}},
+			ExpectedError: "base attribute ID is part of every record",
+		}, {
+			Name: "repeated field name",
+			Schema: Schema{APIVersion: "v1", Attributes: attributes(), Records: []Record{
+				{Codepoint: 0, Name: "A", Description: "a", Attributes: []RecordAttribute{
+					{Name: "Parent"},
+					{Name: "BuildVersion", Field: "Parent"},
+				}},
+			}},
+			ExpectedError: "record A repeats field name Parent",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := tc.Schema.resolve()
+			if tc.ExpectedError != "" {
+				assert.ErrorContains(t, err, tc.ExpectedError)
+				return
+			}
+			assert.Assert(t, err)
+		})
+	}
+}
+
+func TestResolveFields(t *testing.T) {
+	schema := Schema{
+		APIVersion: "v1",
+		Attributes: []Attribute{
+			{Codepoint: 30, Name: "Name", Type: "string"},
+			{Codepoint: 2, Name: "Parent", Type: "string"},
+			{Codepoint: 66, Name: "Path", Type: "string", Unspecified: true},
+		},
+		Records: []Record{{
+			Codepoint:   0,
+			Name:        "A",
+			Description: "a",
+			Attributes: []RecordAttribute{
+				{Name: "Name", Field: "Title", Required: true},
+				{Name: "Parent", Unspecified: true},
+				{Name: "Path"},
+			},
+		}},
+	}
+	assert.Assert(t, schema.resolve())
+	assert.Equal(t, schema.Attributes[0].Name, "Parent")
+	assert.DeepEqual(t, schema.Records[0].Fields, []Field{
+		{Attribute: Attribute{Codepoint: 30, Name: "Title", Type: "string", Required: true}, GoType: "string"},
+		{Attribute: Attribute{Codepoint: 2, Name: "Parent", Type: "string", Unspecified: true}, GoType: "string"},
+		{Attribute: Attribute{Codepoint: 66, Name: "Path", Type: "string", Unspecified: true}, GoType: "string"},
+	})
+}
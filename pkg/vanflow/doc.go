@@ -1,5 +1,9 @@
 /*
 Package vanflow defines types for the message and record types specified in the
 VanFlow specification.
+
+The record types are generated from schema.yaml, which holds the codepoint and
+type of every record attribute. To change a record type, edit the schema and
+run go generate. RECORDS.md documents the generated record types.
 */
 package vanflow
@@ -59,6 +59,13 @@ func Encode(record any) (RecordAttributeSet, error) {
 	return result, err
 }
 
+// Validate checks that a record can be encoded: its type has been registered
+// with MustRegisterRecord and all of its required fields are set.
+func Validate(record any) error {
+	_, err := Encode(record)
+	return err
+}
+
 type fieldEncoder interface {
 	encode(v reflect.Value) (interface{}, error)
 }
@@ -184,3 +184,50 @@ func BenchmarkEncodeDecode(b *testing.B) {
 func ptrTo[T any](t T) *T {
 	return &t
 }
+
+func TestRecordCodepoint(t *testing.T) {
+	codepoint, err := encoding.RecordCodepoint(vanflow.ProcessRecord{})
+	assert.Assert(t, err)
+	assert.Equal(t, codepoint, uint32(7))
+	codepoint, err = encoding.RecordCodepoint(&tRecordAttributeEncodeDecode{})
+	assert.Assert(t, err)
+	assert.Equal(t, codepoint, recordTypeRecordAttributeEncodeDecode)
+	_, err = encoding.RecordCodepoint(struct{}{})
+	assert.ErrorContains(t, err, "unregistered record type")
+}
+
+func TestAttributes(t *testing.T) {
+	start := vanflow.Time{Time: time.UnixMicro(100)}
+	attrs, err := encoding.Attributes(vanflow.SiteRecord{
+		Location: ptrTo("loc"),
+		BaseRecord: vanflow.BaseRecord{
+			ID:        "test",
+			StartTime: ptrTo(start),
+		},
+	})
+	assert.Assert(t, err)
+	assert.DeepEqual(t, attrs, []encoding.Attribute{
+		{Name: "ID", Codepoint: 1, Value: "test"},
+		{Name: "StartTime", Codepoint: 3, Value: start},
+		{Name: "Location", Codepoint: 9, Value: "loc"},
+	})
+
+	attrs, err = encoding.Attributes(&tRecordAttributeEncodeDecode{B: true})
+	assert.Assert(t, err)
+	assert.DeepEqual(t, attrs, []encoding.Attribute{
+		{Name: "B", Codepoint: 100, Value: MagicBool(true)},
+	})
+
+	_, err = encoding.Attributes(nil)
+	assert.ErrorContains(t, err, "nil record")
+	_, err = encoding.Attributes(struct{}{})
+	assert.ErrorContains(t, err, "unregistered record type")
+}
+
+func TestValidate(t *testing.T) {
+	assert.Check(t, encoding.Validate(vanflow.HostRecord{BaseRecord: vanflow.NewBase("host")}))
+	assert.Check(t, encoding.Validate(&vanflow.HostRecord{BaseRecord: vanflow.NewBase("host")}))
+	assert.ErrorContains(t, encoding.Validate(vanflow.HostRecord{}), "missing or empty required field ID")
+	assert.ErrorContains(t, encoding.Validate(struct{}{}), "unregistered record type")
+	assert.ErrorContains(t, encoding.Validate(nil), "nil record")
+}
@@ -32,9 +32,11 @@
 package encoding
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -216,3 +218,64 @@ func fieldsForType(t reflect.Type, index []int) []field {
 	}
 	return fields
 }
+
+// RecordCodepoint returns the codepoint a record type was registered with
+func RecordCodepoint(record any) (uint32, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if record == nil {
+		return 0, errors.New("cannot get codepoint of nil record")
+	}
+	encoding, ok := encodings[reflect.Indirect(reflect.ValueOf(record)).Type()]
+	if !ok {
+		return 0, fmt.Errorf("unregistered record type %T", record)
+	}
+	return encoding.codepoint, nil
+}
+
+// Attribute is a named record attribute
+type Attribute struct {
+	// Name of the struct field the attribute is mapped to
+	Name      string
+	Codepoint uint32
+	// Value of the struct field. Pointers are dereferenced.
+	Value any
+}
+
+// Attributes returns the attributes that are set on a record in codepoint
+// order. Only records types that have been registered with
+// MustRegisterRecord are supported.
+func Attributes(record any) ([]Attribute, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if record == nil {
+		return nil, errors.New("cannot get attributes of nil record")
+	}
+	recordV := reflect.Indirect(reflect.ValueOf(record))
+	encoding, ok := encodings[recordV.Type()]
+	if !ok {
+		return nil, fmt.Errorf("unregistered record type %T", record)
+	}
+	attributes := make([]Attribute, 0, len(encoding.fields))
+	for _, field := range encoding.fields {
+		v := recordV
+		for _, idx := range field.index {
+			v = v.Field(idx)
+		}
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				continue
+			}
+			v = v.Elem()
+		}
+		attributes = append(attributes, Attribute{
+			Name:      field.Name,
+			Codepoint: field.Codepoint,
+			Value:     v.Interface(),
+		})
+	}
+	slices.SortFunc(attributes, func(a, b Attribute) int {
+		return cmp.Compare(a.Codepoint, b.Codepoint)
+	})
+	return attributes, nil
+}
@@ -7,7 +7,9 @@ import (
 	"log/slog"
 	"sync"
 
+	amqp "github.com/Azure/go-amqp"
 	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/encoding"
 	"github.com/skupperproject/skupper/pkg/vanflow/session"
 )
 
@@ -24,6 +26,9 @@ const (
 type Client struct {
 	container   session.Container
 	eventSource Info
+	queueSize   int
+	overflow    OverflowPolicy
+	metrics     *ClientMetrics
 
 	lock              sync.Mutex
 	cleanup           []func()
@@ -37,12 +42,40 @@ type Client struct {
 type ClientOptions struct {
 	// Source of vanflow events
 	Source Info
+
+	// QueueSize is the number of record messages each listener buffers for
+	// the record handlers so that slow handlers do not hold up receiving.
+	// When zero, record handlers are called as each message is received.
+	QueueSize int
+	// Overflow is the policy applied to record messages received while a
+	// listener's queue is full. Defaults to OverflowBlock.
+	Overflow OverflowPolicy
+	// Metrics to report queue depth and dropped records to. Optional.
+	Metrics *ClientMetrics
 }
 
+// OverflowPolicy determines what a Client does with record messages received
+// while its queue is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock stops receiving messages until the record handlers have
+	// caught up, leaving the event source to hold on to them once the link
+	// credit is used up.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropFlows drops the flow records of messages received while the
+	// queue is full, and blocks like OverflowBlock for the remaining records
+	// so that topology records are never lost.
+	OverflowDropFlows
+)
+
 func NewClient(container session.Container, cfg ClientOptions) *Client {
 	c := &Client{
 		container:   container,
 		eventSource: cfg.Source,
+		queueSize:   cfg.QueueSize,
+		overflow:    cfg.Overflow,
+		metrics:     cfg.Metrics,
 		logger: slog.New(slog.Default().Handler()).With(
 			slog.String("component", "vanflow.eventsource.client"),
 			slog.String("instance", cfg.Source.ID),
@@ -80,8 +113,19 @@ func (c *Client) getHandlers() ([]RecordMessageHandler, []HeartbeatMessageHandle
 
 // Listen instructs the Client to listen to an event source using the specified
 // listener configuration until the context is cancelled or client.Close() is
-// called.
+// called. Returns an error when the listener configuration has an invalid
+// RecordFilter.
 func (c *Client) Listen(ctx context.Context, attributes ListenerConfigProvider) error {
+	cfg := attributes.Get(c.eventSource)
+	var keep func(encoding.RecordAttributeSet) bool
+	if cfg.Filter != nil {
+		var err error
+		keep, err = cfg.Filter.matcher()
+		if err != nil {
+			return fmt.Errorf("invalid record filter: %w", err)
+		}
+	}
+
 	c.wg.Add(1)
 	listenerCtx, listenerCancel := context.WithCancel(ctx)
 
@@ -89,9 +133,34 @@ func (c *Client) Listen(ctx context.Context, attributes ListenerConfigProvider)
 	defer c.lock.Unlock()
 	c.cleanup = append(c.cleanup, listenerCancel)
 
+	handleRecords := c.handleRecords
+	if c.queueSize > 0 {
+		queue := make(chan vanflow.RecordMessage, c.queueSize)
+		c.wg.Add(1)
+		go func(ctx context.Context) {
+			defer c.wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					if c.metrics != nil {
+						c.metrics.QueueDepth.Sub(float64(len(queue)))
+					}
+					return
+				case message := <-queue:
+					if c.metrics != nil {
+						c.metrics.QueueDepth.Dec()
+					}
+					c.handleRecords(message)
+				}
+			}
+		}(listenerCtx)
+		handleRecords = func(message vanflow.RecordMessage) {
+			c.enqueue(listenerCtx, queue, message)
+		}
+	}
+
 	go func(ctx context.Context) {
 		defer c.wg.Done()
-		cfg := attributes.Get(c.eventSource)
 		receiver := c.container.NewReceiver(cfg.Address, session.ReceiverOptions{
 			Credit: cfg.Credit,
 		})
@@ -112,18 +181,19 @@ func (c *Client) Listen(ctx context.Context, attributes ListenerConfigProvider)
 				c.logger.Error("client error accepting message", slog.Any("error", err), slog.String("address", cfg.Address))
 				continue
 			}
-			decoded, err := vanflow.Decode(amqpMsg)
+			decoded, err := decodeFiltered(amqpMsg, keep)
 			if err != nil {
 				c.logger.Error("skipping message that could not be decoded", slog.Any("error", err))
 				continue
 			}
-			recordHandlers, heartbeatHandlers := c.getHandlers()
 			switch message := decoded.(type) {
 			case vanflow.RecordMessage:
-				for _, handler := range recordHandlers {
-					handler(message)
+				if keep != nil && len(message.Records) == 0 {
+					continue
 				}
+				handleRecords(message)
 			case vanflow.HeartbeatMessage:
+				_, heartbeatHandlers := c.getHandlers()
 				for _, handler := range heartbeatHandlers {
 					handler(message)
 				}
@@ -133,6 +203,75 @@ func (c *Client) Listen(ctx context.Context, attributes ListenerConfigProvider)
 	return nil
 }
 
+func (c *Client) handleRecords(message vanflow.RecordMessage) {
+	recordHandlers, _ := c.getHandlers()
+	for _, handler := range recordHandlers {
+		handler(message)
+	}
+}
+
+// enqueue a record message for the record handlers, applying the overflow
+// policy when the queue is full.
+func (c *Client) enqueue(ctx context.Context, queue chan<- vanflow.RecordMessage, message vanflow.RecordMessage) {
+	if c.metrics != nil {
+		c.metrics.QueueDepth.Inc()
+	}
+	select {
+	case queue <- message:
+		return
+	default:
+	}
+	if c.overflow == OverflowDropFlows {
+		records := make([]vanflow.Record, 0, len(message.Records))
+		for _, record := range message.Records {
+			if !isFlowRecord(record) {
+				records = append(records, record)
+				continue
+			}
+			if c.metrics != nil {
+				c.metrics.DroppedRecords.WithLabelValues(record.GetTypeMeta().Type).Inc()
+			}
+		}
+		if dropped := len(message.Records) - len(records); dropped > 0 {
+			c.logger.Debug("dropped flow records from full queue", slog.Int("count", dropped))
+		}
+		message.Records = records
+		if len(records) == 0 {
+			if c.metrics != nil {
+				c.metrics.QueueDepth.Dec()
+			}
+			return
+		}
+	}
+	select {
+	case <-ctx.Done():
+		if c.metrics != nil {
+			c.metrics.QueueDepth.Dec()
+		}
+	case queue <- message:
+	}
+}
+
+// isFlowRecord returns true for the record types describing individual
+// connections and requests rather than the network topology
+func isFlowRecord(record vanflow.Record) bool {
+	switch record.(type) {
+	case vanflow.FlowRecord, vanflow.TransportBiflowRecord, vanflow.AppBiflowRecord:
+		return true
+	default:
+		return false
+	}
+}
+
+// decodeFiltered decodes a message, leaving out the records of a record
+// message that keep does not match.
+func decodeFiltered(msg *amqp.Message, keep func(encoding.RecordAttributeSet) bool) (interface{}, error) {
+	if keep != nil && msg.Properties != nil && msg.Properties.Subject != nil && *msg.Properties.Subject == "RECORD" {
+		return vanflow.DecodeRecordFunc(msg, keep)
+	}
+	return vanflow.Decode(msg)
+}
+
 // Close stops all listeners
 func (c *Client) Close() {
 	c.lock.Lock()
@@ -160,6 +299,8 @@ func (c *Client) SendFlush(ctx context.Context) error {
 type ListenerConfig struct {
 	Address string
 	Credit  int
+	// Filter limits the records received by the listener when set
+	Filter *RecordFilter
 }
 
 type ListenerConfigProvider interface {
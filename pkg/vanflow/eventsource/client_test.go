@@ -13,9 +13,12 @@ import (
 	"time"
 
 	amqp "github.com/Azure/go-amqp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/skupperproject/skupper/pkg/vanflow"
 	"github.com/skupperproject/skupper/pkg/vanflow/session"
 	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
 )
 
 func TestClient(t *testing.T) {
@@ -206,6 +209,143 @@ func TestClientFlush(t *testing.T) {
 
 }
 
+func TestClientRecordFilter(t *testing.T) {
+	tstCtx, tstCancel := context.WithCancel(context.Background())
+	defer tstCancel()
+	factory, _ := requireContainers(t)
+	ctr, tstCtr := factory.Create(), factory.Create()
+	ctr.Start(tstCtx)
+	tstCtr.Start(tstCtx)
+
+	clientID := uniqueSuffix("test")
+	client := NewClient(ctr, ClientOptions{Source: Info{
+		ID:      clientID,
+		Address: mcsfe(clientID),
+	}})
+	defer client.Close()
+	records := make(chan vanflow.RecordMessage, 8)
+	client.OnRecord(func(m vanflow.RecordMessage) { records <- m })
+
+	err := client.Listen(tstCtx, WithRecordFilter(FromSourceAddress(), RecordFilter{Types: []vanflow.Record{nil}}))
+	assert.ErrorContains(t, err, "invalid record filter")
+
+	assert.Check(t, client.Listen(tstCtx, WithRecordFilter(FromSourceAddress(), RecordFilter{
+		Types:      []vanflow.Record{vanflow.ListenerRecord{}},
+		Attributes: map[uint32][]any{vanflow.AttributeProtocol: {"tcp", "http2"}},
+	})))
+
+	sender := tstCtr.NewSender(mcsfe(clientID), session.SenderOptions{})
+	send := func(records ...vanflow.Record) {
+		t.Helper()
+		msg, err := vanflow.RecordMessage{Records: records}.Encode()
+		assert.Assert(t, err)
+		assert.Assert(t, sender.Send(tstCtx, msg))
+	}
+	tcp, http1, http2 := "tcp", "http1", "http2"
+	send(
+		vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("l1"), Protocol: &tcp},
+		vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("l2"), Protocol: &http1},
+		vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("l3")},
+		vanflow.ConnectorRecord{BaseRecord: vanflow.NewBase("c1"), Protocol: &tcp},
+	)
+	send(vanflow.ConnectorRecord{BaseRecord: vanflow.NewBase("c2"), Protocol: &http2})
+	send(vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("l4"), Protocol: &http2})
+	// partial updates of matched records pass until they end
+	ended := vanflow.NewBase("l1", time.Now(), time.Now())
+	send(
+		vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("l1")},
+		vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("l2")},
+		vanflow.ListenerRecord{BaseRecord: ended},
+	)
+	send(vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("l1")})
+	send(vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("l4")})
+
+	for _, expected := range [][]string{{"l1"}, {"l4"}, {"l1", "l1"}, {"l4"}} {
+		msg := <-records
+		var ids []string
+		for _, record := range msg.Records {
+			ids = append(ids, record.Identity())
+		}
+		assert.DeepEqual(t, ids, expected)
+	}
+	select {
+	case msg := <-records:
+		t.Errorf("unexpected record message: %v", msg)
+	default:
+	}
+}
+
+func TestClientQueueOverflow(t *testing.T) {
+	tstCtx, tstCancel := context.WithCancel(context.Background())
+	defer tstCancel()
+	factory := session.NewMockContainerFactory()
+	ctr, tstCtr := factory.Create(), factory.Create()
+	ctr.Start(tstCtx)
+	tstCtr.Start(tstCtx)
+
+	metrics := NewClientMetrics(prometheus.NewRegistry())
+	clientID := uniqueSuffix("test")
+	client := NewClient(ctr, ClientOptions{
+		Source:    Info{ID: clientID, Address: mcsfe(clientID)},
+		QueueSize: 1,
+		Overflow:  OverflowDropFlows,
+		Metrics:   metrics,
+	})
+	defer client.Close()
+	handling := make(chan struct{}, 1)
+	release := make(chan struct{})
+	var handled [][]string
+	client.OnRecord(func(m vanflow.RecordMessage) {
+		var ids []string
+		for _, record := range m.Records {
+			ids = append(ids, record.Identity())
+		}
+		handled = append(handled, ids)
+		select {
+		case handling <- struct{}{}:
+		default:
+		}
+		<-release
+	})
+	assert.Assert(t, client.Listen(tstCtx, FromSourceAddress()))
+
+	sender := tstCtr.NewSender(mcsfe(clientID), session.SenderOptions{})
+	send := func(records ...vanflow.Record) {
+		t.Helper()
+		msg, err := vanflow.RecordMessage{Records: records}.Encode()
+		assert.Assert(t, err)
+		assert.Assert(t, sender.Send(tstCtx, msg))
+	}
+	send(vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site")})
+	<-handling
+	// queued while the handler is busy
+	send(vanflow.RouterRecord{BaseRecord: vanflow.NewBase("router")}, vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow-1")})
+	// dropped entirely
+	send(vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow-2")})
+	// flows dropped, topology waits for space
+	send(vanflow.AppBiflowRecord{BaseRecord: vanflow.NewBase("flow-3")}, vanflow.LinkRecord{BaseRecord: vanflow.NewBase("link")})
+
+	poll.WaitOn(t, func(t poll.LogT) poll.Result {
+		dropped := testutil.ToFloat64(metrics.DroppedRecords.WithLabelValues("TransportBiflowRecord")) +
+			testutil.ToFloat64(metrics.DroppedRecords.WithLabelValues("AppBiflowRecord"))
+		if dropped < 2 {
+			return poll.Continue("waiting for flows to be dropped: %v", dropped)
+		}
+		return poll.Success()
+	}, poll.WithDelay(time.Millisecond), poll.WithTimeout(5*time.Second))
+	assert.Equal(t, testutil.ToFloat64(metrics.QueueDepth), float64(2))
+
+	close(release)
+	poll.WaitOn(t, func(t poll.LogT) poll.Result {
+		if depth := testutil.ToFloat64(metrics.QueueDepth); depth != 0 {
+			return poll.Continue("waiting for queue to drain: %v", depth)
+		}
+		return poll.Success()
+	}, poll.WithDelay(time.Millisecond), poll.WithTimeout(5*time.Second))
+	client.Close()
+	assert.DeepEqual(t, handled, [][]string{{"site"}, {"router", "flow-1"}, {"link"}})
+}
+
 func sendHeartbeatMessagesTo(t *testing.T, ctx context.Context, sender session.Sender) {
 	t.Helper()
 	for {
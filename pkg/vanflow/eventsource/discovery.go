@@ -19,12 +19,19 @@ type Discovery struct {
 	container     session.Container
 	lock          sync.Mutex
 	state         map[string]Info
-	discovered    chan Info
-	forgotten     chan Info
+	pending       []discoveryEvent
+	notify        chan struct{}
 	beaconAddress string
 	logger        *slog.Logger
 }
 
+// discoveryEvent is a discovered or forgotten event source waiting to be
+// passed to the DiscoveryHandlers
+type discoveryEvent struct {
+	Info      Info
+	Forgotten bool
+}
+
 type DiscoveryHandlers struct {
 	Discovered func(source Info)
 	Forgotten  func(source Info)
@@ -41,8 +48,7 @@ func NewDiscovery(container session.Container, opts DiscoveryOptions) *Discovery
 	return &Discovery{
 		container:     container,
 		state:         make(map[string]Info),
-		discovered:    make(chan Info, 32),
-		forgotten:     make(chan Info, 32),
+		notify:        make(chan struct{}, 1),
 		beaconAddress: opts.BeaconAddress,
 		logger: slog.New(slog.Default().Handler()).With(
 			slog.String("component", "vanflow.eventsource.discovery"),
@@ -73,23 +79,40 @@ func (d *Discovery) Run(ctx context.Context, handlers DiscoveryHandlers) error {
 	}
 }
 
+// handleDiscovery passes queued events to the handlers in the order they
+// happened. Handlers are called without holding the lock so that they may
+// call back into Discovery.
 func (d *Discovery) handleDiscovery(ctx context.Context, handlers DiscoveryHandlers) {
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case info := <-d.discovered:
-			if handlers.Discovered != nil {
-				handlers.Discovered(info)
-			}
-		case info := <-d.forgotten:
-			if handlers.Forgotten != nil {
-				handlers.Forgotten(info)
+		case <-d.notify:
+			d.lock.Lock()
+			events := d.pending
+			d.pending = nil
+			d.lock.Unlock()
+			for _, event := range events {
+				switch {
+				case event.Forgotten && handlers.Forgotten != nil:
+					handlers.Forgotten(event.Info)
+				case !event.Forgotten && handlers.Discovered != nil:
+					handlers.Discovered(event.Info)
+				}
 			}
 		}
 	}
 }
 
+// enqueue an event for handleDiscovery. Must be called while holding the lock.
+func (d *Discovery) enqueue(event discoveryEvent) {
+	d.pending = append(d.pending, event)
+	select {
+	case d.notify <- struct{}{}:
+	default:
+	}
+}
+
 // Get an EventSource by ID
 func (d *Discovery) Get(id string) (source Info, ok bool) {
 	d.lock.Lock()
@@ -109,7 +132,7 @@ func (d *Discovery) Add(info Info) bool {
 		return false
 	}
 	d.state[info.ID] = info
-	d.discovered <- info
+	d.enqueue(discoveryEvent{Info: info})
 	return true
 }
 
@@ -124,7 +147,7 @@ func (d *Discovery) Forget(id string) bool {
 	state, forget = d.state[id]
 	delete(d.state, id)
 	if forget {
-		d.forgotten <- state
+		d.enqueue(discoveryEvent{Info: state, Forgotten: true})
 	}
 	return forget
 }
@@ -284,7 +307,7 @@ func (d *Discovery) observe(beacon vanflow.BeaconMessage) {
 	state.LastSeen = tObserved
 	d.state[beacon.Identity] = state
 	if discovered {
-		d.discovered <- state
+		d.enqueue(discoveryEvent{Info: state})
 	}
 }
 
@@ -255,3 +255,41 @@ func fixtureBeaconFor(id string, source string) vanflow.BeaconMessage {
 		Identity:   id,
 	}
 }
+
+func TestDiscoveryHandlersInOrder(t *testing.T) {
+	tstCtx, tstCancel := context.WithCancel(context.Background())
+	defer tstCancel()
+	factory, _ := requireContainers(t)
+	ctr := factory.Create()
+	ctr.Start(tstCtx)
+
+	discovery := NewDiscovery(ctr, DiscoveryOptions{BeaconAddress: mcsfe(uniqueSuffix("all"))})
+	events := make(chan string, 256)
+	go discovery.Run(tstCtx, DiscoveryHandlers{
+		Discovered: func(info Info) {
+			// handlers may call back into discovery
+			discovery.Get(info.ID)
+			events <- "discovered " + info.ID
+		},
+		Forgotten: func(info Info) {
+			discovery.List()
+			events <- "forgotten " + info.ID
+		},
+	})
+
+	var expected []string
+	for i := 0; i < 64; i++ {
+		id := fmt.Sprintf("source-%d", i)
+		assert.Assert(t, discovery.Add(Info{ID: id}))
+		assert.Assert(t, discovery.Forget(id))
+		expected = append(expected, "discovered "+id, "forgotten "+id)
+	}
+	for i, want := range expected {
+		select {
+		case got := <-events:
+			assert.Equal(t, got, want, "event %d", i)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for event %d: %s", i, want)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package eventsource
+
+import (
+	"container/list"
+	"slices"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/encoding"
+)
+
+// RecordFilter selects the records received through a Client listener.
+// Records are matched on their encoded attributes so that records that do
+// not match are never decoded or passed to record handlers.
+type RecordFilter struct {
+	// Types of records to accept given as exemplars such as
+	// vanflow.SiteRecord{}. Accepts records of every type when empty.
+	Types []vanflow.Record
+	// Attributes that records must have set to one of the listed values,
+	// keyed by attribute codepoint such as vanflow.AttributeProtocol. Values
+	// are compared to the encoded attribute value: a string for string
+	// attributes, a uint64 for numeric attributes and a uint64 count of
+	// microseconds since the epoch for times.
+	//
+	// Event sources send partial updates carrying only the attributes that
+	// changed, so attributes are only compared until a record matches. From
+	// then on every update with the matched record's identity is accepted
+	// until one sets its end time.
+	Attributes map[uint32][]any
+	// MaxMatched bounds the number of matched record identities tracked
+	// while waiting for their end time. Past that the least recently updated
+	// are forgotten, and their later updates are only accepted when they
+	// carry matching attributes. Defaults to 65536.
+	MaxMatched int
+}
+
+const defaultMaxMatched = 1 << 16
+
+// matcher compiles the filter to a function matching encoded records. The
+// function keeps track of matched records and is not safe for concurrent use.
+func (f RecordFilter) matcher() (func(encoding.RecordAttributeSet) bool, error) {
+	types := make(map[uint32]bool, len(f.Types))
+	for _, exemplar := range f.Types {
+		codepoint, err := encoding.RecordCodepoint(exemplar)
+		if err != nil {
+			return nil, err
+		}
+		types[codepoint] = true
+	}
+	attributes := make(map[uint32][]any, len(f.Attributes))
+	for codepoint, values := range f.Attributes {
+		attributes[codepoint] = slices.Clone(values)
+	}
+	maxMatched := f.MaxMatched
+	if maxMatched <= 0 {
+		maxMatched = defaultMaxMatched
+	}
+	matched := make(map[string]*list.Element)
+	lru := list.New()
+	return func(attrs encoding.RecordAttributeSet) bool {
+		if len(types) > 0 {
+			typ, ok := attrs[uint32(0)].(uint32)
+			if !ok || !types[typ] {
+				return false
+			}
+		}
+		if len(attributes) == 0 {
+			return true
+		}
+		id, _ := attrs[vanflow.AttributeID].(string)
+		_, ended := attrs[vanflow.AttributeEndTime]
+		if elt, ok := matched[id]; ok {
+			if ended {
+				lru.Remove(elt)
+				delete(matched, id)
+			} else {
+				lru.MoveToBack(elt)
+			}
+			return true
+		}
+		for codepoint, values := range attributes {
+			value, ok := attrs[codepoint]
+			if !ok || !slices.Contains(values, value) {
+				return false
+			}
+		}
+		if id != "" && !ended {
+			if lru.Len() >= maxMatched {
+				oldest := lru.Front()
+				lru.Remove(oldest)
+				delete(matched, oldest.Value.(string))
+			}
+			matched[id] = lru.PushBack(id)
+		}
+		return true
+	}, nil
+}
+
+type filtered struct {
+	provider ListenerConfigProvider
+	filter   RecordFilter
+}
+
+func (f filtered) Get(info Info) ListenerConfig {
+	cfg := f.provider.Get(info)
+	cfg.Filter = &f.filter
+	return cfg
+}
+
+// WithRecordFilter limits the records received through a listener configured
+// by provider to those matching filter.
+func WithRecordFilter(provider ListenerConfigProvider, filter RecordFilter) ListenerConfigProvider {
+	return filtered{provider: provider, filter: filter}
+}
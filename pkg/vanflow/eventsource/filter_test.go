@@ -0,0 +1,33 @@
+package eventsource
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/encoding"
+	"gotest.tools/v3/assert"
+)
+
+func TestRecordFilterMaxMatched(t *testing.T) {
+	keep, err := RecordFilter{
+		Attributes: map[uint32][]any{vanflow.AttributeProtocol: {"tcp"}},
+		MaxMatched: 2,
+	}.matcher()
+	assert.Assert(t, err)
+
+	match := func(id string) encoding.RecordAttributeSet {
+		return encoding.RecordAttributeSet{vanflow.AttributeID: id, vanflow.AttributeProtocol: "tcp"}
+	}
+	update := func(id string) encoding.RecordAttributeSet {
+		return encoding.RecordAttributeSet{vanflow.AttributeID: id}
+	}
+	assert.Check(t, keep(match("r1")))
+	assert.Check(t, keep(match("r2")))
+	// updating r1 makes r2 the least recently updated
+	assert.Check(t, keep(update("r1")))
+	assert.Check(t, keep(match("r3")))
+	assert.Check(t, !keep(update("r2")), "expected least recently updated match to be forgotten")
+	assert.Check(t, keep(update("r1")))
+	assert.Check(t, keep(update("r3")))
+	assert.Check(t, keep(match("r2")), "expected forgotten record to match on its attributes")
+}
@@ -27,6 +27,11 @@ type ManagerConfig struct {
 	// heartbeat messages to the source address with the `.heartbeats` suffix.
 	UseAlternateHeartbeatAddress bool
 
+	// UseFlowsAddress indicates that the manager should send flow records to
+	// the source address with the `.flows` suffix like routers do, so that
+	// consumers listening only to the source address do not receive them.
+	UseFlowsAddress bool
+
 	// FlushDelay is the amount of time to wait after receiving an initial
 	// flush message before beginning to send updates.
 	FlushDelay time.Duration
@@ -84,8 +89,14 @@ func (m *Manager) Run(ctx context.Context) {
 }
 
 func (m *Manager) sendRecords(ctx context.Context) {
-	sender := m.container.NewSender(m.Source.Address, session.SenderOptions{})
-	defer sender.Close(ctx)
+	senders := map[string]session.Sender{
+		m.Source.Address: m.container.NewSender(m.Source.Address, session.SenderOptions{}),
+	}
+	defer func() {
+		for _, sender := range senders {
+			sender.Close(ctx)
+		}
+	}()
 	for {
 		select {
 		case <-ctx.Done():
@@ -96,6 +107,11 @@ func (m *Manager) sendRecords(ctx context.Context) {
 				m.logger.Error("skipping record message after encoding error:", slog.Any("error", err))
 				continue
 			}
+			sender, ok := senders[record.To]
+			if !ok {
+				sender = m.container.NewSender(record.To, session.SenderOptions{})
+				senders[record.To] = sender
+			}
 			if err := sender.Send(ctx, msg); err != nil {
 				m.logger.Error("error sending event source record", slog.Any("error", err))
 				continue
@@ -165,23 +181,20 @@ func (m *Manager) serve(ctx context.Context) {
 			}
 			buffer = append([]RecordUpdate{update}, buffer...)
 
-			var record vanflow.RecordMessage
-			record.Records = make([]vanflow.Record, 0, len(buffer))
+			records := make([]vanflow.Record, 0, len(buffer))
 			for _, update := range buffer {
 				delta, changed := m.diffRecord(update)
 				if !changed {
 					continue
 				}
-				record.Records = append(record.Records, delta)
+				records = append(records, delta)
 			}
-			if len(record.Records) == 0 {
+			if len(records) == 0 {
 				m.logger.Debug("record changes buffered but none were changed", slog.Int("record_count", len(buffer)))
 				continue
 			}
-			select {
-			case <-ctx.Done():
+			if !m.enqueue(ctx, records) {
 				return
-			case m.sendQueue <- record:
 			}
 
 		case <-m.flushQueue:
@@ -205,15 +218,12 @@ func (m *Manager) serve(ctx context.Context) {
 						batch, entries = batch[:m.FlushBatchSize], batch[m.FlushBatchSize:]
 					}
 
-					var record vanflow.RecordMessage
-					record.Records = make([]vanflow.Record, len(batch))
+					records := make([]vanflow.Record, len(batch))
 					for i, entry := range batch {
-						record.Records[i] = entry.Record
+						records[i] = entry.Record
 					}
-					select {
-					case <-ctx.Done():
+					if !m.enqueue(ctx, records) {
 						return
-					case m.sendQueue <- record:
 					}
 				}
 			}
@@ -221,6 +231,42 @@ func (m *Manager) serve(ctx context.Context) {
 	}
 }
 
+// enqueue records to be sent in a record message, split between the source
+// address and the flows address when UseFlowsAddress is set. Returns false
+// when the context is cancelled first.
+func (m *Manager) enqueue(ctx context.Context, records []vanflow.Record) bool {
+	var messages []vanflow.RecordMessage
+	if !m.UseFlowsAddress {
+		msg := vanflow.RecordMessage{Records: records}
+		msg.To = m.Source.Address
+		messages = append(messages, msg)
+	} else {
+		var source, flows vanflow.RecordMessage
+		source.To = m.Source.Address
+		flows.To = m.Source.Address + sourceSuffixFlows
+		for _, record := range records {
+			if isFlowRecord(record) {
+				flows.Records = append(flows.Records, record)
+				continue
+			}
+			source.Records = append(source.Records, record)
+		}
+		for _, msg := range []vanflow.RecordMessage{source, flows} {
+			if len(msg.Records) > 0 {
+				messages = append(messages, msg)
+			}
+		}
+	}
+	for _, msg := range messages {
+		select {
+		case <-ctx.Done():
+			return false
+		case m.sendQueue <- msg:
+		}
+	}
+	return true
+}
+
 func (m *Manager) sendKeepalives(ctx context.Context) {
 	beaconInterval := m.BeaconInterval
 	if beaconInterval <= 0 {
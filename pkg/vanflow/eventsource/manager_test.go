@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -93,6 +94,64 @@ func TestManagerClient(t *testing.T) {
 
 }
 
+func TestManagerFlowsAddress(t *testing.T) {
+	tstCtx, tstCancel := context.WithCancel(context.Background())
+	defer tstCancel()
+
+	sourceID := uniqueSuffix("test-event-source-manager")
+	factory, rtt := requireContainers(t)
+	ctr := factory.Create()
+	ctr.Start(tstCtx)
+	ctrClient := factory.Create()
+	ctrClient.Start(tstCtx)
+
+	discovery := NewDiscovery(ctrClient, DiscoveryOptions{})
+	go discovery.Run(tstCtx, DiscoveryHandlers{})
+
+	sourceRef := store.SourceRef{ID: sourceID}
+	source := Info{ID: sourceID, Type: "ROUTER", Address: mcsfe(sourceID), Direct: sfe(sourceID)}
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{})
+	stor.Add(vanflow.RouterRecord{BaseRecord: vanflow.NewBase("router")}, sourceRef)
+	stor.Add(vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow")}, sourceRef)
+	manager := NewManager(ctr, ManagerConfig{
+		Source:            source,
+		Stores:            []store.Interface{stor},
+		HeartbeatInterval: rtt * 10,
+		BeaconInterval:    rtt * 50,
+		UseFlowsAddress:   true,
+	})
+	go manager.Run(tstCtx)
+
+	received := make(chan vanflow.RecordMessage, 8)
+	client := NewClient(ctrClient, ClientOptions{Source: source})
+	client.OnRecord(func(msg vanflow.RecordMessage) { received <- msg })
+	client.Listen(tstCtx, FromSourceAddress())
+	client.Listen(tstCtx, FromSourceAddressFlows())
+	defer client.Close()
+
+	flushCtx, cancel := context.WithTimeout(tstCtx, rtt*100)
+	defer cancel()
+	if err := FlushOnFirstMessage(flushCtx, client); err != nil {
+		t.Fatalf("expected manager to be discovered by the client: %s", err)
+	}
+
+	addresses := make(map[string]string)
+	timeout := time.After(rtt * 100)
+	for len(addresses) < 2 {
+		select {
+		case msg := <-received:
+			for _, record := range msg.Records {
+				addresses[record.Identity()] = msg.To
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for records: %v", addresses)
+		}
+	}
+	if !cmp.Equal(addresses, map[string]string{"router": source.Address, "flow": source.Address + ".flows"}) {
+		t.Errorf("unexpected record addresses: %v", addresses)
+	}
+}
+
 var ignoreLastUpdateAndOrder = []cmp.Option{
 	cmpopts.IgnoreFields(store.Metadata{}, "LastUpdate"),
 	cmpopts.SortSlices(func(a, b store.Entry) bool {
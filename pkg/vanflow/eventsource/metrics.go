@@ -0,0 +1,36 @@
+package eventsource
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ClientMetrics are the metrics of the record message queues of Clients. A
+// single ClientMetrics is meant to be shared by all of a consumer's Clients.
+type ClientMetrics struct {
+	// QueueDepth is the number of record messages queued for record
+	// handlers
+	QueueDepth prometheus.Gauge
+	// DroppedRecords counts records dropped by the overflow policy of a
+	// full queue by record type
+	DroppedRecords *prometheus.CounterVec
+}
+
+// NewClientMetrics creates ClientMetrics and registers them with reg
+func NewClientMetrics(reg prometheus.Registerer) *ClientMetrics {
+	m := &ClientMetrics{
+		QueueDepth: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "skupper",
+			Subsystem: "internal",
+			Name:      "eventsource_queue_depth",
+			Help:      "Number of vanflow record messages queued for processing",
+		}),
+		DroppedRecords: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "skupper",
+			Subsystem: "internal",
+			Name:      "eventsource_dropped_records_total",
+			Help:      "Vanflow records dropped because the processing queue was full",
+		}, []string{"type"}),
+	}
+	reg.MustRegister(m.QueueDepth, m.DroppedRecords)
+	return m
+}
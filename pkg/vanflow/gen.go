@@ -0,0 +1,3 @@
+package vanflow
+
+//go:generate go run ./codegen -o records_gen.go -doc RECORDS.md schema.yaml
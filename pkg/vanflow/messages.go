@@ -177,13 +177,20 @@ type RecordMessage struct {
 }
 
 func DecodeRecord(msg *amqp.Message) (record RecordMessage, err error) {
+	return DecodeRecordFunc(msg, nil)
+}
+
+// DecodeRecordFunc decodes a record message, skipping the records for which
+// keep returns false without decoding them. All records are kept when keep
+// is nil.
+func DecodeRecordFunc(msg *amqp.Message, keep func(encoding.RecordAttributeSet) bool) (record RecordMessage, err error) {
 	if msg.Properties.To != nil {
 		record.To = *msg.Properties.To
 	}
 	if msg.Properties.Subject != nil {
 		record.Subject = *msg.Properties.Subject
 	}
-	record.Records, err = decodeRecords(msg)
+	record.Records, err = decodeRecords(msg, keep)
 	return record, err
 }
 
@@ -207,7 +214,7 @@ func (m RecordMessage) Encode() (*amqp.Message, error) {
 
 // decodeRecords decodes an AMQP Message into a set of Records. Uses the
 // recordDecoders map to find the correct decoder for each record type.
-func decodeRecords(msg *amqp.Message) ([]Record, error) {
+func decodeRecords(msg *amqp.Message, keep func(encoding.RecordAttributeSet) bool) ([]Record, error) {
 	var records []Record
 	values, ok := msg.Value.([]interface{})
 	if !ok {
@@ -218,6 +225,9 @@ func decodeRecords(msg *amqp.Message) ([]Record, error) {
 		if !ok {
 			return records, fmt.Errorf("unexpected type in message Value slice: %T", value)
 		}
+		if keep != nil && !keep(recordAttributes) {
+			continue
+		}
 		record, err := encoding.Decode(recordAttributes)
 		if err != nil {
 			return records, err
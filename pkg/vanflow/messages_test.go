@@ -5,6 +5,7 @@ import (
 	"time"
 
 	amqp "github.com/Azure/go-amqp"
+	"github.com/skupperproject/skupper/pkg/vanflow/encoding"
 	"gotest.tools/v3/assert"
 )
 
@@ -185,4 +186,21 @@ func TestRecordMessage(t *testing.T) {
 	assert.DeepEqual(t, original, dupe)
 }
 
+func TestDecodeRecordFunc(t *testing.T) {
+	original := RecordMessage{
+		Records: []Record{
+			SiteRecord{BaseRecord: NewBase("site")},
+			ProcessRecord{BaseRecord: NewBase("process-a"), Parent: ptrTo("site")},
+			ProcessRecord{BaseRecord: NewBase("process-b")},
+		},
+	}
+	msg, err := original.Encode()
+	assert.Check(t, err)
+	decoded, err := DecodeRecordFunc(msg, func(attrs encoding.RecordAttributeSet) bool {
+		return attrs[uint32(2)] == "site"
+	})
+	assert.Check(t, err)
+	assert.DeepEqual(t, decoded.Records, []Record{original.Records[1]})
+}
+
 func ptrTo[T any](obj T) *T { return &obj }
@@ -0,0 +1,25 @@
+/*
+Package publisher lets components other than the skupper router and controller
+publish their own vanflow records, such as an agent describing the hosts and
+processes it manages.
+
+A Publisher is a vanflow event source. It announces itself with beacons so
+that collectors like the network observer discover it, sends heartbeats while
+it runs, and serves flush requests with the full state of its records. Records
+are validated before they are published: the record type must be one of the
+vanflow record types and the attributes its encoding marks as required must be
+set.
+
+Records are owned by the Publisher once published. Publish adds or replaces a
+record and End marks a record as ended and stops publishing it. Consumers
+receive only the attributes that changed since the record was last published.
+
+	factory := session.NewContainerFactory("amqp://127.0.0.1:5672", session.ContainerConfig{})
+	pub, err := publisher.New(factory, publisher.Config{ID: "agent-1"})
+	if err != nil {
+		return err
+	}
+	go pub.Run(ctx)
+	err = pub.Publish(vanflow.HostRecord{BaseRecord: vanflow.NewBase("host-1"), Name: &hostname})
+*/
+package publisher
@@ -0,0 +1,63 @@
+package publisher_test
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/publisher"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+)
+
+// hostAgent is an example component that enriches the network observer with
+// the host it runs on and the processes it manages for a site.
+type hostAgent struct {
+	siteID string
+	pub    *publisher.Publisher
+}
+
+func (a hostAgent) processStarted(id, name, addr string) error {
+	return a.pub.Publish(vanflow.ProcessRecord{
+		BaseRecord: vanflow.NewBase(id),
+		Parent:     &a.siteID,
+		Name:       &name,
+		SourceHost: &addr,
+	})
+}
+
+func (a hostAgent) processStopped(id string) error {
+	_, err := a.pub.End(id)
+	return err
+}
+
+func Example() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	factory := session.NewContainerFactory("amqp://127.0.0.1:5672", session.ContainerConfig{})
+	pub, err := publisher.New(factory, publisher.Config{ID: "host-agent-1"})
+	if err != nil {
+		slog.Error("error creating publisher", slog.Any("error", err))
+		os.Exit(1)
+	}
+	agent := hostAgent{siteID: "site-1", pub: pub}
+
+	hostname, _ := os.Hostname()
+	if err := pub.Publish(vanflow.HostRecord{BaseRecord: vanflow.NewBase(hostname), Name: &hostname}); err != nil {
+		slog.Error("error publishing host", slog.Any("error", err))
+	}
+	go func() {
+		if err := pub.Run(ctx); err != nil {
+			slog.Error("publisher stopped", slog.Any("error", err))
+		}
+	}()
+
+	if err := agent.processStarted("process-1", "backend", "10.0.0.7"); err != nil {
+		slog.Error("error publishing process", slog.Any("error", err))
+	}
+	if err := agent.processStopped("process-1"); err != nil {
+		slog.Error("error ending process", slog.Any("error", err))
+	}
+}
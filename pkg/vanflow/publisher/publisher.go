@@ -0,0 +1,228 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/encoding"
+	"github.com/skupperproject/skupper/pkg/vanflow/eventsource"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+)
+
+const (
+	// DefaultType is the event source type of a Publisher when none is
+	// configured
+	DefaultType = "AGENT"
+
+	codepointStartTime = uint32(3)
+	codepointEndTime   = uint32(4)
+)
+
+// Config for a Publisher
+type Config struct {
+	// ID of the event source. Must be unique within the network.
+	ID string
+	// Type of the event source. Defaults to DefaultType.
+	Type string
+	// Version of the event source. Defaults to 1.
+	Version int
+
+	// HeartbeatInterval defaults to 2 seconds
+	HeartbeatInterval time.Duration
+	// BeaconInterval defaults to 10 seconds
+	BeaconInterval time.Duration
+	// UpdateBufferTime is the amount of time to wait for a full batch of
+	// record updates before sending a partial batch. Defaults to 1 second.
+	UpdateBufferTime time.Duration
+	// UpdateBatchSize is the maximum number of record updates sent in a
+	// single record message. Defaults to 10.
+	UpdateBatchSize int
+}
+
+// Publisher publishes vanflow records as an event source
+type Publisher struct {
+	source    eventsource.Info
+	ref       store.SourceRef
+	container session.Container
+	manager   *eventsource.Manager
+
+	mu      sync.Mutex
+	records store.Interface
+	running bool
+
+	logger *slog.Logger
+}
+
+// New creates a Publisher using a container from factory. The Publisher does
+// not connect until Run is called.
+func New(factory session.ContainerFactory, cfg Config) (*Publisher, error) {
+	if cfg.ID == "" {
+		return nil, errors.New("publisher ID is required")
+	}
+	if cfg.Type == "" {
+		cfg.Type = DefaultType
+	}
+	if cfg.Version <= 0 {
+		cfg.Version = 1
+	}
+	if cfg.UpdateBufferTime <= 0 {
+		cfg.UpdateBufferTime = time.Second
+	}
+	if cfg.UpdateBatchSize <= 0 {
+		cfg.UpdateBatchSize = 10
+	}
+	source := eventsource.Info{
+		ID:      cfg.ID,
+		Version: cfg.Version,
+		Type:    cfg.Type,
+		Address: fmt.Sprintf("mc/sfe.%s", cfg.ID),
+		Direct:  fmt.Sprintf("sfe.%s", cfg.ID),
+	}
+	records := store.NewSyncMapStore(store.SyncMapStoreConfig{})
+	container := factory.Create()
+	return &Publisher{
+		source:    source,
+		ref:       store.SourceRef{ID: cfg.ID, Version: fmt.Sprint(cfg.Version)},
+		container: container,
+		records:   records,
+		manager: eventsource.NewManager(container, eventsource.ManagerConfig{
+			Source:            source,
+			Stores:            []store.Interface{records},
+			HeartbeatInterval: cfg.HeartbeatInterval,
+			BeaconInterval:    cfg.BeaconInterval,
+			FlushDelay:        time.Millisecond * 100,
+			FlushBatchSize:    20,
+			UpdateBufferTime:  cfg.UpdateBufferTime,
+			UpdateBatchSize:   cfg.UpdateBatchSize,
+		}),
+		logger: slog.New(slog.Default().Handler()).With(
+			slog.String("component", "vanflow.publisher"),
+			slog.String("instance", cfg.ID),
+		),
+	}, nil
+}
+
+// Source describes the event source of the Publisher
+func (p *Publisher) Source() eventsource.Info {
+	return p.source
+}
+
+// Run the publisher until ctx is cancelled. Returns an error when the
+// underlying session fails with an error that cannot be retried.
+func (p *Publisher) Run(ctx context.Context) error {
+	runCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	p.container.OnSessionError(func(err error) {
+		_, retryable := err.(session.RetryableError)
+		if !retryable {
+			cancel(err)
+		}
+		p.logger.Error("amqp session error", slog.Any("error", err), slog.Bool("retryable", retryable))
+	})
+	p.container.Start(runCtx)
+
+	p.mu.Lock()
+	p.running = true
+	p.mu.Unlock()
+	defer func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.running = false
+	}()
+
+	p.manager.Run(runCtx)
+	if ctx.Err() != nil {
+		return nil
+	}
+	return context.Cause(runCtx)
+}
+
+// Publish adds records or replaces the published state of records with the
+// same ID. StartTime is set to the current time on records published for the
+// first time without one. Returns an error without publishing any of the
+// records when one of them is invalid.
+func (p *Publisher) Publish(records ...vanflow.Record) error {
+	for _, record := range records {
+		if err := encoding.Validate(record); err != nil {
+			return fmt.Errorf("invalid record %T: %w", record, err)
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	for _, record := range records {
+		prev, ok := p.records.Get(record.Identity())
+		if !ok {
+			record, err := withTime(record, codepointStartTime, now, false)
+			if err != nil {
+				return err
+			}
+			p.records.Add(record, p.ref)
+			p.publish(nil, record)
+			continue
+		}
+		p.records.Update(record)
+		p.publish(prev.Record, record)
+	}
+	return nil
+}
+
+// End marks the record with the given ID as ended and stops publishing it.
+// Returns false when no record with that ID has been published.
+func (p *Publisher) End(id string) (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	entry, ok := p.records.Get(id)
+	if !ok {
+		return false, nil
+	}
+	ended, err := withTime(entry.Record, codepointEndTime, time.Now(), true)
+	if err != nil {
+		return true, err
+	}
+	p.records.Delete(id)
+	p.publish(entry.Record, ended)
+	return true, nil
+}
+
+// Get returns the published state of a record
+func (p *Publisher) Get(id string) (vanflow.Record, bool) {
+	entry, ok := p.records.Get(id)
+	return entry.Record, ok
+}
+
+// publish an update to consumers. Updates made before Run are sent to
+// consumers when they flush the event source.
+func (p *Publisher) publish(prev, curr vanflow.Record) {
+	if !p.running {
+		return
+	}
+	p.manager.PublishUpdate(eventsource.RecordUpdate{Prev: prev, Curr: curr})
+}
+
+// withTime sets a time attribute of a record. Existing values are only
+// replaced when overwrite is set.
+func withTime(record vanflow.Record, codepoint uint32, t time.Time, overwrite bool) (vanflow.Record, error) {
+	attrs, err := encoding.Encode(record)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := attrs[codepoint]; ok && !overwrite {
+		return record, nil
+	}
+	attrs[codepoint], err = vanflow.Time{Time: t}.EncodeRecordAttribute()
+	if err != nil {
+		return nil, err
+	}
+	out, err := encoding.Decode(attrs)
+	if err != nil {
+		return nil, err
+	}
+	return out.(vanflow.Record), nil
+}
@@ -0,0 +1,125 @@
+package publisher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/eventsource"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+)
+
+func TestNew(t *testing.T) {
+	factory := session.NewMockContainerFactory()
+	_, err := New(factory, Config{})
+	assert.ErrorContains(t, err, "publisher ID is required")
+
+	pub, err := New(factory, Config{ID: "agent"})
+	assert.Assert(t, err)
+	assert.DeepEqual(t, pub.Source(), eventsource.Info{
+		ID:      "agent",
+		Version: 1,
+		Type:    DefaultType,
+		Address: "mc/sfe.agent",
+		Direct:  "sfe.agent",
+	})
+}
+
+func TestPublishValidation(t *testing.T) {
+	pub, err := New(session.NewMockContainerFactory(), Config{ID: "agent"})
+	assert.Assert(t, err)
+
+	err = pub.Publish(vanflow.HostRecord{BaseRecord: vanflow.NewBase("host")}, vanflow.ProcessRecord{})
+	assert.ErrorContains(t, err, "invalid record vanflow.ProcessRecord: missing or empty required field ID")
+	_, ok := pub.Get("host")
+	assert.Assert(t, !ok, "expected no records to be published when one is invalid")
+
+	assert.Assert(t, pub.Publish(vanflow.HostRecord{BaseRecord: vanflow.NewBase("host")}))
+	record, ok := pub.Get("host")
+	assert.Assert(t, ok)
+	assert.Assert(t, record.(vanflow.HostRecord).StartTime != nil, "expected start time to be set")
+
+	start := time.UnixMicro(100)
+	assert.Assert(t, pub.Publish(vanflow.HostRecord{BaseRecord: vanflow.NewBase("host-2", start)}))
+	record, _ = pub.Get("host-2")
+	assert.Equal(t, record.(vanflow.HostRecord).StartTime.Time, start)
+
+	found, err := pub.End("missing")
+	assert.Assert(t, err)
+	assert.Assert(t, !found)
+}
+
+func TestPublisher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	factory := session.NewMockContainerFactory()
+
+	pub, err := New(factory, Config{
+		ID:                "agent",
+		HeartbeatInterval: 10 * time.Millisecond,
+		BeaconInterval:    50 * time.Millisecond,
+		UpdateBufferTime:  10 * time.Millisecond,
+	})
+	assert.Assert(t, err)
+	name, provider := "node-1", "aws"
+	assert.Assert(t, pub.Publish(vanflow.HostRecord{BaseRecord: vanflow.NewBase("host-1"), Name: &name}))
+
+	runErr := make(chan error, 1)
+	go func() { runErr <- pub.Run(ctx) }()
+
+	consumer := factory.Create()
+	consumer.Start(ctx)
+	records := store.NewSyncMapStore(store.SyncMapStoreConfig{})
+	discovery := eventsource.NewDiscovery(consumer, eventsource.DiscoveryOptions{})
+	go discovery.Run(ctx, eventsource.DiscoveryHandlers{
+		Discovered: func(source eventsource.Info) {
+			client := eventsource.NewClient(consumer, eventsource.ClientOptions{Source: source})
+			client.OnRecord(func(msg vanflow.RecordMessage) {
+				for _, record := range msg.Records {
+					records.Patch(record, store.SourceRef{ID: source.ID})
+				}
+			})
+			client.Listen(ctx, eventsource.FromSourceAddress())
+			go eventsource.FlushOnFirstMessage(ctx, client)
+		},
+	})
+
+	waitFor := func(id string, match func(vanflow.HostRecord) bool) {
+		t.Helper()
+		poll.WaitOn(t, func(t poll.LogT) poll.Result {
+			entry, ok := records.Get(id)
+			if !ok {
+				return poll.Continue("waiting for record %s", id)
+			}
+			if !match(entry.Record.(vanflow.HostRecord)) {
+				return poll.Continue("waiting for record %s to match: %v", id, entry.Record)
+			}
+			return poll.Success()
+		}, poll.WithDelay(10*time.Millisecond), poll.WithTimeout(5*time.Second))
+	}
+
+	waitFor("host-1", func(r vanflow.HostRecord) bool {
+		return r.Name != nil && *r.Name == name && r.StartTime != nil
+	})
+
+	assert.Assert(t, pub.Publish(vanflow.HostRecord{BaseRecord: vanflow.NewBase("host-1"), Name: &name, Provider: &provider}))
+	waitFor("host-1", func(r vanflow.HostRecord) bool {
+		return r.Provider != nil && *r.Provider == provider
+	})
+
+	found, err := pub.End("host-1")
+	assert.Assert(t, err)
+	assert.Assert(t, found)
+	waitFor("host-1", func(r vanflow.HostRecord) bool {
+		return r.EndTime != nil
+	})
+	_, ok := pub.Get("host-1")
+	assert.Assert(t, !ok, "expected ended record to no longer be published")
+
+	cancel()
+	assert.Assert(t, <-runErr)
+}
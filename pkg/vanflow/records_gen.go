@@ -1,9 +1,73 @@
+// Code generated by vanflow codegen. DO NOT EDIT.
+
 package vanflow
 
 import "github.com/skupperproject/skupper/pkg/vanflow/encoding"
 
 const apiVersion = "flow/v1"
 
+// Record attribute codepoints
+const (
+	AttributeID               uint32 = 1
+	AttributeParent           uint32 = 2
+	AttributeStartTime        uint32 = 3
+	AttributeEndTime          uint32 = 4
+	AttributeCounterflow      uint32 = 5
+	AttributePeer             uint32 = 6
+	AttributeProcessID        uint32 = 7
+	AttributeLocation         uint32 = 9
+	AttributeProvider         uint32 = 10
+	AttributePlatform         uint32 = 11
+	AttributeNamespace        uint32 = 12
+	AttributeMode             uint32 = 13
+	AttributeSourceHost       uint32 = 14
+	AttributeDestHost         uint32 = 15
+	AttributeProtocol         uint32 = 16
+	AttributeSourcePort       uint32 = 17
+	AttributeDestPort         uint32 = 18
+	AttributeAddress          uint32 = 19
+	AttributeImageName        uint32 = 20
+	AttributeImageVersion     uint32 = 21
+	AttributeHostname         uint32 = 22
+	AttributeOctets           uint32 = 23
+	AttributeLatency          uint32 = 24
+	AttributeMethod           uint32 = 27
+	AttributeResult           uint32 = 28
+	AttributeReason           uint32 = 29
+	AttributeName             uint32 = 30
+	AttributeTrace            uint32 = 31
+	AttributeBuildVersion     uint32 = 32
+	AttributeLinkCost         uint32 = 33
+	AttributeOctetRate        uint32 = 35
+	AttributeOctetsOut        uint32 = 36
+	AttributeOctetsUnacked    uint32 = 37
+	AttributeWindowClosures   uint32 = 38
+	AttributeWindowSize       uint32 = 39
+	AttributeFlowCountL4      uint32 = 40
+	AttributeFlowCountL7      uint32 = 41
+	AttributeFlowRateL4       uint32 = 42
+	AttributeFlowRateL7       uint32 = 43
+	AttributeGroup            uint32 = 46
+	AttributeLogSeverity      uint32 = 48
+	AttributeLogText          uint32 = 49
+	AttributeSourceFile       uint32 = 50
+	AttributeSourceLine       uint32 = 51
+	AttributeLinkCount        uint32 = 52
+	AttributeStatus           uint32 = 53
+	AttributeRole             uint32 = 54
+	AttributeLastUp           uint32 = 55
+	AttributeLastDown         uint32 = 56
+	AttributeDownCount        uint32 = 57
+	AttributeOctetsReverse    uint32 = 58
+	AttributeOctetRateReverse uint32 = 59
+	AttributeConnectorID      uint32 = 60
+	AttributeLatencyReverse   uint32 = 61
+	AttributeProxyHost        uint32 = 62
+	AttributeProxyPort        uint32 = 63
+	AttributeErrorListener    uint32 = 64
+	AttributeErrorConnector   uint32 = 65
+)
+
 func init() {
 	encoding.MustRegisterRecord(0, SiteRecord{})
 	encoding.MustRegisterRecord(1, RouterRecord{})
@@ -25,13 +89,14 @@ func init() {
 	encoding.MustRegisterRecord(17, AppBiflowRecord{})
 }
 
+// SiteRecord is a skupper site
 type SiteRecord struct {
 	BaseRecord
 	Location  *string `vflow:"9"`
 	Provider  *string `vflow:"10"`
 	Platform  *string `vflow:"11"`
 	Namespace *string `vflow:"12"`
-	Name      *string `vflow:"30"` //unspeced
+	Name      *string `vflow:"30"` // unspecified
 	Version   *string `vflow:"32"`
 }
 
@@ -42,6 +107,7 @@ func (r SiteRecord) GetTypeMeta() TypeMeta {
 	}
 }
 
+// RouterRecord is a router in a site
 type RouterRecord struct {
 	BaseRecord
 	Parent       *string `vflow:"2"`
@@ -61,17 +127,15 @@ func (r RouterRecord) GetTypeMeta() TypeMeta {
 	}
 }
 
+// LinkRecord is a link from a router to a router access in another site
 type LinkRecord struct {
 	BaseRecord
-	Parent   *string `vflow:"2"`
-	Name     *string `vflow:"30"`
-	LinkCost *uint64 `vflow:"33"`
-	// Mode      *string `vflow:"13"`
-	// Direction *string `vflow:"34"`
-	Peer   *string `vflow:"6"`
-	Role   *string `vflow:"54"`
-	Status *string `vflow:"53"`
-
+	Parent           *string `vflow:"2"`
+	Name             *string `vflow:"30"`
+	LinkCost         *uint64 `vflow:"33"`
+	Peer             *string `vflow:"6"`
+	Role             *string `vflow:"54"`
+	Status           *string `vflow:"53"`
 	DestHost         *string `vflow:"15"`
 	Protocol         *string `vflow:"16"`
 	DestPort         *string `vflow:"18"`
@@ -79,12 +143,11 @@ type LinkRecord struct {
 	OctetRate        *uint64 `vflow:"35"`
 	OctetsReverse    *uint64 `vflow:"58"`
 	OctetRateReverse *uint64 `vflow:"59"`
-
-	Result    *string `vflow:"28"`
-	Reason    *string `vflow:"29"`
-	LastUp    *uint64 `vflow:"55"`
-	LastDown  *uint64 `vflow:"56"`
-	DownCount *uint64 `vflow:"57"`
+	Result           *string `vflow:"28"`
+	Reason           *string `vflow:"29"`
+	LastUp           *uint64 `vflow:"55"`
+	LastDown         *uint64 `vflow:"56"`
+	DownCount        *uint64 `vflow:"57"`
 }
 
 func (r LinkRecord) GetTypeMeta() TypeMeta {
@@ -94,6 +157,7 @@ func (r LinkRecord) GetTypeMeta() TypeMeta {
 	}
 }
 
+// ControllerRecord is a skupper controller
 type ControllerRecord struct {
 	BaseRecord
 	Parent       *string `vflow:"2"`
@@ -111,6 +175,7 @@ func (r ControllerRecord) GetTypeMeta() TypeMeta {
 	}
 }
 
+// ListenerRecord is a router listener accepting connections for a routing key
 type ListenerRecord struct {
 	BaseRecord
 	Parent      *string `vflow:"2"`
@@ -132,6 +197,7 @@ func (r ListenerRecord) GetTypeMeta() TypeMeta {
 	}
 }
 
+// ConnectorRecord is a router connector making connections to a server for a routing key
 type ConnectorRecord struct {
 	BaseRecord
 	Parent      *string `vflow:"2"`
@@ -154,6 +220,7 @@ func (r ConnectorRecord) GetTypeMeta() TypeMeta {
 	}
 }
 
+// FlowRecord is a unidirectional flow. Superseded by the biflow records
 type FlowRecord struct {
 	BaseRecord
 	Parent         *string `vflow:"2"`
@@ -180,9 +247,10 @@ func (r FlowRecord) GetTypeMeta() TypeMeta {
 	}
 }
 
+// ProcessRecord is a process that is a client or server of the network
 type ProcessRecord struct {
 	BaseRecord
-	Parent       *string `vflow:"2"` //unspeced
+	Parent       *string `vflow:"2"` // unspecified
 	Mode         *string `vflow:"13"`
 	SourceHost   *string `vflow:"14"`
 	ImageName    *string `vflow:"20"`
@@ -199,26 +267,91 @@ func (r ProcessRecord) GetTypeMeta() TypeMeta {
 	}
 }
 
+// ImageRecord is a container image run by processes
 type ImageRecord struct {
 	BaseRecord
+	Name         *string `vflow:"30"`
+	ImageName    *string `vflow:"20"`
+	ImageVersion *string `vflow:"21"`
+}
+
+func (r ImageRecord) GetTypeMeta() TypeMeta {
+	return TypeMeta{
+		APIVersion: apiVersion,
+		Type:       "ImageRecord",
+	}
 }
 
+// IngressRecord is a point where traffic enters the network from outside a site
 type IngressRecord struct {
 	BaseRecord
+	Parent   *string `vflow:"2"`
+	Name     *string `vflow:"30"`
+	Protocol *string `vflow:"16"`
+	DestHost *string `vflow:"15"`
+	DestPort *string `vflow:"18"`
+	Address  *string `vflow:"19"`
+}
+
+func (r IngressRecord) GetTypeMeta() TypeMeta {
+	return TypeMeta{
+		APIVersion: apiVersion,
+		Type:       "IngressRecord",
+	}
 }
 
+// EgressRecord is a point where traffic leaves the network to outside a site
 type EgressRecord struct {
 	BaseRecord
+	Parent   *string `vflow:"2"`
+	Name     *string `vflow:"30"`
+	Protocol *string `vflow:"16"`
+	DestHost *string `vflow:"15"`
+	DestPort *string `vflow:"18"`
+	Address  *string `vflow:"19"`
+}
+
+func (r EgressRecord) GetTypeMeta() TypeMeta {
+	return TypeMeta{
+		APIVersion: apiVersion,
+		Type:       "EgressRecord",
+	}
 }
 
+// CollectorRecord is a consumer collecting vanflow records
 type CollectorRecord struct {
 	BaseRecord
+	Parent       *string `vflow:"2"`
+	Name         *string `vflow:"30"`
+	ImageName    *string `vflow:"20"`
+	ImageVersion *string `vflow:"21"`
+	Hostname     *string `vflow:"22"`
+	BuildVersion *string `vflow:"32"`
 }
 
+func (r CollectorRecord) GetTypeMeta() TypeMeta {
+	return TypeMeta{
+		APIVersion: apiVersion,
+		Type:       "CollectorRecord",
+	}
+}
+
+// ProcessGroupRecord is a group of processes sharing a name
 type ProcessGroupRecord struct {
 	BaseRecord
+	Parent *string `vflow:"2"`
+	Name   *string `vflow:"30"`
+	Mode   *string `vflow:"13"`
 }
 
+func (r ProcessGroupRecord) GetTypeMeta() TypeMeta {
+	return TypeMeta{
+		APIVersion: apiVersion,
+		Type:       "ProcessGroupRecord",
+	}
+}
+
+// HostRecord is a host that processes run on
 type HostRecord struct {
 	BaseRecord
 	Provider *string `vflow:"10"`
@@ -232,6 +365,7 @@ func (r HostRecord) GetTypeMeta() TypeMeta {
 	}
 }
 
+// LogRecord is a log message
 type LogRecord struct {
 	BaseRecord
 	LogSeverity *uint64 `vflow:"48"`
@@ -247,6 +381,7 @@ func (r LogRecord) GetTypeMeta() TypeMeta {
 	}
 }
 
+// RouterAccessRecord is a router endpoint that links from other routers connect to
 type RouterAccessRecord struct {
 	BaseRecord
 	Parent    *string `vflow:"2"`
@@ -262,22 +397,20 @@ func (r RouterAccessRecord) GetTypeMeta() TypeMeta {
 	}
 }
 
+// TransportBiflowRecord is a connection between a client and a server through a listener and a connector
 type TransportBiflowRecord struct {
 	BaseRecord
-	Parent      *string `vflow:"2"`
-	ConnectorID *string `vflow:"60"`
-	Trace       *string `vflow:"31"`
-
-	SourceHost *string `vflow:"14"`
-	SourcePort *string `vflow:"17"`
-	Octets     *uint64 `vflow:"23"`
-	Latency    *uint64 `vflow:"24"`
-
+	Parent         *string `vflow:"2"`
+	ConnectorID    *string `vflow:"60"`
+	Trace          *string `vflow:"31"`
+	SourceHost     *string `vflow:"14"`
+	SourcePort     *string `vflow:"17"`
+	Octets         *uint64 `vflow:"23"`
+	Latency        *uint64 `vflow:"24"`
 	OctetsReverse  *uint64 `vflow:"58"`
 	LatencyReverse *uint64 `vflow:"61"`
 	ProxyHost      *string `vflow:"62"`
 	ProxyPort      *string `vflow:"63"`
-
 	ErrorListener  *string `vflow:"64"`
 	ErrorConnector *string `vflow:"65"`
 }
@@ -289,6 +422,7 @@ func (r TransportBiflowRecord) GetTypeMeta() TypeMeta {
 	}
 }
 
+// AppBiflowRecord is an application protocol request made over a transport biflow
 type AppBiflowRecord struct {
 	BaseRecord
 	Parent        *string `vflow:"2"`
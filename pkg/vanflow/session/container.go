@@ -9,6 +9,7 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -71,19 +72,35 @@ const (
 type ContainerConfig struct {
 	ContainerID  string
 	MaxFrameSize uint32
-	TLSConfig    *tls.Config
-	SASLType     SASLType
+	// TLSConfig and SASLType are used for connections to amqps addresses
+	TLSConfig *tls.Config
+	SASLType  SASLType
 	// BackOff strategy to use when reestablishing a connection defaults to an
 	// exponential backoff capped at 30 second intervals with no set retry
 	// limit.
 	BackOff backoff.BackOff
-}
-
-func (cfg ContainerConfig) toAmqp() *amqp.ConnOptions {
+	// FailoverAddresses are the addresses of other routers, such as the
+	// second router of an HA site, to connect to when the router at the
+	// container address cannot be reached or its connection fails.
+	FailoverAddresses []string
+	// DialTimeout limits how long connecting to each router may take before
+	// the next one is tried. Defaults to 10 seconds.
+	DialTimeout time.Duration
+	// IdleTimeout is how long a connection may go without receiving any
+	// frames before it is considered failed. Routers send heartbeats to keep
+	// healthy connections from idling out. Defaults to one minute.
+	IdleTimeout time.Duration
+}
+
+func (cfg ContainerConfig) toAmqp(address string) *amqp.ConnOptions {
 	opts := amqp.ConnOptions{
 		ContainerID:  cfg.ContainerID,
 		MaxFrameSize: cfg.MaxFrameSize,
 		TLSConfig:    cfg.TLSConfig,
+		IdleTimeout:  cfg.IdleTimeout,
+	}
+	if !strings.HasPrefix(address, "amqps://") {
+		return &opts
 	}
 	switch cfg.SASLType {
 	case SASLTypeExternal:
@@ -96,10 +113,13 @@ func (cfg ContainerConfig) toAmqp() *amqp.ConnOptions {
 // connection + session pair using the supplied amqp connection options for use
 // with the container's Senders and Receivers. Will recreate the connection and
 // session when a link encounters an error using the specified backoff
-// strategy.
+// strategy, failing over to the next of the configured addresses.
 func NewContainer(address string, config ContainerConfig) Container {
+	if config.DialTimeout <= 0 {
+		config.DialTimeout = 10 * time.Second
+	}
 	c := &container{
-		address:       address,
+		endpoints:     newEndpoints(append([]string{address}, config.FailoverAddresses...)),
 		config:        config,
 		hasNext:       make(chan struct{}),
 		sessionErrors: make(chan sessionErr, 32),
@@ -109,8 +129,8 @@ func NewContainer(address string, config ContainerConfig) Container {
 }
 
 type container struct {
-	address string
-	config  ContainerConfig
+	endpoints *endpoints
+	config    ContainerConfig
 
 	mu            sync.Mutex
 	sess          *amqp.Session
@@ -170,13 +190,15 @@ func (c *container) Start(ctx context.Context) {
 		b := backoff.WithContext(c.config.BackOff, ctx)
 		err := backoff.RetryNotify(
 			func() error {
-				conn, err := amqp.Dial(ctx, c.address, c.config.toAmqp())
+				conn, address, err := c.dial(ctx)
 				if err != nil {
 					return fmt.Errorf("dial error: %s", err)
 				}
 				sess, err := conn.NewSession(ctx, nil)
 				if err != nil {
-					return fmt.Errorf("session create error: %s", err)
+					conn.Close()
+					c.endpoints.Failed(address)
+					return fmt.Errorf("session create error on %s: %s", address, err)
 				}
 				generation++
 
@@ -201,7 +223,8 @@ func (c *container) Start(ctx context.Context) {
 						}
 					case recvErr := <-c.sessionErrors:
 						if recvErr.Generation == generation {
-							return fmt.Errorf("session receiver error: %s", recvErr)
+							c.endpoints.Failed(address)
+							return fmt.Errorf("session receiver error on %s: %s", address, recvErr)
 						}
 					}
 				}
@@ -231,6 +254,57 @@ func (c *container) Start(ctx context.Context) {
 	}()
 }
 
+// dial connects to the first router that can be reached, in the order given
+// by the container endpoints.
+func (c *container) dial(ctx context.Context) (*amqp.Conn, string, error) {
+	var errs []error
+	for _, address := range c.endpoints.Order() {
+		dialCtx, cancel := context.WithTimeout(ctx, c.config.DialTimeout)
+		conn, err := amqp.Dial(dialCtx, address, c.config.toAmqp(address))
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, "", ctx.Err()
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", address, err))
+			c.endpoints.Failed(address)
+			continue
+		}
+		return conn, address, nil
+	}
+	return nil, "", errors.Join(errs...)
+}
+
+// endpoints orders the router addresses a container connects to so that the
+// router it last failed to use is tried last.
+type endpoints struct {
+	mu        sync.Mutex
+	addresses []string
+}
+
+func newEndpoints(addresses []string) *endpoints {
+	return &endpoints{addresses: addresses}
+}
+
+// Order returns the addresses in the order they should be tried
+func (e *endpoints) Order() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.addresses...)
+}
+
+// Failed moves address to the back of the order
+func (e *endpoints) Failed(address string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i, a := range e.addresses {
+		if a == address {
+			e.addresses = append(append(e.addresses[:i:i], e.addresses[i+1:]...), address)
+			return
+		}
+	}
+}
+
 type errSessionRestart struct {
 	Err error
 	D   time.Duration
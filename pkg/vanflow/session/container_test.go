@@ -2,7 +2,9 @@ package session
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"os"
 	"testing"
 	"time"
@@ -81,6 +83,76 @@ func TestContainerPing(t *testing.T) {
 
 }
 
+func TestEndpoints(t *testing.T) {
+	e := newEndpoints([]string{"a", "b", "c"})
+	assert.DeepEqual(t, e.Order(), []string{"a", "b", "c"})
+	e.Failed("a")
+	assert.DeepEqual(t, e.Order(), []string{"b", "c", "a"})
+	e.Failed("c")
+	assert.DeepEqual(t, e.Order(), []string{"b", "a", "c"})
+	e.Failed("unknown")
+	assert.DeepEqual(t, e.Order(), []string{"b", "a", "c"})
+}
+
+func TestContainerFailover(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// nothing listening on the primary address
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	primary := "amqp://" + closed.Addr().String()
+	closed.Close()
+
+	// secondary accepts connections but never completes the amqp handshake
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	defer listener.Close()
+	secondary := "amqp://" + listener.Addr().String()
+	accepted := make(chan struct{}, 8)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+			select {
+			case accepted <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	c := NewContainer(primary, ContainerConfig{
+		FailoverAddresses: []string{secondary},
+		DialTimeout:       time.Second,
+	})
+	errs := make(chan error, 8)
+	c.OnSessionError(func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	})
+	c.Start(ctx)
+
+	select {
+	case <-accepted:
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for failover to the secondary router")
+	}
+	select {
+	case err := <-errs:
+		var retryable RetryableError
+		assert.Assert(t, errors.As(err, &retryable), "expected retryable error: %s", err)
+		assert.ErrorContains(t, err, primary)
+		assert.ErrorContains(t, err, secondary)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for session error")
+	}
+}
+
 func containersFromEnv(t *testing.T) ContainerFactory {
 	t.Helper()
 	if testing.Short() {
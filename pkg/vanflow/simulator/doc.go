@@ -0,0 +1,16 @@
+/*
+Package simulator fabricates a vanflow network in process for load and scale
+testing of vanflow consumers.
+
+A Simulator builds a deterministic network of sites from its Config. Each site
+has processes, routers, links to routers in other sites, and the listeners and
+connectors that expose a set of services. Every site runs a controller event
+source and every router runs a router event source, each backed by an
+eventsource.Manager. The event sources run in containers from a
+session.ContainerFactory, so consumers created with the same factory (usually a
+session.NewMockContainerFactory) discover and observe them like a real
+network. Once the network is running, the simulator opens connections through
+the listeners at a target rate. For http services it also makes requests over
+those connections.
+*/
+package simulator
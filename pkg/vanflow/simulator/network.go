@@ -0,0 +1,210 @@
+package simulator
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+)
+
+// site holds the records of a simulated site
+type site struct {
+	Record    vanflow.SiteRecord
+	Processes []vanflow.ProcessRecord
+	Routers   []router
+}
+
+// router holds the records of a simulated router
+type router struct {
+	Record     vanflow.RouterRecord
+	Access     vanflow.RouterAccessRecord
+	Links      []vanflow.LinkRecord
+	Listeners  []vanflow.ListenerRecord
+	Connectors []vanflow.ConnectorRecord
+}
+
+// service is a routing key exposed by a connector and reached through
+// listeners on other sites.
+type service struct {
+	Address   string
+	Protocol  string
+	Connector vanflow.ConnectorRecord
+	// Listeners on routers that are the origin of flows to the service
+	Listeners []listener
+}
+
+type listener struct {
+	Record vanflow.ListenerRecord
+	// Clients are the processes on the listener's site that send traffic
+	// through it
+	Clients []vanflow.ProcessRecord
+	// Router that reports flows through the listener
+	Router string
+}
+
+// network is the full set of simulated records
+type network struct {
+	Sites    []site
+	Services []service
+}
+
+func ptrTo[T any](t T) *T {
+	return &t
+}
+
+// buildNetwork fabricates a deterministic network from the configuration
+func buildNetwork(cfg Config) network {
+	var net network
+	net.Sites = make([]site, cfg.Sites)
+	for i := range net.Sites {
+		s := &net.Sites[i]
+		siteID := fmt.Sprintf("site-%d", i)
+		s.Record = vanflow.SiteRecord{
+			BaseRecord: vanflow.NewBase(siteID),
+			Name:       ptrTo(siteID),
+			Namespace:  ptrTo(fmt.Sprintf("ns-%d", i)),
+			Platform:   ptrTo("kubernetes"),
+			Version:    ptrTo("simulated"),
+		}
+		for j := 0; j < cfg.ProcessesPerSite; j++ {
+			name := fmt.Sprintf("%s-process-%d", siteID, j)
+			s.Processes = append(s.Processes, vanflow.ProcessRecord{
+				BaseRecord: vanflow.NewBase(name),
+				Parent:     ptrTo(siteID),
+				Name:       ptrTo(name),
+				Group:      ptrTo(fmt.Sprintf("group-%d", j)),
+				SourceHost: ptrTo(hostIP(i, j)),
+				Hostname:   ptrTo(name),
+				Mode:       ptrTo("external"),
+			})
+		}
+		for j := 0; j < cfg.RoutersPerSite; j++ {
+			routerID := fmt.Sprintf("%s-router-%d", siteID, j)
+			s.Routers = append(s.Routers, router{
+				Record: vanflow.RouterRecord{
+					BaseRecord: vanflow.NewBase(routerID),
+					Parent:     ptrTo(siteID),
+					Name:       ptrTo(fmt.Sprintf("%s/router-%d", siteID, j)),
+					Namespace:  s.Record.Namespace,
+					Mode:       ptrTo("interior"),
+				},
+				Access: vanflow.RouterAccessRecord{
+					BaseRecord: vanflow.NewBase(routerID + "-access"),
+					Parent:     ptrTo(routerID),
+					Name:       ptrTo("skupper-inter-router"),
+					Role:       ptrTo("inter-router"),
+				},
+			})
+		}
+	}
+
+	// link the first router of each site to the first router of the sites
+	// that follow it, forming a ring when LinksPerSite is one.
+	for i := range net.Sites {
+		from := &net.Sites[i].Routers[0]
+		for k := 1; k <= cfg.LinksPerSite; k++ {
+			to := net.Sites[(i+k)%len(net.Sites)].Routers[0]
+			from.Links = append(from.Links, vanflow.LinkRecord{
+				BaseRecord: vanflow.NewBase(fmt.Sprintf("%s-link-%d", from.Record.ID, k)),
+				Parent:     ptrTo(from.Record.ID),
+				Name:       to.Record.Parent,
+				Peer:       ptrTo(to.Access.ID),
+				Role:       ptrTo("inter-router"),
+				Status:     ptrTo("up"),
+				LinkCost:   ptrTo(uint64(1)),
+			})
+		}
+		// additional routers in a site link to its first router
+		for j := 1; j < len(net.Sites[i].Routers); j++ {
+			r := &net.Sites[i].Routers[j]
+			r.Links = append(r.Links, vanflow.LinkRecord{
+				BaseRecord: vanflow.NewBase(fmt.Sprintf("%s-link-0", r.Record.ID)),
+				Parent:     ptrTo(r.Record.ID),
+				Name:       r.Record.Parent,
+				Peer:       ptrTo(net.Sites[i].Routers[0].Access.ID),
+				Role:       ptrTo("inter-router"),
+				Status:     ptrTo("up"),
+				LinkCost:   ptrTo(uint64(1)),
+			})
+		}
+	}
+
+	// each service is exposed by a process in one site and reached from the
+	// sites that follow it
+	for k := 0; k < cfg.Services; k++ {
+		svc := service{
+			Address:  fmt.Sprintf("service-%d", k),
+			Protocol: cfg.Protocols[k%len(cfg.Protocols)],
+		}
+		siteIdx := k % len(net.Sites)
+		s := &net.Sites[siteIdx]
+		target := s.Processes[k%len(s.Processes)]
+		r := &s.Routers[k%len(s.Routers)]
+		svc.Connector = vanflow.ConnectorRecord{
+			BaseRecord: vanflow.NewBase(fmt.Sprintf("%s-connector-%d", r.Record.ID, k)),
+			Parent:     ptrTo(r.Record.ID),
+			Name:       ptrTo(svc.Address),
+			Address:    ptrTo(svc.Address),
+			Protocol:   ptrTo(svc.Protocol),
+			DestHost:   target.SourceHost,
+			DestPort:   ptrTo("8080"),
+			ProcessID:  ptrTo(target.ID),
+		}
+		r.Connectors = append(r.Connectors, svc.Connector)
+
+		for l := 1; l <= cfg.ListenersPerService; l++ {
+			ls := &net.Sites[(siteIdx+l)%len(net.Sites)]
+			lr := &ls.Routers[(k+l)%len(ls.Routers)]
+			record := vanflow.ListenerRecord{
+				BaseRecord: vanflow.NewBase(fmt.Sprintf("%s-listener-%d", lr.Record.ID, k)),
+				Parent:     ptrTo(lr.Record.ID),
+				Name:       ptrTo(svc.Address),
+				Address:    ptrTo(svc.Address),
+				Protocol:   ptrTo(svc.Protocol),
+				DestHost:   ptrTo("0.0.0.0"),
+				DestPort:   ptrTo("8080"),
+			}
+			lr.Listeners = append(lr.Listeners, record)
+			svc.Listeners = append(svc.Listeners, listener{
+				Record:  record,
+				Clients: ls.Processes,
+				Router:  lr.Record.ID,
+			})
+		}
+		net.Services = append(net.Services, svc)
+	}
+	return net
+}
+
+// Records returns every record in the network
+func (n network) Records() []vanflow.Record {
+	var records []vanflow.Record
+	for _, s := range n.Sites {
+		records = append(records, s.Record)
+		for _, p := range s.Processes {
+			records = append(records, p)
+		}
+		for _, r := range s.Routers {
+			records = append(records, r.routerRecords()...)
+		}
+	}
+	return records
+}
+
+// routerRecords returns the records reported by a router
+func (r router) routerRecords() []vanflow.Record {
+	records := []vanflow.Record{r.Record, r.Access}
+	for _, l := range r.Links {
+		records = append(records, l)
+	}
+	for _, l := range r.Listeners {
+		records = append(records, l)
+	}
+	for _, c := range r.Connectors {
+		records = append(records, c)
+	}
+	return records
+}
+
+func hostIP(site, process int) string {
+	return fmt.Sprintf("10.%d.%d.%d", (site>>8)&0xff, site&0xff, process%254+1)
+}
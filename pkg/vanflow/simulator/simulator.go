@@ -0,0 +1,381 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/eventsource"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+)
+
+// Config describes the simulated network and the traffic flowing through it
+type Config struct {
+	// Sites in the network. Defaults to 1.
+	Sites int
+	// RoutersPerSite defaults to 1.
+	RoutersPerSite int
+	// ProcessesPerSite defaults to 2. At most 254.
+	ProcessesPerSite int
+	// LinksPerSite is the number of sites the first router of each site
+	// links to. Defaults to 1, forming a ring.
+	LinksPerSite int
+	// Services is the number of routing keys, each exposed by a connector
+	// in one site. Defaults to 1.
+	Services int
+	// ListenersPerService is the number of sites with a listener for each
+	// service. Defaults to 1.
+	ListenersPerService int
+	// Protocols are assigned to services in turn. Defaults to tcp and
+	// http1. Application flows are generated for services using http1 or
+	// http2.
+	Protocols []string
+
+	// FlowRate is the number of connections opened per second across the
+	// network. No traffic is generated when zero.
+	FlowRate float64
+	// FlowLimit stops traffic generation once this many connections have
+	// been opened. Unlimited when zero.
+	FlowLimit int64
+	// FlowDuration is how long each connection stays open. Defaults to one
+	// second.
+	FlowDuration time.Duration
+	// RequestsPerFlow is the number of requests made over each connection
+	// to an http service. Defaults to 1.
+	RequestsPerFlow int
+	// Seed for the random choice of listeners and clients
+	Seed int64
+	// TrafficStart holds traffic generation until it is closed when set.
+	// Flows sent before a consumer has discovered their event source are
+	// never seen by it, so benchmarks wait for consumers to discover the
+	// network before starting traffic.
+	TrafficStart <-chan struct{}
+
+	// HeartbeatInterval and BeaconInterval of each event source. See
+	// eventsource.ManagerConfig.
+	HeartbeatInterval time.Duration
+	BeaconInterval    time.Duration
+}
+
+func (c Config) withDefaults() (Config, error) {
+	defaultInt := func(v *int, d int) {
+		if *v <= 0 {
+			*v = d
+		}
+	}
+	defaultInt(&c.Sites, 1)
+	defaultInt(&c.RoutersPerSite, 1)
+	defaultInt(&c.ProcessesPerSite, 2)
+	defaultInt(&c.Services, 1)
+	defaultInt(&c.ListenersPerService, 1)
+	defaultInt(&c.RequestsPerFlow, 1)
+	if c.LinksPerSite <= 0 {
+		c.LinksPerSite = 1
+	}
+	if c.LinksPerSite > c.Sites-1 {
+		c.LinksPerSite = c.Sites - 1
+	}
+	if c.ListenersPerService > c.Sites {
+		c.ListenersPerService = c.Sites
+	}
+	if len(c.Protocols) == 0 {
+		c.Protocols = []string{"tcp", "http1"}
+	}
+	if c.FlowDuration <= 0 {
+		c.FlowDuration = time.Second
+	}
+	if c.ProcessesPerSite > 254 {
+		return c, fmt.Errorf("at most 254 processes per site can be simulated: %d", c.ProcessesPerSite)
+	}
+	for _, protocol := range c.Protocols {
+		switch protocol {
+		case "tcp", "http1", "http2":
+		default:
+			return c, fmt.Errorf("unsupported protocol %q", protocol)
+		}
+	}
+	return c, nil
+}
+
+// Stats counts what the simulator has sent
+type Stats struct {
+	Sources       int
+	FlowsOpened   int64
+	FlowsClosed   int64
+	RequestsSent  int64
+	RecordUpdates int64
+}
+
+// Simulator fabricates a network of vanflow event sources. Each site is
+// represented by a controller event source reporting the site and its
+// processes, and each router by a router event source reporting its links,
+// listeners, connectors and the flows through it.
+type Simulator struct {
+	cfg     Config
+	factory session.ContainerFactory
+	network network
+	logger  *slog.Logger
+
+	routers map[string]*source
+
+	flowsOpened   atomic.Int64
+	flowsClosed   atomic.Int64
+	requestsSent  atomic.Int64
+	recordUpdates atomic.Int64
+}
+
+// source is a simulated event source
+type source struct {
+	records store.Interface
+	manager *eventsource.Manager
+}
+
+// New creates a simulator that runs its event sources in containers created
+// by factory. Consumers created with the same factory, such as a
+// session.NewMockContainerFactory, receive the simulated messages.
+func New(factory session.ContainerFactory, cfg Config) (*Simulator, error) {
+	cfg, err := cfg.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+	return &Simulator{
+		cfg:     cfg,
+		factory: factory,
+		network: buildNetwork(cfg),
+		routers: make(map[string]*source),
+		logger: slog.New(slog.Default().Handler()).With(
+			slog.String("component", "vanflow.simulator"),
+		),
+	}, nil
+}
+
+// Records returns the static records of the simulated network: sites,
+// processes, routers, router accesses, links, listeners and connectors.
+func (s *Simulator) Records() []vanflow.Record {
+	return s.network.Records()
+}
+
+// Stats returns counts of what has been sent so far
+func (s *Simulator) Stats() Stats {
+	return Stats{
+		Sources:       len(s.network.Sites) * (1 + s.cfg.RoutersPerSite),
+		FlowsOpened:   s.flowsOpened.Load(),
+		FlowsClosed:   s.flowsClosed.Load(),
+		RequestsSent:  s.requestsSent.Load(),
+		RecordUpdates: s.recordUpdates.Load(),
+	}
+}
+
+// Run the simulated event sources and generate traffic until the context is
+// cancelled. Traffic is generated no faster than consumers of the event
+// sources receive it.
+func (s *Simulator) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	// sources outlive traffic generation so that it is never left blocked
+	// publishing to a stopped source
+	sourcesCtx, stopSources := context.WithCancel(context.WithoutCancel(ctx))
+	defer stopSources()
+
+	start := func(info eventsource.Info, alternateHeartbeats bool, records []vanflow.Record) *source {
+		container := s.factory.Create()
+		container.Start(sourcesCtx)
+		src := &source{
+			records: store.NewSyncMapStore(store.SyncMapStoreConfig{}),
+		}
+		ref := store.SourceRef{ID: info.ID, Version: fmt.Sprint(info.Version)}
+		for _, record := range records {
+			src.records.Add(record, ref)
+		}
+		src.manager = eventsource.NewManager(container, eventsource.ManagerConfig{
+			Source:                       info,
+			Stores:                       []store.Interface{src.records},
+			HeartbeatInterval:            s.cfg.HeartbeatInterval,
+			BeaconInterval:               s.cfg.BeaconInterval,
+			UseAlternateHeartbeatAddress: alternateHeartbeats,
+			UseFlowsAddress:              info.Type == "ROUTER",
+			FlushBatchSize:               50,
+			UpdateBufferTime:             100 * time.Millisecond,
+			UpdateBatchSize:              50,
+		})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			src.manager.Run(sourcesCtx)
+		}()
+		return src
+	}
+	for _, site := range s.network.Sites {
+		records := []vanflow.Record{site.Record}
+		for _, process := range site.Processes {
+			records = append(records, process)
+		}
+		start(sourceInfo(site.Record.ID, "CONTROLLER"), true, records)
+		for _, router := range site.Routers {
+			s.routers[router.Record.ID] = start(sourceInfo(router.Record.ID, "ROUTER"), false, router.routerRecords())
+		}
+	}
+	s.logger.Info("started simulated network",
+		slog.Int("sites", len(s.network.Sites)),
+		slog.Int("sources", s.Stats().Sources),
+		slog.Int("services", len(s.network.Services)))
+
+	if s.cfg.FlowRate <= 0 {
+		<-ctx.Done()
+		return nil
+	}
+	if s.cfg.TrafficStart != nil {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.cfg.TrafficStart:
+		}
+	}
+	s.generate(ctx)
+	return nil
+}
+
+func sourceInfo(id, typ string) eventsource.Info {
+	return eventsource.Info{
+		ID:      id,
+		Version: 1,
+		Type:    typ,
+		Address: "mc/sfe." + id,
+		Direct:  "sfe." + id,
+	}
+}
+
+// pendingFlow is an open connection waiting to be closed
+type pendingFlow struct {
+	closeAt  time.Time
+	router   *source
+	protocol string
+	flow     vanflow.TransportBiflowRecord
+}
+
+// generate opens connections at the configured rate and closes them once
+// their duration has elapsed.
+func (s *Simulator) generate(ctx context.Context) {
+	const tick = 10 * time.Millisecond
+	rng := rand.New(rand.NewSource(s.cfg.Seed))
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	var (
+		budget  float64
+		pending []pendingFlow
+		last    = time.Now()
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			budget += now.Sub(last).Seconds() * s.cfg.FlowRate
+			last = now
+			for ; budget >= 1; budget-- {
+				if s.cfg.FlowLimit > 0 && s.flowsOpened.Load() >= s.cfg.FlowLimit {
+					budget = 0
+					break
+				}
+				pending = append(pending, s.open(rng, now))
+			}
+			// flows share a duration so pending is ordered by closeAt
+			var closed int
+			for _, p := range pending {
+				if p.closeAt.After(now) {
+					break
+				}
+				s.close(rng, p, now)
+				closed++
+			}
+			pending = pending[closed:]
+		}
+	}
+}
+
+func (s *Simulator) open(rng *rand.Rand, now time.Time) pendingFlow {
+	svc := s.network.Services[rng.Intn(len(s.network.Services))]
+	l := svc.Listeners[rng.Intn(len(svc.Listeners))]
+	client := l.Clients[rng.Intn(len(l.Clients))]
+	n := s.flowsOpened.Add(1)
+	flow := vanflow.TransportBiflowRecord{
+		BaseRecord:  vanflow.NewBase(fmt.Sprintf("%s-flow-%d", l.Router, n), now),
+		Parent:      ptrTo(l.Record.ID),
+		ConnectorID: ptrTo(svc.Connector.ID),
+		SourceHost:  client.SourceHost,
+		SourcePort:  ptrTo(fmt.Sprint(32768 + n%28232)),
+	}
+	router := s.routers[l.Router]
+	s.publish(router, nil, flow)
+	return pendingFlow{
+		closeAt:  now.Add(s.cfg.FlowDuration),
+		router:   router,
+		protocol: svc.Protocol,
+		flow:     flow,
+	}
+}
+
+func (s *Simulator) close(rng *rand.Rand, p pendingFlow, now time.Time) {
+	var sent, received uint64
+	if p.protocol == "http1" || p.protocol == "http2" {
+		for i := 0; i < s.cfg.RequestsPerFlow; i++ {
+			request := vanflow.AppBiflowRecord{
+				BaseRecord:    vanflow.NewBase(fmt.Sprintf("%s-request-%d", p.flow.ID, i), p.flow.StartTime.Time),
+				Parent:        ptrTo(p.flow.ID),
+				Protocol:      ptrTo(httpProtocol(p.protocol)),
+				Method:        ptrTo("GET"),
+				Result:        ptrTo(httpResult(rng)),
+				Latency:       ptrTo(uint64(500 + rng.Intn(20_000))),
+				Octets:        ptrTo(uint64(128 + rng.Intn(512))),
+				OctetsReverse: ptrTo(uint64(256 + rng.Intn(64<<10))),
+			}
+			request.EndTime = &vanflow.Time{Time: now}
+			sent += *request.Octets
+			received += *request.OctetsReverse
+			s.publish(p.router, nil, request)
+			s.requestsSent.Add(1)
+		}
+	} else {
+		sent = uint64(64 + rng.Intn(64<<10))
+		received = uint64(64 + rng.Intn(256<<10))
+	}
+	closed := p.flow
+	closed.EndTime = &vanflow.Time{Time: now}
+	closed.Octets = ptrTo(sent)
+	closed.OctetsReverse = ptrTo(received)
+	closed.Latency = ptrTo(uint64(100 + rng.Intn(5_000)))
+	closed.LatencyReverse = ptrTo(uint64(100 + rng.Intn(5_000)))
+	s.publish(p.router, p.flow, closed)
+	s.flowsClosed.Add(1)
+}
+
+func (s *Simulator) publish(src *source, prev, curr vanflow.Record) {
+	src.manager.PublishUpdate(eventsource.RecordUpdate{Prev: prev, Curr: curr})
+	s.recordUpdates.Add(1)
+}
+
+func httpProtocol(protocol string) string {
+	if protocol == "http2" {
+		return "HTTP/2"
+	}
+	return "HTTP/1.1"
+}
+
+// httpResult returns a status code, mostly successful
+func httpResult(rng *rand.Rand) string {
+	switch n := rng.Intn(100); {
+	case n < 90:
+		return "200"
+	case n < 95:
+		return "404"
+	default:
+		return "503"
+	}
+}
@@ -0,0 +1,132 @@
+package simulator
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/eventsource"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+)
+
+func TestBuildNetwork(t *testing.T) {
+	cfg, err := Config{Sites: 4, RoutersPerSite: 2, Services: 6, ListenersPerService: 2, LinksPerSite: 2}.withDefaults()
+	assert.Assert(t, err)
+	net := buildNetwork(cfg)
+	counts := make(map[string]int)
+	ids := make(map[string]bool)
+	for _, record := range net.Records() {
+		counts[record.GetTypeMeta().Type]++
+		id := record.Identity()
+		assert.Assert(t, !ids[id], "duplicate id %s", id)
+		ids[id] = true
+	}
+	assert.DeepEqual(t, counts, map[string]int{
+		"SiteRecord":         4,
+		"ProcessRecord":      8,
+		"RouterRecord":       8,
+		"RouterAccessRecord": 8,
+		// two per site from the first router plus one for each second router
+		"LinkRecord":      12,
+		"ConnectorRecord": 6,
+		"ListenerRecord":  12,
+	})
+	for _, svc := range net.Services {
+		for _, l := range svc.Listeners {
+			assert.Assert(t, *l.Record.Parent == l.Router)
+			assert.Assert(t, *l.Record.Address == svc.Address)
+		}
+		assert.Assert(t, ids[*svc.Connector.ProcessID])
+	}
+	assert.Equal(t, *net.Services[1].Connector.Protocol, "http1")
+
+	_, err = Config{ProcessesPerSite: 300}.withDefaults()
+	assert.ErrorContains(t, err, "at most 254")
+	_, err = Config{Protocols: []string{"udp"}}.withDefaults()
+	assert.ErrorContains(t, err, "unsupported protocol")
+}
+
+func TestSimulator(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	factory := session.NewMockContainerFactory()
+	sim, err := New(factory, Config{
+		Sites:             3,
+		Services:          2,
+		FlowRate:          500,
+		FlowLimit:         20,
+		FlowDuration:      10 * time.Millisecond,
+		RequestsPerFlow:   2,
+		HeartbeatInterval: 10 * time.Millisecond,
+		BeaconInterval:    50 * time.Millisecond,
+	})
+	assert.Assert(t, err)
+
+	var mu sync.Mutex
+	received := make(map[string]vanflow.Record)
+	closedFlows := make(map[string]bool)
+	container := factory.Create()
+	discovery := eventsource.NewDiscovery(container, eventsource.DiscoveryOptions{})
+	go discovery.Run(ctx, eventsource.DiscoveryHandlers{
+		Discovered: func(info eventsource.Info) {
+			client := eventsource.NewClient(container, eventsource.ClientOptions{Source: info})
+			client.OnRecord(func(msg vanflow.RecordMessage) {
+				mu.Lock()
+				defer mu.Unlock()
+				for _, record := range msg.Records {
+					if flow, ok := record.(vanflow.TransportBiflowRecord); ok && flow.EndTime != nil {
+						closedFlows[flow.ID] = true
+						continue
+					}
+					if _, ok := received[record.Identity()]; !ok {
+						received[record.Identity()] = record
+					}
+				}
+			})
+			client.Listen(ctx, eventsource.FromSourceAddress())
+			client.Listen(ctx, eventsource.FromSourceAddressFlows())
+			client.Listen(ctx, eventsource.FromSourceAddressHeartbeats())
+			go eventsource.FlushOnFirstMessage(ctx, client)
+		},
+	})
+
+	done := make(chan error)
+	go func() { done <- sim.Run(ctx) }()
+
+	expected := sim.Records()
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, record := range expected {
+			if _, ok := received[record.Identity()]; !ok {
+				return poll.Continue("missing %s %s", record.GetTypeMeta().Type, record.Identity())
+			}
+		}
+		if len(closedFlows) < 20 {
+			return poll.Continue("received %d closed flows", len(closedFlows))
+		}
+		return poll.Success()
+	}, poll.WithTimeout(5*time.Second), poll.WithDelay(10*time.Millisecond))
+
+	mu.Lock()
+	var requests int
+	for _, record := range received {
+		if request, ok := record.(vanflow.AppBiflowRecord); ok {
+			requests++
+			assert.Assert(t, closedFlows[*request.Parent], "request for unknown flow %s", *request.Parent)
+		}
+	}
+	mu.Unlock()
+	stats := sim.Stats()
+	assert.Equal(t, stats.Sources, 6)
+	assert.Equal(t, stats.FlowsOpened, int64(20))
+	assert.Equal(t, stats.FlowsClosed, int64(20))
+	assert.Equal(t, int64(requests), stats.RequestsSent)
+
+	cancel()
+	assert.Assert(t, <-done)
+}
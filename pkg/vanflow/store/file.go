@@ -0,0 +1,418 @@
+package store
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+)
+
+// FileStoreConfig configures a disk-backed store.
+type FileStoreConfig struct {
+	SyncMapStoreConfig
+
+	// Path to the journal file backing the store. Created when it does not
+	// exist.
+	Path string
+	// RecordTypes contains an exemplar of each record type that can be
+	// restored from disk. Persisted entries of other types are skipped.
+	RecordTypes []vanflow.Record
+	// Persist selects the entries written to disk. All entries are persisted
+	// when nil.
+	Persist func(Entry) bool
+	// MaxAge is the duration after which persisted entries that have not been
+	// updated are evicted on compaction. Zero disables age based eviction.
+	MaxAge time.Duration
+	// MaxSize is the size in bytes the journal may grow to before it is
+	// compacted. When the live entries alone exceed MaxSize the oldest are
+	// evicted. Zero disables size based eviction.
+	MaxSize int64
+}
+
+// FileStore is a store.Interface that keeps its entries in memory with the
+// same indexing semantics as the sync map store, and journals them to a file
+// so that they can be restored after a restart.
+type FileStore struct {
+	Interface
+
+	path    string
+	types   recordTypes
+	persist func(Entry) bool
+	maxAge  time.Duration
+	maxSize int64
+	logger  *slog.Logger
+
+	mu        sync.Mutex
+	file      *os.File
+	w         *bufio.Writer
+	size      int64
+	baseSize  int64
+	lastEvict time.Time
+	err       error
+}
+
+const (
+	journalOpPut    = "put"
+	journalOpDelete = "delete"
+)
+
+type journalEntry struct {
+	Op         string          `json:"op"`
+	ID         string          `json:"id"`
+	Type       string          `json:"type,omitempty"`
+	Source     SourceRef       `json:"source"`
+	LastUpdate time.Time       `json:"lastUpdate"`
+	Record     json.RawMessage `json:"record,omitempty"`
+}
+
+// NewFileStore opens or creates the journal at cfg.Path, restores any entries
+// it contains and returns a store backed by it.
+func NewFileStore(cfg FileStoreConfig) (*FileStore, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("file store requires a path")
+	}
+	s := &FileStore{
+		path:    cfg.Path,
+		types:   newRecordTypes(cfg.RecordTypes),
+		persist: cfg.Persist,
+		maxAge:  cfg.MaxAge,
+		maxSize: cfg.MaxSize,
+		logger: slog.New(slog.Default().Handler()).With(
+			slog.String("component", "vanflow.store.file"),
+			slog.String("path", cfg.Path),
+		),
+	}
+	if s.persist == nil {
+		s.persist = func(Entry) bool { return true }
+	}
+
+	handlers := cfg.Handlers
+	cfg.Handlers = EventHandlerFuncs{
+		OnAdd: func(e Entry) {
+			s.journalPut(e)
+			if handlers.OnAdd != nil {
+				handlers.OnAdd(e)
+			}
+		},
+		OnChange: func(p, e Entry) {
+			s.journalPut(e)
+			if handlers.OnChange != nil {
+				handlers.OnChange(p, e)
+			}
+		},
+		OnDelete: func(e Entry) {
+			s.journalDelete(e)
+			if handlers.OnDelete != nil {
+				handlers.OnDelete(e)
+			}
+		},
+	}
+	s.Interface = NewSyncMapStore(cfg.SyncMapStoreConfig)
+
+	entries, err := s.restore()
+	if err != nil {
+		return nil, err
+	}
+	s.Interface.Replace(entries)
+	if err := s.rewrite(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Replace the contents of the store and its journal. When the journal cannot
+// be rewritten it no longer reflects the store and journaling is suspended
+// until the next successful Compact.
+func (s *FileStore) Replace(entries []Entry) {
+	s.Interface.Replace(entries)
+	if err := s.rewrite(); err != nil {
+		s.logger.Error("error replacing journal", slog.Any("error", err))
+		s.setErr(err)
+	}
+}
+
+// Compact evicts expired entries and rewrites the journal when it has grown
+// past MaxSize or accumulated more superseded entries than live ones. It is
+// intended to be called periodically. After a failed journal write Compact
+// retries the rewrite on every call, resuming journaling once it succeeds.
+func (s *FileStore) Compact() error {
+	s.mu.Lock()
+	size, baseSize, lastEvict, err := s.size, s.baseSize, s.lastEvict, s.err
+	s.mu.Unlock()
+
+	now := time.Now()
+	if err != nil {
+		s.evict(now)
+		return s.rewrite()
+	}
+
+	overSize := s.maxSize > 0 && size > s.maxSize
+	grown := size > 2*baseSize
+	ageDue := s.maxAge > 0 && now.Sub(lastEvict) > s.maxAge/10
+	if !overSize && !grown && !ageDue {
+		return nil
+	}
+
+	s.evict(now)
+	return s.rewrite()
+}
+
+// Close flushes the journal, capturing the current state of all persisted
+// entries, and closes the backing file.
+func (s *FileStore) Close() error {
+	rewriteErr := s.rewrite()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var err error
+	if s.file != nil {
+		err = s.file.Close()
+	}
+	s.file, s.w, s.err = nil, nil, nil
+	if rewriteErr != nil {
+		return rewriteErr
+	}
+	return err
+}
+
+// evict deletes persisted entries older than MaxAge and, when the remaining
+// entries would not fit in MaxSize, the least recently updated ones.
+func (s *FileStore) evict(now time.Time) {
+	var entries []Entry
+	for _, e := range s.Interface.List() {
+		if s.persist(e) {
+			entries = append(entries, e)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].LastUpdate.After(entries[j].LastUpdate)
+	})
+
+	var (
+		total   int64
+		evicted []string
+	)
+	for _, e := range entries {
+		if s.maxAge > 0 && now.Sub(e.LastUpdate) > s.maxAge {
+			evicted = append(evicted, e.Record.Identity())
+			continue
+		}
+		if s.maxSize > 0 {
+			line, err := s.encode(journalOpPut, e)
+			if err == nil {
+				total += int64(len(line))
+			}
+			if total > s.maxSize {
+				evicted = append(evicted, e.Record.Identity())
+			}
+		}
+	}
+	for _, id := range evicted {
+		s.Interface.Delete(id)
+	}
+
+	s.mu.Lock()
+	s.lastEvict = now
+	s.mu.Unlock()
+}
+
+// rewrite replaces the journal with one containing only the current state of
+// the persisted entries, clearing any prior journal write error. When it
+// fails before the journal is replaced the existing journal is left intact.
+func (s *FileStore) rewrite() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("error creating journal: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	var size int64
+	for _, e := range s.Interface.List() {
+		if !s.persist(e) {
+			continue
+		}
+		line, err := s.encode(journalOpPut, e)
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+		n, err := w.Write(line)
+		if err != nil {
+			tmp.Close()
+			return fmt.Errorf("error writing journal: %w", err)
+		}
+		size += int64(n)
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing journal: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing journal: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("error replacing journal: %w", err)
+	}
+
+	if s.file != nil {
+		s.file.Close()
+	}
+	s.file, err = os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		s.file, s.w = nil, nil
+		s.err = fmt.Errorf("error opening journal: %w", err)
+		return s.err
+	}
+	s.w = bufio.NewWriter(s.file)
+	s.size, s.baseSize = size, size
+	s.err = nil
+	return nil
+}
+
+func (s *FileStore) restore() ([]Entry, error) {
+	file, err := os.Open(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error opening journal: %w", err)
+	}
+	defer file.Close()
+
+	entries := make(map[string]Entry)
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			var je journalEntry
+			if jsonErr := json.Unmarshal(line, &je); jsonErr != nil {
+				// a partial trailing write from an unclean shutdown
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return nil, fmt.Errorf("error decoding journal entry: %w", jsonErr)
+			}
+			switch je.Op {
+			case journalOpPut:
+				record, ok, err := s.types.decode(je.Type, je.ID, je.Record)
+				if err != nil {
+					return nil, err
+				}
+				if !ok {
+					continue
+				}
+				entries[je.ID] = Entry{
+					Metadata: Metadata{LastUpdate: je.LastUpdate, Source: je.Source},
+					Record:   record,
+				}
+			case journalOpDelete:
+				delete(entries, je.ID)
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("error reading journal: %w", err)
+		}
+	}
+
+	restored := make([]Entry, 0, len(entries))
+	for _, e := range entries {
+		restored = append(restored, e)
+	}
+	return restored, nil
+}
+
+func (s *FileStore) encode(op string, e Entry) ([]byte, error) {
+	je := journalEntry{
+		Op:         op,
+		ID:         e.Record.Identity(),
+		Source:     e.Source,
+		LastUpdate: e.LastUpdate,
+	}
+	if op == journalOpPut {
+		je.Type = e.Record.GetTypeMeta().String()
+		record, err := json.Marshal(e.Record)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding %s record %q: %w", je.Type, je.ID, err)
+		}
+		je.Record = record
+	}
+	line, err := json.Marshal(je)
+	if err != nil {
+		return nil, err
+	}
+	return append(line, '\n'), nil
+}
+
+func (s *FileStore) journalPut(e Entry) {
+	if !s.persist(e) {
+		return
+	}
+	s.journal(journalOpPut, e)
+}
+
+func (s *FileStore) journalDelete(e Entry) {
+	if !s.persist(e) {
+		return
+	}
+	s.journal(journalOpDelete, e)
+}
+
+// journal appends an entry to the journal. After a failed write the journal
+// may end in a partial entry, so nothing more is appended until a rewrite
+// replaces it. Entries not journaled in the meantime remain in memory and are
+// captured by that rewrite.
+func (s *FileStore) journal(op string, e Entry) {
+	line, err := s.encode(op, e)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		s.logger.Error("journal unavailable, entry not written",
+			slog.String("id", e.Record.Identity()),
+			slog.Any("error", s.err))
+		return
+	}
+	if s.w == nil {
+		return
+	}
+	if err != nil {
+		s.logger.Error("skipping journal entry after encoding error",
+			slog.String("id", e.Record.Identity()),
+			slog.Any("error", err))
+		return
+	}
+	n, err := s.w.Write(line)
+	if err == nil {
+		err = s.w.Flush()
+	}
+	if err != nil {
+		s.err = fmt.Errorf("error writing journal: %w", err)
+		s.logger.Error("error writing journal entry",
+			slog.String("id", e.Record.Identity()),
+			slog.Any("error", err))
+		return
+	}
+	s.size += int64(n)
+}
+
+func (s *FileStore) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = err
+	}
+}
@@ -0,0 +1,172 @@
+package store
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+)
+
+func TestFileStoreRestore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.jsonl")
+	cfg := FileStoreConfig{
+		Path:        path,
+		RecordTypes: []vanflow.Record{vanflow.SiteRecord{}, vanflow.LogRecord{}},
+	}
+	stor, err := NewFileStore(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error opening store: %s", err)
+	}
+
+	source := SourceRef{ID: "test", Version: "1"}
+	start := time.UnixMicro(1_000_000)
+	stor.Add(vanflow.SiteRecord{BaseRecord: vanflow.NewBase("s1", start), Name: ptrTo("site-1")}, source)
+	stor.Add(vanflow.SiteRecord{BaseRecord: vanflow.NewBase("s2", start), Name: ptrTo("site-2")}, source)
+	stor.Add(vanflow.LogRecord{BaseRecord: vanflow.NewBase("l1"), LogText: ptrTo("hello")}, source)
+	stor.Patch(vanflow.SiteRecord{BaseRecord: vanflow.NewBase("s1"), Namespace: ptrTo("ns-1")}, source)
+	stor.Delete("s2")
+
+	expected := stor.List()
+	// reopen without closing to simulate an unclean shutdown
+	restored, err := NewFileStore(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error reopening store: %s", err)
+	}
+	actual := restored.List()
+	if !cmp.Equal(actual, expected, ignoreOrder) {
+		t.Errorf("restored store does not match: %s", cmp.Diff(actual, expected, ignoreOrder))
+	}
+	if err := restored.Close(); err != nil {
+		t.Errorf("unexpected error closing store: %s", err)
+	}
+
+	siteIdx := restored.Index(TypeIndex, Entry{Record: vanflow.SiteRecord{}})
+	if len(siteIdx) != 1 {
+		t.Errorf("expected one restored site in type index but got %d", len(siteIdx))
+	}
+}
+
+func TestFileStoreCompaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.jsonl")
+	stor, err := NewFileStore(FileStoreConfig{
+		Path:        path,
+		RecordTypes: []vanflow.Record{vanflow.LogRecord{}},
+		Persist: func(e Entry) bool {
+			return e.Record.Identity() != "ephemeral"
+		},
+		MaxAge: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error opening store: %s", err)
+	}
+	defer stor.Close()
+
+	source := SourceRef{ID: "test"}
+	stor.Replace([]Entry{
+		{Record: vanflow.LogRecord{BaseRecord: vanflow.NewBase("old")}, Metadata: Metadata{Source: source, LastUpdate: time.Now().Add(-time.Hour)}},
+		{Record: vanflow.LogRecord{BaseRecord: vanflow.NewBase("new")}, Metadata: Metadata{Source: source, LastUpdate: time.Now()}},
+	})
+	stor.Add(vanflow.LogRecord{BaseRecord: vanflow.NewBase("ephemeral")}, source)
+	for i := 0; i < 16; i++ {
+		stor.Patch(vanflow.LogRecord{BaseRecord: vanflow.NewBase("new"), LogText: ptrTo(time.Duration(i).String())}, source)
+	}
+
+	before, _ := os.Stat(path)
+	if err := stor.Compact(); err != nil {
+		t.Fatalf("unexpected compaction error: %s", err)
+	}
+	after, _ := os.Stat(path)
+	if after.Size() >= before.Size() {
+		t.Errorf("expected journal to shrink on compaction: before %d after %d", before.Size(), after.Size())
+	}
+	if _, ok := stor.Get("old"); ok {
+		t.Error("expected expired entry to be evicted")
+	}
+	if _, ok := stor.Get("ephemeral"); !ok {
+		t.Error("expected unpersisted entry to remain in memory")
+	}
+
+	restored, err := NewFileStore(FileStoreConfig{
+		Path:        path,
+		RecordTypes: []vanflow.Record{vanflow.LogRecord{}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error reopening store: %s", err)
+	}
+	var ids []string
+	for _, e := range restored.List() {
+		ids = append(ids, e.Record.Identity())
+	}
+	if !cmp.Equal(ids, []string{"new"}) {
+		t.Errorf("unexpected restored entries: %v", ids)
+	}
+}
+
+func TestFileStoreWriteFailureRecovery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.jsonl")
+	cfg := FileStoreConfig{
+		Path:        path,
+		RecordTypes: []vanflow.Record{vanflow.LogRecord{}},
+	}
+	stor, err := NewFileStore(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error opening store: %s", err)
+	}
+	defer stor.Close()
+
+	source := SourceRef{ID: "test"}
+	stor.Add(vanflow.LogRecord{BaseRecord: vanflow.NewBase("before")}, source)
+
+	// simulate the disk filling up mid write
+	stor.mu.Lock()
+	stor.w = bufio.NewWriterSize(failingWriter{}, 16)
+	stor.mu.Unlock()
+	stor.Add(vanflow.LogRecord{BaseRecord: vanflow.NewBase("failed")}, source)
+	stor.Add(vanflow.LogRecord{BaseRecord: vanflow.NewBase("suspended")}, source)
+	stor.mu.Lock()
+	writeErr := stor.err
+	stor.mu.Unlock()
+	if !errors.Is(writeErr, errDiskFull) {
+		t.Fatalf("expected journal write error but got %v", writeErr)
+	}
+
+	if err := stor.Compact(); err != nil {
+		t.Fatalf("unexpected error recovering journal: %s", err)
+	}
+	stor.Add(vanflow.LogRecord{BaseRecord: vanflow.NewBase("after")}, source)
+	if err := stor.Compact(); err != nil {
+		t.Errorf("unexpected compaction error after recovery: %s", err)
+	}
+
+	restored, err := NewFileStore(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error reopening store: %s", err)
+	}
+	var ids []string
+	for _, e := range restored.List() {
+		ids = append(ids, e.Record.Identity())
+	}
+	sort.Strings(ids)
+	if expected := []string{"after", "before", "failed", "suspended"}; !cmp.Equal(ids, expected) {
+		t.Errorf("unexpected restored entries: %v", ids)
+	}
+}
+
+var errDiskFull = errors.New("no space left on device")
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errDiskFull
+}
+
+var ignoreOrder = cmpopts.SortSlices(func(a, b Entry) bool {
+	return a.Record.Identity() < b.Record.Identity()
+})
@@ -0,0 +1,692 @@
+package store
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+)
+
+// Query is a filter expression compiled against the fields of a record type.
+//
+// Expressions compare fields with literal values and combine comparisons with
+// and, or, not and parentheses:
+//
+//	sourceSiteName == "east" and (latency > 10ms or protocol != "tcp")
+//
+// Fields are named case insensitively, with dots separating the fields of
+// nested structs. The comparison operators are ==, !=, <, <=, > and >=.
+// Values are double quoted strings, numbers, durations such as 10ms (in
+// microseconds, the unit of vanflow latencies and times), true, false and
+// null. Time fields are compared with RFC 3339 strings or microseconds since
+// the Unix epoch. Fields that are unset only match == null, and slice fields
+// match a comparison when any of their elements do.
+type Query struct {
+	expr string
+	typ  reflect.Type
+	root queryNode
+}
+
+// CompileQuery compiles the expression expr against the type of exemplar,
+// returning an error when expr is invalid or references fields the type does
+// not have.
+func CompileQuery(expr string, exemplar any) (*Query, error) {
+	return compileQuery(expr, exemplar, nil)
+}
+
+// CompileQueryFields compiles an expression written against another
+// representation of a record, such as an API view of it, against the type of
+// exemplar. Fields names the fields expr may reference, case insensitively,
+// and maps each to the field of exemplar holding the same value. Referencing
+// any other field is an error.
+func CompileQueryFields(expr string, exemplar any, fields map[string]string) (*Query, error) {
+	mapped := make(map[string]string, len(fields))
+	for name, field := range fields {
+		mapped[strings.ToLower(name)] = field
+	}
+	return compileQuery(expr, exemplar, mapped)
+}
+
+func compileQuery(expr string, exemplar any, fields map[string]string) (*Query, error) {
+	typ := reflect.TypeOf(exemplar)
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot query type %v: not a struct", typ)
+	}
+	tokens, err := lexQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryParser{tokens: tokens, typ: typ, fields: fields}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at offset %d", tok.text, tok.pos)
+	}
+	return &Query{expr: expr, typ: typ, root: root}, nil
+}
+
+// Match returns true when record is of the type the query was compiled
+// against and matches the expression.
+func (q *Query) Match(record any) bool {
+	v := reflect.ValueOf(record)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+	if v.Type() != q.typ {
+		return false
+	}
+	return q.root.match(v)
+}
+
+func (q *Query) String() string {
+	return q.expr
+}
+
+// recordType returns the vanflow type of the records the query matches, or
+// false when it was not compiled against a vanflow.Record.
+func (q *Query) recordType() (string, bool) {
+	record, ok := reflect.Zero(q.typ).Interface().(vanflow.Record)
+	if !ok {
+		return "", false
+	}
+	return record.GetTypeMeta().String(), true
+}
+
+// indexTerms returns the equality comparisons that every match must satisfy
+// and that can be looked up in a FieldIndex.
+func (q *Query) indexTerms() []comparison {
+	var terms []comparison
+	var walk func(n queryNode)
+	walk = func(n queryNode) {
+		switch n := n.(type) {
+		case andNode:
+			walk(n.left)
+			walk(n.right)
+		case comparison:
+			if n.indexed {
+				terms = append(terms, n)
+			}
+		}
+	}
+	walk(q.root)
+	return terms
+}
+
+// FieldIndex is the name a FieldIndexer for field is registered with so that
+// Select can use it.
+func FieldIndex(field string) string {
+	return "ByField/" + strings.ToLower(field)
+}
+
+// FieldIndexer indexes records by their type and the value of field, named as
+// in a Query. Only string, integer and boolean fields are indexed.
+func FieldIndexer(field string) Indexer {
+	var fields sync.Map // reflect.Type to *queryField, nil without the field
+	return func(e Entry) []string {
+		if e.Record == nil {
+			return nil
+		}
+		typ := reflect.TypeOf(e.Record)
+		cached, ok := fields.Load(typ)
+		if !ok {
+			var f *queryField
+			if resolved, err := resolveField(typ, field); err == nil {
+				f = &resolved
+			}
+			cached, _ = fields.LoadOrStore(typ, f)
+		}
+		f := cached.(*queryField)
+		if f == nil {
+			return nil
+		}
+		val, ok := f.value(reflect.ValueOf(e.Record))
+		if !ok {
+			return nil
+		}
+		prefix := e.Record.GetTypeMeta().String() + "/"
+		if !f.slice {
+			if key, ok := indexKey(val); ok {
+				return []string{prefix + key}
+			}
+			return nil
+		}
+		var keys []string
+		for i := 0; i < val.Len(); i++ {
+			if elem, ok := indirect(val.Index(i)); ok {
+				if key, ok := indexKey(elem); ok {
+					keys = append(keys, prefix+key)
+				}
+			}
+		}
+		return keys
+	}
+}
+
+func indexKey(v reflect.Value) (string, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
+	}
+	return "", false
+}
+
+type queryNode interface {
+	match(v reflect.Value) bool
+}
+
+type andNode struct {
+	left, right queryNode
+}
+
+func (n andNode) match(v reflect.Value) bool {
+	return n.left.match(v) && n.right.match(v)
+}
+
+type orNode struct {
+	left, right queryNode
+}
+
+func (n orNode) match(v reflect.Value) bool {
+	return n.left.match(v) || n.right.match(v)
+}
+
+type notNode struct {
+	operand queryNode
+}
+
+func (n notNode) match(v reflect.Value) bool {
+	return !n.operand.match(v)
+}
+
+// comparison of a field with a value
+type comparison struct {
+	field queryField
+	op    string
+	// null is set when comparing with null
+	null bool
+	// test a value of the field
+	test func(reflect.Value) bool
+
+	// indexed is set for equality comparisons that can use a FieldIndex
+	// with key
+	indexed bool
+	key     string
+}
+
+func (c comparison) match(v reflect.Value) bool {
+	val, ok := c.field.value(v)
+	if c.null {
+		return ok == (c.op == "!=")
+	}
+	if !ok {
+		return false
+	}
+	if !c.field.slice {
+		return c.test(val)
+	}
+	for i := 0; i < val.Len(); i++ {
+		if elem, ok := indirect(val.Index(i)); ok && c.test(elem) {
+			return true
+		}
+	}
+	return false
+}
+
+// queryField is a reference to a possibly nested field of a struct
+type queryField struct {
+	name string
+	// steps are the field indexes of each part of the name
+	steps [][]int
+	// typ of the field, or of its elements for slices, with pointers
+	// dereferenced
+	typ   reflect.Type
+	slice bool
+}
+
+func resolveField(typ reflect.Type, name string) (queryField, error) {
+	f := queryField{name: name}
+	for _, part := range strings.Split(name, ".") {
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct {
+			return f, fmt.Errorf("cannot reference field %q on type %s: not a struct", part, typ)
+		}
+		sf, ok := typ.FieldByNameFunc(func(n string) bool {
+			return strings.EqualFold(n, part)
+		})
+		if !ok || !sf.IsExported() {
+			return f, fmt.Errorf("unknown field %q on %s", part, typ)
+		}
+		f.steps = append(f.steps, sf.Index)
+		typ = sf.Type
+	}
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() == reflect.Slice {
+		f.slice = true
+		typ = typ.Elem()
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+	}
+	f.typ = typ
+	return f, nil
+}
+
+// value of the field in v. Returns false when the field is unset.
+func (f queryField) value(v reflect.Value) (reflect.Value, bool) {
+	var ok bool
+	for _, index := range f.steps {
+		if v, ok = indirect(v); !ok {
+			return v, false
+		}
+		field, err := v.FieldByIndexErr(index)
+		if err != nil {
+			return v, false
+		}
+		v = field
+	}
+	if v, ok = indirect(v); !ok {
+		return v, false
+	}
+	if v.Kind() == reflect.Slice && v.IsNil() {
+		return v, false
+	}
+	return v, true
+}
+
+func indirect(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
+
+type microTimer interface {
+	UnixMicro() int64
+}
+
+var microTimerType = reflect.TypeOf((*microTimer)(nil)).Elem()
+
+func compileComparison(field queryField, op token, lit token) (comparison, error) {
+	c := comparison{field: field, op: op.text}
+	cmp := func(d int) bool {
+		switch c.op {
+		case "==":
+			return d == 0
+		case "!=":
+			return d != 0
+		case "<":
+			return d < 0
+		case "<=":
+			return d <= 0
+		case ">":
+			return d > 0
+		default:
+			return d >= 0
+		}
+	}
+	mismatch := func() error {
+		return fmt.Errorf("cannot compare field %q of type %s with %s at offset %d", field.name, field.typ, lit.text, lit.pos)
+	}
+
+	if lit.kind == tokIdent && lit.text == "null" {
+		if c.op != "==" && c.op != "!=" {
+			return c, fmt.Errorf("operator %s cannot be used with null at offset %d", c.op, op.pos)
+		}
+		c.null = true
+		return c, nil
+	}
+
+	typ := field.typ
+	switch {
+	case typ.Implements(microTimerType):
+		var micros int64
+		switch lit.kind {
+		case tokString:
+			t, err := time.Parse(time.RFC3339Nano, lit.text)
+			if err != nil {
+				return c, fmt.Errorf("invalid time %q at offset %d: expected RFC 3339 format", lit.text, lit.pos)
+			}
+			micros = t.UnixMicro()
+		case tokNumber:
+			n, err := parseNumber(lit)
+			if err != nil {
+				return c, err
+			}
+			micros = int64(n)
+		default:
+			return c, mismatch()
+		}
+		c.test = func(v reflect.Value) bool {
+			return cmp(compareOrdered(v.Interface().(microTimer).UnixMicro(), micros))
+		}
+	case typ.Kind() == reflect.String:
+		if lit.kind != tokString {
+			return c, mismatch()
+		}
+		s := lit.text
+		c.test = func(v reflect.Value) bool {
+			return cmp(strings.Compare(v.String(), s))
+		}
+		c.key, c.indexed = s, true
+	case typ.Kind() == reflect.Bool:
+		if lit.kind != tokIdent || (lit.text != "true" && lit.text != "false") {
+			return c, mismatch()
+		}
+		if c.op != "==" && c.op != "!=" {
+			return c, fmt.Errorf("operator %s cannot be used with boolean field %q at offset %d", c.op, field.name, op.pos)
+		}
+		b := lit.text == "true"
+		c.test = func(v reflect.Value) bool {
+			return cmp(compareOrdered(boolInt(v.Bool()), boolInt(b)))
+		}
+		c.key, c.indexed = strconv.FormatBool(b), true
+	case isNumberKind(typ.Kind()):
+		if lit.kind != tokNumber {
+			return c, mismatch()
+		}
+		n, err := parseNumber(lit)
+		if err != nil {
+			return c, err
+		}
+		var get func(reflect.Value) float64
+		switch typ.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			get = func(v reflect.Value) float64 { return float64(v.Int()) }
+			if n == math.Trunc(n) {
+				c.key, c.indexed = strconv.FormatInt(int64(n), 10), true
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			get = func(v reflect.Value) float64 { return float64(v.Uint()) }
+			if n >= 0 && n == math.Trunc(n) {
+				c.key, c.indexed = strconv.FormatUint(uint64(n), 10), true
+			}
+		default:
+			get = func(v reflect.Value) float64 { return v.Float() }
+		}
+		c.test = func(v reflect.Value) bool {
+			return cmp(compareOrdered(get(v), n))
+		}
+	default:
+		return c, fmt.Errorf("cannot filter on field %q of type %s", field.name, typ)
+	}
+	c.indexed = c.indexed && c.op == "=="
+	return c, nil
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func compareOrdered[T int64 | float64](x, y T) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+func boolInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// parseNumber parses a number literal, converting durations to microseconds
+func parseNumber(lit token) (float64, error) {
+	if n, err := strconv.ParseFloat(lit.text, 64); err == nil {
+		return n, nil
+	}
+	d, err := time.ParseDuration(lit.text)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q at offset %d", lit.text, lit.pos)
+	}
+	return float64(d) / float64(time.Microsecond), nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokOperator
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func lexQuery(expr string) ([]token, error) {
+	var tokens []token
+	isDigit := func(c byte) bool { return c >= '0' && c <= '9' }
+	isLetter := func(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' }
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokRParen, text: ")", pos: i})
+			i++
+		case c == '"':
+			j := i + 1
+			for ; j < len(expr) && expr[j] != '"'; j++ {
+				if expr[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(expr) {
+				return nil, fmt.Errorf("unterminated string at offset %d", i)
+			}
+			s, err := strconv.Unquote(expr[i : j+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at offset %d: %s", i, err)
+			}
+			tokens = append(tokens, token{kind: tokString, text: s, pos: i})
+			i = j + 1
+		case strings.IndexByte("=!<>", c) >= 0:
+			op := expr[i : i+1]
+			if i+1 < len(expr) && expr[i+1] == '=' {
+				op = expr[i : i+2]
+			}
+			if op == "=" || op == "!" {
+				return nil, fmt.Errorf("unexpected %q at offset %d", op, i)
+			}
+			tokens = append(tokens, token{kind: tokOperator, text: op, pos: i})
+			i += len(op)
+		case isDigit(c) || c == '-' && i+1 < len(expr) && isDigit(expr[i+1]):
+			j := i + 1
+			for j < len(expr) && (isDigit(expr[j]) || isLetter(expr[j]) || expr[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: expr[i:j], pos: i})
+			i = j
+		case isLetter(c):
+			j := i + 1
+			for j < len(expr) && (isDigit(expr[j]) || isLetter(expr[j]) || expr[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: expr[i:j], pos: i})
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected %q at offset %d", c, i)
+		}
+	}
+	tokens = append(tokens, token{kind: tokEOF, pos: len(expr)})
+	return tokens, nil
+}
+
+// queryParser is a recursive descent parser of the grammar
+//
+//	or         = and { "or" and }
+//	and        = unary { "and" unary }
+//	unary      = "not" unary | "(" or ")" | comparison
+//	comparison = field operator value
+type queryParser struct {
+	tokens []token
+	pos    int
+	typ    reflect.Type
+	// fields maps the field names an expression may use to the fields of
+	// typ when set
+	fields map[string]string
+}
+
+func (p *queryParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *queryParser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *queryParser) unexpected(tok token, expected string) error {
+	if tok.kind == tokEOF {
+		return fmt.Errorf("unexpected end of filter: expected %s", expected)
+	}
+	return fmt.Errorf("unexpected %q at offset %d: expected %s", tok.text, tok.pos, expected)
+}
+
+func isKeyword(tok token, keyword string) bool {
+	return tok.kind == tokIdent && tok.text == keyword
+}
+
+func (p *queryParser) parseOr() (queryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for isKeyword(p.peek(), "or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (queryNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for isKeyword(p.peek(), "and") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseUnary() (queryNode, error) {
+	tok := p.peek()
+	switch {
+	case isKeyword(tok, "not"):
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	case tok.kind == tokLParen:
+		p.next()
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if tok := p.next(); tok.kind != tokRParen {
+			return nil, p.unexpected(tok, `")"`)
+		}
+		return n, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *queryParser) parseComparison() (queryNode, error) {
+	name := p.next()
+	if name.kind != tokIdent || isQueryKeyword(name.text) {
+		return nil, p.unexpected(name, "a field name")
+	}
+	path := name.text
+	if p.fields != nil {
+		var ok bool
+		if path, ok = p.fields[strings.ToLower(name.text)]; !ok {
+			return nil, fmt.Errorf("invalid field at offset %d: unknown field %q", name.pos, name.text)
+		}
+	}
+	field, err := resolveField(p.typ, path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid field at offset %d: %s", name.pos, err)
+	}
+	op := p.next()
+	if op.kind != tokOperator {
+		return nil, p.unexpected(op, "a comparison operator")
+	}
+	lit := p.next()
+	switch {
+	case lit.kind == tokString, lit.kind == tokNumber:
+	case lit.kind == tokIdent && (lit.text == "true" || lit.text == "false" || lit.text == "null"):
+	default:
+		return nil, p.unexpected(lit, "a value")
+	}
+	return compileComparison(field, op, lit)
+}
+
+func isQueryKeyword(s string) bool {
+	switch s {
+	case "and", "or", "not", "true", "false", "null":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+package store
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+)
+
+func TestQuery(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	records := []vanflow.TransportBiflowRecord{
+		{
+			BaseRecord: vanflow.NewBase("1", start),
+			SourceHost: ptrTo("10.0.0.1"),
+			Latency:    ptrTo[uint64](500),
+			Octets:     ptrTo[uint64](2048),
+		}, {
+			BaseRecord: vanflow.NewBase("2", start.Add(time.Minute)),
+			SourceHost: ptrTo("10.0.0.2"),
+			Latency:    ptrTo[uint64](25_000),
+		}, {
+			BaseRecord:    vanflow.NewBase("3"),
+			SourceHost:    ptrTo("10.0.0.1"),
+			Latency:       ptrTo[uint64](10_000),
+			ErrorListener: ptrTo("connection refused"),
+		},
+	}
+	testCases := []struct {
+		Expr     string
+		Expected []string
+	}{
+		{Expr: `sourceHost == "10.0.0.1"`, Expected: []string{"1", "3"}},
+		{Expr: `SOURCEHOST != "10.0.0.1"`, Expected: []string{"2"}},
+		{Expr: `latency > 10ms`, Expected: []string{"2"}},
+		{Expr: `latency >= 10ms`, Expected: []string{"2", "3"}},
+		{Expr: `latency < 1000`, Expected: []string{"1"}},
+		{Expr: `sourceHost == "10.0.0.1" and latency > 1ms`, Expected: []string{"3"}},
+		{Expr: `sourceHost == "10.0.0.2" or not (latency <= 500)`, Expected: []string{"2", "3"}},
+		{Expr: `octets > 0`, Expected: []string{"1"}},
+		{Expr: `octets == null`, Expected: []string{"2", "3"}},
+		{Expr: `errorListener != null`, Expected: []string{"3"}},
+		{Expr: `startTime > "2024-01-01T00:00:30Z"`, Expected: []string{"2"}},
+		{Expr: `startTime <= 1704067200000000`, Expected: []string{"1"}},
+		{Expr: `id >= "2" and id < "3"`, Expected: []string{"2"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Expr, func(t *testing.T) {
+			q, err := CompileQuery(tc.Expr, vanflow.TransportBiflowRecord{})
+			if err != nil {
+				t.Fatalf("unexpected error compiling query: %s", err)
+			}
+			var actual []string
+			for _, record := range records {
+				if q.Match(record) {
+					actual = append(actual, record.ID)
+				}
+			}
+			if !cmp.Equal(actual, tc.Expected) {
+				t.Errorf("expected matches %v but got %v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	testCases := []struct {
+		Expr          string
+		ExpectedError string
+	}{
+		{Expr: ``, ExpectedError: "unexpected end of filter: expected a field name"},
+		{Expr: `sourceHost = "a"`, ExpectedError: `unexpected "=" at offset 11`},
+		{Expr: `sourceHost == "a`, ExpectedError: "unterminated string at offset 14"},
+		{Expr: `host == "a"`, ExpectedError: `invalid field at offset 0: unknown field "host"`},
+		{Expr: `sourceHost == 1`, ExpectedError: `cannot compare field "sourceHost" of type string with 1`},
+		{Expr: `latency > "1"`, ExpectedError: `cannot compare field "latency" of type uint64 with 1`},
+		{Expr: `latency > 1parsec`, ExpectedError: `invalid number "1parsec"`},
+		{Expr: `latency > null`, ExpectedError: "operator > cannot be used with null"},
+		{Expr: `startTime > "yesterday"`, ExpectedError: `invalid time "yesterday"`},
+		{Expr: `(latency > 1`, ExpectedError: `unexpected end of filter: expected ")"`},
+		{Expr: `latency > 1 latency`, ExpectedError: `unexpected "latency" at offset 12`},
+		{Expr: `latency > 1 and`, ExpectedError: "unexpected end of filter: expected a field name"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Expr, func(t *testing.T) {
+			_, err := CompileQuery(tc.Expr, vanflow.TransportBiflowRecord{})
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Errorf("expected error containing %q but got %v", tc.ExpectedError, err)
+			}
+		})
+	}
+	if _, err := CompileQuery(`a == 1`, "not a struct"); err == nil {
+		t.Error("expected error compiling query against a string")
+	}
+}
+
+func TestSyncMapStoreSelect(t *testing.T) {
+	fieldIndexer := FieldIndexer("sourceHost")
+	indexers := defaultIndexers()
+	// index that leaves out flow 3 so that lookups using it can be told
+	// apart from scans
+	indexers[FieldIndex("sourceHost")] = func(e Entry) []string {
+		if e.Record.Identity() == "3" {
+			return nil
+		}
+		return fieldIndexer(e)
+	}
+	stor := NewSyncMapStore(SyncMapStoreConfig{Indexers: indexers})
+	source := SourceRef{ID: "test"}
+	stor.Add(vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("1"), SourceHost: ptrTo("a"), Latency: ptrTo[uint64](1)}, source)
+	stor.Add(vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("2"), SourceHost: ptrTo("b"), Latency: ptrTo[uint64](2)}, source)
+	stor.Add(vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("3"), SourceHost: ptrTo("a"), Latency: ptrTo[uint64](3)}, source)
+	stor.Add(vanflow.FlowRecord{BaseRecord: vanflow.NewBase("4"), SourceHost: ptrTo("a")}, source)
+
+	selectIDs := func(expr string) []string {
+		t.Helper()
+		q, err := CompileQuery(expr, vanflow.TransportBiflowRecord{})
+		if err != nil {
+			t.Fatalf("unexpected error compiling query: %s", err)
+		}
+		var ids []string
+		for _, e := range stor.Select(q) {
+			ids = append(ids, e.Record.Identity())
+		}
+		sort.Strings(ids)
+		return ids
+	}
+	testCases := []struct {
+		Expr     string
+		Expected []string
+	}{
+		{Expr: `sourceHost == "a"`, Expected: []string{"1"}},
+		{Expr: `latency > 1 and sourceHost == "b"`, Expected: []string{"2"}},
+		{Expr: `sourceHost == "c"`, Expected: nil},
+		// scanned by type without an equality term
+		{Expr: `sourceHost != "b"`, Expected: []string{"1", "3"}},
+		{Expr: `sourceHost == "a" or latency == 2`, Expected: []string{"1", "2", "3"}},
+	}
+	for _, tc := range testCases {
+		if actual := selectIDs(tc.Expr); !cmp.Equal(actual, tc.Expected) {
+			t.Errorf("%s: expected %v but got %v", tc.Expr, tc.Expected, actual)
+		}
+	}
+}
+
+func TestCompileQueryFields(t *testing.T) {
+	fields := map[string]string{
+		"clientHost": "SourceHost",
+		"latency":    "Latency",
+	}
+	q, err := CompileQueryFields(`clienthost == "a" and latency > 1`, vanflow.TransportBiflowRecord{}, fields)
+	if err != nil {
+		t.Fatalf("unexpected error compiling query: %s", err)
+	}
+	if !q.Match(vanflow.TransportBiflowRecord{SourceHost: ptrTo("a"), Latency: ptrTo[uint64](2)}) {
+		t.Error("expected record to match")
+	}
+	if q.Match(vanflow.TransportBiflowRecord{SourceHost: ptrTo("b"), Latency: ptrTo[uint64](2)}) {
+		t.Error("expected record not to match")
+	}
+	if terms := q.indexTerms(); len(terms) != 1 || FieldIndex(terms[0].field.name) != FieldIndex("sourceHost") {
+		t.Errorf("expected index term on the mapped field: %v", terms)
+	}
+	if _, err := CompileQueryFields(`sourceHost == "a"`, vanflow.TransportBiflowRecord{}, fields); err == nil || !strings.Contains(err.Error(), `unknown field "sourceHost"`) {
+		t.Errorf("expected error referencing a field that is not mapped but got %v", err)
+	}
+}
+
+func TestFieldIndexer(t *testing.T) {
+	indexer := FieldIndexer("SourceHost")
+	testCases := []struct {
+		Record   vanflow.Record
+		Expected []string
+	}{
+		{Record: vanflow.FlowRecord{SourceHost: ptrTo("a")}, Expected: []string{"flow/v1/FlowRecord/a"}},
+		{Record: vanflow.FlowRecord{}, Expected: nil},
+		{Record: vanflow.SiteRecord{Name: ptrTo("a")}, Expected: nil},
+	}
+	for _, tc := range testCases {
+		if actual := indexer(Entry{Record: tc.Record}); !cmp.Equal(actual, tc.Expected) {
+			t.Errorf("expected index values %v for %T but got %v", tc.Expected, tc.Record, actual)
+		}
+	}
+}
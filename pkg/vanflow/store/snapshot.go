@@ -0,0 +1,175 @@
+package store
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+)
+
+// SnapshotVersion is the version of the snapshot format written by
+// Snapshot.Write. Snapshots of other versions cannot be read.
+const SnapshotVersion = 1
+
+// Snapshot is a point in time copy of the entries of a store
+type Snapshot struct {
+	// Time the snapshot was taken
+	Time    time.Time
+	Entries []Entry
+}
+
+// snapshotHeader is the first line of an encoded snapshot. Each entry follows
+// on its own line.
+type snapshotHeader struct {
+	Version int       `json:"version"`
+	Time    time.Time `json:"time"`
+	Entries int       `json:"entries"`
+}
+
+type snapshotEntry struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Source     SourceRef       `json:"source"`
+	LastUpdate time.Time       `json:"lastUpdate"`
+	Record     json.RawMessage `json:"record"`
+}
+
+// TakeSnapshot copies the entries of a store selected by keep. All entries are
+// copied when keep is nil.
+func TakeSnapshot(stor Interface, keep func(Entry) bool) Snapshot {
+	snapshot := Snapshot{Time: time.Now()}
+	for _, e := range stor.List() {
+		if keep == nil || keep(e) {
+			snapshot.Entries = append(snapshot.Entries, e)
+		}
+	}
+	return snapshot
+}
+
+// Write the snapshot to w
+func (s Snapshot) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	if err := enc.Encode(snapshotHeader{Version: SnapshotVersion, Time: s.Time, Entries: len(s.Entries)}); err != nil {
+		return fmt.Errorf("error writing snapshot: %w", err)
+	}
+	for _, e := range s.Entries {
+		se := snapshotEntry{
+			ID:         e.Record.Identity(),
+			Type:       e.Record.GetTypeMeta().String(),
+			Source:     e.Source,
+			LastUpdate: e.LastUpdate,
+		}
+		record, err := json.Marshal(e.Record)
+		if err != nil {
+			return fmt.Errorf("error encoding %s record %q: %w", se.Type, se.ID, err)
+		}
+		se.Record = record
+		if err := enc.Encode(se); err != nil {
+			return fmt.Errorf("error writing snapshot: %w", err)
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("error writing snapshot: %w", err)
+	}
+	return nil
+}
+
+// ReadSnapshot reads a snapshot written by Snapshot.Write. exemplars
+// contains an exemplar of each record type that can be read. Entries of other
+// types are skipped.
+func ReadSnapshot(r io.Reader, exemplars []vanflow.Record) (Snapshot, error) {
+	var snapshot Snapshot
+	types := newRecordTypes(exemplars)
+	dec := json.NewDecoder(bufio.NewReader(r))
+	var header snapshotHeader
+	if err := dec.Decode(&header); err != nil {
+		return snapshot, fmt.Errorf("error reading snapshot header: %w", err)
+	}
+	if header.Version != SnapshotVersion {
+		return snapshot, fmt.Errorf("unsupported snapshot version %d", header.Version)
+	}
+	snapshot.Time = header.Time
+	snapshot.Entries = make([]Entry, 0, header.Entries)
+	for i := 0; i < header.Entries; i++ {
+		var se snapshotEntry
+		if err := dec.Decode(&se); err != nil {
+			return snapshot, fmt.Errorf("error reading snapshot entry %d of %d: %w", i+1, header.Entries, err)
+		}
+		record, ok, err := types.decode(se.Type, se.ID, se.Record)
+		if err != nil {
+			return snapshot, err
+		}
+		if !ok {
+			continue
+		}
+		snapshot.Entries = append(snapshot.Entries, Entry{
+			Metadata: Metadata{LastUpdate: se.LastUpdate, Source: se.Source},
+			Record:   record,
+		})
+	}
+	return snapshot, nil
+}
+
+// SaveSnapshot writes a snapshot to the file at path. The file is replaced
+// atomically so that a crash while saving leaves the previous snapshot intact.
+func SaveSnapshot(path string, s Snapshot) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("error creating snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if err := s.Write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error replacing snapshot: %w", err)
+	}
+	return nil
+}
+
+// LoadSnapshot reads the snapshot saved to the file at path. Returns an error
+// matching os.ErrNotExist when there is no snapshot.
+func LoadSnapshot(path string, exemplars []vanflow.Record) (Snapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	defer file.Close()
+	return ReadSnapshot(file, exemplars)
+}
+
+// recordTypes maps the type name of records to their Go type so that records
+// encoded as JSON can be decoded
+type recordTypes map[string]reflect.Type
+
+func newRecordTypes(exemplars []vanflow.Record) recordTypes {
+	types := make(recordTypes, len(exemplars))
+	for _, exemplar := range exemplars {
+		types[exemplar.GetTypeMeta().String()] = reflect.TypeOf(exemplar)
+	}
+	return types
+}
+
+// decode a record. Returns false when the record type is unknown.
+func (t recordTypes) decode(typ, id string, data json.RawMessage) (vanflow.Record, bool, error) {
+	goType, ok := t[typ]
+	if !ok {
+		return nil, false, nil
+	}
+	recordV := reflect.New(goType)
+	if err := json.Unmarshal(data, recordV.Interface()); err != nil {
+		return nil, false, fmt.Errorf("error decoding %s record %q: %w", typ, id, err)
+	}
+	return recordV.Elem().Interface().(vanflow.Record), true, nil
+}
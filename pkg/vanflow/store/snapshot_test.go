@@ -0,0 +1,124 @@
+package store
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+)
+
+func TestSnapshot(t *testing.T) {
+	stor := NewSyncMapStore(SyncMapStoreConfig{})
+	source := SourceRef{ID: "router-1", Version: "1", Network: "east"}
+	start := time.UnixMicro(1_000_000)
+	stor.Add(vanflow.SiteRecord{BaseRecord: vanflow.NewBase("s1", start), Name: ptrTo("site-1")}, source)
+	stor.Add(vanflow.RouterRecord{BaseRecord: vanflow.NewBase("r1", start), Parent: ptrTo("s1")}, source)
+	stor.Add(vanflow.LogRecord{BaseRecord: vanflow.NewBase("l1"), LogText: ptrTo("hello")}, source)
+
+	snapshot := TakeSnapshot(stor, func(e Entry) bool {
+		_, isLog := e.Record.(vanflow.LogRecord)
+		return !isLog
+	})
+	if len(snapshot.Entries) != 2 {
+		t.Fatalf("expected log record to be left out of snapshot: %v", snapshot.Entries)
+	}
+
+	var buf bytes.Buffer
+	if err := snapshot.Write(&buf); err != nil {
+		t.Fatalf("unexpected error writing snapshot: %s", err)
+	}
+	actual, err := ReadSnapshot(bytes.NewReader(buf.Bytes()), []vanflow.Record{vanflow.SiteRecord{}, vanflow.RouterRecord{}})
+	if err != nil {
+		t.Fatalf("unexpected error reading snapshot: %s", err)
+	}
+	if !actual.Time.Equal(snapshot.Time) {
+		t.Errorf("expected snapshot time %s but got %s", snapshot.Time, actual.Time)
+	}
+	if !cmp.Equal(actual.Entries, snapshot.Entries, ignoreOrder) {
+		t.Errorf("read snapshot does not match: %s", cmp.Diff(actual.Entries, snapshot.Entries, ignoreOrder))
+	}
+
+	// unknown types are skipped
+	sitesOnly, err := ReadSnapshot(bytes.NewReader(buf.Bytes()), []vanflow.Record{vanflow.SiteRecord{}})
+	if err != nil {
+		t.Fatalf("unexpected error reading snapshot: %s", err)
+	}
+	if len(sitesOnly.Entries) != 1 || sitesOnly.Entries[0].Record.Identity() != "s1" {
+		t.Errorf("expected only the site to be read: %v", sitesOnly.Entries)
+	}
+
+	restored := NewSyncMapStore(SyncMapStoreConfig{})
+	restored.Replace(actual.Entries)
+	if !cmp.Equal(restored.List(), snapshot.Entries, ignoreOrder) {
+		t.Errorf("expected restored entries to keep their metadata: %s", cmp.Diff(restored.List(), snapshot.Entries, ignoreOrder))
+	}
+}
+
+func TestReadSnapshotErrors(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Input         string
+		ExpectedError string
+	}{
+		{
+			Name:          "empty",
+			ExpectedError: "error reading snapshot header",
+		}, {
+			Name:          "unsupported version",
+			Input:         `{"version":99,"entries":0}`,
+			ExpectedError: "unsupported snapshot version 99",
+		}, {
+			Name:          "truncated",
+			Input:         `{"version":1,"entries":2}` + "\n" + `{"id":"s1","type":"flow/v1/SiteRecord","record":{"ID":"s1"}}`,
+			ExpectedError: "error reading snapshot entry 2 of 2",
+		}, {
+			Name:          "invalid record",
+			Input:         `{"version":1,"entries":1}` + "\n" + `{"id":"s1","type":"flow/v1/SiteRecord","record":{"ID":1}}`,
+			ExpectedError: `error decoding flow/v1/SiteRecord record "s1"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := ReadSnapshot(strings.NewReader(tc.Input), []vanflow.Record{vanflow.SiteRecord{}})
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Errorf("expected error containing %q but got %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestSaveSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.jsonl")
+	if _, err := LoadSnapshot(path, nil); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected not exist error loading missing snapshot: %v", err)
+	}
+	for _, name := range []string{"site-1", "site-2"} {
+		snapshot := Snapshot{
+			Time: time.Now(),
+			Entries: []Entry{{
+				Metadata: Metadata{LastUpdate: time.UnixMicro(10), Source: SourceRef{ID: "a"}},
+				Record:   vanflow.SiteRecord{BaseRecord: vanflow.NewBase("s1"), Name: ptrTo(name)},
+			}},
+		}
+		if err := SaveSnapshot(path, snapshot); err != nil {
+			t.Fatalf("unexpected error saving snapshot: %s", err)
+		}
+		loaded, err := LoadSnapshot(path, []vanflow.Record{vanflow.SiteRecord{}})
+		if err != nil {
+			t.Fatalf("unexpected error loading snapshot: %s", err)
+		}
+		if !cmp.Equal(loaded.Entries, snapshot.Entries) {
+			t.Errorf("loaded snapshot does not match: %s", cmp.Diff(loaded.Entries, snapshot.Entries))
+		}
+	}
+	files, _ := os.ReadDir(filepath.Dir(path))
+	if len(files) != 1 {
+		t.Errorf("expected temporary files to be cleaned up: %v", files)
+	}
+}
@@ -23,6 +23,9 @@ type Metadata struct {
 type SourceRef struct {
 	ID      string
 	Version string
+	// Network optionally names the application network the source belongs
+	// to when records from several networks share a store.
+	Network string
 }
 
 // Interface to a vanflow record store
@@ -40,5 +43,10 @@ type Interface interface {
 	Index(index string, exemplar Entry) []Entry
 	IndexValues(index string) []string
 
+	// Select returns the entries with records matching the query. Equality
+	// comparisons on fields indexed with a FieldIndexer are looked up in the
+	// index instead of scanning every entry.
+	Select(q *Query) []Entry
+
 	Replace([]Entry)
 }
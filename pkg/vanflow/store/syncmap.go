@@ -231,6 +231,46 @@ func (m *syncMapStore) IndexValues(index string) []string {
 	return values
 }
 
+func (m *syncMapStore) Select(q *Query) []Entry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var entries []Entry
+	keys, ok := m.candidates(q)
+	if !ok {
+		for _, entry := range m.items {
+			if q.Match(entry.Record) {
+				entries = append(entries, entry)
+			}
+		}
+		return entries
+	}
+	for key := range keys {
+		if entry := m.items[key]; q.Match(entry.Record) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// candidates returns the keys of the entries that can match q when they can
+// be narrowed down using an index.
+func (m *syncMapStore) candidates(q *Query) (keySet, bool) {
+	typ, ok := q.recordType()
+	if !ok {
+		return nil, false
+	}
+	for _, term := range q.indexTerms() {
+		name := FieldIndex(term.field.name)
+		if _, ok := m.indexers[name]; ok {
+			return m.indices[name][typ+"/"+term.key], true
+		}
+	}
+	if _, ok := m.indexers[TypeIndex]; ok {
+		return m.indices[TypeIndex][typ], true
+	}
+	return nil, false
+}
+
 func (m *syncMapStore) Replace(items []Entry) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -309,6 +349,9 @@ const (
 )
 
 func SourceIndexer(e Entry) []string {
+	if e.Source.Network != "" {
+		return []string{fmt.Sprintf("%s/%s/%s", e.Source.Network, e.Source.Version, e.Source.ID)}
+	}
 	return []string{fmt.Sprintf("%s/%s", e.Source.Version, e.Metadata.Source.ID)}
 }
 func TypeIndexer(e Entry) []string {